@@ -0,0 +1,12 @@
+package opts
+
+import "fmt"
+
+// ValidateBlkioWeight verifies the block IO weight of container, it should be
+// in range [10, 1000], and 0 means not to set the weight.
+func ValidateBlkioWeight(weight uint16) error {
+	if weight != 0 && (weight < 10 || weight > 1000) {
+		return fmt.Errorf("invalid blkio weight %d: should be in range [10, 1000], or 0 to disable", weight)
+	}
+	return nil
+}
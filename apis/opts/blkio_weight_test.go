@@ -0,0 +1,16 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBlkioWeight(t *testing.T) {
+	for _, weight := range []uint16{0, 10, 500, 1000} {
+		assert.NoError(t, ValidateBlkioWeight(weight), weight)
+	}
+	for _, weight := range []uint16{1, 9, 1001} {
+		assert.Error(t, ValidateBlkioWeight(weight), weight)
+	}
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -12,6 +13,14 @@ import (
 
 const blkioOptsType = "strings"
 
+// validateDevicePath makes sure the device is specified by absolute path.
+func validateDevicePath(val, path string) error {
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("invalid device %s: device path %s should be an absolute path, such as /dev/sda", val, path)
+	}
+	return nil
+}
+
 // WeightDevice defines weight device
 type WeightDevice struct {
 	values []*types.WeightDevice
@@ -22,6 +31,9 @@ func getValidateWeightDevice(val string) (*types.WeightDevice, error) {
 	if len(pairs) != 2 {
 		return nil, fmt.Errorf("invalid weight device %s: format must be <device-id>:<weight> with weight in range [10, 1000]", val)
 	}
+	if err := validateDevicePath(val, pairs[0]); err != nil {
+		return nil, err
+	}
 
 	weight, err := strconv.ParseUint(pairs[1], 10, 0)
 	if err != nil {
@@ -81,6 +93,9 @@ func getValidThrottleDeviceBps(val string) (*types.ThrottleDevice, error) {
 	if len(pairs) != 2 {
 		return nil, fmt.Errorf("invalid throttle device %s: format must be <device-id>:<rate> with optional rate unit", val)
 	}
+	if err := validateDevicePath(val, pairs[0]); err != nil {
+		return nil, err
+	}
 
 	rate, err := units.RAMInBytes(pairs[1])
 	if err != nil || rate < 0 {
@@ -137,6 +152,9 @@ func getValidThrottleDeviceIOps(val string) (*types.ThrottleDevice, error) {
 	if len(pairs) != 2 {
 		return nil, fmt.Errorf("invalid throttle device %s: format must be <device-id>:<rate> with optional rate unit", val)
 	}
+	if err := validateDevicePath(val, pairs[0]); err != nil {
+		return nil, err
+	}
 
 	rate, err := strconv.ParseUint(pairs[1], 10, 64)
 	if err != nil || rate < 0 {
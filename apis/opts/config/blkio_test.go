@@ -20,10 +20,10 @@ func Test_getValidateWeightDevice(t *testing.T) {
 		{
 			name: "valid path and weight",
 			args: args{
-				val: "device1:50",
+				val: "/dev/device1:50",
 			},
 			want: &types.WeightDevice{
-				Path:   "device1",
+				Path:   "/dev/device1",
 				Weight: uint16(50),
 			},
 			wantErr: false,
@@ -31,10 +31,10 @@ func Test_getValidateWeightDevice(t *testing.T) {
 		{
 			name: "valid path and weight",
 			args: args{
-				val: "device1:0",
+				val: "/dev/device1:0",
 			},
 			want: &types.WeightDevice{
-				Path:   "device1",
+				Path:   "/dev/device1",
 				Weight: uint16(0),
 			},
 			wantErr: false,
@@ -42,7 +42,7 @@ func Test_getValidateWeightDevice(t *testing.T) {
 		{
 			name: "invalid weight -1",
 			args: args{
-				val: "device1:-1",
+				val: "/dev/device1:-1",
 			},
 			want:    nil,
 			wantErr: true,
@@ -50,7 +50,7 @@ func Test_getValidateWeightDevice(t *testing.T) {
 		{
 			name: "invalid weight 5",
 			args: args{
-				val: "device1:5",
+				val: "/dev/device1:5",
 			},
 			want:    nil,
 			wantErr: true,
@@ -58,10 +58,10 @@ func Test_getValidateWeightDevice(t *testing.T) {
 		{
 			name: "valid upper limit 1000",
 			args: args{
-				val: "device1:1000",
+				val: "/dev/device1:1000",
 			},
 			want: &types.WeightDevice{
-				Path:   "device1",
+				Path:   "/dev/device1",
 				Weight: uint16(1000),
 			},
 			wantErr: false,
@@ -69,7 +69,7 @@ func Test_getValidateWeightDevice(t *testing.T) {
 		{
 			name: "invalid weight 1000 larger than upper limit",
 			args: args{
-				val: "device1:1001",
+				val: "/dev/device1:1001",
 			},
 			want:    nil,
 			wantErr: true,
@@ -77,7 +77,15 @@ func Test_getValidateWeightDevice(t *testing.T) {
 		{
 			name: "invalid weight device, and format must be <device-id>:<weight>",
 			args: args{
-				val: "device1",
+				val: "/dev/device1",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid relative device path",
+			args: args{
+				val: "device1:100",
 			},
 			want:    nil,
 			wantErr: true,
@@ -116,7 +124,7 @@ func TestWeightDevice_Set(t *testing.T) {
 				values: nil,
 			},
 			args: args{
-				val: "device1:100",
+				val: "/dev/device1:100",
 			},
 			wantErr: false,
 		},
@@ -126,7 +134,7 @@ func TestWeightDevice_Set(t *testing.T) {
 				values: nil,
 			},
 			args: args{
-				val: "device1:-100",
+				val: "/dev/device1:-100",
 			},
 			wantErr: true,
 		},
@@ -135,13 +143,13 @@ func TestWeightDevice_Set(t *testing.T) {
 			fields: fields{
 				values: []*types.WeightDevice{
 					{
-						Path:   "device1",
+						Path:   "/dev/device1",
 						Weight: uint16(100),
 					},
 				},
 			},
 			args: args{
-				val: "device1:100",
+				val: "/dev/device1:100",
 			},
 			wantErr: false,
 		},
@@ -172,28 +180,28 @@ func TestWeightDevice_String(t *testing.T) {
 			fields: fields{
 				values: []*types.WeightDevice{
 					{
-						Path:   "device1",
+						Path:   "/dev/device1",
 						Weight: uint16(20),
 					},
 				},
 			},
-			want: "[device1:20]",
+			want: "[/dev/device1:20]",
 		},
 		{
 			name: "",
 			fields: fields{
 				values: []*types.WeightDevice{
 					{
-						Path:   "device1",
+						Path:   "/dev/device1",
 						Weight: uint16(20),
 					},
 					{
-						Path:   "device2",
+						Path:   "/dev/device2",
 						Weight: uint16(40),
 					},
 				},
 			},
-			want: "[device1:20 device2:40]",
+			want: "[/dev/device1:20 /dev/device2:40]",
 		},
 	}
 	for _, tt := range tests {
@@ -222,7 +230,7 @@ func TestWeightDevice_Type(t *testing.T) {
 			fields: fields{
 				values: []*types.WeightDevice{
 					{
-						Path:   "device1",
+						Path:   "/dev/device1",
 						Weight: uint16(1000),
 					},
 				},
@@ -256,22 +264,22 @@ func TestWeightDevice_value(t *testing.T) {
 			fields: fields{
 				values: []*types.WeightDevice{
 					{
-						Path:   "device1",
+						Path:   "/dev/device1",
 						Weight: uint16(1),
 					},
 					{
-						Path:   "device2",
+						Path:   "/dev/device2",
 						Weight: uint16(1000),
 					},
 				},
 			},
 			want: []*types.WeightDevice{
 				{
-					Path:   "device1",
+					Path:   "/dev/device1",
 					Weight: uint16(1),
 				},
 				{
-					Path:   "device2",
+					Path:   "/dev/device2",
 					Weight: uint16(1000),
 				},
 			},
@@ -302,10 +310,10 @@ func Test_getValidThrottleDeviceBps(t *testing.T) {
 		{
 			name: "valid path and weight",
 			args: args{
-				val: "device1:50",
+				val: "/dev/device1:50",
 			},
 			want: &types.ThrottleDevice{
-				Path: "device1",
+				Path: "/dev/device1",
 				Rate: uint64(50),
 			},
 			wantErr: false,
@@ -313,7 +321,7 @@ func Test_getValidThrottleDeviceBps(t *testing.T) {
 		{
 			name: "invalid weight less than 0",
 			args: args{
-				val: "device1:-50",
+				val: "/dev/device1:-50",
 			},
 			want:    nil,
 			wantErr: true,
@@ -321,10 +329,10 @@ func Test_getValidThrottleDeviceBps(t *testing.T) {
 		{
 			name: "valid 50kB in rate",
 			args: args{
-				val: "device1:50kB",
+				val: "/dev/device1:50kB",
 			},
 			want: &types.ThrottleDevice{
-				Path: "device1",
+				Path: "/dev/device1",
 				Rate: uint64(51200),
 			},
 			wantErr: false,
@@ -332,10 +340,10 @@ func Test_getValidThrottleDeviceBps(t *testing.T) {
 		{
 			name: "valid 500MB in rate",
 			args: args{
-				val: "device1:500MB",
+				val: "/dev/device1:500MB",
 			},
 			want: &types.ThrottleDevice{
-				Path: "device1",
+				Path: "/dev/device1",
 				Rate: uint64(524288000),
 			},
 			wantErr: false,
@@ -343,7 +351,15 @@ func Test_getValidThrottleDeviceBps(t *testing.T) {
 		{
 			name: "invalid throttle device and format must be <device-id>:<rate>",
 			args: args{
-				val: "device1",
+				val: "/dev/device1",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid relative device path",
+			args: args{
+				val: "device1:100",
 			},
 			want:    nil,
 			wantErr: true,
@@ -382,7 +398,7 @@ func TestThrottleBpsDevice_Set(t *testing.T) {
 				values: nil,
 			},
 			args: args{
-				val: "device1:100",
+				val: "/dev/device1:100",
 			},
 			wantErr: false,
 		},
@@ -392,7 +408,7 @@ func TestThrottleBpsDevice_Set(t *testing.T) {
 				values: nil,
 			},
 			args: args{
-				val: "device1:-100",
+				val: "/dev/device1:-100",
 			},
 			wantErr: true,
 		},
@@ -401,13 +417,13 @@ func TestThrottleBpsDevice_Set(t *testing.T) {
 			fields: fields{
 				values: []*types.ThrottleDevice{
 					{
-						Path: "device1",
+						Path: "/dev/device1",
 						Rate: uint64(100),
 					},
 				},
 			},
 			args: args{
-				val: "device1:100",
+				val: "/dev/device1:100",
 			},
 			wantErr: false,
 		},
@@ -438,28 +454,28 @@ func TestThrottleBpsDevice_String(t *testing.T) {
 			fields: fields{
 				values: []*types.ThrottleDevice{
 					{
-						Path: "device1",
+						Path: "/dev/device1",
 						Rate: uint64(20),
 					},
 				},
 			},
-			want: "[device1:20]",
+			want: "[/dev/device1:20]",
 		},
 		{
 			name: "",
 			fields: fields{
 				values: []*types.ThrottleDevice{
 					{
-						Path: "device1",
+						Path: "/dev/device1",
 						Rate: uint64(51200),
 					},
 					{
-						Path: "device2",
+						Path: "/dev/device2",
 						Rate: uint64(40),
 					},
 				},
 			},
-			want: "[device1:51200 device2:40]",
+			want: "[/dev/device1:51200 /dev/device2:40]",
 		},
 	}
 	for _, tt := range tests {
@@ -488,7 +504,7 @@ func TestThrottleBpsDevice_Type(t *testing.T) {
 			fields: fields{
 				values: []*types.ThrottleDevice{
 					{
-						Path: "device1",
+						Path: "/dev/device1",
 						Rate: uint64(51200),
 					},
 				},
@@ -522,7 +538,7 @@ func TestThrottleBpsDevice_value(t *testing.T) {
 			fields: fields{
 				values: []*types.ThrottleDevice{
 					{
-						Path: "device1",
+						Path: "/dev/device1",
 					},
 					{
 						Rate: uint64(51200),
@@ -531,7 +547,7 @@ func TestThrottleBpsDevice_value(t *testing.T) {
 			},
 			want: []*types.ThrottleDevice{
 				{
-					Path: "device1",
+					Path: "/dev/device1",
 				},
 				{
 					Rate: uint64(51200),
@@ -543,22 +559,22 @@ func TestThrottleBpsDevice_value(t *testing.T) {
 			fields: fields{
 				values: []*types.ThrottleDevice{
 					{
-						Path: "device1",
+						Path: "/dev/device1",
 						Rate: uint64(51200),
 					},
 					{
-						Path: "device2",
+						Path: "/dev/device2",
 						Rate: uint64(102400),
 					},
 				},
 			},
 			want: []*types.ThrottleDevice{
 				{
-					Path: "device1",
+					Path: "/dev/device1",
 					Rate: uint64(51200),
 				},
 				{
-					Path: "device2",
+					Path: "/dev/device2",
 					Rate: uint64(102400),
 				},
 			},
@@ -589,10 +605,10 @@ func Test_getValidThrottleDeviceIOps(t *testing.T) {
 		{
 			name: "valid path and weight",
 			args: args{
-				val: "device1:50",
+				val: "/dev/device1:50",
 			},
 			want: &types.ThrottleDevice{
-				Path: "device1",
+				Path: "/dev/device1",
 				Rate: uint64(50),
 			},
 			wantErr: false,
@@ -600,7 +616,7 @@ func Test_getValidThrottleDeviceIOps(t *testing.T) {
 		{
 			name: "invalid weight less than 0",
 			args: args{
-				val: "device1:-50",
+				val: "/dev/device1:-50",
 			},
 			want:    nil,
 			wantErr: true,
@@ -608,10 +624,10 @@ func Test_getValidThrottleDeviceIOps(t *testing.T) {
 		{
 			name: "valid 50 in rate",
 			args: args{
-				val: "device1:50",
+				val: "/dev/device1:50",
 			},
 			want: &types.ThrottleDevice{
-				Path: "device1",
+				Path: "/dev/device1",
 				Rate: uint64(50),
 			},
 			wantErr: false,
@@ -619,10 +635,10 @@ func Test_getValidThrottleDeviceIOps(t *testing.T) {
 		{
 			name: "valid 500 in rate",
 			args: args{
-				val: "device1:500",
+				val: "/dev/device1:500",
 			},
 			want: &types.ThrottleDevice{
-				Path: "device1",
+				Path: "/dev/device1",
 				Rate: uint64(500),
 			},
 			wantErr: false,
@@ -630,10 +646,18 @@ func Test_getValidThrottleDeviceIOps(t *testing.T) {
 		{
 			name: "invalid throttle device and format must be <device-id>:<rate>",
 			args: args{
-				val: "device1",
+				val: "/dev/device1",
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid relative device path",
+			args: args{
+				val: "device1:100",
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -668,7 +692,7 @@ func TestThrottleIOpsDevice_Set(t *testing.T) {
 				values: nil,
 			},
 			args: args{
-				val: "device1:100",
+				val: "/dev/device1:100",
 			},
 			wantErr: false,
 		},
@@ -678,7 +702,7 @@ func TestThrottleIOpsDevice_Set(t *testing.T) {
 				values: nil,
 			},
 			args: args{
-				val: "device1:-100",
+				val: "/dev/device1:-100",
 			},
 			wantErr: true,
 		},
@@ -687,13 +711,13 @@ func TestThrottleIOpsDevice_Set(t *testing.T) {
 			fields: fields{
 				values: []*types.ThrottleDevice{
 					{
-						Path: "device1",
+						Path: "/dev/device1",
 						Rate: uint64(100),
 					},
 				},
 			},
 			args: args{
-				val: "device1:100",
+				val: "/dev/device1:100",
 			},
 			wantErr: false,
 		},
@@ -724,28 +748,28 @@ func TestThrottleIOpsDevice_String(t *testing.T) {
 			fields: fields{
 				values: []*types.ThrottleDevice{
 					{
-						Path: "device1",
+						Path: "/dev/device1",
 						Rate: uint64(20),
 					},
 				},
 			},
-			want: "[device1:20]",
+			want: "[/dev/device1:20]",
 		},
 		{
 			name: "",
 			fields: fields{
 				values: []*types.ThrottleDevice{
 					{
-						Path: "device1",
+						Path: "/dev/device1",
 						Rate: uint64(51200),
 					},
 					{
-						Path: "device2",
+						Path: "/dev/device2",
 						Rate: uint64(40),
 					},
 				},
 			},
-			want: "[device1:51200 device2:40]",
+			want: "[/dev/device1:51200 /dev/device2:40]",
 		},
 	}
 	for _, tt := range tests {
@@ -774,7 +798,7 @@ func TestThrottleIOpsDevice_Type(t *testing.T) {
 			fields: fields{
 				values: []*types.ThrottleDevice{
 					{
-						Path: "device1",
+						Path: "/dev/device1",
 						Rate: uint64(51200),
 					},
 				},
@@ -808,7 +832,7 @@ func TestThrottleIOpsDevice_value(t *testing.T) {
 			fields: fields{
 				values: []*types.ThrottleDevice{
 					{
-						Path: "device1",
+						Path: "/dev/device1",
 					},
 					{
 						Rate: uint64(102400),
@@ -817,7 +841,7 @@ func TestThrottleIOpsDevice_value(t *testing.T) {
 			},
 			want: []*types.ThrottleDevice{
 				{
-					Path: "device1",
+					Path: "/dev/device1",
 				},
 				{
 					Rate: uint64(102400),
@@ -829,22 +853,22 @@ func TestThrottleIOpsDevice_value(t *testing.T) {
 			fields: fields{
 				values: []*types.ThrottleDevice{
 					{
-						Path: "device1",
+						Path: "/dev/device1",
 						Rate: uint64(51200),
 					},
 					{
-						Path: "device2",
+						Path: "/dev/device2",
 						Rate: uint64(102400),
 					},
 				},
 			},
 			want: []*types.ThrottleDevice{
 				{
-					Path: "device1",
+					Path: "/dev/device1",
 					Rate: uint64(51200),
 				},
 				{
-					Path: "device2",
+					Path: "/dev/device2",
 					Rate: uint64(102400),
 				},
 			},
@@ -2,7 +2,9 @@ package config
 
 import (
 	"fmt"
+	"sort"
 
+	"github.com/alibaba/pouch/apis/opts"
 	"github.com/alibaba/pouch/apis/types"
 
 	units "github.com/docker/go-units"
@@ -20,6 +22,10 @@ func (u *Ulimit) Set(val string) error {
 		return err
 	}
 
+	if err := opts.ValidateUlimit(&types.Ulimit{Name: ul.Name, Soft: ul.Soft, Hard: ul.Hard}); err != nil {
+		return err
+	}
+
 	if u.values == nil {
 		u.values = make(map[string]*units.Ulimit)
 	}
@@ -43,7 +49,7 @@ func (u *Ulimit) Type() string {
 	return "ulimit"
 }
 
-// Value return ulimit values as type Ulimit
+// Value return ulimit values as type Ulimit, which are sorted by name.
 func (u *Ulimit) Value() []*types.Ulimit {
 	var ulimit []*types.Ulimit
 	for _, ul := range u.values {
@@ -53,6 +59,9 @@ func (u *Ulimit) Value() []*types.Ulimit {
 			Soft: ul.Soft,
 		})
 	}
+	sort.Slice(ulimit, func(i, j int) bool {
+		return ulimit[i].Name < ulimit[j].Name
+	})
 
 	return ulimit
 }
@@ -49,6 +49,21 @@ func TestUlimitSet(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid unlimited ulimit",
+			args: args{
+				val: "memlock=-1",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid negative ulimit",
+			args: args{
+				val: "memlock=-2",
+			},
+			wantErr: true,
+			err:     fmt.Errorf("invalid ulimit memlock=-2:-2: limits should not be less than -1"),
+		},
 		{
 			name: "invalid ulimit value type 1",
 			args: args{
@@ -0,0 +1,15 @@
+package opts
+
+import "fmt"
+
+// ParseCPUs parses the number of CPUs into nano CPUs, --cpus can not be set
+// with --cpu-period and --cpu-quota, since it is applied by them.
+func ParseCPUs(cpus float64, period, quota int64) (int64, error) {
+	if cpus < 0 {
+		return 0, fmt.Errorf("invalid cpus %v: must not be negative", cpus)
+	}
+	if cpus > 0 && (period != 0 || quota != 0) {
+		return 0, fmt.Errorf("Conflicting options: --cpus and --cpu-period/--cpu-quota")
+	}
+	return int64(cpus * 1e9), nil
+}
@@ -0,0 +1,24 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCPUs(t *testing.T) {
+	nanoCPUs, err := ParseCPUs(1.5, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1500000000), nanoCPUs)
+
+	nanoCPUs, err = ParseCPUs(0, 100000, 50000)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), nanoCPUs)
+
+	_, err = ParseCPUs(-1, 0, 0)
+	assert.Error(t, err)
+	_, err = ParseCPUs(1, 100000, 0)
+	assert.Error(t, err)
+	_, err = ParseCPUs(1, 0, 50000)
+	assert.Error(t, err)
+}
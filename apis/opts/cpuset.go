@@ -0,0 +1,84 @@
+package opts
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxCpusetIndex is the upper bound of cpu or mem node index, to avoid
+// allocating huge set for the invalid input.
+const maxCpusetIndex = 8192
+
+// ParseCpuset parses the cpuset param of container, such as "0-3,5", into a
+// set of cpu or mem node indexes.
+func ParseCpuset(cpuset string) (map[int]bool, error) {
+	result := map[int]bool{}
+	if cpuset == "" {
+		return result, nil
+	}
+
+	for _, r := range strings.Split(cpuset, ",") {
+		bounds := strings.SplitN(r, "-", 2)
+
+		low, err := parseCpusetIndex(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cpuset %q: %v", cpuset, err)
+		}
+
+		high := low
+		if len(bounds) == 2 {
+			high, err = parseCpusetIndex(bounds[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid cpuset %q: %v", cpuset, err)
+			}
+			if high < low {
+				return nil, fmt.Errorf("invalid cpuset %q: invalid range %s", cpuset, r)
+			}
+		}
+
+		for i := low; i <= high; i++ {
+			result[i] = true
+		}
+	}
+
+	return result, nil
+}
+
+func parseCpusetIndex(s string) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid index %q", s)
+	}
+	if i > maxCpusetIndex {
+		return 0, fmt.Errorf("index %d is out of range", i)
+	}
+	return i, nil
+}
+
+// ValidateCpuset verifies the syntax of cpuset param of container.
+func ValidateCpuset(cpuset string) error {
+	_, err := ParseCpuset(cpuset)
+	return err
+}
+
+// IsCpusetAvailable checks whether all the cpus or mem nodes in provided
+// cpuset are in the available cpuset.
+func IsCpusetAvailable(provided, available string) (bool, error) {
+	parsedProvided, err := ParseCpuset(provided)
+	if err != nil {
+		return false, err
+	}
+
+	parsedAvailable, err := ParseCpuset(available)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range parsedProvided {
+		if !parsedAvailable[i] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
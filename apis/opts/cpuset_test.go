@@ -0,0 +1,59 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCpuset(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected map[int]bool
+		err      bool
+	}{
+		{input: "", expected: map[int]bool{}},
+		{input: "0", expected: map[int]bool{0: true}},
+		{input: "0,2", expected: map[int]bool{0: true, 2: true}},
+		{input: "0-3,5", expected: map[int]bool{0: true, 1: true, 2: true, 3: true, 5: true}},
+		{input: "1-1", expected: map[int]bool{1: true}},
+		{input: "3-1", err: true},
+		{input: "a", err: true},
+		{input: "-1", err: true},
+		{input: "0,", err: true},
+		{input: "0-", err: true},
+		{input: "0 - 3", err: true},
+		{input: "0-100000000", err: true},
+	} {
+		got, err := ParseCpuset(tc.input)
+		if tc.err {
+			assert.Error(t, err, tc.input)
+			continue
+		}
+		assert.NoError(t, err, tc.input)
+		assert.Equal(t, tc.expected, got, tc.input)
+	}
+}
+
+func TestIsCpusetAvailable(t *testing.T) {
+	for _, tc := range []struct {
+		provided  string
+		available string
+		expected  bool
+		err       bool
+	}{
+		{provided: "0-3", available: "0-7", expected: true},
+		{provided: "0,7", available: "0-7", expected: true},
+		{provided: "8", available: "0-7", expected: false},
+		{provided: "0-3", available: "0-1,3", expected: false},
+		{provided: "0-a", available: "0-7", err: true},
+	} {
+		got, err := IsCpusetAvailable(tc.provided, tc.available)
+		if tc.err {
+			assert.Error(t, err, tc.provided)
+			continue
+		}
+		assert.NoError(t, err, tc.provided)
+		assert.Equal(t, tc.expected, got, tc.provided)
+	}
+}
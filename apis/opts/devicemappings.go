@@ -2,11 +2,17 @@ package opts
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
 )
 
+// deviceCgroupRuleRegexp matches the device cgroup rule in the format of
+// "type major:minor access", like "c 13:* rwm".
+var deviceCgroupRuleRegexp = regexp.MustCompile(`^([acb]) ([0-9]+|\*):([0-9]+|\*) ([rwm]{1,3})$`)
+
 // ParseDeviceMappings parse devicemappings
 func ParseDeviceMappings(devices []string) ([]*types.DeviceMapping, error) {
 	results := []*types.DeviceMapping{}
@@ -76,3 +82,42 @@ func ValidateDeviceMode(mode string) bool {
 	}
 	return true
 }
+
+// ParseDeviceCgroupRule parses the device cgroup rule in the format of
+// "type major:minor access". The type is one of a (all), c (char) and b
+// (block), the wildcard * of major or minor number is returned as -1, and
+// the access is a composition of r, w and m.
+func ParseDeviceCgroupRule(rule string) (devType string, major, minor int64, access string, err error) {
+	matches := deviceCgroupRuleRegexp.FindStringSubmatch(rule)
+	if matches == nil {
+		return "", 0, 0, "", fmt.Errorf("invalid device cgroup rule %q, must be in the format of 'type major:minor access', like 'c 13:* rwm'", rule)
+	}
+
+	if !ValidateDeviceMode(matches[4]) {
+		return "", 0, 0, "", fmt.Errorf("invalid device cgroup rule %q, invalid access mode: %s", rule, matches[4])
+	}
+
+	parseNumber := func(s string) (int64, error) {
+		if s == "*" {
+			return -1, nil
+		}
+		return strconv.ParseInt(s, 10, 64)
+	}
+	if major, err = parseNumber(matches[2]); err != nil {
+		return "", 0, 0, "", fmt.Errorf("invalid device cgroup rule %q: %v", rule, err)
+	}
+	if minor, err = parseNumber(matches[3]); err != nil {
+		return "", 0, 0, "", fmt.Errorf("invalid device cgroup rule %q: %v", rule, err)
+	}
+	return matches[1], major, minor, matches[4], nil
+}
+
+// ValidateDeviceCgroupRules checks the device cgroup rules are valid.
+func ValidateDeviceCgroupRules(rules []string) error {
+	for _, rule := range rules {
+		if _, _, _, _, err := ParseDeviceCgroupRule(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
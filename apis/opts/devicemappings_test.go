@@ -164,3 +164,36 @@ func TestValidateDeviceMode(t *testing.T) {
 		assert.Equal(t, modeCase.expected, isValid, modeCase.input)
 	}
 }
+
+func TestParseDeviceCgroupRule(t *testing.T) {
+	devType, major, minor, access, err := ParseDeviceCgroupRule("c 13:* rwm")
+	assert.NoError(t, err)
+	assert.Equal(t, "c", devType)
+	assert.Equal(t, int64(13), major)
+	assert.Equal(t, int64(-1), minor)
+	assert.Equal(t, "rwm", access)
+
+	devType, major, minor, access, err = ParseDeviceCgroupRule("b *:8 r")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", devType)
+	assert.Equal(t, int64(-1), major)
+	assert.Equal(t, int64(8), minor)
+	assert.Equal(t, "r", access)
+
+	for _, rule := range []string{
+		"",
+		"c 13:* ",
+		"x 13:* rwm",
+		"c 13 rwm",
+		"c 13:* rwx",
+		"c 13:* rrw",
+		"c -1:* rwm",
+		"c 99999999999999999999:* rwm",
+	} {
+		_, _, _, _, err := ParseDeviceCgroupRule(rule)
+		assert.Error(t, err, rule)
+	}
+
+	assert.NoError(t, ValidateDeviceCgroupRules([]string{"c 13:* rwm", "a *:* m"}))
+	assert.Error(t, ValidateDeviceCgroupRules([]string{"c 13:* rwm", "c 13:*"}))
+}
@@ -0,0 +1,45 @@
+package opts
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ValidateDNS verifies the dns servers, which should be ip addresses.
+func ValidateDNS(dns []string) error {
+	for _, d := range dns {
+		if net.ParseIP(d) == nil {
+			return fmt.Errorf("invalid dns server %s: should be an ip address", d)
+		}
+	}
+	return nil
+}
+
+// ValidateDNSSearch verifies the dns search domains, "." means no search
+// domain is used.
+func ValidateDNSSearch(search []string) error {
+	for _, s := range search {
+		if s == "." {
+			continue
+		}
+
+		domain := strings.TrimSuffix(s, ".")
+		if domain == "" || len(domain) > 255 || strings.HasPrefix(domain, ".") ||
+			strings.HasPrefix(domain, "-") || strings.ContainsAny(domain, " \t") {
+			return fmt.Errorf("invalid dns search domain %q", s)
+		}
+	}
+	return nil
+}
+
+// ValidateDNSOptions verifies the dns options, which is in the format of
+// option or option:value.
+func ValidateDNSOptions(options []string) error {
+	for _, o := range options {
+		if o == "" || strings.HasPrefix(o, ":") || strings.ContainsAny(o, " \t") {
+			return fmt.Errorf("invalid dns option %q", o)
+		}
+	}
+	return nil
+}
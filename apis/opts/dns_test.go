@@ -0,0 +1,29 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDNS(t *testing.T) {
+	assert.NoError(t, ValidateDNS(nil))
+	assert.NoError(t, ValidateDNS([]string{"8.8.8.8", "2001:4860:4860::8888"}))
+	assert.Error(t, ValidateDNS([]string{"8.8.8.8", "dns.example.com"}))
+	assert.Error(t, ValidateDNS([]string{""}))
+}
+
+func TestValidateDNSSearch(t *testing.T) {
+	assert.NoError(t, ValidateDNSSearch([]string{"example.com", "example.com.", "mydomain", "."}))
+	assert.Error(t, ValidateDNSSearch([]string{""}))
+	assert.Error(t, ValidateDNSSearch([]string{".example.com"}))
+	assert.Error(t, ValidateDNSSearch([]string{"-example.com"}))
+	assert.Error(t, ValidateDNSSearch([]string{"example .com"}))
+}
+
+func TestValidateDNSOptions(t *testing.T) {
+	assert.NoError(t, ValidateDNSOptions([]string{"ndots:2", "rotate"}))
+	assert.Error(t, ValidateDNSOptions([]string{""}))
+	assert.Error(t, ValidateDNSOptions([]string{":2"}))
+	assert.Error(t, ValidateDNSOptions([]string{"ndots: 2"}))
+}
@@ -0,0 +1,54 @@
+package opts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// ParseHealthcheck parses the health check params of container, it returns
+// nil if no health check command is specified.
+func ParseHealthcheck(cmd string, interval, timeout, startPeriod time.Duration, retries int) (*types.HealthConfig, error) {
+	if cmd == "" {
+		if interval != 0 || timeout != 0 || startPeriod != 0 || retries != 0 {
+			return nil, fmt.Errorf("--health-interval, --health-timeout, --health-start-period and --health-retries can only be used with --health-cmd")
+		}
+		return nil, nil
+	}
+
+	config := &types.HealthConfig{
+		Test:        []string{"CMD-SHELL", cmd},
+		Interval:    int64(interval),
+		Timeout:     int64(timeout),
+		StartPeriod: int64(startPeriod),
+		Retries:     int64(retries),
+	}
+
+	if err := ValidateHealthcheck(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// ValidateHealthcheck verifies the correctness of health check config of container.
+func ValidateHealthcheck(config *types.HealthConfig) error {
+	if config == nil {
+		return nil
+	}
+
+	if config.Interval < 0 {
+		return fmt.Errorf("--health-interval can not be negative")
+	}
+	if config.Timeout < 0 {
+		return fmt.Errorf("--health-timeout can not be negative")
+	}
+	if config.StartPeriod < 0 {
+		return fmt.Errorf("--health-start-period can not be negative")
+	}
+	if config.Retries < 0 {
+		return fmt.Errorf("--health-retries can not be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package opts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHealthcheck(t *testing.T) {
+	config, err := ParseHealthcheck("", 0, 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	_, err = ParseHealthcheck("", time.Second, 0, 0, 0)
+	assert.Error(t, err)
+
+	_, err = ParseHealthcheck("", 0, 0, 0, 3)
+	assert.Error(t, err)
+
+	config, err = ParseHealthcheck("exit 0", time.Second, 2*time.Second, 3*time.Second, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, &types.HealthConfig{
+		Test:        []string{"CMD-SHELL", "exit 0"},
+		Interval:    int64(time.Second),
+		Timeout:     int64(2 * time.Second),
+		StartPeriod: int64(3 * time.Second),
+		Retries:     4,
+	}, config)
+
+	_, err = ParseHealthcheck("exit 0", -time.Second, 0, 0, 0)
+	assert.Error(t, err)
+
+	_, err = ParseHealthcheck("exit 0", 0, 0, 0, -1)
+	assert.Error(t, err)
+}
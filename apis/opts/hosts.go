@@ -15,6 +15,9 @@ func ValidateExtraHost(val string) error {
 	if len(arr) != 2 || len(arr[0]) == 0 {
 		return errors.Errorf("bad format for add-host: %q", val)
 	}
+	if strings.ContainsAny(arr[0], " \t") {
+		return errors.Errorf("bad format for add-host: %q, host cannot contain whitespace", val)
+	}
 	// TODO(lang710): Skip ipaddr validation for special "host-gateway" string
 	//  If the IP Address is a string called "host-gateway", replace this
 	//  value with the IP address stored in the daemon level HostGatewayIP
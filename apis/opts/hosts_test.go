@@ -18,6 +18,8 @@ func TestValidateExtraHosts(t *testing.T) {
 		`thathost-nosemicolon10.0.0.1`: `bad format`,
 		`anipv6host:::::1`:             `invalid IP`,
 		`ipv6local:::0::`:              `invalid IP`,
+		`:10.0.0.1`:                    `bad format`,
+		`my host:10.0.0.1`:             `bad format`,
 	}
 
 	for _, extrahost := range valid {
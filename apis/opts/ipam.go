@@ -0,0 +1,80 @@
+package opts
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// ValidateIPAMConfigs verifies the ipam configs of network, every config
+// should be valid and the subnets should not overlap with each other.
+func ValidateIPAMConfigs(configs []types.IPAMConfig) error {
+	subnets := make([]*net.IPNet, 0, len(configs))
+	for _, cfg := range configs {
+		if err := ValidateIPAMConfig(cfg); err != nil {
+			return err
+		}
+
+		_, subnet, _ := net.ParseCIDR(cfg.Subnet)
+		for _, s := range subnets {
+			if s.Contains(subnet.IP) || subnet.Contains(s.IP) {
+				return fmt.Errorf("invalid subnet %s: overlaps with subnet %s", cfg.Subnet, s.String())
+			}
+		}
+		subnets = append(subnets, subnet)
+	}
+	return nil
+}
+
+// ValidateIPAMConfig verifies the ipam config of network, the gateway, ip
+// range and auxiliary addresses must be in the subnet.
+func ValidateIPAMConfig(cfg types.IPAMConfig) error {
+	if cfg.Subnet == "" {
+		if cfg.Gateway != "" || cfg.IPRange != "" || len(cfg.AuxAddress) > 0 {
+			return fmt.Errorf("gateway, ip range and auxiliary addresses cannot be set without subnet")
+		}
+		return nil
+	}
+
+	_, subnet, err := net.ParseCIDR(cfg.Subnet)
+	if err != nil {
+		return fmt.Errorf("invalid subnet %s: %v", cfg.Subnet, err)
+	}
+
+	if cfg.IPRange != "" {
+		ip, ipRange, err := net.ParseCIDR(cfg.IPRange)
+		if err != nil {
+			return fmt.Errorf("invalid ip range %s: %v", cfg.IPRange, err)
+		}
+		rangeOnes, _ := ipRange.Mask.Size()
+		subnetOnes, _ := subnet.Mask.Size()
+		if !subnet.Contains(ip) || rangeOnes < subnetOnes {
+			return fmt.Errorf("invalid ip range %s: not in subnet %s", cfg.IPRange, cfg.Subnet)
+		}
+	}
+
+	if cfg.Gateway != "" {
+		if err := validateIPInSubnet(cfg.Gateway, subnet); err != nil {
+			return fmt.Errorf("invalid gateway %s: %v", cfg.Gateway, err)
+		}
+	}
+
+	for name, addr := range cfg.AuxAddress {
+		if err := validateIPInSubnet(addr, subnet); err != nil {
+			return fmt.Errorf("invalid auxiliary address %s=%s: %v", name, addr, err)
+		}
+	}
+	return nil
+}
+
+func validateIPInSubnet(addr string, subnet *net.IPNet) error {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return fmt.Errorf("invalid ip address")
+	}
+	if !subnet.Contains(ip) {
+		return fmt.Errorf("not in subnet %s", subnet.String())
+	}
+	return nil
+}
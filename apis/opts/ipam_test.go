@@ -0,0 +1,60 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateIPAMConfig(t *testing.T) {
+	for _, tc := range []struct {
+		cfg       types.IPAMConfig
+		expectErr bool
+	}{
+		{cfg: types.IPAMConfig{}, expectErr: false},
+		{cfg: types.IPAMConfig{Subnet: "192.168.1.0/24"}, expectErr: false},
+		{
+			cfg: types.IPAMConfig{
+				Subnet:     "192.168.1.0/24",
+				Gateway:    "192.168.1.1",
+				IPRange:    "192.168.1.128/25",
+				AuxAddress: map[string]string{"host1": "192.168.1.5"},
+			},
+			expectErr: false,
+		},
+		{cfg: types.IPAMConfig{Gateway: "192.168.1.1"}, expectErr: true},
+		{cfg: types.IPAMConfig{Subnet: "192.168.1.0"}, expectErr: true},
+		{cfg: types.IPAMConfig{Subnet: "192.168.1.0/24", Gateway: "192.168.2.1"}, expectErr: true},
+		{cfg: types.IPAMConfig{Subnet: "192.168.1.0/24", Gateway: "foo"}, expectErr: true},
+		{cfg: types.IPAMConfig{Subnet: "192.168.1.0/24", IPRange: "192.168.0.0/16"}, expectErr: true},
+		{cfg: types.IPAMConfig{Subnet: "192.168.1.0/24", IPRange: "192.168.2.0/25"}, expectErr: true},
+		{
+			cfg: types.IPAMConfig{
+				Subnet:     "192.168.1.0/24",
+				AuxAddress: map[string]string{"host1": "192.168.2.5"},
+			},
+			expectErr: true,
+		},
+	} {
+		err := ValidateIPAMConfig(tc.cfg)
+		if tc.expectErr {
+			assert.Error(t, err, "config %v", tc.cfg)
+		} else {
+			assert.NoError(t, err, "config %v", tc.cfg)
+		}
+	}
+}
+
+func TestValidateIPAMConfigs(t *testing.T) {
+	assert.NoError(t, ValidateIPAMConfigs([]types.IPAMConfig{
+		{Subnet: "192.168.1.0/24"},
+		{Subnet: "192.168.2.0/24"},
+	}))
+
+	assert.Error(t, ValidateIPAMConfigs([]types.IPAMConfig{
+		{Subnet: "192.168.1.0/24"},
+		{Subnet: "192.168.0.0/16"},
+	}))
+}
@@ -1,7 +1,9 @@
 package opts
 
 import (
+	"fmt"
 	"strings"
+	"unicode"
 )
 
 // ParseLabels parses the labels params of container.
@@ -23,3 +25,26 @@ func parseLabel(label string) []string {
 	}
 	return fields
 }
+
+// ValidateLabels verifies the labels params of container, which are in the
+// format of KEY=VALUE.
+func ValidateLabels(labels []string) error {
+	for _, label := range labels {
+		if err := ValidateLabelKey(parseLabel(label)[0]); err != nil {
+			return fmt.Errorf("invalid label %q: %v", label, err)
+		}
+	}
+	return nil
+}
+
+// ValidateLabelKey verifies the key of label, it should not be empty or
+// contain whitespaces.
+func ValidateLabelKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("label key should not be empty")
+	}
+	if strings.IndexFunc(key, unicode.IsSpace) != -1 {
+		return fmt.Errorf("label key %q should not contain whitespaces", key)
+	}
+	return nil
+}
@@ -86,3 +86,11 @@ func TestParseLabels(t *testing.T) {
 		assert.Equal(t, testCase.expected.labels, labels)
 	}
 }
+
+func TestValidateLabels(t *testing.T) {
+	assert.NoError(t, ValidateLabels([]string{"a=b", "a", "a=", "com.example.foo=bar baz", "app.kubernetes.io/name=pouch"}))
+
+	for _, label := range []string{"", "=b", "a b=c", "a\tb"} {
+		assert.Error(t, ValidateLabels([]string{label}), label)
+	}
+}
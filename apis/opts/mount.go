@@ -0,0 +1,146 @@
+package opts
+
+import (
+	"encoding/csv"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// ParseMounts parses the long-form mount params of container, each of them
+// is in the form of comma-separated key=value pairs, such as
+// "type=tmpfs,target=/run,tmpfs-size=64m".
+func ParseMounts(mounts []string) ([]*types.Mount, error) {
+	var results []*types.Mount
+
+	for _, m := range mounts {
+		mount, err := ParseMount(m)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range results {
+			if filepath.Clean(r.Target) == filepath.Clean(mount.Target) {
+				return nil, fmt.Errorf("invalid mount %q: duplicate mount point %s", m, mount.Target)
+			}
+		}
+		results = append(results, mount)
+	}
+
+	return results, nil
+}
+
+// ParseMount parses one long-form mount param of container.
+func ParseMount(mount string) (*types.Mount, error) {
+	fields, err := csv.NewReader(strings.NewReader(mount)).Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid mount %q: %v", mount, err)
+	}
+
+	m := &types.Mount{}
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		key := strings.ToLower(parts[0])
+
+		if len(parts) == 1 {
+			// readonly can be specified without value.
+			if key == "readonly" || key == "ro" {
+				m.ReadOnly = true
+				continue
+			}
+			return nil, fmt.Errorf("invalid mount %q: invalid field %q, must be a key=value pair", mount, field)
+		}
+
+		value := parts[1]
+		switch key {
+		case "type":
+			m.Type = value
+		case "source", "src":
+			m.Source = value
+		case "target", "destination", "dst":
+			m.Target = value
+		case "readonly", "ro":
+			m.ReadOnly, err = strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid mount %q: invalid value %q for field %q", mount, value, key)
+			}
+		case "bind-propagation":
+			m.BindOptions = &types.MountBindOptions{Propagation: value}
+		case "tmpfs-size":
+			size, err := ParseMemory(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid mount %q: invalid value %q for field %q: %v", mount, value, key, err)
+			}
+			m.TmpfsOptions = &types.MountTmpfsOptions{SizeBytes: size}
+		default:
+			return nil, fmt.Errorf("invalid mount %q: unknown field %q", mount, key)
+		}
+	}
+
+	if err := ValidateMount(m); err != nil {
+		return nil, fmt.Errorf("invalid mount %q: %v", mount, err)
+	}
+	return m, nil
+}
+
+// ValidateMount verifies the correctness of long-form mount of container.
+func ValidateMount(m *types.Mount) error {
+	if m.Type == "" {
+		return fmt.Errorf("field \"type\" is required")
+	}
+
+	if m.Target == "" {
+		return fmt.Errorf("field \"target\" is required")
+	}
+	if !filepath.IsAbs(m.Target) {
+		return fmt.Errorf("invalid target %q, must be an absolute path", m.Target)
+	}
+
+	switch m.Type {
+	case types.MountTypeBind:
+		if m.Source == "" {
+			return fmt.Errorf("field \"source\" is required for bind mount")
+		}
+		if !filepath.IsAbs(m.Source) {
+			return fmt.Errorf("invalid source %q, must be an absolute path for bind mount", m.Source)
+		}
+		if m.TmpfsOptions != nil {
+			return fmt.Errorf("field \"tmpfs-size\" is not allowed for bind mount")
+		}
+	case types.MountTypeVolume:
+		if strings.Contains(m.Source, "/") {
+			return fmt.Errorf("invalid source %q, must be a volume name for volume mount", m.Source)
+		}
+		if m.BindOptions != nil {
+			return fmt.Errorf("field \"bind-propagation\" is not allowed for volume mount")
+		}
+		if m.TmpfsOptions != nil {
+			return fmt.Errorf("field \"tmpfs-size\" is not allowed for volume mount")
+		}
+	case types.MountTypeTmpfs:
+		if m.Source != "" {
+			return fmt.Errorf("field \"source\" is not allowed for tmpfs mount")
+		}
+		if m.BindOptions != nil {
+			return fmt.Errorf("field \"bind-propagation\" is not allowed for tmpfs mount")
+		}
+		if m.TmpfsOptions != nil && m.TmpfsOptions.SizeBytes < 0 {
+			return fmt.Errorf("invalid tmpfs size %d, can not be negative", m.TmpfsOptions.SizeBytes)
+		}
+	default:
+		return fmt.Errorf("invalid type %q, must be one of bind, volume and tmpfs", m.Type)
+	}
+
+	if m.BindOptions != nil {
+		switch m.BindOptions.Propagation {
+		case "private", "rprivate", "slave", "rslave", "shared", "rshared":
+		default:
+			return fmt.Errorf("invalid bind propagation %q, must be one of [r]private, [r]shared and [r]slave", m.BindOptions.Propagation)
+		}
+	}
+
+	return nil
+}
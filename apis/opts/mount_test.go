@@ -0,0 +1,134 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMount(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected *types.Mount
+		err      string
+	}{
+		{
+			input: "type=tmpfs,target=/run,tmpfs-size=64m",
+			expected: &types.Mount{
+				Type:         "tmpfs",
+				Target:       "/run",
+				TmpfsOptions: &types.MountTmpfsOptions{SizeBytes: 64 * 1024 * 1024},
+			},
+		},
+		{
+			input: "type=bind,src=/data,dst=/data,readonly,bind-propagation=rslave",
+			expected: &types.Mount{
+				Type:        "bind",
+				Source:      "/data",
+				Target:      "/data",
+				ReadOnly:    true,
+				BindOptions: &types.MountBindOptions{Propagation: "rslave"},
+			},
+		},
+		{
+			input: "type=volume,source=vol,target=/vol,readonly=false",
+			expected: &types.Mount{
+				Type:   "volume",
+				Source: "vol",
+				Target: "/vol",
+			},
+		},
+		{
+			input: "type=volume,target=/vol",
+			expected: &types.Mount{
+				Type:   "volume",
+				Target: "/vol",
+			},
+		},
+		{
+			input: "type=bind,target=/data,foo",
+			err:   `invalid field "foo", must be a key=value pair`,
+		},
+		{
+			input: "type=bind,target=/data,foo=bar",
+			err:   `unknown field "foo"`,
+		},
+		{
+			input: "type=bind,target=/data,readonly=yes",
+			err:   `invalid value "yes" for field "readonly"`,
+		},
+		{
+			input: "type=tmpfs,target=/run,tmpfs-size=abc",
+			err:   `invalid value "abc" for field "tmpfs-size"`,
+		},
+		{
+			input: "target=/data",
+			err:   `field "type" is required`,
+		},
+		{
+			input: "type=overlay,target=/data",
+			err:   `invalid type "overlay"`,
+		},
+		{
+			input: "type=tmpfs",
+			err:   `field "target" is required`,
+		},
+		{
+			input: "type=tmpfs,target=run",
+			err:   `invalid target "run"`,
+		},
+		{
+			input: "type=bind,target=/data",
+			err:   `field "source" is required for bind mount`,
+		},
+		{
+			input: "type=bind,source=data,target=/data",
+			err:   `invalid source "data"`,
+		},
+		{
+			input: "type=bind,source=/data,target=/data,tmpfs-size=1m",
+			err:   `field "tmpfs-size" is not allowed for bind mount`,
+		},
+		{
+			input: "type=volume,source=/data,target=/data",
+			err:   `invalid source "/data"`,
+		},
+		{
+			input: "type=tmpfs,source=/data,target=/data",
+			err:   `field "source" is not allowed for tmpfs mount`,
+		},
+		{
+			input: "type=tmpfs,target=/data,bind-propagation=shared",
+			err:   `field "bind-propagation" is not allowed for tmpfs mount`,
+		},
+		{
+			input: "type=bind,source=/data,target=/data,bind-propagation=foo",
+			err:   `invalid bind propagation "foo"`,
+		},
+	} {
+		m, err := ParseMount(tc.input)
+		if tc.err != "" {
+			if assert.Error(t, err, tc.input) {
+				assert.Contains(t, err.Error(), tc.err, tc.input)
+				assert.Contains(t, err.Error(), tc.input, tc.input)
+			}
+			continue
+		}
+		assert.NoError(t, err, tc.input)
+		assert.Equal(t, tc.expected, m, tc.input)
+	}
+}
+
+func TestParseMounts(t *testing.T) {
+	mounts, err := ParseMounts(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, mounts)
+
+	mounts, err = ParseMounts([]string{"type=tmpfs,target=/run", "type=volume,target=/vol"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(mounts))
+
+	_, err = ParseMounts([]string{"type=tmpfs,target=/run", "type=volume,target=/run/"})
+	assert.Error(t, err)
+}
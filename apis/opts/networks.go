@@ -30,12 +30,19 @@ func ParseNetworks(networks []string) (*types.NetworkingConfig, string, error) {
 
 		if name == "container" {
 			networkMode = fmt.Sprintf("%s:%s", name, parameter)
-		} else if ipaddr := net.ParseIP(parameter); ipaddr != nil {
-			networkingConfig.EndpointsConfig[name] = &types.EndpointSettings{
-				IPAddress: parameter,
-				IPAMConfig: &types.EndpointIPAMConfig{
-					IPV4Address: parameter,
-				},
+			continue
+		}
+
+		// every network attached has its endpoint settings.
+		epConfig := networkingConfig.EndpointsConfig[name]
+		if epConfig == nil {
+			epConfig = &types.EndpointSettings{}
+			networkingConfig.EndpointsConfig[name] = epConfig
+		}
+		if ipaddr := net.ParseIP(parameter); ipaddr != nil {
+			epConfig.IPAddress = parameter
+			epConfig.IPAMConfig = &types.EndpointIPAMConfig{
+				IPV4Address: parameter,
 			}
 		}
 	}
@@ -88,23 +95,31 @@ func ValidateNetworks(nwConfig *types.NetworkingConfig) error {
 		return nil
 	}
 
-	// FIXME(ziren): this limitation may be removed in future
-	// Now not create more then one interface for container is not allowed
+	// the container sharing the network namespace of host or another
+	// container can't be connected to more networks.
 	if len(nwConfig.EndpointsConfig) > 1 {
 		l := make([]string, 0, len(nwConfig.EndpointsConfig))
+		shared := false
 		for k := range nwConfig.EndpointsConfig {
 			l = append(l, k)
+			shared = shared || isSharedNetworkMode(k)
+		}
+		if shared {
+			// make sure l is sorted
+			sort.Strings(l)
+			return fmt.Errorf("Container cannot be connected to network endpoints: %s", strings.Join(l, ", "))
 		}
-		// make sure l is sorted
-		sort.Strings(l)
-		return fmt.Errorf("Container cannot be connected to network endpoints: %s", strings.Join(l, ", "))
 	}
 
-	// len(nwConfig.EndpointsConfig) == 1
 	for k, v := range nwConfig.EndpointsConfig {
 		if v == nil {
 			return fmt.Errorf("no EndpointSettings for %s", k)
 		}
+		for _, alias := range v.Aliases {
+			if alias == "" || strings.ContainsAny(alias, " \t:") {
+				return fmt.Errorf("invalid network alias %q of network %s", alias, k)
+			}
+		}
 		if v.IPAMConfig != nil {
 			if v.IPAMConfig.IPV4Address != "" && net.ParseIP(v.IPAMConfig.IPV4Address).To4() == nil {
 				return fmt.Errorf("invalid IPv4 address: %s", v.IPAMConfig.IPV4Address)
@@ -124,7 +139,7 @@ func SetEndpointIPAddress(nwConfig *types.NetworkingConfig, mode, ipv4, ipv6 str
 		return nil
 	}
 
-	if nwConfig.EndpointsConfig != nil {
+	if nwConfig.EndpointsConfig == nil {
 		nwConfig.EndpointsConfig = make(map[string]*types.EndpointSettings)
 	}
 
@@ -137,10 +152,43 @@ func SetEndpointIPAddress(nwConfig *types.NetworkingConfig, mode, ipv4, ipv6 str
 		epConfig.IPAMConfig = &types.EndpointIPAMConfig{}
 	}
 
-	epConfig.IPAMConfig.IPV4Address = ipv4
-	epConfig.IPAMConfig.IPV6Address = ipv6
+	// keep the address specified by --net <network>:<ip> if not set.
+	if ipv4 != "" {
+		epConfig.IPAMConfig.IPV4Address = ipv4
+	}
+	if ipv6 != "" {
+		epConfig.IPAMConfig.IPV6Address = ipv6
+	}
 
 	nwConfig.EndpointsConfig[mode] = epConfig
 
 	return nil
 }
+
+// SetEndpointAliases adds the network-scoped aliases to the endpoints, the
+// alias is in the format of [network:]alias, and the network is the network
+// mode of container if not set.
+func SetEndpointAliases(nwConfig *types.NetworkingConfig, mode string, aliases []string) error {
+	for _, a := range aliases {
+		network, alias := mode, a
+		if parts := strings.SplitN(a, ":", 2); len(parts) == 2 {
+			network, alias = parts[0], parts[1]
+		}
+		if alias == "" {
+			return fmt.Errorf("invalid network alias %q: alias cannot be empty", a)
+		}
+
+		epConfig := nwConfig.EndpointsConfig[network]
+		if epConfig == nil {
+			return fmt.Errorf("invalid network alias %q: container is not connected to network %s", a, network)
+		}
+		epConfig.Aliases = append(epConfig.Aliases, alias)
+	}
+	return nil
+}
+
+// isSharedNetworkMode returns true if the container shares the network
+// namespace of host or another container, or has no network.
+func isSharedNetworkMode(mode string) bool {
+	return mode == "host" || mode == "none" || strings.HasPrefix(mode, "container:") || strings.HasPrefix(mode, "netns:")
+}
@@ -46,7 +46,9 @@ func TestParseNetworks(t *testing.T) {
 				networks: []string{"foo:bar:mode"},
 			},
 			want: &types.NetworkingConfig{
-				EndpointsConfig: map[string]*types.EndpointSettings{},
+				EndpointsConfig: map[string]*types.EndpointSettings{
+					"foo": {},
+				},
 			},
 			want1:   "foo",
 			wantErr: false,
@@ -80,6 +82,25 @@ func TestParseNetworks(t *testing.T) {
 			want1:   "foo",
 			wantErr: false,
 		},
+		{
+			name: "multiple networks",
+			args: args{
+				networks: []string{"foo", "bar:127.0.0.1"},
+			},
+			want: &types.NetworkingConfig{
+				EndpointsConfig: map[string]*types.EndpointSettings{
+					"foo": {},
+					"bar": {
+						IPAddress: "127.0.0.1",
+						IPAMConfig: &types.EndpointIPAMConfig{
+							IPV4Address: "127.0.0.1",
+						},
+					},
+				},
+			},
+			want1:   "foo",
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -169,17 +190,41 @@ func TestVerifyNetworks(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "container cannot be connected to network endpoints",
+			name: "container can be connected to multiple networks",
 			args: args{
 				nwConfig: &types.NetworkingConfig{
 					EndpointsConfig: map[string]*types.EndpointSettings{
-						"foo": {},
+						"foo": {Aliases: []string{"web"}},
 						"bar": {},
 					},
 				},
 			},
+			wantErr: false,
+		},
+		{
+			name: "container cannot be connected to network endpoints",
+			args: args{
+				nwConfig: &types.NetworkingConfig{
+					EndpointsConfig: map[string]*types.EndpointSettings{
+						"foo":  {},
+						"host": {},
+					},
+				},
+			},
 			wantErr: true,
-			err:     fmt.Errorf("Container cannot be connected to network endpoints: bar, foo"),
+			err:     fmt.Errorf("Container cannot be connected to network endpoints: foo, host"),
+		},
+		{
+			name: "invalid network alias",
+			args: args{
+				nwConfig: &types.NetworkingConfig{
+					EndpointsConfig: map[string]*types.EndpointSettings{
+						"foo": {Aliases: []string{"we b"}},
+					},
+				},
+			},
+			wantErr: true,
+			err:     fmt.Errorf("invalid network alias \"we b\" of network foo"),
 		},
 		{
 			name: "invalid IPv4 address",
@@ -211,6 +256,31 @@ func TestVerifyNetworks(t *testing.T) {
 	}
 }
 
+func TestSetEndpointIPAddress(t *testing.T) {
+	nwConfig, mode, err := ParseNetworks([]string{"foo:127.0.0.1"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, SetEndpointIPAddress(nwConfig, mode, "", "2001:db8::1"))
+	assert.Equal(t, "127.0.0.1", nwConfig.EndpointsConfig["foo"].IPAMConfig.IPV4Address)
+	assert.Equal(t, "2001:db8::1", nwConfig.EndpointsConfig["foo"].IPAMConfig.IPV6Address)
+
+	nwConfig = &types.NetworkingConfig{}
+	assert.NoError(t, SetEndpointIPAddress(nwConfig, "bar", "127.0.0.2", ""))
+	assert.Equal(t, "127.0.0.2", nwConfig.EndpointsConfig["bar"].IPAMConfig.IPV4Address)
+}
+
+func TestSetEndpointAliases(t *testing.T) {
+	nwConfig, mode, err := ParseNetworks([]string{"foo", "bar"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, SetEndpointAliases(nwConfig, mode, []string{"web", "bar:db", "foo:app"}))
+	assert.Equal(t, []string{"web", "app"}, nwConfig.EndpointsConfig["foo"].Aliases)
+	assert.Equal(t, []string{"db"}, nwConfig.EndpointsConfig["bar"].Aliases)
+
+	assert.Error(t, SetEndpointAliases(nwConfig, mode, []string{"baz:web"}))
+	assert.Error(t, SetEndpointAliases(nwConfig, mode, []string{"foo:"}))
+}
+
 func Test_parseNetwork(t *testing.T) {
 	type net struct {
 		name      string
@@ -0,0 +1,12 @@
+package opts
+
+import "fmt"
+
+// ValidateOOMScoreAdj verifies the oom score adj of container is in range
+// [-1000, 1000].
+func ValidateOOMScoreAdj(score int64) error {
+	if score < -1000 || score > 1000 {
+		return fmt.Errorf("invalid oom score adj %d: should be in range [-1000, 1000]", score)
+	}
+	return nil
+}
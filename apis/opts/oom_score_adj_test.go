@@ -0,0 +1,16 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateOOMScoreAdj(t *testing.T) {
+	for _, score := range []int64{-1000, -500, 0, 1000} {
+		assert.NoError(t, ValidateOOMScoreAdj(score), score)
+	}
+	for _, score := range []int64{-1001, 1001} {
+		assert.Error(t, ValidateOOMScoreAdj(score), score)
+	}
+}
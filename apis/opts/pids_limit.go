@@ -0,0 +1,12 @@
+package opts
+
+import "fmt"
+
+// ValidatePidsLimit verifies the pids limit of container, -1 means unlimited
+// and 0 means not to set the limit.
+func ValidatePidsLimit(limit int64) error {
+	if limit < -1 {
+		return fmt.Errorf("invalid pids limit %d: should be -1 (unlimited) or greater", limit)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePidsLimit(t *testing.T) {
+	for _, limit := range []int64{-1, 0, 1, 1024} {
+		assert.NoError(t, ValidatePidsLimit(limit), limit)
+	}
+
+	for _, limit := range []int64{-2, -1024} {
+		assert.Error(t, ValidatePidsLimit(limit), limit)
+	}
+}
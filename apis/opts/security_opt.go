@@ -0,0 +1,88 @@
+package opts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// seccompBuiltinProfiles are the seccomp profiles known by daemon, which are
+// not loaded from local file.
+var seccompBuiltinProfiles = map[string]bool{
+	"unconfined":    true,
+	"pouch/default": true,
+}
+
+// ParseSecurityOpts validates the --security-opt flags in the format of
+// no-new-privileges, apparmor=<profile>, seccomp=<profile> or label=<label>.
+// The local seccomp profile file is loaded and validated, and the content of
+// profile replaces the file path so that it can be shipped to daemon.
+func ParseSecurityOpts(securityOpts []string) ([]string, error) {
+	parsed := make([]string, 0, len(securityOpts))
+	for _, opt := range securityOpts {
+		if opt == "no-new-privileges" {
+			parsed = append(parsed, opt)
+			continue
+		}
+
+		fields := strings.SplitN(opt, "=", 2)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid --security-opt %s: must be in format of key=value or no-new-privileges", opt)
+		}
+
+		key, value := fields[0], fields[1]
+		switch key {
+		case "apparmor", "seccomp", "label":
+		default:
+			return nil, fmt.Errorf("invalid --security-opt %s: unknown key %s, only apparmor, seccomp, label and no-new-privileges are supported", opt, key)
+		}
+		if value == "" {
+			return nil, fmt.Errorf("invalid --security-opt %s: value of %s should not be empty", opt, key)
+		}
+
+		if key == "seccomp" && !seccompBuiltinProfiles[value] {
+			profile, err := loadSeccompProfile(value)
+			if err != nil {
+				return nil, err
+			}
+			opt = key + "=" + profile
+		}
+		parsed = append(parsed, opt)
+	}
+	return parsed, nil
+}
+
+// loadSeccompProfile reads the seccomp profile file and returns the compacted
+// profile content.
+func loadSeccompProfile(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to load seccomp profile %s: %v", path, err)
+	}
+
+	if err := ValidateSeccompProfile(data); err != nil {
+		return "", fmt.Errorf("invalid seccomp profile %s: %v", path, err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := json.Compact(buf, data); err != nil {
+		return "", fmt.Errorf("invalid seccomp profile %s: %v", path, err)
+	}
+	return buf.String(), nil
+}
+
+// ValidateSeccompProfile validates the content of seccomp profile in JSON.
+func ValidateSeccompProfile(data []byte) error {
+	profile := &specs.LinuxSeccomp{}
+	if err := json.Unmarshal(data, profile); err != nil {
+		return fmt.Errorf("failed to decode profile: %v", err)
+	}
+	if profile.DefaultAction == "" {
+		return fmt.Errorf("defaultAction should not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package opts
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSecurityOpts(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "security-opt-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	profile := filepath.Join(tmpDir, "profile.json")
+	assert.NoError(t, ioutil.WriteFile(profile, []byte(`{
+	"defaultAction": "SCMP_ACT_ALLOW",
+	"syscalls": [{"names": ["chmod"], "action": "SCMP_ACT_ERRNO"}]
+}`), 0644))
+	invalid := filepath.Join(tmpDir, "invalid.json")
+	assert.NoError(t, ioutil.WriteFile(invalid, []byte(`{"defaultAction": `), 0644))
+	noAction := filepath.Join(tmpDir, "noaction.json")
+	assert.NoError(t, ioutil.WriteFile(noAction, []byte(`{"syscalls": []}`), 0644))
+
+	parsed, err := ParseSecurityOpts([]string{
+		"no-new-privileges",
+		"apparmor=unconfined",
+		"seccomp=unconfined",
+		"label=type:svirt_apache_t",
+		"seccomp=" + profile,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"no-new-privileges",
+		"apparmor=unconfined",
+		"seccomp=unconfined",
+		"label=type:svirt_apache_t",
+		`seccomp={"defaultAction":"SCMP_ACT_ALLOW","syscalls":[{"names":["chmod"],"action":"SCMP_ACT_ERRNO"}]}`,
+	}, parsed)
+
+	for _, opt := range []string{
+		"no-new-privilege",
+		"foo=bar",
+		"apparmor=",
+		"seccomp=",
+		"seccomp=" + filepath.Join(tmpDir, "not-exist.json"),
+		"seccomp=" + invalid,
+		"seccomp=" + noAction,
+	} {
+		_, err := ParseSecurityOpts([]string{opt})
+		assert.Error(t, err, opt)
+	}
+}
@@ -26,3 +26,36 @@ func parseSysctl(sysctl string) ([]string, error) {
 	}
 	return fields, nil
 }
+
+// ipcSysctls are the sysctls of ipc namespace, besides the fs.mqueue.* ones.
+var ipcSysctls = map[string]bool{
+	"kernel.msgmax":          true,
+	"kernel.msgmnb":          true,
+	"kernel.msgmni":          true,
+	"kernel.sem":             true,
+	"kernel.shmall":          true,
+	"kernel.shmmax":          true,
+	"kernel.shmmni":          true,
+	"kernel.shm_rmid_forced": true,
+}
+
+// IsIPCSysctl returns true if the sysctl is in the ipc namespace.
+func IsIPCSysctl(key string) bool {
+	return ipcSysctls[key] || strings.HasPrefix(key, "fs.mqueue.")
+}
+
+// IsNetSysctl returns true if the sysctl is in the network namespace.
+func IsNetSysctl(key string) bool {
+	return strings.HasPrefix(key, "net.")
+}
+
+// ValidateSysctls checks the sysctls are namespaced, the others affect the
+// whole host and are unsafe to be set by container.
+func ValidateSysctls(sysctls map[string]string) error {
+	for k := range sysctls {
+		if !IsIPCSysctl(k) && !IsNetSysctl(k) {
+			return fmt.Errorf("sysctl %s is not allowed, only the namespaced sysctls kernel.msg*, kernel.sem, kernel.shm*, fs.mqueue.* and net.* are supported", k)
+		}
+	}
+	return nil
+}
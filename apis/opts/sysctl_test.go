@@ -40,3 +40,32 @@ func TestParseSysctls(t *testing.T) {
 		assert.Equal(t, testCase.expect.err, err)
 	}
 }
+
+func TestValidateSysctls(t *testing.T) {
+	assert.NoError(t, ValidateSysctls(nil))
+	assert.NoError(t, ValidateSysctls(map[string]string{
+		"net.core.somaxconn":      "1024",
+		"net.ipv4.ip_forward":     "1",
+		"kernel.shmmax":           "68719476736",
+		"fs.mqueue.msg_max":       "100",
+		"kernel.shm_rmid_forced":  "1",
+		"net.ipv6.conf.all.mtu":   "1500",
+		"kernel.msgmnb":           "65536",
+		"fs.mqueue.queues_max":    "256",
+		"kernel.sem":              "250 32000 100 128",
+		"net.ipv4.tcp_syncookies": "1",
+	}))
+
+	for _, key := range []string{"kernel.pid_max", "vm.swappiness", "fs.file-max", "kernel.shm"} {
+		err := ValidateSysctls(map[string]string{key: "1"})
+		if assert.Error(t, err, key) {
+			assert.Contains(t, err.Error(), "is not allowed", key)
+		}
+	}
+
+	assert.True(t, IsIPCSysctl("kernel.sem"))
+	assert.True(t, IsIPCSysctl("fs.mqueue.msg_max"))
+	assert.False(t, IsIPCSysctl("net.core.somaxconn"))
+	assert.True(t, IsNetSysctl("net.core.somaxconn"))
+	assert.False(t, IsNetSysctl("kernel.sem"))
+}
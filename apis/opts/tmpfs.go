@@ -0,0 +1,147 @@
+package opts
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	units "github.com/docker/go-units"
+)
+
+// DefaultTmpfsSize is the size of tmpfs mount specified by --tmpfs if the
+// size option is not set.
+const DefaultTmpfsSize = 64 * 1024 * 1024
+
+// tmpfsFlagGroups maps the flag options of tmpfs to the group, the later
+// flag overrides the former one of the same group.
+var tmpfsFlagGroups = map[string]string{
+	"rw":          "rw",
+	"ro":          "rw",
+	"exec":        "exec",
+	"noexec":      "exec",
+	"suid":        "suid",
+	"nosuid":      "suid",
+	"dev":         "dev",
+	"nodev":       "dev",
+	"atime":       "atime",
+	"noatime":     "atime",
+	"relatime":    "atime",
+	"strictatime": "atime",
+	"sync":        "sync",
+	"async":       "sync",
+}
+
+// tmpfsOptionOrder is the order of options in the parsed tmpfs options.
+var tmpfsOptionOrder = []string{"rw", "exec", "suid", "dev", "atime", "sync", "size", "mode", "nr_inodes", "uid", "gid"}
+
+// ParseTmpfs parses the --tmpfs flags in the format of path[:options] into
+// the map from path to options, like "/run:rw,size=64m".
+func ParseTmpfs(tmpfs []string) (map[string]string, error) {
+	results := make(map[string]string)
+	for _, t := range tmpfs {
+		parts := strings.SplitN(t, ":", 2)
+		path, options := filepath.Clean(parts[0]), ""
+		if len(parts) == 2 {
+			options = parts[1]
+		}
+
+		if err := ValidateTmpfs(path, options); err != nil {
+			return nil, err
+		}
+		if _, exist := results[path]; exist {
+			return nil, fmt.Errorf("duplicate tmpfs mount point: %s", path)
+		}
+		results[path] = options
+	}
+	return results, nil
+}
+
+// ValidateTmpfs checks the path of tmpfs mount is absolute and the options
+// are valid.
+func ValidateTmpfs(path, options string) error {
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("invalid tmpfs mount point %q, must be an absolute path", path)
+	}
+	if _, err := ParseTmpfsOptions(options); err != nil {
+		return fmt.Errorf("invalid tmpfs mount %s: %v", path, err)
+	}
+	return nil
+}
+
+// ParseTmpfsOptions parses the comma-separated options of tmpfs into mount
+// options. The tmpfs is mounted with noexec, nosuid and nodev by default,
+// and the size is DefaultTmpfsSize if not set.
+func ParseTmpfsOptions(options string) ([]string, error) {
+	parsed := map[string]string{
+		"exec": "noexec",
+		"suid": "nosuid",
+		"dev":  "nodev",
+		"size": fmt.Sprintf("size=%d", DefaultTmpfsSize),
+	}
+
+	for _, opt := range strings.Split(options, ",") {
+		opt = strings.TrimSpace(opt)
+		if opt == "" {
+			continue
+		}
+
+		kv := strings.SplitN(opt, "=", 2)
+		if len(kv) == 1 {
+			group, ok := tmpfsFlagGroups[opt]
+			if !ok {
+				return nil, fmt.Errorf("unknown tmpfs option %q", opt)
+			}
+			parsed[group] = opt
+			continue
+		}
+
+		key, value := kv[0], kv[1]
+		switch key {
+		case "size":
+			size, err := parseTmpfsSize(value)
+			if err != nil {
+				return nil, err
+			}
+			parsed[key] = "size=" + size
+		case "mode":
+			if mode, err := strconv.ParseUint(value, 8, 32); err != nil || mode > 07777 {
+				return nil, fmt.Errorf("invalid tmpfs mode %q, must be an octal number not greater than 7777", value)
+			}
+			parsed[key] = opt
+		case "nr_inodes", "uid", "gid":
+			if _, err := strconv.ParseUint(value, 10, 32); err != nil {
+				return nil, fmt.Errorf("invalid tmpfs %s %q, must be a non-negative integer", key, value)
+			}
+			parsed[key] = opt
+		default:
+			return nil, fmt.Errorf("unknown tmpfs option %q", opt)
+		}
+	}
+
+	results := make([]string, 0, len(parsed))
+	for _, key := range tmpfsOptionOrder {
+		if v, ok := parsed[key]; ok {
+			results = append(results, v)
+		}
+	}
+	return results, nil
+}
+
+// parseTmpfsSize parses the size of tmpfs in bytes, like 64m, or in
+// percentage of the memory, like 50%.
+func parseTmpfsSize(value string) (string, error) {
+	if strings.HasSuffix(value, "%") {
+		percent, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+		if err != nil || percent <= 0 || percent > 100 {
+			return "", fmt.Errorf("invalid tmpfs size %q, percentage must be in range (0, 100]", value)
+		}
+		return value, nil
+	}
+
+	size, err := units.RAMInBytes(value)
+	if err != nil || size <= 0 {
+		return "", fmt.Errorf("invalid tmpfs size %q, must be a positive size like 64m", value)
+	}
+	return strconv.FormatInt(size, 10), nil
+}
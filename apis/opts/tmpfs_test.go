@@ -0,0 +1,68 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTmpfs(t *testing.T) {
+	tmpfs, err := ParseTmpfs([]string{"/run", "/tmp/:rw,size=1g", "/data:exec"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"/run":  "",
+		"/tmp":  "rw,size=1g",
+		"/data": "exec",
+	}, tmpfs)
+
+	for _, input := range []string{"run", "/run:foo", "/run:size=-1"} {
+		_, err := ParseTmpfs([]string{input})
+		assert.Error(t, err, input)
+	}
+
+	_, err = ParseTmpfs([]string{"/run", "/run/:size=1m"})
+	assert.Error(t, err)
+}
+
+func TestParseTmpfsOptions(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected []string
+	}{
+		{
+			input:    "",
+			expected: []string{"noexec", "nosuid", "nodev", "size=67108864"},
+		},
+		{
+			input:    "ro,exec,size=64k,mode=1777",
+			expected: []string{"ro", "exec", "nosuid", "nodev", "size=65536", "mode=1777"},
+		},
+		{
+			input:    "rw, noatime ,size=50%,nr_inodes=1024,uid=1000,gid=1000",
+			expected: []string{"rw", "noexec", "nosuid", "nodev", "noatime", "size=50%", "nr_inodes=1024", "uid=1000", "gid=1000"},
+		},
+		{
+			input:    "suid,dev,nosuid",
+			expected: []string{"noexec", "nosuid", "dev", "size=67108864"},
+		},
+	} {
+		options, err := ParseTmpfsOptions(tc.input)
+		assert.NoError(t, err, tc.input)
+		assert.Equal(t, tc.expected, options, tc.input)
+	}
+
+	for _, input := range []string{
+		"foo",
+		"foo=bar",
+		"size=abc",
+		"size=0",
+		"size=101%",
+		"mode=999",
+		"mode=17777",
+		"uid=-1",
+		"nr_inodes=abc",
+	} {
+		_, err := ParseTmpfsOptions(input)
+		assert.Error(t, err, input)
+	}
+}
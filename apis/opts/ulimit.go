@@ -0,0 +1,54 @@
+package opts
+
+import (
+	"fmt"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	units "github.com/docker/go-units"
+)
+
+// ValidateUlimits verifies the ulimits of container, the name of ulimit must
+// be known and unique, and the soft limit should not be greater than the hard
+// limit.
+func ValidateUlimits(ulimits []*types.Ulimit) error {
+	names := make(map[string]bool, len(ulimits))
+	for _, ul := range ulimits {
+		if ul == nil {
+			continue
+		}
+
+		if err := ValidateUlimit(ul); err != nil {
+			return err
+		}
+
+		if names[ul.Name] {
+			return fmt.Errorf("duplicate ulimit %s", ul.Name)
+		}
+		names[ul.Name] = true
+	}
+	return nil
+}
+
+// unlimitedUlimit is the value of ulimit meaning unlimited.
+const unlimitedUlimit = -1
+
+// ValidateUlimit verifies the name and the limits of ulimit, -1 means
+// unlimited.
+func ValidateUlimit(ul *types.Ulimit) error {
+	if _, err := (&units.Ulimit{Name: ul.Name}).GetRlimit(); err != nil {
+		return fmt.Errorf("invalid ulimit type: %s", ul.Name)
+	}
+
+	if ul.Soft < unlimitedUlimit || ul.Hard < unlimitedUlimit {
+		return fmt.Errorf("invalid ulimit %s=%d:%d: limits should not be less than -1", ul.Name, ul.Soft, ul.Hard)
+	}
+
+	if ul.Hard == unlimitedUlimit {
+		return nil
+	}
+	if ul.Soft == unlimitedUlimit || ul.Soft > ul.Hard {
+		return fmt.Errorf("invalid ulimit %s=%d:%d: soft limit should not be greater than hard limit", ul.Name, ul.Soft, ul.Hard)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateUlimits(t *testing.T) {
+	assert.NoError(t, ValidateUlimits(nil))
+	assert.NoError(t, ValidateUlimits([]*types.Ulimit{
+		{Name: "nofile", Soft: 1024, Hard: 2048},
+		{Name: "nproc", Soft: 256, Hard: 256},
+		{Name: "core", Soft: 0, Hard: 0},
+		{Name: "memlock", Soft: -1, Hard: -1},
+		{Name: "stack", Soft: 8192, Hard: -1},
+	}))
+
+	for _, ulimits := range [][]*types.Ulimit{
+		{{Name: "foo", Soft: 1, Hard: 1}},
+		{{Name: "nofile", Soft: -1, Hard: 1}},
+		{{Name: "nofile", Soft: -2, Hard: -1}},
+		{{Name: "nofile", Soft: 1, Hard: -2}},
+		{{Name: "nofile", Soft: 2, Hard: 1}},
+		{{Name: "nofile", Soft: 1, Hard: 1}, {Name: "nofile", Soft: 2, Hard: 2}},
+	} {
+		assert.Error(t, ValidateUlimits(ulimits))
+	}
+}
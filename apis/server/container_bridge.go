@@ -8,8 +8,10 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
+	apifilters "github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/metrics"
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/daemon/mgr"
@@ -20,6 +22,7 @@ import (
 	"github.com/alibaba/pouch/pkg/utils/filters"
 	util_metrics "github.com/alibaba/pouch/pkg/utils/metrics"
 
+	"github.com/docker/docker/pkg/signal"
 	"github.com/go-openapi/strfmt"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
@@ -222,6 +225,8 @@ func (s *Server) restartContainer(ctx context.Context, rw http.ResponseWriter, r
 		metrics.ContainerActionsTimer.WithLabelValues(label).Observe(time.Since(start).Seconds())
 	}(time.Now())
 
+	// use the stop timeout of container if not set.
+	t = -1
 	if v := req.FormValue("t"); v != "" {
 		if t, err = strconv.Atoi(v); err != nil {
 			return httputils.NewHTTPError(err, http.StatusBadRequest)
@@ -252,6 +257,8 @@ func (s *Server) stopContainer(ctx context.Context, rw http.ResponseWriter, req
 		metrics.ContainerActionsTimer.WithLabelValues(label).Observe(time.Since(start).Seconds())
 	}(time.Now())
 
+	// use the stop timeout of container if not set.
+	t = -1
 	if v := req.FormValue("t"); v != "" {
 		if t, err = strconv.Atoi(v); err != nil {
 			return httputils.NewHTTPError(err, http.StatusBadRequest)
@@ -292,6 +299,25 @@ func (s *Server) unpauseContainer(ctx context.Context, rw http.ResponseWriter, r
 	return nil
 }
 
+func (s *Server) killContainer(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	sig := syscall.SIGKILL
+	if rawSignal := req.FormValue("signal"); rawSignal != "" {
+		var err error
+		if sig, err = signal.ParseSignal(rawSignal); err != nil {
+			return httputils.NewHTTPError(err, http.StatusBadRequest)
+		}
+	}
+
+	name := mux.Vars(req)["name"]
+
+	if err := s.ContainerMgr.Kill(ctx, name, sig); err != nil {
+		return err
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
 func (s *Server) renameContainer(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 	label := util_metrics.ActionRenameLabel
 	defer func(start time.Time) {
@@ -513,16 +539,34 @@ func (s *Server) removeContainers(ctx context.Context, rw http.ResponseWriter, r
 		Link: httputils.BoolValue(req, "link"),
 	}
 
-	if err := s.ContainerMgr.Remove(ctx, name, option); err != nil {
+	resp, err := s.ContainerMgr.Remove(ctx, name, option)
+	if err != nil {
 		return err
 	}
 
 	metrics.ContainerSuccessActionsCounter.WithLabelValues(label).Inc()
 
+	// report the removed and kept volumes if removing with volumes.
+	if resp != nil {
+		return EncodeResponse(rw, http.StatusOK, resp)
+	}
 	rw.WriteHeader(http.StatusNoContent)
 	return nil
 }
 
+func (s *Server) pruneContainers(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	filter, err := apifilters.FromParam(req.FormValue("filters"))
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.ContainerMgr.Prune(ctx, filter)
+	if err != nil {
+		return err
+	}
+	return EncodeResponse(rw, http.StatusOK, resp)
+}
+
 func (s *Server) waitContainer(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 	name := mux.Vars(req)["name"]
 
@@ -598,6 +642,9 @@ func (s *Server) commitContainer(ctx context.Context, rw http.ResponseWriter, re
 		Tag:        req.FormValue("tag"),
 		Author:     req.FormValue("author"),
 		Comment:    req.FormValue("comment"),
+		Changes:    req.URL.Query()["changes"],
+		// the container is paused by default
+		Pause: req.FormValue("pause") == "" || httputils.BoolValue(req, "pause"),
 	}
 
 	id, err := s.ContainerMgr.Commit(ctx, req.FormValue("container"), options)
@@ -607,3 +654,31 @@ func (s *Server) commitContainer(ctx context.Context, rw http.ResponseWriter, re
 
 	return EncodeResponse(rw, http.StatusCreated, id)
 }
+
+// exportContainer exports the container filesystem by http tar stream.
+func (s *Server) exportContainer(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	name := mux.Vars(req)["name"]
+
+	r, err := s.ContainerMgr.Export(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	rw.Header().Set("Content-Type", "application/x-tar")
+
+	output := newWriteFlusher(rw)
+	_, err = io.Copy(output, r)
+	return err
+}
+
+func (s *Server) changesContainer(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	name := mux.Vars(req)["name"]
+
+	changes, err := s.ContainerMgr.Changes(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	return EncodeResponse(rw, http.StatusOK, changes)
+}
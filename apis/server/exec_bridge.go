@@ -7,12 +7,14 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"syscall"
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/pkg/httputils"
 	"github.com/alibaba/pouch/pkg/log"
 	"github.com/alibaba/pouch/pkg/streams"
 
+	"github.com/docker/docker/pkg/signal"
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/go-openapi/strfmt"
 	"github.com/gorilla/mux"
@@ -62,12 +64,16 @@ func (s *Server) startContainerExec(ctx context.Context, rw http.ResponseWriter,
 	ba, _ := json.Marshal(config)
 	log.With(ctx).Infof("start exec %s, upgrade: %v, body: %s", name, upgrade, string(ba))
 
-	if err := s.ContainerMgr.CheckExecExist(ctx, name); err != nil {
+	execConfig, err := s.ContainerMgr.GetExecConfig(ctx, name)
+	if err != nil {
 		return err
 	}
 
+	execConfig.Lock()
+	execConfig.Log = config.Log
+	execConfig.Unlock()
+
 	var (
-		err     error
 		closeFn func() error
 		attach  = new(streams.AttachConfig)
 		stdin   io.ReadCloser
@@ -92,11 +98,12 @@ func (s *Server) startContainerExec(ctx context.Context, rw http.ResponseWriter,
 		attach.UseStdin, attach.Stdin = true, stdin
 		attach.Terminal = config.Tty
 
+		// only the streams attached when creating exec are copied back.
 		if config.Tty {
-			attach.UseStdout, attach.Stdout = true, stdout
+			attach.UseStdout, attach.Stdout = execConfig.AttachStdout, stdout
 		} else {
-			attach.UseStdout, attach.Stdout = true, stdcopy.NewStdWriter(stdout, stdcopy.Stdout)
-			attach.UseStderr, attach.Stderr = true, stdcopy.NewStdWriter(stdout, stdcopy.Stderr)
+			attach.UseStdout, attach.Stdout = execConfig.AttachStdout, stdcopy.NewStdWriter(stdout, stdcopy.Stdout)
+			attach.UseStderr, attach.Stderr = execConfig.AttachStderr, stdcopy.NewStdWriter(stdout, stdcopy.Stderr)
 		}
 	}
 	attach.Detach = config.Detach
@@ -146,6 +153,36 @@ func (s *Server) resizeExec(ctx context.Context, rw http.ResponseWriter, req *ht
 
 }
 
+func (s *Server) killExec(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	sig := syscall.SIGKILL
+	if rawSignal := req.FormValue("signal"); rawSignal != "" {
+		var err error
+		if sig, err = signal.ParseSignal(rawSignal); err != nil {
+			return httputils.NewHTTPError(err, http.StatusBadRequest)
+		}
+	}
+
+	name := mux.Vars(req)["name"]
+
+	if err := s.ContainerMgr.KillExec(ctx, name, sig); err != nil {
+		return err
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (s *Server) removeExec(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	name := mux.Vars(req)["name"]
+
+	if err := s.ContainerMgr.RemoveExec(ctx, name); err != nil {
+		return err
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
 func openHijackConnection(rw http.ResponseWriter) (io.ReadCloser, io.Writer, func() error, error) {
 	hijacker, ok := rw.(http.Hijacker)
 	if !ok {
@@ -28,6 +28,7 @@ import (
 func (s *Server) pullImage(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 	image := req.FormValue("fromImage")
 	tag := req.FormValue("tag")
+	platform := req.FormValue("platform")
 
 	if image == "" {
 		err := fmt.Errorf("fromImage cannot be empty")
@@ -57,7 +58,7 @@ func (s *Server) pullImage(ctx context.Context, rw http.ResponseWriter, req *htt
 		}
 	}
 	// Error information has be sent to client, so no need call resp.Write
-	if err := s.ImageMgr.PullImage(ctx, image, &authConfig, newWriteFlusher(rw)); err != nil {
+	if err := s.ImageMgr.PullImage(ctx, image, platform, &authConfig, newWriteFlusher(rw)); err != nil {
 		log.With(ctx).Errorf("failed to pull image %s: %v", image, err)
 		if err == errtypes.ErrNotfound {
 			return httputils.NewHTTPError(err, http.StatusNotFound)
@@ -174,6 +175,31 @@ func (s *Server) removeImage(ctx context.Context, rw http.ResponseWriter, req *h
 	return nil
 }
 
+// pruneImages deletes the images which are not used by any container.
+func (s *Server) pruneImages(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	filter, err := filters.FromParam(req.FormValue("filters"))
+	if err != nil {
+		return err
+	}
+
+	containers, err := s.ContainerMgr.List(ctx, &mgr.ContainerListOption{All: true})
+	if err != nil {
+		return err
+	}
+
+	usedImages := make(map[string]bool, len(containers))
+	for _, c := range containers {
+		usedImages[c.Image] = true
+	}
+
+	resp, err := s.ImageMgr.PruneImages(ctx, filter, usedImages, httputils.BoolValue(req, "dryRun"))
+	if err != nil {
+		log.With(ctx).Errorf("failed to prune images: %v", err)
+		return err
+	}
+	return EncodeResponse(rw, http.StatusOK, resp)
+}
+
 // postImageTag adds tag for the existing image.
 func (s *Server) postImageTag(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 	name := mux.Vars(req)["name"]
@@ -205,21 +231,34 @@ func (s *Server) loadImage(ctx context.Context, rw http.ResponseWriter, req *htt
 
 // saveImage saves an image by http tar stream.
 func (s *Server) saveImage(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
-	imageName := req.FormValue("name")
+	imageNames := req.URL.Query()["name"]
 
-	rw.Header().Set("Content-Type", "application/x-tar")
-
-	r, err := s.ImageMgr.SaveImage(ctx, imageName)
+	r, err := s.ImageMgr.SaveImage(ctx, imageNames)
 	if err != nil {
 		return err
 	}
 	defer r.Close()
 
+	rw.Header().Set("Content-Type", "application/x-tar")
+
 	output := newWriteFlusher(rw)
 	_, err = io.Copy(output, r)
 	return err
 }
 
+// importImage creates a single-layer image from the rootfs tar stream.
+func (s *Server) importImage(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	ref := req.FormValue("name")
+	changes := req.URL.Query()["changes"]
+
+	imgInfo, err := s.ImageMgr.ImportImage(ctx, ref, changes, req.Body)
+	if err != nil {
+		return err
+	}
+
+	return EncodeResponse(rw, http.StatusOK, imgInfo)
+}
+
 // getImageHistory gets image history.
 func (s *Server) getImageHistory(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 	imageName := mux.Vars(req)["name"]
@@ -19,11 +19,11 @@ import (
 
 type mockImgePull struct {
 	mgr.ImageMgr
-	handler func(ctx context.Context, imageRef string, authConfig *types.AuthConfig, out io.Writer) error
+	handler func(ctx context.Context, imageRef, platform string, authConfig *types.AuthConfig, out io.Writer) error
 }
 
-func (m *mockImgePull) PullImage(ctx context.Context, imageRef string, authConfig *types.AuthConfig, out io.Writer) error {
-	return m.handler(ctx, imageRef, authConfig, out)
+func (m *mockImgePull) PullImage(ctx context.Context, imageRef, platform string, authConfig *types.AuthConfig, out io.Writer) error {
+	return m.handler(ctx, imageRef, platform, authConfig, out)
 }
 
 func Test_pullImage_without_tag(t *testing.T) {
@@ -31,7 +31,7 @@ func Test_pullImage_without_tag(t *testing.T) {
 
 	s.ImageMgr = &mockImgePull{
 		ImageMgr: &mgr.ImageManager{},
-		handler: func(ctx context.Context, imageRef string, authConfig *types.AuthConfig, out io.Writer) error {
+		handler: func(ctx context.Context, imageRef, platform string, authConfig *types.AuthConfig, out io.Writer) error {
 			assert.Equal(t, "reg.abc.com/base/os:7.2", imageRef)
 			return nil
 		},
@@ -43,6 +43,27 @@ func Test_pullImage_without_tag(t *testing.T) {
 	s.pullImage(context.Background(), nil, req)
 }
 
+func Test_pullImage_with_platform(t *testing.T) {
+	var s Server
+
+	s.ImageMgr = &mockImgePull{
+		ImageMgr: &mgr.ImageManager{},
+		handler: func(ctx context.Context, imageRef, platform string, authConfig *types.AuthConfig, out io.Writer) error {
+			assert.Equal(t, "reg.abc.com/base/os:7.2", imageRef)
+			assert.Equal(t, "linux/arm64", platform)
+			return nil
+		},
+	}
+	req := &http.Request{
+		Form: map[string][]string{
+			"fromImage": {"reg.abc.com/base/os:7.2"},
+			"platform":  {"linux/arm64"},
+		},
+		Header: map[string][]string{},
+	}
+	assert.NoError(t, s.pullImage(context.Background(), nil, req))
+}
+
 func Test_pullImage_counter(t *testing.T) {
 	var s Server
 	ctx := context.Background()
@@ -55,7 +76,7 @@ func Test_pullImage_counter(t *testing.T) {
 	go func() {
 		s.ImageMgr = &mockImgePull{
 			ImageMgr: &mgr.ImageManager{},
-			handler: func(ctx context.Context, imageRef string, authConfig *types.AuthConfig, out io.Writer) error {
+			handler: func(ctx context.Context, imageRef, platform string, authConfig *types.AuthConfig, out io.Writer) error {
 				assert.Equal(t, "reg.abc.com/base/os:7.2", imageRef)
 				time.Sleep(2 * time.Second)
 				return nil
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/types"
 	networktypes "github.com/alibaba/pouch/network/types"
 	"github.com/alibaba/pouch/pkg/httputils"
@@ -76,6 +77,19 @@ func (s *Server) deleteNetwork(ctx context.Context, rw http.ResponseWriter, req
 	return nil
 }
 
+func (s *Server) pruneNetworks(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	filter, err := filters.FromParam(req.FormValue("filters"))
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.NetworkMgr.Prune(ctx, filter)
+	if err != nil {
+		return err
+	}
+	return EncodeResponse(rw, http.StatusOK, resp)
+}
+
 func (s *Server) connectToNetwork(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 	networkIDOrName := mux.Vars(req)["id"]
 	connectConfig := &types.NetworkConnect{}
@@ -34,6 +34,7 @@ func initRoute(s *Server) *mux.Router {
 		{Method: http.MethodGet, Path: "/version", HandlerFunc: s.version},
 		{Method: http.MethodPost, Path: "/auth", HandlerFunc: s.auth},
 		{Method: http.MethodGet, Path: "/events", HandlerFunc: withCancelHandler(s.events)},
+		{Method: http.MethodGet, Path: "/system/df", HandlerFunc: s.diskUsage},
 
 		// daemon, we still list this API into system manager.
 		{Method: http.MethodPost, Path: "/daemon/update", HandlerFunc: s.updateDaemon},
@@ -43,6 +44,7 @@ func initRoute(s *Server) *mux.Router {
 		{Method: http.MethodGet, Path: "/containers/{name:.*}/checkpoints", HandlerFunc: withCancelHandler(s.listContainerCheckpoint)},
 		{Method: http.MethodDelete, Path: "/containers/{name}/checkpoints/{id}", HandlerFunc: withCancelHandler(s.deleteContainerCheckpoint)},
 		{Method: http.MethodPost, Path: "/containers/create", HandlerFunc: s.createContainer},
+		{Method: http.MethodPost, Path: "/containers/prune", HandlerFunc: s.pruneContainers},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/start", HandlerFunc: s.startContainer},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/stop", HandlerFunc: s.stopContainer},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/attach", HandlerFunc: s.attachContainer},
@@ -53,10 +55,13 @@ func initRoute(s *Server) *mux.Router {
 		{Method: http.MethodGet, Path: "/exec/{name:.*}/json", HandlerFunc: s.getExecInfo},
 		{Method: http.MethodPost, Path: "/exec/{name:.*}/start", HandlerFunc: s.startContainerExec},
 		{Method: http.MethodPost, Path: "/exec/{name:.*}/resize", HandlerFunc: s.resizeExec},
+		{Method: http.MethodPost, Path: "/exec/{name:.*}/kill", HandlerFunc: s.killExec},
+		{Method: http.MethodDelete, Path: "/exec/{name:.*}", HandlerFunc: s.removeExec},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/rename", HandlerFunc: s.renameContainer},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/restart", HandlerFunc: s.restartContainer},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/pause", HandlerFunc: s.pauseContainer},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/unpause", HandlerFunc: s.unpauseContainer},
+		{Method: http.MethodPost, Path: "/containers/{name:.*}/kill", HandlerFunc: s.killContainer},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/update", HandlerFunc: s.updateContainer},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/upgrade", HandlerFunc: s.upgradeContainer},
 		{Method: http.MethodGet, Path: "/containers/{name:.*}/top", HandlerFunc: s.topContainer},
@@ -66,28 +71,34 @@ func initRoute(s *Server) *mux.Router {
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/restart", HandlerFunc: s.restartContainer},
 		{Method: http.MethodPost, Path: "/containers/{name:.*}/wait", HandlerFunc: withCancelHandler(s.waitContainer)},
 		{Method: http.MethodPost, Path: "/commit", HandlerFunc: withCancelHandler(s.commitContainer)},
+		{Method: http.MethodGet, Path: "/containers/{name:.*}/export", HandlerFunc: withCancelHandler(s.exportContainer)},
+		{Method: http.MethodGet, Path: "/containers/{name:.*}/changes", HandlerFunc: s.changesContainer},
 
 		// image
 		{Method: http.MethodPost, Path: "/images/create", HandlerFunc: withCancelHandler(s.pullImage)},
 		{Method: http.MethodPost, Path: "/images/search", HandlerFunc: s.searchImages},
+		{Method: http.MethodPost, Path: "/images/prune", HandlerFunc: s.pruneImages},
 		{Method: http.MethodGet, Path: "/images/json", HandlerFunc: s.listImages},
 		{Method: http.MethodDelete, Path: "/images/{name:.*}", HandlerFunc: s.removeImage},
 		{Method: http.MethodGet, Path: "/images/{name:.*}/json", HandlerFunc: s.getImage},
 		{Method: http.MethodPost, Path: "/images/{name:.*}/tag", HandlerFunc: s.postImageTag},
 		{Method: http.MethodPost, Path: "/images/load", HandlerFunc: withCancelHandler(s.loadImage)},
 		{Method: http.MethodGet, Path: "/images/save", HandlerFunc: withCancelHandler(s.saveImage)},
+		{Method: http.MethodPost, Path: "/images/import", HandlerFunc: withCancelHandler(s.importImage)},
 		{Method: http.MethodGet, Path: "/images/{name:.*}/history", HandlerFunc: s.getImageHistory},
 		{Method: http.MethodPost, Path: "/images/{name:.*}/push", HandlerFunc: s.pushImage},
 
 		// volume
 		{Method: http.MethodGet, Path: "/volumes", HandlerFunc: s.listVolume},
 		{Method: http.MethodPost, Path: "/volumes/create", HandlerFunc: s.createVolume},
+		{Method: http.MethodPost, Path: "/volumes/prune", HandlerFunc: s.pruneVolumes},
 		{Method: http.MethodGet, Path: "/volumes/{name:.*}", HandlerFunc: s.getVolume},
 		{Method: http.MethodDelete, Path: "/volumes/{name:.*}", HandlerFunc: s.removeVolume},
 
 		// network
 		{Method: http.MethodGet, Path: "/networks", HandlerFunc: s.listNetwork},
 		{Method: http.MethodPost, Path: "/networks/create", HandlerFunc: s.createNetwork},
+		{Method: http.MethodPost, Path: "/networks/prune", HandlerFunc: s.pruneNetworks},
 		{Method: http.MethodGet, Path: "/networks/{id:.*}", HandlerFunc: s.getNetwork},
 		{Method: http.MethodDelete, Path: "/networks/{id:.*}", HandlerFunc: s.deleteNetwork},
 		{Method: http.MethodPost, Path: "/networks/{id:.*}/connect", HandlerFunc: s.connectToNetwork},
@@ -254,6 +265,8 @@ func EncodeResponse(rw http.ResponseWriter, statusCode int, data interface{}) er
 }
 
 // HandleErrorResponse handles err from daemon side and constructs response for client side.
+// The errors conflicting with the state of object, such as the container is
+// not running, are replied with 409 by all endpoints.
 func HandleErrorResponse(w http.ResponseWriter, err error) {
 	var (
 		code   int
@@ -273,6 +286,8 @@ func HandleErrorResponse(w http.ResponseWriter, err error) {
 		code = http.StatusBadRequest
 	} else if errtypes.IsAlreadyExisted(err) {
 		code = http.StatusConflict
+	} else if errtypes.IsConflict(err) {
+		code = http.StatusConflict
 	} else if errtypes.IsNotModified(err) {
 		code = http.StatusNotModified
 	} else if errtypes.IsInvalidAuthorization(err) {
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleErrorResponse(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{err: errors.Wrap(errtypes.ErrNotfound, "container foo"), code: http.StatusNotFound},
+		{err: errors.Wrap(errtypes.ErrInvalidParam, "invalid name"), code: http.StatusBadRequest},
+		{err: errors.Wrap(errtypes.ErrAlreadyExisted, "container foo"), code: http.StatusConflict},
+		{err: errors.Wrap(errtypes.ErrConflict, "container foo is not running"), code: http.StatusConflict},
+		{err: fmt.Errorf("unknown error"), code: http.StatusInternalServerError},
+	} {
+		rw := httptest.NewRecorder()
+		HandleErrorResponse(rw, tc.err)
+		assert.Equal(t, tc.code, rw.Code, tc.err.Error())
+		assert.Equal(t, fmt.Sprintf("{\"message\":%q}\n", tc.err.Error()), rw.Body.String())
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/daemon/mgr"
 	"github.com/alibaba/pouch/pkg/httputils"
 	"github.com/alibaba/pouch/pkg/log"
 	"github.com/alibaba/pouch/pkg/utils"
@@ -70,8 +71,7 @@ func (s *Server) auth(ctx context.Context, rw http.ResponseWriter, req *http.Req
 
 	token, err := s.SystemMgr.Auth(&auth)
 	if err != nil {
-		rw.WriteHeader(http.StatusUnauthorized)
-		return
+		return httputils.NewHTTPError(err, http.StatusUnauthorized)
 	}
 
 	authResp := types.AuthResponse{
@@ -104,7 +104,7 @@ func (s *Server) events(ctx context.Context, rw http.ResponseWriter, req *http.R
 	)
 	if !until.IsZero() {
 		if until.Before(since) {
-			return fmt.Errorf("until time (%s) cannot be after since (%s)", req.FormValue("until"), req.FormValue("since"))
+			return fmt.Errorf("until time (%s) cannot be before since (%s)", req.FormValue("until"), req.FormValue("since"))
 		}
 
 		now := time.Now()
@@ -155,6 +155,39 @@ func (s *Server) events(ctx context.Context, rw http.ResponseWriter, req *http.R
 	}
 }
 
+func (s *Server) diskUsage(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
+	containers, err := s.ContainerMgr.List(ctx, &mgr.ContainerListOption{All: true})
+	if err != nil {
+		return err
+	}
+
+	usedImages := make(map[string]bool, len(containers))
+	usedVolumes := make(map[string]bool)
+	for _, c := range containers {
+		usedImages[c.Image] = true
+		for _, m := range c.Mounts {
+			if m.Name != "" {
+				usedVolumes[m.Name] = true
+			}
+		}
+	}
+
+	du := &types.DiskUsage{
+		// NOTE: the build cache is managed by buildkitd instead of pouchd.
+		BuildCache: []*types.DiskUsageObject{},
+	}
+	if du.Images, err = s.ImageMgr.DiskUsage(ctx, usedImages); err != nil {
+		return err
+	}
+	if du.Containers, err = s.ContainerMgr.DiskUsage(ctx); err != nil {
+		return err
+	}
+	if du.Volumes, err = s.VolumeMgr.DiskUsage(ctx, usedVolumes); err != nil {
+		return err
+	}
+	return EncodeResponse(rw, http.StatusOK, du)
+}
+
 func (s *Server) metrics(ctx context.Context, rw http.ResponseWriter, req *http.Request) (err error) {
 	metrics.GetPrometheusHandler().ServeHTTP(rw, req)
 	return nil
@@ -103,6 +103,7 @@ func writeLogStream(ctx context.Context, w io.Writer, tty bool, opt *types.Conta
 			if msg.Source == "stderr" && opt.ShowStderr {
 				if _, err := stderrStream.Write(logLine); err != nil {
 					log.With(ctx).Errorf("unexpected error during stderr log: %v\n", err)
+					return
 				}
 			}
 		}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/daemon/mgr"
 	"github.com/alibaba/pouch/pkg/httputils"
 	"github.com/alibaba/pouch/pkg/randomid"
 	volumetypes "github.com/alibaba/pouch/storage/volume/types"
@@ -137,3 +138,30 @@ func (s *Server) removeVolume(ctx context.Context, rw http.ResponseWriter, req *
 	rw.WriteHeader(http.StatusNoContent)
 	return nil
 }
+
+func (s *Server) pruneVolumes(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	filter, err := filters.FromParam(req.FormValue("filters"))
+	if err != nil {
+		return err
+	}
+
+	containers, err := s.ContainerMgr.List(ctx, &mgr.ContainerListOption{All: true})
+	if err != nil {
+		return err
+	}
+
+	usedVolumes := make(map[string]bool)
+	for _, c := range containers {
+		for _, m := range c.Mounts {
+			if m.Name != "" {
+				usedVolumes[m.Name] = true
+			}
+		}
+	}
+
+	resp, err := s.VolumeMgr.Prune(ctx, filter, usedVolumes)
+	if err != nil {
+		return err
+	}
+	return EncodeResponse(rw, http.StatusOK, resp)
+}
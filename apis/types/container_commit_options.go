@@ -17,9 +17,15 @@ type ContainerCommitOptions struct {
 	// author is the one build the image
 	Author string `json:"Author,omitempty"`
 
+	// Dockerfile instructions applied to the config of the image, support CMD, ENTRYPOINT, ENV, USER and WORKDIR
+	Changes []string `json:"Changes"`
+
 	// comment is external information add for the image
 	Comment string `json:"Comment,omitempty"`
 
+	// pause the container during commit, default is true
+	Pause bool `json:"Pause,omitempty"`
+
 	// repository is the image name
 	Repository string `json:"Repository,omitempty"`
 
@@ -61,6 +61,9 @@ type ContainerConfig struct {
 	// An object mapping ports to an empty object in the form:`{<port>/<tcp|udp>: {}}`
 	ExposedPorts map[string]interface{} `json:"ExposedPorts,omitempty"`
 
+	// healthcheck
+	Healthcheck *HealthConfig `json:"Healthcheck,omitempty"`
+
 	// The hostname to use for the container, as a valid RFC 1123 hostname.
 	// Min Length: 1
 	// Format: hostname
@@ -154,6 +157,10 @@ func (m *ContainerConfig) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateHealthcheck(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateHostname(formats); err != nil {
 		res = append(res, err)
 	}
@@ -217,6 +224,24 @@ func (m *ContainerConfig) validateExposedPorts(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ContainerConfig) validateHealthcheck(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Healthcheck) { // not required
+		return nil
+	}
+
+	if m.Healthcheck != nil {
+		if err := m.Healthcheck.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("Healthcheck")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *ContainerConfig) validateHostname(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Hostname) { // not required
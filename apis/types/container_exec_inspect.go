@@ -48,6 +48,9 @@ type ContainerExecInspect struct {
 	// Required: true
 	OpenStdout bool `json:"OpenStdout"`
 
+	// The host PID of the exec process, 0 if it has not started
+	Pid int64 `json:"Pid,omitempty"`
+
 	// process config
 	// Required: true
 	ProcessConfig *ProcessConfig `json:"ProcessConfig"`
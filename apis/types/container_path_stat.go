@@ -16,6 +16,9 @@ import (
 // swagger:model ContainerPathStat
 type ContainerPathStat struct {
 
+	// The target of symbol link, it is empty if the path is not a symbol link.
+	LinkTarget string `json:"linkTarget,omitempty"`
+
 	// mode
 	Mode uint32 `json:"mode,omitempty"`
 
@@ -25,6 +25,15 @@ type ExecCreateConfig struct {
 	// Attach the standard output
 	AttachStdout bool `json:"AttachStdout,omitempty"`
 
+	// A list of kernel capabilities to add to the exec process.
+	CapAdd []string `json:"CapAdd"`
+
+	// A list of kernel capabilities to drop from the exec process.
+	CapDrop []string `json:"CapDrop"`
+
+	// Start the exec process with only the envs in Env, ignoring the container's envs
+	ClearEnv bool `json:"ClearEnv,omitempty"`
+
 	// Execution commands and args
 	// Required: true
 	// Min Items: 1
@@ -39,6 +48,12 @@ type ExecCreateConfig struct {
 	// envs for exec command in container
 	Env []string `json:"Env"`
 
+	// A list of additional groups, in name or GID, that the exec process will run as.
+	GroupAdd []string `json:"GroupAdd"`
+
+	// Run the exec process under the init binary configured by exec-init-path of pouchd, which reaps the zombie processes and forwards signals
+	Init bool `json:"Init,omitempty"`
+
 	// Is the container in privileged mode
 	Privileged bool `json:"Privileged,omitempty"`
 
@@ -47,6 +62,9 @@ type ExecCreateConfig struct {
 
 	// User that will run the command
 	User string `json:"User,omitempty"`
+
+	// The working directory for the exec process inside the container
+	WorkingDir string `json:"WorkingDir,omitempty"`
 }
 
 // Validate validates this exec create config
@@ -17,6 +17,9 @@ type ExecStartConfig struct {
 	// ExecStart will first check if it's detached
 	Detach bool `json:"Detach,omitempty"`
 
+	// Copy the output of exec process into the log driver of container as well
+	Log bool `json:"Log,omitempty"`
+
 	// Check if there's a tty
 	Tty bool `json:"Tty,omitempty"`
 }
@@ -19,7 +19,7 @@ import (
 // swagger:model HostConfig
 type HostConfig struct {
 
-	// Automatically remove the container when the container's process exits. This has no effect if `RestartPolicy` is set.
+	// Automatically remove the container and its anonymous volumes when the container's process exits. It can not be set with `RestartPolicy`.
 	AutoRemove bool `json:"AutoRemove,omitempty"`
 
 	// A list of volume bindings for this container. Each volume binding is a string in one of these forms:
@@ -100,6 +100,9 @@ type HostConfig struct {
 	// Masks over the provided paths inside the container.
 	MaskedPaths []string `json:"MaskedPaths"`
 
+	// Specification for mounts to be added to the container, in the long-form syntax.
+	Mounts []*Mount `json:"Mounts"`
+
 	// Network mode to use for this container. Supported standard values are: `netns:<path>`, `bridge`, `host`, `none`, and `container:<name|id>`. Any other value is taken as a custom network's name to which this container should connect to.
 	NetworkMode string `json:"NetworkMode,omitempty"`
 
@@ -128,7 +131,7 @@ type HostConfig struct {
 	// Set the provided paths as RO inside the container.
 	ReadonlyPaths []string `json:"ReadonlyPaths"`
 
-	// Mount the container's root filesystem as read only.
+	// Mount the container's root filesystem as read only. Volumes, bind mounts and tmpfs mounts are not affected and remain writable unless they are mounted read only.
 	ReadonlyRootfs bool `json:"ReadonlyRootfs,omitempty"`
 
 	// Restart policy to be used to manage the container
@@ -225,6 +228,8 @@ func (m *HostConfig) UnmarshalJSON(raw []byte) error {
 
 		MaskedPaths []string `json:"MaskedPaths"`
 
+		Mounts []*Mount `json:"Mounts"`
+
 		NetworkMode string `json:"NetworkMode,omitempty"`
 
 		OomScoreAdj int64 `json:"OomScoreAdj,omitempty"`
@@ -313,6 +318,8 @@ func (m *HostConfig) UnmarshalJSON(raw []byte) error {
 
 	m.MaskedPaths = dataAO0.MaskedPaths
 
+	m.Mounts = dataAO0.Mounts
+
 	m.NetworkMode = dataAO0.NetworkMode
 
 	m.OomScoreAdj = dataAO0.OomScoreAdj
@@ -412,6 +419,8 @@ func (m HostConfig) MarshalJSON() ([]byte, error) {
 
 		MaskedPaths []string `json:"MaskedPaths"`
 
+		Mounts []*Mount `json:"Mounts"`
+
 		NetworkMode string `json:"NetworkMode,omitempty"`
 
 		OomScoreAdj int64 `json:"OomScoreAdj,omitempty"`
@@ -497,6 +506,8 @@ func (m HostConfig) MarshalJSON() ([]byte, error) {
 
 	dataAO0.MaskedPaths = m.MaskedPaths
 
+	dataAO0.Mounts = m.Mounts
+
 	dataAO0.NetworkMode = m.NetworkMode
 
 	dataAO0.OomScoreAdj = m.OomScoreAdj
@@ -572,6 +583,10 @@ func (m *HostConfig) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateMounts(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateOomScoreAdj(formats); err != nil {
 		res = append(res, err)
 	}
@@ -685,6 +700,31 @@ func (m *HostConfig) validateLogConfig(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *HostConfig) validateMounts(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Mounts) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Mounts); i++ {
+		if swag.IsZero(m.Mounts[i]) { // not required
+			continue
+		}
+
+		if m.Mounts[i] != nil {
+			if err := m.Mounts[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Mounts" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *HostConfig) validateOomScoreAdj(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.OomScoreAdj) { // not required
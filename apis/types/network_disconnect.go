@@ -17,7 +17,7 @@ type NetworkDisconnect struct {
 	// The ID or name of the container to disconnect from the network.
 	Container string `json:"Container,omitempty"`
 
-	// Force the container to disconnect from the network.
+	// Force the container to disconnect from the network, it is required when the network is the last one of the container.
 	Force bool `json:"Force,omitempty"`
 }
 
@@ -28,6 +28,11 @@ type UpdateConfig struct {
 	// List of labels set to container.
 	Label []string `json:"Label"`
 
+	// An integer value containing the score given to the container in order to tune OOM killer preferences.
+	// The range is in [-1000, 1000]. The score is applied to the main process of a running container at once.
+	//
+	OomScoreAdj *int64 `json:"OomScoreAdj,omitempty"`
+
 	// restart policy
 	RestartPolicy *RestartPolicy `json:"RestartPolicy,omitempty"`
 
@@ -52,6 +57,8 @@ func (m *UpdateConfig) UnmarshalJSON(raw []byte) error {
 
 		Label []string `json:"Label"`
 
+		OomScoreAdj *int64 `json:"OomScoreAdj,omitempty"`
+
 		RestartPolicy *RestartPolicy `json:"RestartPolicy,omitempty"`
 
 		SpecAnnotation map[string]string `json:"SpecAnnotation,omitempty"`
@@ -66,6 +73,8 @@ func (m *UpdateConfig) UnmarshalJSON(raw []byte) error {
 
 	m.Label = dataAO1.Label
 
+	m.OomScoreAdj = dataAO1.OomScoreAdj
+
 	m.RestartPolicy = dataAO1.RestartPolicy
 
 	m.SpecAnnotation = dataAO1.SpecAnnotation
@@ -90,6 +99,8 @@ func (m UpdateConfig) MarshalJSON() ([]byte, error) {
 
 		Label []string `json:"Label"`
 
+		OomScoreAdj *int64 `json:"OomScoreAdj,omitempty"`
+
 		RestartPolicy *RestartPolicy `json:"RestartPolicy,omitempty"`
 
 		SpecAnnotation map[string]string `json:"SpecAnnotation,omitempty"`
@@ -101,6 +112,8 @@ func (m UpdateConfig) MarshalJSON() ([]byte, error) {
 
 	dataAO1.Label = m.Label
 
+	dataAO1.OomScoreAdj = m.OomScoreAdj
+
 	dataAO1.RestartPolicy = m.RestartPolicy
 
 	dataAO1.SpecAnnotation = m.SpecAnnotation
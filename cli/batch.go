@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// runOnContainers runs the operation on every container with at most
+// parallel operations running concurrently. The name of container is printed
+// once its operation succeeds, and the errors of all failed operations are
+// returned together after all containers are tried.
+func runOnContainers(names []string, parallel int, op func(name string) error) error {
+	if parallel < 1 {
+		return fmt.Errorf("invalid parallel %d: should be a positive integer", parallel)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		lock sync.Mutex
+		sem  = make(chan struct{}, parallel)
+		errs = make([]error, len(names))
+	)
+
+	for i, name := range names {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, name string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := op(name); err != nil {
+				errs[i] = err
+				return
+			}
+
+			lock.Lock()
+			fmt.Printf("%s\n", name)
+			lock.Unlock()
+		}(i, name)
+	}
+	wg.Wait()
+
+	// keep the errors in the order of containers.
+	var msgs []string
+	for _, err := range errs {
+		if err != nil {
+			msgs = append(msgs, err.Error())
+		}
+	}
+	if len(msgs) > 0 {
+		return errors.New(strings.Join(msgs, "\n"))
+	}
+	return nil
+}
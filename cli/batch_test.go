@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunOnContainers(t *testing.T) {
+	var (
+		lock  sync.Mutex
+		tried []string
+	)
+	err := runOnContainers([]string{"a", "b", "c", "d"}, 1, func(name string) error {
+		lock.Lock()
+		tried = append(tried, name)
+		lock.Unlock()
+		if name == "b" || name == "d" {
+			return fmt.Errorf("failed %s", name)
+		}
+		return nil
+	})
+	assert.Equal(t, []string{"a", "b", "c", "d"}, tried)
+	assert.EqualError(t, err, "failed b\nfailed d")
+
+	assert.NoError(t, runOnContainers([]string{"a"}, 1, func(string) error { return nil }))
+	assert.Error(t, runOnContainers([]string{"a"}, 0, func(string) error { return nil }))
+}
+
+func TestRunOnContainersParallel(t *testing.T) {
+	var (
+		lock             sync.Mutex
+		running, maxSeen int
+	)
+	op := func(string) error {
+		lock.Lock()
+		running++
+		if running > maxSeen {
+			maxSeen = running
+		}
+		lock.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		lock.Lock()
+		running--
+		lock.Unlock()
+		return nil
+	}
+
+	assert.NoError(t, runOnContainers([]string{"a", "b", "c", "d", "e", "f"}, 2, op))
+	assert.Equal(t, 2, maxSeen)
+}
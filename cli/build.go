@@ -3,6 +3,9 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/alibaba/pouch/cli/build"
@@ -11,16 +14,20 @@ import (
 )
 
 // buildDescription is used to describe build command in detail and auto generate command doc.
-var buildDescription = "Build an image from a Dockerfile"
+var buildDescription = "Build an image from a Dockerfile by the builder of Pouchd, which requires Pouchd started with --enable-builder. " +
+	"The files of build context matched by the patterns in .pouchignore, or .dockerignore if .pouchignore does not exist, are not sent to builder. " +
+	"The ID of built image is printed after building."
 
 // BuildCommand use to implement 'build' command, it download image.
 type BuildCommand struct {
 	baseCommand
 
-	buildArgs []string
-	tagList   []string
-	target    string
-	addr      string
+	buildArgs  []string
+	tagList    []string
+	target     string
+	addr       string
+	dockerfile string
+	noCache    bool
 }
 
 // Init initialize pull command.
@@ -35,6 +42,7 @@ func (b *BuildCommand) Init(c *Cli) {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return b.runBuild(args)
 		},
+		Example: buildExample(),
 	}
 	b.addFlags()
 }
@@ -46,31 +54,93 @@ func (b *BuildCommand) addFlags() {
 	flagSet.StringArrayVarP(&b.tagList, "tag", "t", nil, "Name and optionally a tag in the 'name:tag' format")
 	flagSet.StringVar(&b.target, "target", "", "Set the target build stage to build")
 	flagSet.StringVar(&b.addr, "addr", "unix:///run/buildkit/buildkitd.sock", "buildkitd address")
+	flagSet.StringVarP(&b.dockerfile, "file", "f", "", "Name of the Dockerfile (default is 'PATH/Dockerfile')")
+	flagSet.BoolVar(&b.noCache, "no-cache", false, "Do not use cache when building the image")
 }
 
 func (b *BuildCommand) runBuild(args []string) error {
 	ctx, cancel := context.WithCancel(context.TODO())
 	defer cancel()
 
-	opts := b.buildOptions(args[0])
-	return build.Build(ctx, b.addr, opts)
+	opts, err := b.buildOptions(args[0])
+	if err != nil {
+		return err
+	}
+	if err := build.Build(ctx, b.addr, opts); err != nil {
+		return err
+	}
+
+	img, err := b.cli.Client().ImageInspect(ctx, opts.TagList[0])
+	if err != nil {
+		return fmt.Errorf("failed to get the built image %s: %v", opts.TagList[0], err)
+	}
+	fmt.Println(img.ID)
+	return nil
 }
 
-func (b *BuildCommand) buildOptions(workdir string) *build.Options {
+func (b *BuildCommand) buildOptions(workdir string) (*build.Options, error) {
+	buildArgs, err := parseBuildArgs(b.buildArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	excludes, err := build.ReadExcludes(workdir)
+	if err != nil {
+		return nil, err
+	}
+
 	opts := &build.Options{
-		TagList: b.tagList,
-		// TODO: build args
-		Target: b.target,
+		TagList:   b.tagList,
+		BuildArgs: buildArgs,
+		Target:    b.target,
+		NoCache:   b.noCache,
+		Excludes:  excludes,
+	}
+
+	// the Dockerfile can be out of build context
+	dockerfileDir := workdir
+	if b.dockerfile != "" {
+		dockerfileDir, opts.Filename = filepath.Split(b.dockerfile)
+		if dockerfileDir == "" {
+			dockerfileDir = "."
+		}
 	}
 
 	opts.LocalDirs = map[string]string{
-		"dockerfile": workdir,
-		"context":    workdir,
+		build.LocalNameDockerfile: dockerfileDir,
+		build.LocalNameContext:    workdir,
 	}
 
 	// using unknown:timestamp if there is no tag
 	if len(opts.TagList) == 0 {
 		opts.TagList = append(opts.TagList, fmt.Sprintf("unknown:%v", time.Now().UnixNano()))
 	}
-	return opts
+	return opts, nil
+}
+
+// parseBuildArgs parses the build-time variables in the format of KEY=VALUE,
+// the value of KEY without "=" is read from the client's environment, and the
+// unset one is skipped.
+func parseBuildArgs(args []string) (map[string]string, error) {
+	buildArgs := make(map[string]string, len(args))
+	for _, arg := range args {
+		fields := strings.SplitN(arg, "=", 2)
+		if fields[0] == "" {
+			return nil, fmt.Errorf("invalid build-arg %s: variable name should not be empty", arg)
+		}
+
+		if len(fields) == 2 {
+			buildArgs[fields[0]] = fields[1]
+		} else if value, ok := os.LookupEnv(fields[0]); ok {
+			buildArgs[fields[0]] = value
+		}
+	}
+	return buildArgs, nil
+}
+
+// buildExample shows examples in build command, and is used in auto-generated cli docs.
+func buildExample() string {
+	return `$ pouch build -t hello:v1 --build-arg VERSION=1.0 -f dockerfiles/Dockerfile.hello .
+...
+sha256:4f39e5f4c77e0e3c9d4bbbfd1b5b9ae7c86d4c6cb7a0e8bd5a5c9e5ae1a7f6b3`
 }
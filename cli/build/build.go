@@ -2,12 +2,16 @@ package build
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/containerd/console"
 	"github.com/moby/buildkit/client"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/filesync"
 	"github.com/moby/buildkit/util/progress/progressui"
 	"github.com/sirupsen/logrus"
+	fstypes "github.com/tonistiigi/fsutil/types"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -31,13 +35,20 @@ func Build(ctx context.Context, addr string, opt *Options) error {
 		return err
 	}
 
+	dirs, err := syncedDirs(opt)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: the local directories are shared by the session provider
+	// instead of SolveOpt.LocalDirs, so that the files of build context can
+	// be excluded by the client.
 	solveOpt := client.SolveOpt{
 		Exporter:      "image",
 		ExporterAttrs: exporterAttrs,
 		Frontend:      "dockerfile.v0",
 		FrontendAttrs: frontendAttrs,
-		// TODO: basically, we only need to support one workdir
-		LocalDirs: opt.LocalDirs,
+		Session:       []session.Attachable{filesync.NewFSSyncProvider(dirs)},
 	}
 
 	ch := make(chan *client.SolveStatus)
@@ -55,10 +66,39 @@ func Build(ctx context.Context, addr string, opt *Options) error {
 		if err == nil {
 			c = cf
 		} else {
-			logrus.Debug("failed to use tty for status: %v", err)
+			logrus.Debugf("failed to use tty for status: %v", err)
 		}
 
 		return progressui.DisplaySolveStatus(ctx, "", c, os.Stdout, ch)
 	})
 	return eg.Wait()
 }
+
+// syncedDirs returns the local directories shared with builder, and the
+// excluded files of build context are not sent.
+func syncedDirs(opt *Options) ([]filesync.SyncedDir, error) {
+	// the owner of files is reset to root in image.
+	resetUIDAndGID := func(st *fstypes.Stat) bool {
+		st.Uid = 0
+		st.Gid = 0
+		return true
+	}
+
+	dirs := make([]filesync.SyncedDir, 0, len(opt.LocalDirs))
+	for name, dir := range opt.LocalDirs {
+		fi, err := os.Stat(dir)
+		if err != nil {
+			return nil, fmt.Errorf("could not find %s: %v", dir, err)
+		}
+		if !fi.IsDir() {
+			return nil, fmt.Errorf("%s is not a directory", dir)
+		}
+
+		syncedDir := filesync.SyncedDir{Name: name, Dir: dir, Map: resetUIDAndGID}
+		if name == LocalNameContext {
+			syncedDir.Excludes = opt.Excludes
+		}
+		dirs = append(dirs, syncedDir)
+	}
+	return dirs, nil
+}
@@ -2,23 +2,68 @@ package build
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/alibaba/pouch/pkg/reference"
+
+	"github.com/docker/docker/builder/dockerignore"
 )
 
 // from frontend dockerfile codebase
 var (
 	keyTarget         = "target"
+	keyFilename       = "filename"
+	keyNoCache        = "no-cache"
 	keyBuildArgPrefix = "build-arg:"
 )
 
+const (
+	// LocalNameContext is the name of local directory of build context.
+	LocalNameContext = "context"
+	// LocalNameDockerfile is the name of local directory containing Dockerfile.
+	LocalNameDockerfile = "dockerfile"
+)
+
+// ignoreFiles are the files listing the patterns of files excluded from
+// build context, the former takes precedence.
+var ignoreFiles = []string{".pouchignore", ".dockerignore"}
+
 // Options is used to contains the user setting for build.
 type Options struct {
 	Target    string
 	BuildArgs map[string]string
 	TagList   []string
 	LocalDirs map[string]string
+
+	// Filename is the name of Dockerfile in the dockerfile directory.
+	Filename string
+	NoCache  bool
+	// Excludes are the patterns of files not sent in build context.
+	Excludes []string
+}
+
+// ReadExcludes reads the patterns of excluded files from .pouchignore or
+// .dockerignore in the build context directory.
+func ReadExcludes(contextDir string) ([]string, error) {
+	for _, name := range ignoreFiles {
+		f, err := os.Open(filepath.Join(contextDir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		defer f.Close()
+
+		excludes, err := dockerignore.ReadAll(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", name, err)
+		}
+		return excludes, nil
+	}
+	return nil, nil
 }
 
 // optsToFrontendAttrs converts build options to FrontendAttrs.
@@ -30,6 +75,15 @@ func optsToFrontendAttrs(opt *Options) (map[string]string, error) {
 		attrs[keyTarget] = opt.Target
 	}
 
+	if opt.Filename != "" {
+		attrs[keyFilename] = opt.Filename
+	}
+
+	// empty value means disabling cache for all stages
+	if opt.NoCache {
+		attrs[keyNoCache] = ""
+	}
+
 	// add build-args
 	for key, value := range opt.BuildArgs {
 		attrs[keyBuildArgPrefix+key] = value
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alibaba/pouch/cli/build"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBuildArgs(t *testing.T) {
+	os.Setenv("__BUILD_ARG_SET", "from-env")
+	defer os.Unsetenv("__BUILD_ARG_SET")
+	os.Unsetenv("__BUILD_ARG_UNSET")
+
+	args, err := parseBuildArgs([]string{"A=1", "B=", "C=x=y", "__BUILD_ARG_SET", "__BUILD_ARG_UNSET"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"A":               "1",
+		"B":               "",
+		"C":               "x=y",
+		"__BUILD_ARG_SET": "from-env",
+	}, args)
+
+	_, err = parseBuildArgs([]string{"=1"})
+	assert.Error(t, err)
+}
+
+func TestBuildOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "build-options")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b := &BuildCommand{
+		tagList:    []string{"hello:v1"},
+		buildArgs:  []string{"VERSION=1.0"},
+		dockerfile: filepath.Join("dockerfiles", "Dockerfile.hello"),
+		noCache:    true,
+	}
+
+	opts, err := b.buildOptions(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, "Dockerfile.hello", opts.Filename)
+	assert.Equal(t, map[string]string{
+		build.LocalNameDockerfile: "dockerfiles" + string(filepath.Separator),
+		build.LocalNameContext:    dir,
+	}, opts.LocalDirs)
+	assert.Equal(t, map[string]string{"VERSION": "1.0"}, opts.BuildArgs)
+	assert.True(t, opts.NoCache)
+	assert.Empty(t, opts.Excludes)
+
+	// .pouchignore takes precedence over .dockerignore
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".dockerignore"), []byte("*.log\n"), 0644))
+	opts, err = b.buildOptions(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"*.log"}, opts.Excludes)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".pouchignore"), []byte("# comment\n.git\ntmp/*\n"), 0644))
+	opts, err = b.buildOptions(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{".git", "tmp/*"}, opts.Excludes)
+
+	// the Dockerfile is in build context by default, and the image is tagged
+	// with unknown if no tag is given.
+	opts, err = (&BuildCommand{}).buildOptions(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, "", opts.Filename)
+	assert.Equal(t, dir, opts.LocalDirs[build.LocalNameDockerfile])
+	assert.Len(t, opts.TagList, 1)
+}
@@ -11,7 +11,8 @@ import (
 )
 
 // checkpointDescription is used to describe checkpoint command in detail and auto generate command doc.
-var checkpointDescription = "\nManage checkpoint commands, create checkpoint."
+var checkpointDescription = "\nManage checkpoint commands, create checkpoint. " +
+	"Checkpoint and restore are done by CRIU, which must be installed on the daemon host."
 
 // CheckpointCommand use to implement 'checkpoint' command, it checkpoint a container.
 type CheckpointCommand struct {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strconv"
@@ -20,9 +21,10 @@ var pouchDescription = "pouch is a client side tool pouch to interact with daemo
 
 // Option uses to define the global options.
 type Option struct {
-	host  string
-	Debug bool
-	TLS   client.TLSConfig
+	hosts   []string
+	Debug   bool
+	noColor bool
+	TLS     client.TLSConfig
 }
 
 // Cli is the client's core struct, it will be used to manage all subcommand, send http request
@@ -51,8 +53,9 @@ func NewCli() *Cli {
 // SetFlags sets all global options.
 func (c *Cli) SetFlags() *Cli {
 	flags := c.rootCmd.PersistentFlags()
-	flags.StringVarP(&c.Option.host, "host", "H", "unix:///var/run/pouchd.sock", "Specify connecting address of Pouch CLI")
+	flags.StringArrayVarP(&c.Option.hosts, "host", "H", []string{"unix:///var/run/pouchd.sock"}, "Specify connecting address of Pouch CLI, repeat it to fail over to the next address which is reachable")
 	flags.BoolVarP(&c.Option.Debug, "debug", "D", false, "Switch client log level to DEBUG mode")
+	flags.BoolVar(&c.Option.noColor, "no-color", false, "Disable colors and escape sequences in output, also set by NO_COLOR environment variable")
 	flags.StringVar(&c.Option.TLS.Key, "tlskey", "", "Specify key file of TLS")
 	flags.StringVar(&c.Option.TLS.Cert, "tlscert", "", "Specify cert file of TLS")
 	flags.StringVar(&c.Option.TLS.CA, "tlscacert", "", "Specify CA file of TLS")
@@ -60,14 +63,38 @@ func (c *Cli) SetFlags() *Cli {
 	return c
 }
 
-// NewAPIClient initializes the API client in Cli.
+// NewAPIClient initializes the API client in Cli. With multiple hosts, the
+// first one which is reachable is used.
 func (c *Cli) NewAPIClient() {
-	client, err := client.NewAPIClient(c.Option.host, c.Option.TLS)
+	var (
+		apiClient client.CommonAPIClient
+		err       error
+	)
+
+	switch len(c.Option.hosts) {
+	case 0:
+		apiClient, err = client.NewAPIClient("", c.Option.TLS)
+	case 1:
+		apiClient, err = client.NewAPIClient(c.Option.hosts[0], c.Option.TLS)
+	default:
+		apiClient, err = client.NewFailoverAPIClient(context.Background(), c.Option.hosts, c.Option.TLS)
+	}
 	if err != nil {
 		log.With(nil).Fatal(err)
 	}
 
-	c.APIClient = client
+	c.APIClient = apiClient
+}
+
+// Hosts returns the connecting addresses of daemons given by --host.
+func (c *Cli) Hosts() []string {
+	return c.Option.hosts
+}
+
+// NoColor returns whether colors and escape sequences should be disabled in
+// output, by --no-color or the NO_COLOR environment variable.
+func (c *Cli) NoColor() bool {
+	return c.Option.noColor || os.Getenv("NO_COLOR") != ""
 }
 
 // Client returns API client towards daemon.
@@ -94,6 +121,9 @@ func (c *Cli) AddCommand(parent, child Command) {
 
 	childCmd.PreRun = func(cmd *cobra.Command, args []string) {
 		log.Init(c.Debug)
+		if c.NoColor() {
+			log.DisableColors()
+		}
 		c.NewAPIClient()
 	}
 
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoColor(t *testing.T) {
+	defer os.Setenv("NO_COLOR", os.Getenv("NO_COLOR"))
+
+	c := NewCli().SetFlags()
+	os.Setenv("NO_COLOR", "")
+	assert.False(t, c.NoColor())
+
+	os.Setenv("NO_COLOR", "1")
+	assert.True(t, c.NoColor())
+
+	os.Setenv("NO_COLOR", "")
+	assert.NoError(t, c.rootCmd.PersistentFlags().Set("no-color", "true"))
+	assert.True(t, c.NoColor())
+}
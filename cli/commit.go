@@ -12,23 +12,27 @@ import (
 )
 
 // commitDescription is used to describe commit command in detail and auto generate command doc.
-var commitDescription = "commit an image from a container."
+var commitDescription = "Create a new image from a container's changes. " +
+	"The running container is paused during commit by default. " +
+	"If REPOSITORY is omitted, the image is named commit-<short container id>."
 
 // CommitCommand is used to implement 'commit' command.
 type CommitCommand struct {
 	baseCommand
 	author  string
 	message string
+	changes []string
+	pause   bool
 }
 
 // Init initializes CommitCommand command.
 func (cc *CommitCommand) Init(c *Cli) {
 	cc.cli = c
 	cc.cmd = &cobra.Command{
-		Use:   "commit [OPTIONS] CONTAINER REPOSITORY[:TAG]",
+		Use:   "commit [OPTIONS] CONTAINER [REPOSITORY[:TAG]]",
 		Short: "Commit an image from a container",
 		Long:  commitDescription,
-		Args:  cobra.ExactArgs(2),
+		Args:  cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cc.runCommit(args)
 		},
@@ -43,6 +47,8 @@ func (cc *CommitCommand) addFlags() {
 
 	flagSet.StringVarP(&cc.author, "author", "a", "", "Image author, eg.(name <email@email.com>)")
 	flagSet.StringVarP(&cc.message, "message", "m", "", "Commit message")
+	flagSet.StringArrayVarP(&cc.changes, "change", "c", nil, "Apply Dockerfile instruction to the created image, support CMD, ENTRYPOINT, ENV, USER and WORKDIR")
+	flagSet.BoolVarP(&cc.pause, "pause", "p", true, "Pause container during commit")
 }
 
 // runCommit is the entry of CommitCommand command.
@@ -52,20 +58,20 @@ func (cc *CommitCommand) runCommit(args []string) error {
 
 	// create commit process.
 	id := args[0]
-	ref := args[1]
-
-	namedRef, err := reference.Parse(ref)
-	if err != nil {
-		return err
-	}
-
-	namedRef = reference.WithDefaultTagIfMissing(namedRef)
 
 	var name, tag string
-	if reference.IsNameTagged(namedRef) {
-		name, tag = namedRef.Name(), namedRef.(reference.Tagged).Tag()
-	} else {
-		name, tag = namedRef.String(), "latest"
+	if len(args) > 1 {
+		namedRef, err := reference.Parse(args[1])
+		if err != nil {
+			return err
+		}
+
+		namedRef = reference.WithDefaultTagIfMissing(namedRef)
+		if reference.IsNameTagged(namedRef) {
+			name, tag = namedRef.Name(), namedRef.(reference.Tagged).Tag()
+		} else {
+			name, tag = namedRef.String(), "latest"
+		}
 	}
 
 	commitConfig := types.ContainerCommitOptions{
@@ -73,6 +79,8 @@ func (cc *CommitCommand) runCommit(args []string) error {
 		Tag:        tag,
 		Comment:    cc.message,
 		Author:     cc.author,
+		Changes:    cc.changes,
+		Pause:      cc.pause,
 	}
 
 	respCommit, err := apiClient.ContainerCommit(ctx, id, commitConfig)
@@ -88,5 +96,6 @@ func (cc *CommitCommand) runCommit(args []string) error {
 func commitExample() string {
 	return `$ pouch commit 25bf50 test:image
 1c7e415csa333
-`
+$ pouch commit -m "add foo" -c "CMD top" -c "ENV foo=bar" --pause=false 25bf50 test:foo
+6d2b0bc5a8f1`
 }
@@ -20,8 +20,8 @@ func addCommonFlags(flagSet *pflag.FlagSet) *container {
 	flagSet.Var(&c.blkioDeviceWriteIOps, "device-write-iops", "Limit write rate (IO per second) from a device")
 
 	// capbilities
-	flagSet.StringSliceVar(&c.capAdd, "cap-add", nil, "Add Linux capabilities")
-	flagSet.StringSliceVar(&c.capDrop, "cap-drop", nil, "Drop Linux capabilities")
+	flagSet.StringSliceVar(&c.capAdd, "cap-add", nil, "Add Linux capabilities, ALL adds all capabilities")
+	flagSet.StringSliceVar(&c.capDrop, "cap-drop", nil, "Drop Linux capabilities, ALL drops all capabilities, use with --cap-add to only keep the specific ones")
 
 	// cpu
 	flagSet.Int64Var(&c.cpushare, "cpu-shares", 0, "CPU shares (relative weight)")
@@ -29,12 +29,14 @@ func addCommonFlags(flagSet *pflag.FlagSet) *container {
 	flagSet.StringVar(&c.cpusetmems, "cpuset-mems", "", "MEMs in which to allow execution (0-3, 0,1)")
 	flagSet.Int64Var(&c.cpuperiod, "cpu-period", 0, "Limit CPU CFS (Completely Fair Scheduler) period, range is in [1000(1ms),1000000(1s)]")
 	flagSet.Int64Var(&c.cpuquota, "cpu-quota", 0, "Limit CPU CFS (Completely Fair Scheduler) quota, range is in [1000,∞)")
+	flagSet.Float64Var(&c.cpus, "cpus", 0, "Number of CPUs, which is applied by CFS quota in period of 100ms and can not be set with --cpu-period and --cpu-quota")
 
 	// device related options
-	flagSet.StringSliceVarP(&c.devices, "device", "", nil, "Add a host device to the container")
+	flagSet.StringSliceVarP(&c.devices, "device", "", nil, "Add a host device to the container, in the format of HOST[:CONTAINER[:PERMS]], PERMS is a composition of r, w and m, default is rwm")
+	flagSet.StringSliceVar(&c.deviceCgroupRules, "device-cgroup-rule", nil, "Add a rule to the cgroup allowed devices list, in the format of 'type major:minor access', like 'c 13:* rwm'")
 
 	flagSet.BoolVar(&c.enableLxcfs, "enableLxcfs", false, "Enable lxcfs for the container, only effective when enable-lxcfs switched on in Pouchd")
-	flagSet.StringVar(&c.entrypoint, "entrypoint", "", "Overwrite the default ENTRYPOINT of the image")
+	flagSet.StringVar(&c.entrypoint, "entrypoint", "", "Overwrite the default ENTRYPOINT of the image, an empty string clears it")
 	flagSet.StringArrayVarP(&c.env, "env", "e", nil, "Set environment variables for container('--env A=' means setting env A to empty, '--env B' means removing env B from container env inherited from image)")
 	flagSet.StringArrayVar(&c.envfile, "env-file", nil, "Read in a file of environment variables")
 	flagSet.StringVar(&c.hostname, "hostname", "", "Set container's hostname")
@@ -45,6 +47,7 @@ func addCommonFlags(flagSet *pflag.FlagSet) *container {
 
 	flagSet.StringVar(&c.ipcMode, "ipc", "", "IPC namespace to use")
 	flagSet.StringArrayVarP(&c.labels, "label", "l", nil, "Set labels for a container")
+	flagSet.StringArrayVar(&c.labelFiles, "label-file", nil, "Read in a line delimited file of labels")
 
 	// log driver and log options
 	flagSet.StringVar(&c.logDriver, "log-driver", types.LogConfigLogDriverJSONFile, "Logging driver for the container")
@@ -60,11 +63,19 @@ func addCommonFlags(flagSet *pflag.FlagSet) *container {
 	flagSet.BoolVar(&c.oomKillDisable, "oom-kill-disable", false, "Disable OOM Killer")
 	flagSet.Int64Var(&c.oomScoreAdj, "oom-score-adj", -500, "Tune host's OOM preferences (-1000 to 1000)")
 
+	// health check
+	flagSet.StringVar(&c.healthCmd, "health-cmd", "", "Command to run to check health")
+	flagSet.DurationVar(&c.healthInterval, "health-interval", 0, "Time between running the check (ms|s|m|h) (default 30s)")
+	flagSet.DurationVar(&c.healthTimeout, "health-timeout", 0, "Maximum time to allow one check to run (ms|s|m|h) (default 30s)")
+	flagSet.DurationVar(&c.healthStartPeriod, "health-start-period", 0, "Start period for the container to initialize before counting retries towards unstable (ms|s|m|h) (default 0s)")
+	flagSet.IntVar(&c.healthRetries, "health-retries", 0, "Consecutive failures needed to report unhealthy (default 3)")
+
 	flagSet.StringVar(&c.name, "name", "", "Specify name of container")
 	flagSet.StringVar(&c.specificID, "specific-id", "", "Specify id of container, length of id should be 64, characters of id should be in '0123456789abcdef'")
 
 	// network
 	flagSet.StringSliceVar(&c.networks, "net", nil, "Set networks to container")
+	flagSet.StringSliceVar(&c.networkAliases, "network-alias", nil, "Add network-scoped alias for the container, format is [network:]alias")
 	flagSet.StringSliceVarP(&c.ports, "publish", "p", nil, "Set container ports mapping")
 	flagSet.StringSliceVar(&c.expose, "expose", nil, "Set expose container's ports")
 	flagSet.BoolVarP(&c.publishAll, "publish-all", "P", false, "Publish all exposed ports to random ports")
@@ -79,14 +90,20 @@ func addCommonFlags(flagSet *pflag.FlagSet) *container {
 	flagSet.StringArrayVar(&c.dnsSearch, "dns-search", nil, "Set DNS search domains")
 
 	flagSet.StringVar(&c.pidMode, "pid", "", "PID namespace to use")
+	flagSet.StringVar(&c.platform, "platform", "", "Set platform if the image is multi-platform, in the format of os/arch[/variant] (default is the platform of daemon host)")
+	flagSet.StringVar(&c.pull, "pull", pullMissing, "Pull image before creating the container, support always, missing and never")
 	flagSet.BoolVar(&c.privileged, "privileged", false, "Give extended privileges to the container")
+	flagSet.BoolVar(&c.readOnly, "read-only", false, "Mount the container's root filesystem as read only, volumes and tmpfs mounts remain writable")
 
-	flagSet.StringVar(&c.restartPolicy, "restart", "", "Restart policy to apply when container exits")
+	flagSet.StringVar(&c.restartPolicy, "restart", "", "Restart policy to apply when container exits, support no, on-failure[:max-retries], always and unless-stopped")
 	flagSet.StringVar(&c.runtime, "runtime", "", "OCI runtime to use for this container")
 
-	flagSet.StringSliceVar(&c.securityOpt, "security-opt", nil, "Security Options")
+	flagSet.StringArrayVar(&c.securityOpt, "security-opt", nil, "Security options, support no-new-privileges, apparmor=<profile>, seccomp=<profile> and label=<label>, seccomp profile can be unconfined, pouch/default or a local JSON file")
+
+	flagSet.StringVar(&c.stopSignal, "stop-signal", "", "Signal to stop the container, in the format of name or number, default is SIGTERM")
+	flagSet.IntVar(&c.stopTimeout, "stop-timeout", -1, "Seconds to wait for stop before killing the container, default is 10, 0 kills it immediately")
 
-	flagSet.StringSliceVar(&c.sysctls, "sysctl", nil, "Sysctl options")
+	flagSet.StringSliceVar(&c.sysctls, "sysctl", nil, "Set namespaced kernel parameters in the format of key=value, only kernel.msg*, kernel.sem, kernel.shm*, fs.mqueue.* and net.* are supported")
 	flagSet.BoolVarP(&c.tty, "tty", "t", false, "Allocate a pseudo-TTY")
 
 	// user
@@ -97,12 +114,14 @@ func addCommonFlags(flagSet *pflag.FlagSet) *container {
 	flagSet.StringVar(&c.utsMode, "uts", "", "UTS namespace to use")
 
 	flagSet.VarP(config.NewVolumes(&c.volume), "volume", "v", "Bind mount volumes to container, format is: [source:]<destination>[:mode], [source] can be volume or host's path, <destination> is container's path, [mode] can be \"ro/rw/dr/rr/z/Z/nocopy/private/rprivate/slave/rslave/shared/rshared\"")
+	flagSet.StringArrayVar(&c.mounts, "mount", nil, "Attach a filesystem mount to the container, format is: type=<bind|volume|tmpfs>,[source=<src>,]target=<dst>[,readonly][,bind-propagation=<mode>][,tmpfs-size=<size>]")
+	flagSet.StringArrayVar(&c.tmpfs, "tmpfs", nil, "Mount a tmpfs to the container, format is: <destination>[:options], [options] can be \"rw/ro/exec/noexec/suid/nosuid/dev/nodev/size=<size>/mode=<mode>/...\", default is noexec,nosuid,nodev,size=64m")
 	flagSet.StringSliceVar(&c.volumesFrom, "volumes-from", nil, "set volumes from other containers, format is <container>[:mode]")
 	flagSet.StringVar(&c.volumeDriver, "volume-driver", "", "set volume driver for container's volumes")
 
 	flagSet.StringVarP(&c.workdir, "workdir", "w", "", "Set the working directory in a container")
-	flagSet.Var(&c.ulimit, "ulimit", "Set container ulimit")
-	flagSet.Int64Var(&c.pidsLimit, "pids-limit", 0, "Set container pids limit")
+	flagSet.Var(&c.ulimit, "ulimit", "Set container ulimit, in the format of NAME=SOFT[:HARD] (e.g. nofile=1024:2048)")
+	flagSet.Int64Var(&c.pidsLimit, "pids-limit", 0, "Tune container pids limit (set -1 for unlimited)")
 
 	flagSet.BoolVar(&c.rich, "rich", false, "Start container in rich container mode. (default false)")
 	flagSet.StringVar(&c.richMode, "rich-mode", "", "Choose one rich container mode. dumb-init(default), systemd, sbin-init")
@@ -2,6 +2,7 @@ package main
 
 import (
 	"strings"
+	"time"
 
 	"github.com/alibaba/pouch/apis/opts"
 	"github.com/alibaba/pouch/apis/opts/config"
@@ -12,9 +13,12 @@ import (
 
 type container struct {
 	labels              []string
+	labelFiles          []string
 	name                string
 	tty                 bool
 	volume              config.Volumes
+	mounts              []string
+	tmpfs               []string
 	volumesFrom         []string
 	volumeDriver        string
 	runtime             string
@@ -28,6 +32,8 @@ type container struct {
 	rm                  bool
 	disableNetworkFiles bool
 	specificID          string
+	platform            string
+	pull                string
 
 	blkioWeight          uint16
 	blkioWeightDevice    config.WeightDevice
@@ -41,6 +47,7 @@ type container struct {
 	cpusetmems string
 	cpuperiod  int64
 	cpuquota   int64
+	cpus       float64
 
 	memory            string
 	memoryReservation string
@@ -54,28 +61,31 @@ type container struct {
 	scheLatSwitch       int64
 	oomKillDisable      bool
 
-	devices       []string
-	enableLxcfs   bool
-	privileged    bool
-	restartPolicy string
-	ipcMode       string
-	pidMode       string
-	utsMode       string
-	sysctls       []string
+	devices           []string
+	deviceCgroupRules []string
+	enableLxcfs       bool
+	privileged        bool
+	readOnly          bool
+	restartPolicy     string
+	ipcMode           string
+	pidMode           string
+	utsMode           string
+	sysctls           []string
 
 	// set network options
-	networks    []string
-	ports       []string
-	expose      []string
-	publishAll  bool
-	ip          string
-	ipv6        string
-	macAddress  string
-	netPriority int64
-	extraHosts  []string
-	dns         []string
-	dnsOptions  []string
-	dnsSearch   []string
+	networks       []string
+	networkAliases []string
+	ports          []string
+	expose         []string
+	publishAll     bool
+	ip             string
+	ipv6           string
+	macAddress     string
+	netPriority    int64
+	extraHosts     []string
+	dns            []string
+	dnsOptions     []string
+	dnsSearch      []string
 
 	securityOpt    []string
 	capAdd         []string
@@ -102,10 +112,28 @@ type container struct {
 	// nvidia container
 	nvidiaVisibleDevices     string
 	nvidiaDriverCapabilities string
+
+	// health check
+	healthCmd         string
+	healthInterval    time.Duration
+	healthTimeout     time.Duration
+	healthStartPeriod time.Duration
+	healthRetries     int
+
+	// stop options
+	stopSignal  string
+	stopTimeout int
 }
 
 func (c *container) config() (*types.ContainerCreateConfig, error) {
-	labels := opts.ParseLabels(c.labels)
+	labelStrings, err := readLabelStrings(c.labelFiles, c.labels)
+	if err != nil {
+		return nil, err
+	}
+	if err := opts.ValidateLabels(labelStrings); err != nil {
+		return nil, err
+	}
+	labels := opts.ParseLabels(labelStrings)
 
 	memory, err := opts.ParseMemory(c.memory)
 	if err != nil {
@@ -137,6 +165,10 @@ func (c *container) config() (*types.ContainerCreateConfig, error) {
 		return nil, err
 	}
 
+	if err := opts.ValidateDeviceCgroupRules(c.deviceCgroupRules); err != nil {
+		return nil, err
+	}
+
 	restartPolicy, err := opts.ParseRestartPolicy(c.restartPolicy)
 	if err != nil {
 		return nil, err
@@ -146,11 +178,23 @@ func (c *container) config() (*types.ContainerCreateConfig, error) {
 		return nil, err
 	}
 
+	if err := opts.ValidatePidsLimit(c.pidsLimit); err != nil {
+		return nil, err
+	}
+
+	if err := opts.ValidateBlkioWeight(c.blkioWeight); err != nil {
+		return nil, err
+	}
+
 	sysctls, err := opts.ParseSysctls(c.sysctls)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := opts.ValidateSysctls(sysctls); err != nil {
+		return nil, err
+	}
+
 	diskQuota, err := opts.ParseDiskQuota(c.diskQuota)
 	if err != nil {
 		return nil, err
@@ -179,6 +223,10 @@ func (c *container) config() (*types.ContainerCreateConfig, error) {
 		return nil, err
 	}
 
+	if err := opts.SetEndpointAliases(networkingConfig, networkMode, c.networkAliases); err != nil {
+		return nil, err
+	}
+
 	if err := opts.ValidateNetworks(networkingConfig); err != nil {
 		return nil, err
 	}
@@ -207,6 +255,49 @@ func (c *container) config() (*types.ContainerCreateConfig, error) {
 		return nil, err
 	}
 
+	mounts, err := opts.ParseMounts(c.mounts)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpfs, err := opts.ParseTmpfs(c.tmpfs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := opts.ValidateOOMScoreAdj(c.oomScoreAdj); err != nil {
+		return nil, err
+	}
+
+	securityOpt, err := opts.ParseSecurityOpts(c.securityOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	nanoCPUs, err := opts.ParseCPUs(c.cpus, c.cpuperiod, c.cpuquota)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := opts.ValidateCpuset(c.cpusetcpus); err != nil {
+		return nil, err
+	}
+
+	if err := opts.ValidateCpuset(c.cpusetmems); err != nil {
+		return nil, err
+	}
+
+	healthcheck, err := opts.ParseHealthcheck(c.healthCmd, c.healthInterval, c.healthTimeout, c.healthStartPeriod, c.healthRetries)
+	if err != nil {
+		return nil, err
+	}
+
+	var stopTimeout *int64
+	if c.stopTimeout >= 0 {
+		timeout := int64(c.stopTimeout)
+		stopTimeout = &timeout
+	}
+
 	config := &types.ContainerCreateConfig{
 		ContainerConfig: types.ContainerConfig{
 			Tty:                 c.tty,
@@ -227,10 +318,15 @@ func (c *container) config() (*types.ContainerCreateConfig, error) {
 			NetPriority:         c.netPriority,
 			SpecificID:          c.specificID,
 			MacAddress:          c.macAddress,
+			Healthcheck:         healthcheck,
+			StopSignal:          c.stopSignal,
+			StopTimeout:         stopTimeout,
 		},
 
 		HostConfig: &types.HostConfig{
 			Binds:        c.volume.Value(),
+			Mounts:       mounts,
+			Tmpfs:        tmpfs,
 			VolumesFrom:  c.volumesFrom,
 			VolumeDriver: c.volumeDriver,
 			Runtime:      c.runtime,
@@ -241,6 +337,7 @@ func (c *container) config() (*types.ContainerCreateConfig, error) {
 				CpusetMems: c.cpusetmems,
 				CPUPeriod:  c.cpuperiod,
 				CPUQuota:   c.cpuquota,
+				NanoCpus:   nanoCPUs,
 
 				// memory
 				Memory:            memory,
@@ -263,11 +360,12 @@ func (c *container) config() (*types.ContainerCreateConfig, error) {
 				BlkioDeviceWriteBps:  c.blkioDeviceWriteBps.Value(),
 				BlkioDeviceWriteIOps: c.blkioDeviceWriteIOps.Value(),
 
-				Devices:       deviceMappings,
-				IntelRdtL3Cbm: intelRdtL3Cbm,
-				CgroupParent:  c.cgroupParent,
-				Ulimits:       c.ulimit.Value(),
-				PidsLimit:     c.pidsLimit,
+				Devices:           deviceMappings,
+				DeviceCgroupRules: c.deviceCgroupRules,
+				IntelRdtL3Cbm:     intelRdtL3Cbm,
+				CgroupParent:      c.cgroupParent,
+				Ulimits:           c.ulimit.Value(),
+				PidsLimit:         c.pidsLimit,
 			},
 			ExtraHosts:      c.extraHosts,
 			DNS:             c.dns,
@@ -275,13 +373,14 @@ func (c *container) config() (*types.ContainerCreateConfig, error) {
 			DNSSearch:       c.dnsSearch,
 			EnableLxcfs:     c.enableLxcfs,
 			Privileged:      c.privileged,
+			ReadonlyRootfs:  c.readOnly,
 			RestartPolicy:   restartPolicy,
 			IpcMode:         c.ipcMode,
 			PidMode:         c.pidMode,
 			UTSMode:         c.utsMode,
 			GroupAdd:        c.groupAdd,
 			Sysctls:         sysctls,
-			SecurityOpt:     c.securityOpt,
+			SecurityOpt:     securityOpt,
 			NetworkMode:     networkMode,
 			PublishAllPorts: c.publishAll,
 			CapAdd:          c.capAdd,
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/client"
+)
+
+// noSuchContainerError is returned when no container matches the name, id
+// or prefix of id on client side.
+type noSuchContainerError struct {
+	name string
+}
+
+// Error implements error interface.
+func (e noSuchContainerError) Error() string {
+	return fmt.Sprintf("no such container: %s", e.name)
+}
+
+// resolveContainerID resolves the container's name, id or prefix of id into
+// the full container id on client side. Like pouchd, the name is matched
+// first, and then the prefix of id.
+func resolveContainerID(ctx context.Context, apiClient client.CommonAPIClient, nameOrPrefix string) (string, error) {
+	containers, err := apiClient.ContainerList(ctx, types.ContainerListOptions{All: true})
+	if err != nil {
+		return "", fmt.Errorf("failed to list containers: %v", err)
+	}
+
+	return matchContainerID(containers, nameOrPrefix)
+}
+
+// matchContainerID matches the name, id or prefix of id in the containers,
+// and returns an error listing the candidates if the prefix is ambiguous.
+func matchContainerID(containers []*types.Container, nameOrPrefix string) (string, error) {
+	if nameOrPrefix == "" {
+		return "", fmt.Errorf("container name or id can not be empty")
+	}
+
+	for _, c := range containers {
+		if c.ID == nameOrPrefix {
+			return c.ID, nil
+		}
+		for _, name := range c.Names {
+			if name == nameOrPrefix {
+				return c.ID, nil
+			}
+		}
+	}
+
+	var candidates []string
+	for _, c := range containers {
+		if strings.HasPrefix(c.ID, nameOrPrefix) {
+			candidates = append(candidates, c.ID)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", noSuchContainerError{name: nameOrPrefix}
+	case 1:
+		return candidates[0], nil
+	default:
+		return "", fmt.Errorf("ambiguous prefix %s, candidates: %s", nameOrPrefix, strings.Join(candidates, ", "))
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchContainerID(t *testing.T) {
+	containers := []*types.Container{
+		{ID: "abc123", Names: []string{"foo"}},
+		{ID: "abc456", Names: []string{"bar"}},
+		{ID: "def789", Names: []string{"abc"}},
+	}
+
+	for _, tc := range []struct {
+		input    string
+		expected string
+		errMsg   string
+	}{
+		{input: "foo", expected: "abc123"},
+		{input: "abc456", expected: "abc456"},
+		{input: "de", expected: "def789"},
+		// name is matched before the prefix of id
+		{input: "abc", expected: "def789"},
+		{input: "ab", errMsg: "ambiguous prefix ab, candidates: abc123, abc456"},
+		{input: "xyz", errMsg: "no such container: xyz"},
+		{input: "", errMsg: "can not be empty"},
+	} {
+		id, err := matchContainerID(containers, tc.input)
+		if tc.errMsg != "" {
+			assert.Error(t, err, tc.input)
+			assert.Contains(t, err.Error(), tc.errMsg)
+			continue
+		}
+		assert.NoError(t, err, tc.input)
+		assert.Equal(t, tc.expected, id)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/alibaba/pouch/client"
+
 	"github.com/docker/docker/pkg/archive"
 	"github.com/spf13/cobra"
 )
@@ -17,17 +19,25 @@ var copyDescription = "Copy files/folders between a container and the local file
 	"\nUse '-' as the source to read a tar archive from stdin\n" +
 	"and extract it to a directory destination in a container.\n" +
 	"Use '-' as the destination to stream a tar archive of a\n" +
-	"container source to stdout."
+	"container source to stdout.\n" +
+	"\nThe permissions of files/folders are kept. The copied files/folders\n" +
+	"are owned by the root of container when copied into a container, and by\n" +
+	"the current user when copied to the local filesystem, unless '-a' is\n" +
+	"specified to keep the uid/gid of the source."
 
 // CopyCommand use to implement 'copy' command, it copy files between host and container.
 type CopyCommand struct {
 	*container
 	baseCommand
+	archive    bool
+	followLink bool
 }
 
 type copyOptions struct {
 	source      string
 	destination string
+	archive     bool
+	followLink  bool
 }
 
 // Init initialize copy command.
@@ -50,6 +60,8 @@ func (cc *CopyCommand) Init(c *Cli) {
 
 			opts.source = args[0]
 			opts.destination = args[1]
+			opts.archive = cc.archive
+			opts.followLink = cc.followLink
 			return cc.runCopy(opts)
 		},
 		Example: copyExample(),
@@ -63,7 +75,8 @@ func (cc *CopyCommand) addFlags() {
 	flagSet := cc.cmd.Flags()
 	flagSet.SetInterspersed(false)
 
-	// TODO: add more flags here
+	flagSet.BoolVarP(&cc.archive, "archive", "a", false, "Archive mode (copy all uid/gid information)")
+	flagSet.BoolVarP(&cc.followLink, "follow-link", "L", false, "Always follow symbol link in SRC_PATH")
 }
 
 func splitCpArg(arg string) (container, path string) {
@@ -106,9 +119,9 @@ func (cc *CopyCommand) runCopy(opts copyOptions) error {
 
 	switch direction {
 	case fromContainer:
-		return copyFromContainer(ctx, cc.cli, srcContainer, srcPath, dstPath)
+		return copyFromContainer(ctx, cc.cli, srcContainer, srcPath, dstPath, opts)
 	case toContainer:
-		return copyToContainer(ctx, cc.cli, srcPath, dstContainer, dstPath)
+		return copyToContainer(ctx, cc.cli, srcPath, dstContainer, dstPath, opts)
 	case acrossContainers:
 		// Copying between containers isn't supported.
 		return fmt.Errorf("copying between containers is not supported")
@@ -126,7 +139,7 @@ func resolveLocalPath(localPath string) (absPath string, err error) {
 	return archive.PreserveTrailingDotOrSeparator(absPath, localPath, os.PathSeparator), nil
 }
 
-func copyFromContainer(ctx context.Context, cli *Cli, srcContainer, srcPath, dstPath string) (err error) {
+func copyFromContainer(ctx context.Context, cli *Cli, srcContainer, srcPath, dstPath string, opts copyOptions) (err error) {
 	apiClient := cli.Client()
 
 	if dstPath != "-" {
@@ -137,6 +150,24 @@ func copyFromContainer(ctx context.Context, cli *Cli, srcContainer, srcPath, dst
 		}
 	}
 
+	// If follow link is required and the source is a symbol link, copy the
+	// link target and rename it to the name of the link.
+	var rebaseName string
+	if opts.followLink {
+		srcStat, err := apiClient.ContainerStatPath(ctx, srcContainer, srcPath)
+		if err == nil && os.FileMode(srcStat.Mode)&os.ModeSymlink != 0 {
+			linkTarget := srcStat.LinkTarget
+			if !filepath.IsAbs(linkTarget) {
+				// Join with the parent directory.
+				srcParent, _ := archive.SplitPathDirEntry(srcPath)
+				linkTarget = filepath.Join(srcParent, linkTarget)
+			}
+
+			linkTarget, rebaseName = archive.GetRebaseName(srcPath, linkTarget)
+			srcPath = linkTarget
+		}
+	}
+
 	content, stat, err := apiClient.CopyFromContainer(ctx, srcContainer, srcPath)
 	if err != nil {
 		return err
@@ -154,7 +185,7 @@ func copyFromContainer(ctx context.Context, cli *Cli, srcContainer, srcPath, dst
 		Path:       srcPath,
 		Exists:     true,
 		IsDir:      os.FileMode(stat.Mode).IsDir(),
-		RebaseName: "",
+		RebaseName: rebaseName,
 	}
 
 	preArchive := content
@@ -162,13 +193,37 @@ func copyFromContainer(ctx context.Context, cli *Cli, srcContainer, srcPath, dst
 		_, srcBase := archive.SplitPathDirEntry(srcInfo.Path)
 		preArchive = archive.RebaseArchiveEntries(content, srcBase, srcInfo.RebaseName)
 	}
-	// See comments in the implementation of `archive.CopyTo` for exactly what
-	// goes into deciding how and whether the source archive needs to be
-	// altered for the correct copy behavior.
-	return archive.CopyTo(preArchive, srcInfo, dstPath)
+	return extractToLocal(preArchive, srcInfo, dstPath, opts.archive)
+}
+
+// extractToLocal extracts the archive of container source to dstPath in the
+// same way as `archive.CopyTo`, except that the uid/gid of archive entries
+// is kept in archive mode.
+func extractToLocal(content io.Reader, srcInfo archive.CopyInfo, dstPath string, archiveMode bool) error {
+	// The destination path need not exist, but CopyInfoDestinationPath will
+	// ensure that at least the parent directory exists.
+	dstInfo, err := archive.CopyInfoDestinationPath(dstPath)
+	if err != nil {
+		return err
+	}
+
+	// See comments in the implementation of `archive.PrepareArchiveCopy` for
+	// exactly what goes into deciding how and whether the source archive
+	// needs to be altered for the correct copy behavior, e.g. copying a
+	// directory into an existing directory.
+	dstDir, copyArchive, err := archive.PrepareArchiveCopy(content, srcInfo, dstInfo)
+	if err != nil {
+		return err
+	}
+	defer copyArchive.Close()
+
+	return archive.Untar(copyArchive, dstDir, &archive.TarOptions{
+		NoLchown:             !archiveMode,
+		NoOverwriteDirNonDir: true,
+	})
 }
 
-func copyToContainer(ctx context.Context, cli *Cli, srcPath, dstContainer, dstPath string) (err error) {
+func copyToContainer(ctx context.Context, cli *Cli, srcPath, dstContainer, dstPath string, opts copyOptions) (err error) {
 	apiClient := cli.Client()
 
 	if srcPath != "-" {
@@ -182,6 +237,19 @@ func copyToContainer(ctx context.Context, cli *Cli, srcPath, dstContainer, dstPa
 	dstInfo := archive.CopyInfo{Path: dstPath}
 	dstStat, err := apiClient.ContainerStatPath(ctx, dstContainer, dstPath)
 
+	// If the destination is a symbol link, we should follow it.
+	if err == nil && os.FileMode(dstStat.Mode)&os.ModeSymlink != 0 {
+		linkTarget := dstStat.LinkTarget
+		if !filepath.IsAbs(linkTarget) {
+			// Join with the parent directory.
+			dstParent, _ := archive.SplitPathDirEntry(dstPath)
+			linkTarget = filepath.Join(dstParent, linkTarget)
+		}
+
+		dstInfo.Path = linkTarget
+		dstStat, err = apiClient.ContainerStatPath(ctx, dstContainer, linkTarget)
+	}
+
 	// Ignore any error and assume that the parent directory of the destination
 	// path exists, in which case the copy may still succeed. If there is any
 	// type of conflict (e.g., non-directory overwriting an existing directory
@@ -206,7 +274,7 @@ func copyToContainer(ctx context.Context, cli *Cli, srcPath, dstContainer, dstPa
 		}
 	} else {
 		// Prepare source copy info.
-		srcInfo, err := archive.CopyInfoSourcePath(srcPath, false)
+		srcInfo, err := archive.CopyInfoSourcePath(srcPath, opts.followLink)
 		if err != nil {
 			return err
 		}
@@ -227,11 +295,14 @@ func copyToContainer(ctx context.Context, cli *Cli, srcPath, dstContainer, dstPa
 		content = preparedArchive
 	}
 
-	return apiClient.CopyToContainer(ctx, dstContainer, resolvedDstPath, content)
+	return apiClient.CopyToContainer(ctx, dstContainer, resolvedDstPath, content, client.CopyToContainerOptions{
+		CopyUIDGID: opts.archive,
+	})
 }
 
 // copyExample shows examples in copy command, and is used in auto-generated cli docs.
 func copyExample() string {
 	return `$ pouch cp 8assd1234:/root/foo /home
-$ pouch cp /home/bar 712yasbc:/root`
+$ pouch cp /home/bar 712yasbc:/root
+$ pouch cp -a /home/dir 712yasbc:/root/dir`
 }
@@ -59,7 +59,7 @@ func (cc *CreateCommand) runCreate(args []string) error {
 	// collect all the environment variables for the container
 	config.Env, err = readKVStrings(cc.envfile, cc.env)
 	if err != nil {
-		return nil
+		return fmt.Errorf("failed to read env file: %v", err)
 	}
 	config.ContainerConfig.OpenStdin = cc.openstdin
 
@@ -67,11 +67,15 @@ func (cc *CreateCommand) runCreate(args []string) error {
 	if len(args) > 1 {
 		config.Cmd = args[1:]
 	}
+	// the empty --entrypoint clears the ENTRYPOINT of image.
+	if cc.cmd.Flags().Changed("entrypoint") && len(config.Entrypoint) == 0 {
+		config.Entrypoint = []string{""}
+	}
 	containerName := cc.name
 
 	ctx := context.Background()
 	apiClient := cc.cli.Client()
-	if err := pullMissingImage(ctx, apiClient, config.Image, false); err != nil {
+	if err := pullImageWithPolicy(ctx, apiClient, config.Image, cc.platform, cc.pull); err != nil {
 		return err
 	}
 
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/alibaba/pouch/pkg/term"
+)
+
+// detachKeys implements pflag.Value interface for --detach-keys, so that
+// the key sequence is validated when parsing flags.
+type detachKeys struct {
+	value string
+	keys  []byte
+}
+
+// Set implements pflag.Value interface.
+func (d *detachKeys) Set(value string) error {
+	keys, err := term.ToBytes(value)
+	if err != nil {
+		return fmt.Errorf("invalid detach keys %q: %v", value, err)
+	}
+
+	d.value, d.keys = value, keys
+	return nil
+}
+
+// String implements pflag.Value interface.
+func (d *detachKeys) String() string {
+	return d.value
+}
+
+// Type implements pflag.Value interface.
+func (d *detachKeys) Type() string {
+	return "string"
+}
+
+// Bytes returns the key sequence for detaching, default is ctrl-p,ctrl-q.
+func (d *detachKeys) Bytes() []byte {
+	if d.keys == nil {
+		keys, _ := term.ToBytes(term.DefaultDetachKeys)
+		return keys
+	}
+	return d.keys
+}
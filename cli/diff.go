@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/spf13/cobra"
+)
+
+// diffDescription is used to describe diff command in detail and auto generate command doc.
+var diffDescription = "Inspect changes to files or directories on a container's filesystem. " +
+	"Only the changes in the upper layer of container are shown, the changes in volumes are not included. " +
+	"The container can be running or stopped. Each change is prefixed with A for added, C for changed and D for deleted."
+
+// DiffCommand use to implement 'diff' command.
+type DiffCommand struct {
+	baseCommand
+	format string
+}
+
+// Init initialize diff command.
+func (d *DiffCommand) Init(c *Cli) {
+	d.cli = c
+	d.cmd = &cobra.Command{
+		Use:   "diff [OPTIONS] CONTAINER",
+		Short: "Inspect changes to files or directories on a container's filesystem",
+		Long:  diffDescription,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return d.runDiff(args)
+		},
+		Example: diffExample(),
+	}
+	d.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (d *DiffCommand) addFlags() {
+	flagSet := d.cmd.Flags()
+	flagSet.StringVar(&d.format, "format", "", "Print the changes in the given format, 'json' prints the changes as a JSON array")
+}
+
+// runDiff is the entry of diff command.
+func (d *DiffCommand) runDiff(args []string) error {
+	if d.format != "" && d.format != "json" {
+		return fmt.Errorf("invalid format %q: only 'json' is supported", d.format)
+	}
+
+	ctx := context.Background()
+	apiClient := d.cli.Client()
+
+	changes, err := apiClient.ContainerChanges(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	if d.format == "json" {
+		return json.NewEncoder(os.Stdout).Encode(changes)
+	}
+
+	for _, change := range changes {
+		fmt.Println(formatChange(change))
+	}
+	return nil
+}
+
+// formatChange formats the change with the prefix of its kind.
+func formatChange(change types.ContainerChangeResponseItem) string {
+	var kind string
+	switch change.Kind {
+	case 0:
+		kind = "C"
+	case 1:
+		kind = "A"
+	case 2:
+		kind = "D"
+	default:
+		kind = "?"
+	}
+	return kind + " " + change.Path
+}
+
+// diffExample shows examples in diff command, and is used in auto-generated cli docs.
+func diffExample() string {
+	return `$ pouch run -d --name foo busybox:latest sh -c "touch /tmp/foo && rm -rf /home"
+$ pouch diff foo
+C /tmp
+A /tmp/foo
+D /home
+$ pouch diff --format json foo
+[{"Kind":0,"Path":"/tmp"},{"Kind":1,"Path":"/tmp/foo"},{"Kind":2,"Path":"/home"}]`
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatChange(t *testing.T) {
+	for _, tc := range []struct {
+		change types.ContainerChangeResponseItem
+		expect string
+	}{
+		{change: types.ContainerChangeResponseItem{Kind: 0, Path: "/tmp"}, expect: "C /tmp"},
+		{change: types.ContainerChangeResponseItem{Kind: 1, Path: "/tmp/foo"}, expect: "A /tmp/foo"},
+		{change: types.ContainerChangeResponseItem{Kind: 2, Path: "/home"}, expect: "D /home"},
+	} {
+		assert.Equal(t, tc.expect, formatChange(tc.change))
+	}
+}
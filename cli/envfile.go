@@ -6,14 +6,19 @@ import (
 	"os"
 	"strings"
 	"unicode"
+
+	"github.com/alibaba/pouch/pkg/log"
 )
 
 // reads a file of line terminated key=value pairs, and overrides any keys
 // present in the file with additional pairs specified in the override parameter
 func readKVStrings(files []string, override []string) ([]string, error) {
 	envVariables := []string{}
+	// the variables parsed from the former files can be referenced by the
+	// latter ones.
+	vars := map[string]string{}
 	for _, ef := range files {
-		parsedVars, err := parseEnvFile(ef)
+		parsedVars, err := parseEnvFile(ef, vars)
 		if err != nil {
 			return nil, err
 		}
@@ -25,39 +30,229 @@ func readKVStrings(files []string, override []string) ([]string, error) {
 	return envVariables, nil
 }
 
-// parseEnvFile reads a file with environment variables enumerated by lines
-func parseEnvFile(filename string) ([]string, error) {
-	fh, err := os.Open(filename)
+// readLabelStrings reads the files of line terminated key=value labels, the
+// labels specified in the override parameter take precedence.
+func readLabelStrings(files []string, override []string) ([]string, error) {
+	labels := []string{}
+	for _, lf := range files {
+		parsedLabels, err := parseKeyValueFile(lf)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, parsedLabels...)
+	}
+	labels = append(labels, override...)
+
+	return labels, nil
+}
+
+// parseEnvFile reads a file with environment variables enumerated by lines.
+// A line ending with backslash is continued on the next line. The value can
+// be quoted to keep the spaces, and ${NAME} in the value which is not single
+// quoted is expanded with the variables parsed before, which are kept in vars,
+// or the client's environment.
+func parseEnvFile(filename string, vars map[string]string) ([]string, error) {
+	if vars == nil {
+		vars = map[string]string{}
+	}
+	lookup := func(name string) string {
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		return os.Getenv(name)
+	}
+
+	lines := []string{}
+	err := scanKeyValueFile(filename, func(line string) error {
+		key, value, ok, err := parseEnvLine(line, lookup)
+		if err != nil || !ok {
+			return err
+		}
+		vars[key] = value
+		lines = append(lines, key+"="+value)
+		return nil
+	}, func(msg string) error {
+		return ErrBadEnvVariable{msg}
+	})
 	if err != nil {
 		return []string{}, err
 	}
+	return lines, nil
+}
+
+// scanKeyValueFile reads the file line by line, skips the comment lines and
+// joins the lines ending with backslash, then hands each line to parse. The
+// errors of parse are located by the file and line, and built by newErr.
+func scanKeyValueFile(filename string, parse func(line string) error, newErr func(msg string) error) error {
+	fh, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
 	defer fh.Close()
 
-	lines := []string{}
+	var (
+		lineNo    int
+		startNo   int
+		pending   string
+		continued bool
+	)
 	scanner := bufio.NewScanner(fh)
 	for scanner.Scan() {
-		// trim the line from all leading whitespace first
-		line := strings.TrimLeftFunc(scanner.Text(), unicode.IsSpace)
-		// line is not empty, and not starting with '#'
-		if len(line) > 0 && !strings.HasPrefix(line, "#") {
-			data := strings.SplitN(line, "=", 2)
-
-			// trim the front of a variable, but nothing else
-			variable := data[0]
-			if strings.IndexFunc(variable, unicode.IsSpace) != -1 {
-				return []string{}, ErrBadEnvVariable{fmt.Sprintf("variable '%s' has white spaces", variable)}
+		lineNo++
+		text := scanner.Text()
+		if !continued {
+			startNo = lineNo
+			// the comment line is never continued.
+			if strings.HasPrefix(strings.TrimLeftFunc(text, unicode.IsSpace), "#") {
+				continue
 			}
+		}
 
-			if len(data) > 1 {
-				// pass the value through, no trimming
-				lines = append(lines, fmt.Sprintf("%s=%s", variable, data[1]))
-			} else {
-				// if only a pass-through variable is given, clean it up.
-				lines = append(lines, fmt.Sprintf("%s=%s", strings.TrimSpace(line), os.Getenv(line)))
-			}
+		if strings.HasSuffix(text, `\`) {
+			pending += strings.TrimSuffix(text, `\`)
+			continued = true
+			continue
+		}
+		line := pending + text
+		pending, continued = "", false
+
+		if err := parse(line); err != nil {
+			return newErr(fmt.Sprintf("%s:%d: %v", filename, startNo, err))
 		}
 	}
-	return lines, scanner.Err()
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if continued {
+		return newErr(fmt.Sprintf("%s:%d: line continuation at the end of file", filename, startNo))
+	}
+	return nil
+}
+
+// parseEnvLine parses the line in the format of KEY=VALUE or KEY, the value of
+// KEY is looked up by lookup. It returns false if the line is empty.
+func parseEnvLine(line string, lookup func(string) string) (string, string, bool, error) {
+	// trim the line from all leading whitespace first
+	line = strings.TrimLeftFunc(line, unicode.IsSpace)
+	if len(line) == 0 {
+		return "", "", false, nil
+	}
+
+	data := strings.SplitN(line, "=", 2)
+
+	// trim the front of a variable, but nothing else
+	variable := data[0]
+	if strings.IndexFunc(variable, unicode.IsSpace) != -1 {
+		return "", "", false, fmt.Errorf("variable '%s' has white spaces", variable)
+	}
+	if variable == "" {
+		return "", "", false, fmt.Errorf("variable name of '%s' is empty", line)
+	}
+
+	// if only a pass-through variable is given, look it up.
+	if len(data) == 1 {
+		return variable, lookup(variable), true, nil
+	}
+
+	value := data[1]
+	if len(value) > 0 && (value[0] == '"' || value[0] == '\'') {
+		quote := value[0]
+		trimmed := strings.TrimRightFunc(value, unicode.IsSpace)
+		if len(trimmed) < 2 || trimmed[len(trimmed)-1] != quote {
+			return "", "", false, fmt.Errorf("value of variable '%s' has unterminated quote", variable)
+		}
+		value = trimmed[1 : len(trimmed)-1]
+
+		// the single quoted value is kept as it is.
+		if quote == '\'' {
+			return variable, value, true, nil
+		}
+	}
+
+	// pass the value through, no trimming
+	expanded, err := expandEnvValue(value, lookup)
+	if err != nil {
+		return "", "", false, fmt.Errorf("value of variable '%s' %v", variable, err)
+	}
+	return variable, expanded, true, nil
+}
+
+// expandEnvValue replaces the ${NAME} in value with the result of lookup.
+func expandEnvValue(value string, lookup func(string) string) (string, error) {
+	var buf strings.Builder
+	for {
+		start := strings.Index(value, "${")
+		if start == -1 {
+			buf.WriteString(value)
+			return buf.String(), nil
+		}
+
+		end := strings.IndexByte(value[start:], '}')
+		if end == -1 {
+			return "", fmt.Errorf("has unterminated reference %s", value[start:])
+		}
+		name := value[start+2 : start+end]
+		if name == "" {
+			return "", fmt.Errorf("has empty reference ${}")
+		}
+
+		buf.WriteString(value[:start])
+		buf.WriteString(lookup(name))
+		value = value[start+end+1:]
+	}
+}
+
+// parseKeyValueFile reads a file with key=value pairs enumerated by lines,
+// the value is passed through as it is.
+func parseKeyValueFile(filename string) ([]string, error) {
+	lines := []string{}
+	err := scanKeyValueFile(filename, func(line string) error {
+		// trim the line from all leading whitespace first
+		line = strings.TrimLeftFunc(line, unicode.IsSpace)
+		if len(line) == 0 {
+			return nil
+		}
+
+		data := strings.SplitN(line, "=", 2)
+		key := data[0]
+		if strings.IndexFunc(key, unicode.IsSpace) != -1 {
+			return fmt.Errorf("key '%s' has white spaces", key)
+		}
+
+		if len(data) > 1 {
+			// pass the value through, no trimming
+			lines = append(lines, fmt.Sprintf("%s=%s", key, data[1]))
+		} else {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+		return nil
+	}, func(msg string) error {
+		return ErrBadLabel{msg}
+	})
+	if err != nil {
+		return []string{}, err
+	}
+	return lines, nil
+}
+
+// resolvePassthroughEnvs resolves the variables given in the form of NAME
+// without "=" from the client's environment, the unset ones are skipped.
+func resolvePassthroughEnvs(envs []string) []string {
+	resolved := make([]string, 0, len(envs))
+	for _, env := range envs {
+		if strings.Contains(env, "=") {
+			resolved = append(resolved, env)
+			continue
+		}
+
+		value, ok := os.LookupEnv(env)
+		if !ok {
+			log.With(nil).Debugf("environment variable %s is not set, skip it", env)
+			continue
+		}
+		resolved = append(resolved, env+"="+value)
+	}
+	return resolved
 }
 
 // ErrBadEnvVariable typed error for bad environment variable
@@ -69,3 +264,13 @@ type ErrBadEnvVariable struct {
 func (e ErrBadEnvVariable) Error() string {
 	return fmt.Sprintf("poorly formatted environment: %s", e.msg)
 }
+
+// ErrBadLabel typed error for bad label in the label file
+type ErrBadLabel struct {
+	msg string
+}
+
+// Error implements error interface.
+func (e ErrBadLabel) Error() string {
+	return fmt.Sprintf("poorly formatted label: %s", e.msg)
+}
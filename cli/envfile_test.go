@@ -44,7 +44,7 @@ and_underscore=working too
 	tmpFile := tmpFileWithContent(content, t)
 	defer os.Remove(tmpFile)
 
-	lines, err := parseEnvFile(tmpFile)
+	lines, err := parseEnvFile(tmpFile, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -70,7 +70,7 @@ func TestParseEnvFileEmptyFile(t *testing.T) {
 	tmpFile := tmpFileWithContent("", t)
 	defer os.Remove(tmpFile)
 
-	lines, err := parseEnvFile(tmpFile)
+	lines, err := parseEnvFile(tmpFile, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -82,7 +82,7 @@ func TestParseEnvFileEmptyFile(t *testing.T) {
 
 // Test ParseEnvFile for a non existent file
 func TestParseEnvFileNonExistentFile(t *testing.T) {
-	_, err := parseEnvFile("foo_bar_baz")
+	_, err := parseEnvFile("foo_bar_baz", nil)
 	if err == nil {
 		t.Fatal("ParseEnvFile succeeded; expected failure")
 	}
@@ -99,14 +99,14 @@ func TestParseEnvFileBadlyFormattedFile(t *testing.T) {
 	tmpFile := tmpFileWithContent(content, t)
 	defer os.Remove(tmpFile)
 
-	_, err := parseEnvFile(tmpFile)
+	_, err := parseEnvFile(tmpFile, nil)
 	if err == nil {
 		t.Fatalf("Expected an ErrBadEnvVariable, got nothing")
 	}
 	if _, ok := err.(ErrBadEnvVariable); !ok {
 		t.Fatalf("Expected an ErrBadEnvVariable, got [%v]", err)
 	}
-	expectedMessage := "poorly formatted environment: variable 'f   ' has white spaces"
+	expectedMessage := fmt.Sprintf("poorly formatted environment: %s:2: variable 'f   ' has white spaces", tmpFile)
 	if err.Error() != expectedMessage {
 		t.Fatalf("Expected [%v], got [%v]", expectedMessage, err.Error())
 	}
@@ -120,7 +120,7 @@ func TestParseEnvFileLineTooLongFile(t *testing.T) {
 	tmpFile := tmpFileWithContent(content, t)
 	defer os.Remove(tmpFile)
 
-	_, err := parseEnvFile(tmpFile)
+	_, err := parseEnvFile(tmpFile, nil)
 	if err == nil {
 		t.Fatal("ParseEnvFile succeeded; expected failure")
 	}
@@ -133,7 +133,7 @@ another invalid line`
 	tmpFile := tmpFileWithContent(content, t)
 	defer os.Remove(tmpFile)
 
-	_, err := parseEnvFile(tmpFile)
+	_, err := parseEnvFile(tmpFile, nil)
 
 	if err == nil {
 		t.Fatalf("Expected an ErrBadEnvVariable, got nothing")
@@ -141,7 +141,151 @@ another invalid line`
 	if _, ok := err.(ErrBadEnvVariable); !ok {
 		t.Fatalf("Expected an ErrBadEnvvariable, got [%v]", err)
 	}
-	expectedMessage := "poorly formatted environment: variable 'first line' has white spaces"
+	expectedMessage := fmt.Sprintf("poorly formatted environment: %s:1: variable 'first line' has white spaces", tmpFile)
+	if err.Error() != expectedMessage {
+		t.Fatalf("Expected [%v], got [%v]", expectedMessage, err.Error())
+	}
+}
+
+// Test ParseEnvFile expands the variables, joins the continued lines and
+// keeps the spaces of quoted values
+func TestParseEnvFileExpansion(t *testing.T) {
+	os.Setenv("__ENVFILE_HOST", "host")
+	defer os.Unsetenv("__ENVFILE_HOST")
+	os.Unsetenv("__ENVFILE_UNSET")
+
+	content := `BASE=/opt
+PATH_A=${BASE}/bin
+FROM_HOST=${__ENVFILE_HOST}-${__ENVFILE_UNSET}x
+QUOTED="  ${BASE} with spaces  "  
+LITERAL='${BASE} kept'
+LONG=first,\
+second,\
+third
+# comment \
+NOT_CONTINUED=yes
+__ENVFILE_HOST
+`
+	tmpFile := tmpFileWithContent(content, t)
+	defer os.Remove(tmpFile)
+
+	lines, err := parseEnvFile(tmpFile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedLines := []string{
+		"BASE=/opt",
+		"PATH_A=/opt/bin",
+		"FROM_HOST=host-x",
+		"QUOTED=  /opt with spaces  ",
+		"LITERAL=${BASE} kept",
+		"LONG=first,second,third",
+		"NOT_CONTINUED=yes",
+		"__ENVFILE_HOST=host",
+	}
+	if !reflect.DeepEqual(lines, expectedLines) {
+		t.Fatalf("Expected %v, got %v", expectedLines, lines)
+	}
+}
+
+// Test ParseEnvFile reports the file and line of malformed lines
+func TestParseEnvFileMalformedLines(t *testing.T) {
+	for _, tc := range []struct {
+		content string
+		message string
+	}{
+		{content: "A=1\nB=\"unterminated\n", message: ":2: value of variable 'B' has unterminated quote"},
+		{content: "A=1\n\nB=${A\n", message: ":3: value of variable 'B' has unterminated reference ${A"},
+		{content: "A=${}\n", message: ":1: value of variable 'A' has empty reference ${}"},
+		{content: "A=1\n=2\n", message: ":2: variable name of '=2' is empty"},
+		{content: "A=1\\\n", message: ":1: line continuation at the end of file"},
+		{content: "A=1\nB\\\n C=3\n", message: ":2: variable 'B C' has white spaces"},
+	} {
+		tmpFile := tmpFileWithContent(tc.content, t)
+		_, err := parseEnvFile(tmpFile, nil)
+		os.Remove(tmpFile)
+
+		if _, ok := err.(ErrBadEnvVariable); !ok {
+			t.Fatalf("Expected an ErrBadEnvVariable for %q, got [%v]", tc.content, err)
+		}
+		expectedMessage := "poorly formatted environment: " + tmpFile + tc.message
+		if err.Error() != expectedMessage {
+			t.Fatalf("Expected [%v], got [%v]", expectedMessage, err.Error())
+		}
+	}
+}
+
+// Test readKVStrings expands the variables parsed from the former files
+func TestReadKVStringsAcrossFiles(t *testing.T) {
+	first := tmpFileWithContent("BASE=/opt\n", t)
+	defer os.Remove(first)
+	second := tmpFileWithContent("PATH_A=${BASE}/bin\n", t)
+	defer os.Remove(second)
+
+	envs, err := readKVStrings([]string{first, second}, []string{"BASE=/usr"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"BASE=/opt", "PATH_A=/opt/bin", "BASE=/usr"}
+	if !reflect.DeepEqual(envs, expected) {
+		t.Fatalf("Expected %v, got %v", expected, envs)
+	}
+}
+
+// Test resolvePassthroughEnvs resolves the bare variable names from the client
+func TestResolvePassthroughEnvs(t *testing.T) {
+	os.Setenv("__PASSTHROUGH_SET", "value")
+	defer os.Unsetenv("__PASSTHROUGH_SET")
+	os.Unsetenv("__PASSTHROUGH_UNSET")
+
+	envs := resolvePassthroughEnvs([]string{"FOO=bar", "__PASSTHROUGH_SET", "__PASSTHROUGH_UNSET", "EMPTY="})
+	expected := []string{"FOO=bar", "__PASSTHROUGH_SET=value", "EMPTY="}
+	if !reflect.DeepEqual(envs, expected) {
+		t.Fatalf("Expected %v, got %v", expected, envs)
+	}
+}
+
+// Test readLabelStrings reads the label files and keeps the bare keys
+func TestReadLabelStrings(t *testing.T) {
+	os.Setenv("__LABEL_KEY", "value")
+	defer os.Unsetenv("__LABEL_KEY")
+
+	content := `# labels
+com.example.foo=bar
+__LABEL_KEY
+
+com.example.empty=
+`
+	tmpFile := tmpFileWithContent(content, t)
+	defer os.Remove(tmpFile)
+
+	labels, err := readLabelStrings([]string{tmpFile}, []string{"com.example.foo=override"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"com.example.foo=bar", "__LABEL_KEY", "com.example.empty=", "com.example.foo=override"}
+	if !reflect.DeepEqual(labels, expected) {
+		t.Fatalf("Expected %v, got %v", expected, labels)
+	}
+
+	if _, err := readLabelStrings([]string{tmpFile + ".missing"}, nil); err == nil {
+		t.Fatal("Expected an error of missing label file, got nothing")
+	}
+}
+
+// Test readLabelStrings reports the file and line of malformed labels
+func TestReadLabelStringsBadlyFormattedFile(t *testing.T) {
+	tmpFile := tmpFileWithContent("# labels\ncom.example.foo=bar\ncom.example bar=baz\n", t)
+	defer os.Remove(tmpFile)
+
+	_, err := readLabelStrings([]string{tmpFile}, nil)
+	if _, ok := err.(ErrBadLabel); !ok {
+		t.Fatalf("Expected an ErrBadLabel, got [%v]", err)
+	}
+	expectedMessage := fmt.Sprintf("poorly formatted label: %s:3: key 'com.example bar' has white spaces", tmpFile)
 	if err.Error() != expectedMessage {
 		t.Fatalf("Expected [%v], got [%v]", expectedMessage, err.Error())
 	}
@@ -49,8 +49,8 @@ func (e *EventsCommand) Init(c *Cli) {
 func (e *EventsCommand) addFlags() {
 	flagSet := e.cmd.Flags()
 
-	flagSet.StringVarP(&e.since, "since", "s", "", "Show all events created since timestamp")
-	flagSet.StringVarP(&e.until, "until", "u", "", "Stream events until this timestamp")
+	flagSet.StringVarP(&e.since, "since", "s", "", "Show all events created since timestamp, RFC3339 timestamp or relative duration (e.g. 10m)")
+	flagSet.StringVarP(&e.until, "until", "u", "", "Stream events until this timestamp, RFC3339 timestamp or relative duration (e.g. 10m)")
 	flagSet.StringSliceVarP(&e.filter, "filter", "f", []string{}, "Filter output based on conditions provided")
 }
 
@@ -59,6 +59,10 @@ func (e *EventsCommand) runEvents() error {
 	ctx := context.Background()
 	apiClient := e.cli.Client()
 
+	if err := validateTimeWindow(e.since, e.until, time.Now()); err != nil {
+		return err
+	}
+
 	eventFilterArgs, err := filters.FromFilterOpts(e.filter)
 	if err != nil {
 		return err
@@ -72,6 +76,42 @@ func (e *EventsCommand) runEvents() error {
 	return streamEvents(responseBody, os.Stdout)
 }
 
+// validateTimeWindow checks that until is after since if both of them are
+// specified. The relative durations are relative to now.
+func validateTimeWindow(since, until string, now time.Time) error {
+	if since == "" || until == "" {
+		return nil
+	}
+
+	sinceTime, err := parseEventsTime(since, now)
+	if err != nil {
+		return fmt.Errorf("invalid since %q: %v", since, err)
+	}
+	untilTime, err := parseEventsTime(until, now)
+	if err != nil {
+		return fmt.Errorf("invalid until %q: %v", until, err)
+	}
+
+	if !untilTime.After(sinceTime) {
+		return fmt.Errorf("until (%s) must be after since (%s)", until, since)
+	}
+	return nil
+}
+
+// parseEventsTime converts the timestamp or relative duration into time.
+func parseEventsTime(value string, now time.Time) (time.Time, error) {
+	ts, err := utils.GetUnixTimestamp(value, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	sec, nsec, err := utils.ParseTimestamp(ts, 0)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(sec, nsec), nil
+}
+
 // streamEvents decodes prints the incoming events in the provided output.
 func streamEvents(input io.Reader, output io.Writer) error {
 	return DecodeEvents(input, func(event types.EventsMessage, err error) error {
@@ -140,7 +180,8 @@ func DecodeEvents(input io.Reader, ep eventProcessor) error {
 }
 
 func eventsExample() string {
-	return `$ pouch events -s "2018-08-10T10:52:05"
+	return `$ pouch events -s 10m -u 5m -f type=container
+$ pouch events -s "2018-08-10T10:52:05"
 	2018-08-10T10:53:15.071664386-04:00 volume create 9fff54f207615ccc5a29477f5ae2234c6b804ed8aad2f0dfc0dccb0cc69d4d12 (driver=local)
 2018-08-10T10:53:15.091131306-04:00 container create f2b58eb6bc616d7a22bdb89de50b3f04e2c23134accdec1a9b9a7490d609d34c (image=registry.hub.docker.com/library/centos:latest, name=test)
 2018-08-10T10:53:15.537704818-04:00 container start f2b58eb6bc616d7a22bdb89de50b3f04e2c23134accdec1a9b9a7490d609d34c (image=registry.hub.docker.com/library/centos:latest, name=test)`
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTimeWindow(t *testing.T) {
+	now := time.Date(2018, 8, 10, 10, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		since, until string
+		valid        bool
+	}{
+		{since: "", until: "", valid: true},
+		{since: "10m", until: "", valid: true},
+		{since: "", until: "2018-08-10T09:00:00Z", valid: true},
+		{since: "10m", until: "5m", valid: true},
+		{since: "2018-08-10T09:00:00Z", until: "2018-08-10T09:00:00.5Z", valid: true},
+		{since: "2018-08-10T09:00:00Z", until: "30m", valid: true},
+		{since: "5m", until: "10m", valid: false},
+		{since: "2018-08-10T09:00:00Z", until: "2018-08-10T09:00:00Z", valid: false},
+		{since: "2018-08-10T09:00:00Z", until: "2h", valid: false},
+		{since: "yesterday", until: "5m", valid: false},
+	} {
+		err := validateTimeWindow(tc.since, tc.until, now)
+		if tc.valid {
+			assert.NoError(t, err, "since %q, until %q", tc.since, tc.until)
+		} else {
+			assert.Error(t, err, "since %q, until %q", tc.since, tc.until)
+		}
+	}
+}
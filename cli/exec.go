@@ -2,26 +2,35 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
-	"net"
+	"io/ioutil"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"text/template"
+	"time"
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/client"
-	"github.com/alibaba/pouch/pkg/ioutils"
 	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/term"
+	"github.com/alibaba/pouch/pkg/utils/filters"
 
-	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh/terminal"
-	"golang.org/x/sys/unix"
 )
 
 // execDescription is used to describe exec command in detail and auto generate command doc.
-var execDescription = "Run a command in a running container"
+var execDescription = "Run a command in a running container. " +
+	"To run the command in multiple containers, list the containers before \"--\", " +
+	"or give them by --container or --filter. Each line of the output is then prefixed with the container name."
 
 // ExecCommand is used to implement 'exec' command.
 type ExecCommand struct {
@@ -31,17 +40,117 @@ type ExecCommand struct {
 	Detach      bool
 	User        string
 	Envs        []string
+	EnvFiles    []string
+	InheritEnv  bool
+	ClearEnv    bool
 	Privileged  bool
+	CmdFile     string
+	Workdir     string
+	Timeout     time.Duration
+	DetachKeys  detachKeys
+	PidFile     string
+	Format      string
+	SigProxy    bool
+	Containers  []string
+	Filter      []string
+	Parallel    int
+	Demux       bool
+	Rm          bool
+	GroupAdd    []string
+	IdleTimeout time.Duration
+	NoStdout    bool
+	NoStderr    bool
+	Output      string
+	ErrorOutput string
+	CapAdd      []string
+	CapDrop     []string
+	Log         bool
+	Quiet       bool
+	Rows        int
+	Cols        int
+	Preset      string
+	UseInit     bool
+	Locate      bool
+
+	formatTmpl *template.Template
+	stdout     io.Writer
+	stderr     io.Writer
 }
 
+// execResult is the metadata of a finished exec process, printed by --format.
+type execResult struct {
+	ExecID   string
+	ExitCode int
+	Pid      int64
+	Elapsed  time.Duration
+	streamBytes
+}
+
+// execTimeoutExitCode is the exit code of exec command when the exec process
+// is killed because of --timeout, the same as coreutils timeout(1).
+const execTimeoutExitCode = 124
+
+// execIdleExitCode is the exit code of exec command when the session is
+// disconnected because of --interactive-timeout.
+const execIdleExitCode = 120
+
+const (
+	// execNoSuchContainerExitCode is the exit code of exec command when the
+	// container does not exist.
+	execNoSuchContainerExitCode = 125
+	// execNotRunningExitCode is the exit code of exec command when the
+	// container is not running.
+	execNotRunningExitCode = 126
+)
+
+const (
+	// execInspectRetryTimes is the max times of retrying to inspect exec.
+	execInspectRetryTimes = 3
+	// execInspectRetryInterval is the interval between two inspections.
+	execInspectRetryInterval = 100 * time.Millisecond
+
+	// execWaitMaxTimes is the max times of polling exec until it is not running.
+	execWaitMaxTimes = 10
+)
+
+// the backoff intervals of polling exec, which are shortened by tests.
+var (
+	// execWaitInitialInterval is the first backoff interval of polling exec.
+	execWaitInitialInterval = 10 * time.Millisecond
+	// execWaitMaxInterval is the max backoff interval of polling exec.
+	execWaitMaxInterval = time.Second
+)
+
+// defaultCmdFileInterpreter is the interpreter used to run the script read
+// from --cmd-file when no interpreter is given.
+const defaultCmdFileInterpreter = "/bin/sh"
+
 // Init initializes ExecCommand command.
 func (e *ExecCommand) Init(c *Cli) {
 	e.cli = c
 	e.cmd = &cobra.Command{
-		Use:   "exec [OPTIONS] CONTAINER COMMAND [ARG...]",
+		Use:   "exec [OPTIONS] CONTAINER [CONTAINER...] [--] COMMAND [ARG...]",
 		Short: "Run a command in a running container",
 		Long:  execDescription,
-		Args:  cobra.MinimumNArgs(2),
+		Args: func(cmd *cobra.Command, args []string) error {
+			// all args are the command when the containers are given by options.
+			if len(e.Containers) > 0 || len(e.Filter) > 0 {
+				if e.Preset != "" {
+					return nil
+				}
+				if e.CmdFile != "" {
+					return cobra.MaximumNArgs(1)(cmd, args)
+				}
+				return cobra.MinimumNArgs(1)(cmd, args)
+			}
+			if e.Preset != "" {
+				return cobra.MinimumNArgs(1)(cmd, args)
+			}
+			if e.CmdFile != "" {
+				return cobra.RangeArgs(1, 2)(cmd, args)
+			}
+			return cobra.MinimumNArgs(2)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return e.runExec(args)
 		},
@@ -55,11 +164,41 @@ func (e *ExecCommand) addFlags() {
 	flagSet := e.cmd.Flags()
 	flagSet.SetInterspersed(false)
 	flagSet.BoolVarP(&e.Detach, "detach", "d", false, "Run the process in the background")
+	flagSet.Var(&e.DetachKeys, "detach-keys", "Override the key sequence for detaching the exec process (default ctrl-p,ctrl-q)")
 	flagSet.BoolVarP(&e.Terminal, "tty", "t", false, "Allocate a tty device")
+	flagSet.IntVar(&e.Rows, "rows", 0, "Fix the number of rows of tty, the window size changes are ignored")
+	flagSet.IntVar(&e.Cols, "cols", 0, "Fix the number of columns of tty, the window size changes are ignored")
 	flagSet.BoolVarP(&e.Interactive, "interactive", "i", false, "Open container's STDIN")
+	flagSet.DurationVar(&e.IdleTimeout, "interactive-timeout", 0, "Disconnect and kill the exec process if no data flows on STDIN and STDOUT for the duration, exit with code 120, 0 means no timeout")
 	flagSet.StringVarP(&e.User, "user", "u", "", "Username or UID (format: <name|uid>[:<group|gid>])")
+	flagSet.StringSliceVar(&e.GroupAdd, "group-add", nil, "Add additional groups, in name or GID, to the exec process")
 	flagSet.StringArrayVarP(&e.Envs, "env", "e", []string{}, "Set environment variables")
+	flagSet.BoolVar(&e.UseInit, "init", false, "Run the exec process under an init which reaps zombies and forwards signals, requires exec-init-path of pouchd")
+	flagSet.BoolVar(&e.InheritEnv, "inherit-env", true, "Inherit the container's environment variables, which are overridden by --env-file and -e")
+	flagSet.BoolVar(&e.ClearEnv, "clear-env", false, "Start the exec process with only the environment variables set by --env-file and -e")
+	flagSet.StringArrayVar(&e.Containers, "container", nil, "Run the command in the container, can be repeated to run it in multiple containers, all args are the command then")
+	flagSet.StringSliceVar(&e.Filter, "filter", nil, "Run the command in all running containers matching the filter, support filter key [ id label name status ]")
+	flagSet.IntVar(&e.Parallel, "parallel", 1, "Number of containers to run the command in concurrently, with multiple containers")
+	flagSet.BoolVar(&e.Demux, "demux", false, "Keep STDOUT and STDERR separated even if -t is set, no tty is allocated in the container")
+	flagSet.BoolVar(&e.Rm, "rm", false, "Remove the exec record from the daemon after the exec process exits")
+	flagSet.BoolVar(&e.NoStdout, "no-stdout", false, "Do not attach STDOUT of the exec process")
+	flagSet.BoolVar(&e.NoStderr, "no-stderr", false, "Do not attach STDERR of the exec process")
+	flagSet.BoolVar(&e.Log, "log", false, "Copy the output of the exec process into the log driver of container as well")
+	flagSet.StringVar(&e.Output, "output", "", "Write the output of the exec process to the file, including STDERR unless --error-output is set")
+	flagSet.StringVar(&e.ErrorOutput, "error-output", "", "Write STDERR of the exec process to the file")
+	flagSet.StringArrayVar(&e.EnvFiles, "env-file", nil, "Read in a file of environment variables")
+	flagSet.StringVar(&e.Format, "format", "", "Print the exec result (ExecID, ExitCode, Pid, Elapsed, StdinBytes, StdoutBytes, StderrBytes) to STDERR using the given go template, or 'json'")
+	flagSet.StringVar(&e.PidFile, "pid-file", "", "Write the host PID of the exec process to the file once it has started")
 	flagSet.BoolVar(&e.Privileged, "privileged", false, "Give extended privileges to the exec process")
+	flagSet.BoolVar(&e.Locate, "locate", false, "With multiple --host, run the command on the first host which has the container, rather than the first reachable one")
+	flagSet.BoolVarP(&e.Quiet, "quiet", "q", false, "Suppress the warnings and diagnostics on STDERR, the exit summary of multiple containers is still printed")
+	flagSet.StringSliceVar(&e.CapAdd, "cap-add", nil, "Add Linux capabilities to the exec process")
+	flagSet.StringSliceVar(&e.CapDrop, "cap-drop", nil, "Drop Linux capabilities from the exec process")
+	flagSet.BoolVar(&e.SigProxy, "sig-proxy", true, "Proxy SIGINT and SIGTERM to the exec process when no tty is allocated")
+	flagSet.DurationVar(&e.Timeout, "timeout", 0, "Kill the exec process after the given duration, 0 means no timeout")
+	flagSet.StringVarP(&e.Workdir, "workdir", "w", "", "Working directory inside the container")
+	flagSet.StringVar(&e.Preset, "preset", "", "Load the command, env, user, workdir, tty and interactive settings from the preset in ~/.pouch/exec_presets.json, the explicit ones take precedence")
+	flagSet.StringVar(&e.CmdFile, "cmd-file", "", "Read the script from a file ('-' for STDIN) and pipe it to the interpreter (default /bin/sh)")
 }
 
 // runExec is the entry of ExecCommand command.
@@ -67,56 +206,539 @@ func (e *ExecCommand) runExec(args []string) error {
 	ctx := context.Background()
 	apiClient := e.cli.Client()
 
-	// create exec process.
-	id := args[0]
-	command := args[1:]
+	var preset *execPreset
+	if e.Preset != "" {
+		p, err := loadExecPreset(execPresetsPath(), e.Preset)
+		if err != nil {
+			return err
+		}
+		preset = p
+		e.applyPreset(preset)
+	}
+
+	if e.Locate {
+		if len(e.Filter) > 0 || len(e.Containers) > 0 {
+			return fmt.Errorf("Conflicting options: --locate and --filter or --container")
+		}
+
+		located, err := client.LocateContainer(ctx, e.cli.Hosts(), e.cli.TLS, args[0])
+		if err != nil {
+			return execExitError(err)
+		}
+		apiClient = located
+	}
+
+	// the output is multiplexed only when there is no tty in the container.
+	if e.Demux {
+		e.Terminal = false
+	}
+
+	if e.Format != "" {
+		tmpl, err := parseFormat(e.Format)
+		if err != nil {
+			return err
+		}
+		e.formatTmpl = tmpl
+	}
+
+	targets, command, err := e.execTargets(ctx, apiClient, args)
+	if err != nil {
+		return execExitError(err)
+	}
+	if preset != nil && len(command) == 0 {
+		command = preset.Cmd
+	}
+
+	multiple := len(e.Filter) > 0 || len(targets) > 1
+	if multiple {
+		if e.Locate {
+			return fmt.Errorf("Conflicting options: --locate and multiple containers")
+		}
+		if e.Terminal || e.Interactive || e.CmdFile != "" || e.PidFile != "" {
+			return fmt.Errorf("Conflicting options: multiple containers and -i, -t, --cmd-file or --pid-file")
+		}
+		if e.Parallel < 1 {
+			return fmt.Errorf("invalid --parallel %d: must be positive", e.Parallel)
+		}
+	}
+
+	if flags := e.cmd.Flags(); flags.Changed("rows") || flags.Changed("cols") {
+		if !e.Terminal {
+			return fmt.Errorf("Conflicting options: --rows (or --cols) without -t")
+		}
+		if flags.Changed("rows") && e.Rows <= 0 {
+			return fmt.Errorf("invalid --rows %d: must be positive", e.Rows)
+		}
+		if flags.Changed("cols") && e.Cols <= 0 {
+			return fmt.Errorf("invalid --cols %d: must be positive", e.Cols)
+		}
+	}
+
+	if e.NoStdout && e.Terminal {
+		return fmt.Errorf("Conflicting options: --no-stdout and -t")
+	}
+
+	if (e.Output != "" || e.ErrorOutput != "") && (e.Terminal || e.Detach) {
+		return fmt.Errorf("Conflicting options: --output (or --error-output) and -t (or -d)")
+	}
+
+	if e.Rm && e.Detach {
+		return fmt.Errorf("Conflicting options: --rm and -d")
+	}
+
+	if e.Detach && (e.Interactive || e.Terminal) {
+		e.warnf("WARNING: -i and -t are ignored with -d, the exec process is not attached to this terminal\n")
+	}
+
+	var stdin io.Reader = os.Stdin
+	if e.CmdFile != "" {
+		if e.Detach || e.Terminal {
+			return fmt.Errorf("Conflicting options: --cmd-file and -d (or -t)")
+		}
+
+		script, err := readCmdFile(e.CmdFile)
+		if err != nil {
+			return err
+		}
+		stdin = bytes.NewReader(script)
+
+		if len(command) == 0 {
+			command = []string{defaultCmdFileInterpreter}
+		}
+	}
+
+	if len(command) == 0 {
+		return fmt.Errorf("command can not be empty")
+	}
+
+	if e.ClearEnv && e.InheritEnv && e.cmd.Flags().Changed("inherit-env") {
+		return fmt.Errorf("Conflicting options: --inherit-env and --clear-env")
+	}
+
+	// variables set by -e override the ones in env files, and both of them
+	// override the container's environment variables.
+	envs, err := readKVStrings(e.EnvFiles, resolvePassthroughEnvs(e.Envs))
+	if err != nil {
+		return fmt.Errorf("failed to read env file: %v", err)
+	}
+	if preset != nil {
+		// the variables of preset are overridden by the explicit ones.
+		envs = append(append([]string{}, preset.Env...), envs...)
+	}
 
 	createExecConfig := &types.ExecCreateConfig{
 		Cmd:          command,
 		Tty:          e.Terminal,
 		Detach:       e.Detach,
-		AttachStderr: !e.Detach,
-		AttachStdout: !e.Detach,
-		AttachStdin:  !e.Detach && e.Interactive,
+		DetachKeys:   e.DetachKeys.String(),
+		AttachStderr: !e.Detach && !e.NoStderr,
+		AttachStdout: !e.Detach && !e.NoStdout,
+		AttachStdin:  !e.Detach && (e.Interactive || e.CmdFile != ""),
 		Privileged:   e.Privileged,
+		CapAdd:       e.CapAdd,
+		CapDrop:      e.CapDrop,
 		User:         e.User,
-		Env:          e.Envs,
+		GroupAdd:     e.GroupAdd,
+		Init:         e.UseInit,
+		Env:          envs,
+		ClearEnv:     e.ClearEnv || !e.InheritEnv,
+		WorkingDir:   e.Workdir,
+	}
+
+	if !e.Detach && !createExecConfig.AttachStdin && !createExecConfig.AttachStdout && !createExecConfig.AttachStderr {
+		return fmt.Errorf("Conflicting options: --no-stdout and --no-stderr without -i, nothing is attached")
 	}
 
 	if err := checkTty(createExecConfig.AttachStdin, createExecConfig.Tty, os.Stdin.Fd()); err != nil {
 		return err
 	}
+	if createExecConfig.Tty && !e.Detach && !terminal.IsTerminal(int(os.Stdin.Fd())) {
+		// only -t is given, the output still works without a terminal.
+		e.warnf("WARNING: the input device is not a TTY, the tty size is taken from COLUMNS and LINES or defaults to 80x24\n")
+	}
+
+	// the output files are created before the exec process.
+	e.stdout, e.stderr = os.Stdout, os.Stderr
+	if e.Output != "" {
+		f, err := os.Create(e.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %v", err)
+		}
+		defer f.Close()
+		e.stdout, e.stderr = f, f
+	}
+	if e.ErrorOutput != "" {
+		f, err := os.Create(e.ErrorOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create error output file: %v", err)
+		}
+		defer f.Close()
+		e.stderr = f
+	}
+
+	if multiple {
+		return e.runMultiExec(ctx, apiClient, targets, createExecConfig)
+	}
+	return e.execInContainer(ctx, apiClient, targets[0].ID, createExecConfig, stdin, e.stdout, e.stderr)
+}
+
+// execTarget is the container to run the command in.
+type execTarget struct {
+	// ID is the full id of the container.
+	ID string
+	// Name is the name of the container, which prefixes the output and the
+	// exit code of the container with multiple containers.
+	Name string
+}
+
+// execTargets parses the target containers and the command from args. The
+// containers are given by --container or matched by --filter, in which case
+// all args are the command. Otherwise, the args before the first "--" are the
+// containers if there are more than one and all of them are containers, or
+// the first arg is the container and the rest are the command.
+func (e *ExecCommand) execTargets(ctx context.Context, apiClient client.CommonAPIClient, args []string) ([]execTarget, []string, error) {
+	if len(e.Filter) > 0 {
+		if len(e.Containers) > 0 {
+			return nil, nil, fmt.Errorf("Conflicting options: --container and --filter")
+		}
+
+		filter, err := filters.Parse(e.Filter)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		containers, err := apiClient.ContainerList(ctx, types.ContainerListOptions{Filter: filter})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list containers: %v", err)
+		}
+		if len(containers) == 0 {
+			return nil, nil, fmt.Errorf("no running container matches the filter %s", strings.Join(e.Filter, ","))
+		}
+
+		var targets []execTarget
+		for _, c := range containers {
+			name := c.ID
+			if len(c.Names) > 0 {
+				name = c.Names[0]
+			}
+			targets = append(targets, execTarget{ID: c.ID, Name: name})
+		}
+		return targets, args, nil
+	}
+
+	names, command := e.Containers, args
+	// a "--" may belong to the command, e.g. "grep -- -v file", so the args
+	// before it are taken as containers only if all of them are containers.
+	guessed := false
+	if len(names) == 0 {
+		names, command = args[:1], args[1:]
+		for i, arg := range args {
+			if arg == "--" {
+				if i > 1 {
+					names, command, guessed = args[:i], args[i+1:], true
+				}
+				break
+			}
+		}
+	}
+
+	if len(names) == 1 {
+		id, err := resolveContainerID(ctx, apiClient, names[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		return []execTarget{{ID: id, Name: names[0]}}, command, nil
+	}
+
+	containers, err := apiClient.ContainerList(ctx, types.ContainerListOptions{All: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list containers: %v", err)
+	}
+
+	targets, err := matchExecTargets(containers, names)
+	if err != nil && guessed {
+		// the args before "--" are not all containers, so only the first
+		// one is the container.
+		command = args[1:]
+		targets, err = matchExecTargets(containers, args[:1])
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return targets, command, nil
+}
+
+// matchExecTargets matches the names, ids or prefixes of id in the containers.
+func matchExecTargets(containers []*types.Container, names []string) ([]execTarget, error) {
+	targets := make([]execTarget, 0, len(names))
+	for _, name := range names {
+		id, err := matchContainerID(containers, name)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, execTarget{ID: id, Name: name})
+	}
+	return targets, nil
+}
+
+// runMultiExec runs the command in each container, at most --parallel ones at
+// the same time, and reports the exit code of each container at the end. Each
+// line of the output is prefixed with the name of the container.
+func (e *ExecCommand) runMultiExec(ctx context.Context, apiClient client.CommonAPIClient, targets []execTarget, config *types.ExecCreateConfig) error {
+	errs := make([]error, len(targets))
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	limit := make(chan struct{}, e.Parallel)
+	for i, target := range targets {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(i int, target execTarget) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+
+			prefix := target.Name + ": "
+			stdout := newLinePrefixWriter(e.stdout, prefix, &mu)
+			stderr := newLinePrefixWriter(e.stderr, prefix, &mu)
+			errs[i] = e.execInContainer(ctx, apiClient, target.ID, config, nil, stdout, stderr)
+			stdout.Flush()
+			stderr.Flush()
+		}(i, target)
+	}
+	wg.Wait()
+
+	// the summary is always printed, even with --quiet.
+	failed := 0
+	for i, target := range targets {
+		switch err := errs[i].(type) {
+		case nil:
+			fmt.Fprintf(os.Stderr, "%s: exit code 0\n", target.Name)
+		case ExitError:
+			failed++
+			fmt.Fprintf(os.Stderr, "%s: exit code %d\n", target.Name, err.Code)
+		default:
+			failed++
+			fmt.Fprintf(os.Stderr, "%s: %v\n", target.Name, err)
+		}
+	}
+
+	if failed > 0 {
+		return ExitError{
+			Code:   1,
+			Status: fmt.Sprintf("exec failed in %d of %d containers", failed, len(targets)),
+		}
+	}
+	return nil
+}
+
+// linePrefixWriter writes each line with the prefix to the underlying writer.
+// The lines of the writers sharing the lock are not interleaved.
+type linePrefixWriter struct {
+	w      io.Writer
+	prefix string
+	mu     *sync.Mutex
+	buf    []byte
+}
+
+// newLinePrefixWriter creates a linePrefixWriter.
+func newLinePrefixWriter(w io.Writer, prefix string, mu *sync.Mutex) *linePrefixWriter {
+	return &linePrefixWriter{w: w, prefix: prefix, mu: mu}
+}
+
+// Write implements io.Writer interface, the incomplete line is kept until
+// the newline is written or the writer is flushed.
+func (p *linePrefixWriter) Write(data []byte) (int, error) {
+	p.buf = append(p.buf, data...)
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i == -1 {
+			return len(data), nil
+		}
+		if err := p.writeLine(p.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		p.buf = p.buf[i+1:]
+	}
+}
 
+// Flush writes the last line which is not terminated by newline.
+func (p *linePrefixWriter) Flush() error {
+	if len(p.buf) == 0 {
+		return nil
+	}
+	line := append(p.buf, '\n')
+	p.buf = nil
+	return p.writeLine(line)
+}
+
+func (p *linePrefixWriter) writeLine(line []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, err := p.w.Write(append([]byte(p.prefix), line...))
+	return err
+}
+
+// execInContainer creates and starts the exec process in the container, and
+// holds the streams until the exec process exits.
+func (e *ExecCommand) execInContainer(ctx context.Context, apiClient client.CommonAPIClient, id string, createExecConfig *types.ExecCreateConfig, stdin io.Reader, stdout, stderr io.Writer) error {
 	createResp, err := apiClient.ContainerCreateExec(ctx, id, createExecConfig)
 	if err != nil {
-		return fmt.Errorf("failed to create exec: %v", err)
+		return execExitError(errors.Wrap(err, "failed to create exec"))
+	}
+
+	// with --rm, the exec record is removed once the exec process exits,
+	// including the exits of the processes killed on timeout.
+	var exited bool
+	if e.Rm {
+		defer func() {
+			if !exited {
+				return
+			}
+			if err := apiClient.ContainerExecRemove(ctx, createResp.ID); err != nil {
+				log.With(ctx).Debugf("failed to remove exec process %s: %v", createResp.ID, err)
+			}
+		}()
 	}
 
 	// start exec process.
 	startExecConfig := &types.ExecStartConfig{
 		Detach: e.Detach,
 		Tty:    e.Terminal,
+		Log:    e.Log && e.checkLogDriver(ctx, apiClient, id),
 	}
 
+	start := time.Now()
 	conn, reader, err := apiClient.ContainerStartExec(ctx, createResp.ID, startExecConfig)
 	if err != nil {
 		return fmt.Errorf("failed to start exec: %v", err)
 	}
 
 	if e.Detach {
+		if e.PidFile != "" {
+			return writeExecPidFile(ctx, apiClient, createResp.ID, e.PidFile)
+		}
 		return nil
 	}
+	defer conn.Close()
+
+	if e.PidFile != "" {
+		go func() {
+			if err := writeExecPidFile(ctx, apiClient, createResp.ID, e.PidFile); err != nil {
+				e.warnf("failed to write pid file: %v\n", err)
+			}
+		}()
+	}
+
+	// with tty, signals are passed through the raw stream as control keys.
+	if e.SigProxy && !e.Terminal {
+		stop := forwardExecSignals(ctx, apiClient, createResp.ID)
+		defer stop()
+	}
+
+	streamCtx := ctx
+	if e.Timeout > 0 {
+		var cancel context.CancelFunc
+		streamCtx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+
+	var idle *idleTimer
+	if e.IdleTimeout > 0 {
+		var cancel context.CancelFunc
+		streamCtx, cancel = context.WithCancel(streamCtx)
+		defer cancel()
+
+		idle = newIdleTimer(e.IdleTimeout, cancel)
+		defer idle.Stop()
+
+		stdin = idle.Reader(stdin)
+		reader = bufio.NewReader(idle.Reader(reader))
+	}
 
 	// handle stdio.
-	if err := holdHijackConnection(ctx, apiClient, createResp.ID, conn, reader, createExecConfig.AttachStdin, createExecConfig.AttachStdout, createExecConfig.AttachStderr, e.Terminal); err != nil {
-		return err
+	streams := &streamAttach{
+		conn:        conn,
+		reader:      reader,
+		in:          stdin,
+		out:         stdout,
+		errOut:      stderr,
+		escapeKeys:  e.DetachKeys.Bytes(),
+		stdin:       createExecConfig.AttachStdin,
+		stdout:      createExecConfig.AttachStdout,
+		stderr:      createExecConfig.AttachStderr,
+		tty:         e.Terminal,
+		multiplexed: !e.Terminal,
+		rows:        e.Rows,
+		cols:        e.Cols,
+		resize: func(ctx context.Context, width, height int) error {
+			return apiClient.ContainerExecResize(ctx, createResp.ID, types.ResizeOptions{Width: int64(width), Height: int64(height)})
+		},
+	}
+	transferred, streamErr := streams.hold(streamCtx)
+	if streamErr == term.ErrEscapeDetach {
+		// detached from the exec process, leave it running.
+		return nil
+	}
+	log.With(ctx).Debugf("exec process %s transferred %d bytes from STDIN, %d bytes to STDOUT and %d bytes to STDERR",
+		createResp.ID, transferred.StdinBytes, transferred.StdoutBytes, transferred.StderrBytes)
+
+	if idle != nil && idle.Fired() {
+		exited = e.killExec(ctx, apiClient, createResp.ID)
+		e.printResult(execResult{
+			ExecID:      createResp.ID,
+			ExitCode:    execIdleExitCode,
+			Elapsed:     time.Since(start),
+			streamBytes: transferred,
+		})
+		return ExitError{
+			Code:   execIdleExitCode,
+			Status: fmt.Sprintf("exec process %s has been idle for %s, disconnected", createResp.ID, e.IdleTimeout),
+		}
 	}
 
-	execInfo, err := apiClient.ContainerExecInspect(ctx, createResp.ID)
+	if streamCtx.Err() == context.DeadlineExceeded {
+		exited = e.killExec(ctx, apiClient, createResp.ID)
+		e.printResult(execResult{
+			ExecID:      createResp.ID,
+			ExitCode:    execTimeoutExitCode,
+			Elapsed:     time.Since(start),
+			streamBytes: transferred,
+		})
+		return ExitError{
+			Code:   execTimeoutExitCode,
+			Status: fmt.Sprintf("exec process %s timed out after %s", createResp.ID, e.Timeout),
+		}
+	}
+
+	execInfo, err := waitExecExit(ctx, apiClient, createResp.ID)
 	if err != nil {
+		// only surface the stream error when we can not determine the
+		// exit status of exec process.
+		if streamErr != nil {
+			return streamErr
+		}
 		return err
 	}
 
+	if streamErr != nil {
+		log.With(ctx).Debugf("exec process %s has exited, ignore stream error: %v", createResp.ID, streamErr)
+	}
+
+	exited = true
+
+	e.printResult(execResult{
+		ExecID:      createResp.ID,
+		ExitCode:    int(execInfo.ExitCode),
+		Pid:         execInfo.Pid,
+		Elapsed:     time.Since(start),
+		streamBytes: transferred,
+	})
+
 	code := execInfo.ExitCode
 	if code != 0 {
 		return ExitError{Code: int(code)}
@@ -125,106 +747,179 @@ func (e *ExecCommand) runExec(args []string) error {
 	return nil
 }
 
-func holdHijackConnection(ctx context.Context, apiClient client.CommonAPIClient, execID string, conn net.Conn, reader *bufio.Reader, stdin, stdout, stderr, tty bool) error {
-	if stdin && tty {
-		in, out, err := setRawMode(true, false)
-		if err != nil {
-			return fmt.Errorf("failed to set raw mode")
-		}
-		defer func() {
-			if err := restoreMode(in, out); err != nil {
-				fmt.Fprintf(os.Stderr, "failed to restore term mode")
-			}
-		}()
+// killExec kills the exec process which is timed out. With --rm, it also
+// waits for the exit of exec process, since the running exec record can not
+// be removed, and returns true if the exec process has exited.
+func (e *ExecCommand) killExec(ctx context.Context, apiClient client.CommonAPIClient, execID string) bool {
+	if err := apiClient.ContainerExecKill(ctx, execID, "KILL"); err != nil {
+		log.With(ctx).Debugf("failed to kill exec process %s: %v", execID, err)
+		return false
+	}
+	if !e.Rm {
+		return false
 	}
 
-	stdoutDone := make(chan error, 1)
+	if _, err := waitExecExit(ctx, apiClient, execID); err != nil {
+		log.With(ctx).Debugf("failed to wait for the exit of killed exec process %s: %v", execID, err)
+		return false
+	}
+	return true
+}
+
+// execExitError converts the errors of container not found and not running
+// into ExitError with distinct exit codes, so that scripts can tell them
+// apart from the failures of exec process.
+func execExitError(err error) error {
+	switch errors.Cause(err).(type) {
+	case client.NotFoundError, noSuchContainerError:
+		return ExitError{Code: execNoSuchContainerExitCode, Status: err.Error()}
+	case client.ConflictError:
+		return ExitError{Code: execNotRunningExitCode, Status: err.Error()}
+	}
+	return err
+}
+
+// checkLogDriver checks whether the container has a log driver for --log,
+// and prints a warning if not.
+func (e *ExecCommand) checkLogDriver(ctx context.Context, apiClient client.CommonAPIClient, id string) bool {
+	c, err := apiClient.ContainerGet(ctx, id)
+	if err != nil {
+		log.With(ctx).Debugf("failed to get container %s: %v", id, err)
+		return true
+	}
+
+	if c.HostConfig != nil && c.HostConfig.LogConfig != nil && c.HostConfig.LogConfig.LogDriver == types.LogConfigLogDriverNone {
+		e.warnf("WARNING: container %s uses the none log driver, the output of exec process is not logged\n", id)
+		return false
+	}
+	return true
+}
+
+// printResult prints the exec result to STDERR if --format is set, it should
+// be called after the terminal is restored.
+func (e *ExecCommand) printResult(result execResult) {
+	if e.formatTmpl == nil {
+		return
+	}
+
+	if err := e.formatTmpl.Execute(os.Stderr, result); err != nil {
+		e.warnf("failed to format exec result: %v\n", err)
+		return
+	}
+	fmt.Fprintln(os.Stderr)
+}
+
+// warnf prints the non-fatal diagnostics to STDERR unless --quiet is set.
+func (e *ExecCommand) warnf(format string, args ...interface{}) {
+	if e.Quiet {
+		return
+	}
+	fmt.Fprintf(os.Stderr, format, args...)
+}
+
+// forwardExecSignals forwards SIGINT and SIGTERM received by the client to
+// the exec process, the returned function stops forwarding.
+func forwardExecSignals(ctx context.Context, apiClient client.CommonAPIClient, execID string) func() {
+	sigc := make(chan os.Signal, 16)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+
+	done := make(chan struct{})
 	go func() {
-		var err error
-		if stderr || stdout {
-			if !tty {
-				_, err = stdcopy.StdCopy(os.Stdout, os.Stderr, reader)
-			} else {
-				_, err = io.Copy(os.Stdout, reader)
+		for {
+			select {
+			case s := <-sigc:
+				sig, ok := s.(syscall.Signal)
+				if !ok {
+					continue
+				}
+				if err := apiClient.ContainerExecKill(ctx, execID, strconv.Itoa(int(sig))); err != nil {
+					log.With(ctx).Debugf("failed to forward signal %s to exec process %s: %v", sig, execID, err)
+				}
+			case <-done:
+				return
 			}
 		}
-		stdoutDone <- err
 	}()
 
-	stdinDone := make(chan struct{})
-	go func() {
-		if stdin {
-			io.Copy(conn, os.Stdin)
-			// close write if receive CTRL-D
-			if cw, ok := conn.(ioutils.CloseWriter); ok {
-				cw.CloseWrite()
-			}
+	return func() {
+		signal.Stop(sigc)
+		close(done)
+	}
+}
+
+// waitExecExit polls the exec process with backoff until it is not running,
+// since the exec record may still be running for a while after the streams
+// closed on a busy daemon.
+func waitExecExit(ctx context.Context, apiClient client.CommonAPIClient, execID string) (*types.ContainerExecInspect, error) {
+	interval := execWaitInitialInterval
+	for i := 0; i < execWaitMaxTimes; i++ {
+		execInfo, err := inspectExecWithRetry(ctx, apiClient, execID)
+		if err != nil {
+			return nil, err
 		}
 
-		close(stdinDone)
-	}()
+		if !execInfo.Running {
+			return execInfo, nil
+		}
 
-	// resize exec tty
-	if tty {
-		if err := execResize(ctx, apiClient, execID); err != nil {
-			return err
+		time.Sleep(interval)
+		if interval *= 2; interval > execWaitMaxInterval {
+			interval = execWaitMaxInterval
 		}
 	}
+	return nil, fmt.Errorf("failed to get exit code of exec process %s: still running after %d inspections", execID, execWaitMaxTimes)
+}
 
-	select {
-	case err := <-stdoutDone:
+// writeExecPidFile polls the exec process until it has started, and writes
+// its host pid into the file.
+func writeExecPidFile(ctx context.Context, apiClient client.CommonAPIClient, execID string, path string) error {
+	interval := execWaitInitialInterval
+	for i := 0; i < execWaitMaxTimes; i++ {
+		execInfo, err := apiClient.ContainerExecInspect(ctx, execID)
 		if err != nil {
-			log.With(ctx).Debugf("receive stdout error: %s", err)
 			return err
 		}
 
-	case <-stdinDone:
-		if stdout || stderr {
-			select {
-			case err := <-stdoutDone:
-				log.With(ctx).Debugf("receive stdout error: %s", err)
-				return err
-			case <-ctx.Done():
-			}
+		if execInfo.Pid != 0 {
+			return ioutil.WriteFile(path, []byte(strconv.FormatInt(execInfo.Pid, 10)), 0644)
 		}
 
-	case <-ctx.Done():
+		time.Sleep(interval)
+		if interval *= 2; interval > execWaitMaxInterval {
+			interval = execWaitMaxInterval
+		}
 	}
-
-	return nil
+	return fmt.Errorf("exec process %s has not started after %d inspections", execID, execWaitMaxTimes)
 }
 
-func execResize(ctx context.Context, apiClient client.CommonAPIClient, execID string) error {
-	width, height, err := terminal.GetSize(int(os.Stdin.Fd()))
-	if err != nil {
-		return err
-	}
-
-	// retry first resize exec.
-	for i := 0; i < 16; i++ {
-		err = apiClient.ContainerExecResize(ctx, execID, types.ResizeOptions{Width: int64(width), Height: int64(height)})
-		if err == nil {
-			break
+// inspectExecWithRetry inspects the exec process, retries a few times if
+// failed, since the connection may be broken for a moment.
+func inspectExecWithRetry(ctx context.Context, apiClient client.CommonAPIClient, execID string) (*types.ContainerExecInspect, error) {
+	var (
+		execInfo *types.ContainerExecInspect
+		err      error
+	)
+
+	for i := 0; i < execInspectRetryTimes; i++ {
+		if execInfo, err = apiClient.ContainerExecInspect(ctx, execID); err == nil {
+			return execInfo, nil
 		}
+		time.Sleep(execInspectRetryInterval)
 	}
+	return nil, err
+}
 
-	s := make(chan os.Signal, 16)
-	signal.Notify(s, unix.SIGWINCH)
-	go func() {
-		for range s {
-			width, height, err = terminal.GetSize(int(os.Stdin.Fd()))
-			if err != nil {
-				log.With(ctx).Debugf("failed to get tty size, err(%v)", err)
-				continue
-			}
-			err = apiClient.ContainerExecResize(ctx, execID, types.ResizeOptions{Width: int64(width), Height: int64(height)})
-			if err != nil {
-				log.With(ctx).Debugf("failed to resize tty, err(%v)", err)
-			}
-		}
-	}()
+// readCmdFile reads the script passed by --cmd-file, '-' means reading from STDIN.
+func readCmdFile(path string) ([]byte, error) {
+	if path == "-" {
+		return ioutil.ReadAll(os.Stdin)
+	}
 
-	return nil
+	script, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read command file %s: %v", path, err)
+	}
+	return script, nil
 }
 
 // execExample shows examples in exec command, and is used in auto-generated cli docs.
@@ -233,5 +928,13 @@ func execExample() string {
 PID   USER     TIME  COMMAND
     1 root      0:00 /bin/sh
    38 root      0:00 ps
+$ pouch exec --cmd-file ./script.sh 25bf50
+hello from script
+$ pouch exec --format '{{.ExitCode}} {{.Elapsed}}' 25bf50 sh -c 'exit 3'
+3 25.136ms
+$ pouch exec --filter label=app=web -- sh -c 'exit 1'
+25bf50fc9ab8e8b1dd4f2c4e1c4f7e3d2e5e3fbd1a6b1d2a9d3c1a3fb1d9a8e2: exit code 1
+3f2b11a63e5d9c4e6c3d9b0b3a0c4a1f8d1c9e4b7a2d5f6e3c8b9a0d1e2f3a4b: exit code 1
+exec failed in 2 of 2 containers
 `
 }
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// execPresetsFile is the file of exec presets, relative to the home dir.
+const execPresetsFile = ".pouch/exec_presets.json"
+
+// execPreset is a named exec template loaded by --preset, the file maps the
+// preset names to them, for example:
+//
+//	{
+//	    "diag": {"cmd": ["sh", "-c", "top -bn1"], "user": "root"}
+//	}
+type execPreset struct {
+	Cmd         []string `json:"cmd"`
+	Env         []string `json:"env"`
+	User        string   `json:"user"`
+	Workdir     string   `json:"workdir"`
+	Tty         bool     `json:"tty"`
+	Interactive bool     `json:"interactive"`
+}
+
+// execPresetsPath returns the path of exec presets file in the home dir.
+func execPresetsPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return execPresetsFile
+	}
+	return filepath.Join(home, execPresetsFile)
+}
+
+// loadExecPreset loads the preset by name from the file, and returns an
+// error listing the available presets if the name is unknown.
+func loadExecPreset(path, name string) (*execPreset, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open exec presets: %v", err)
+	}
+	defer fd.Close()
+
+	presets := map[string]*execPreset{}
+	if err := json.NewDecoder(fd).Decode(&presets); err != nil {
+		return nil, fmt.Errorf("failed to decode exec presets %s: %v", path, err)
+	}
+
+	if preset, ok := presets[name]; ok && preset != nil {
+		return preset, nil
+	}
+
+	var names []string
+	for n := range presets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("unknown exec preset %q, available presets: [ %s ]", name, strings.Join(names, " "))
+}
+
+// applyPreset loads the settings of preset into the flags which are not
+// given explicitly.
+func (e *ExecCommand) applyPreset(preset *execPreset) {
+	flags := e.cmd.Flags()
+	if !flags.Changed("tty") {
+		e.Terminal = preset.Tty
+	}
+	if !flags.Changed("interactive") {
+		e.Interactive = preset.Interactive
+	}
+	if !flags.Changed("user") {
+		e.User = preset.User
+	}
+	if !flags.Changed("workdir") {
+		e.Workdir = preset.Workdir
+	}
+}
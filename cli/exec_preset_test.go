@@ -0,0 +1,50 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadExecPreset(t *testing.T) {
+	dir, err := ioutil.TempDir("", "exec-preset")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "exec_presets.json")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{
+		"diag": {"cmd": ["sh", "-c", "top -bn1"], "env": ["A=1"], "user": "root", "tty": true},
+		"shell": {"cmd": ["sh"], "interactive": true}
+	}`), 0644))
+
+	preset, err := loadExecPreset(path, "diag")
+	assert.NoError(t, err)
+	assert.Equal(t, &execPreset{
+		Cmd:  []string{"sh", "-c", "top -bn1"},
+		Env:  []string{"A=1"},
+		User: "root",
+		Tty:  true,
+	}, preset)
+
+	_, err = loadExecPreset(path, "nothing")
+	assert.EqualError(t, err, `unknown exec preset "nothing", available presets: [ diag shell ]`)
+
+	_, err = loadExecPreset(filepath.Join(dir, "nonexistent"), "diag")
+	assert.Error(t, err)
+}
+
+func TestApplyPreset(t *testing.T) {
+	e := &ExecCommand{}
+	e.Init(&Cli{})
+	assert.NoError(t, e.cmd.Flags().Set("user", "nobody"))
+
+	// the explicit flags win.
+	e.applyPreset(&execPreset{User: "root", Workdir: "/tmp", Tty: true, Interactive: true})
+	assert.Equal(t, "nobody", e.User)
+	assert.Equal(t, "/tmp", e.Workdir)
+	assert.True(t, e.Terminal)
+	assert.True(t, e.Interactive)
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/client"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+// fakeExecClient mocks the exec related methods of client.CommonAPIClient.
+type fakeExecClient struct {
+	client.CommonAPIClient
+
+	// inspects is the sequence of inspect results returned one by one.
+	inspects []*types.ContainerExecInspect
+	calls    int
+
+	// kills receives the signals sent by ContainerExecKill.
+	kills chan string
+
+	// containers is returned by ContainerList.
+	containers []*types.Container
+}
+
+func (f *fakeExecClient) ContainerList(ctx context.Context, options types.ContainerListOptions) ([]*types.Container, error) {
+	return f.containers, nil
+}
+
+func (f *fakeExecClient) ContainerExecKill(ctx context.Context, execID string, signal string) error {
+	f.kills <- signal
+	return nil
+}
+
+func (f *fakeExecClient) ContainerExecInspect(ctx context.Context, execID string) (*types.ContainerExecInspect, error) {
+	info := f.inspects[f.calls]
+	if f.calls < len(f.inspects)-1 {
+		f.calls++
+	}
+	return info, nil
+}
+
+// shortenExecWait shortens the backoff intervals of polling exec, and returns
+// the function to restore them.
+func shortenExecWait() func() {
+	initial, max := execWaitInitialInterval, execWaitMaxInterval
+	execWaitInitialInterval, execWaitMaxInterval = time.Millisecond, time.Millisecond
+	return func() {
+		execWaitInitialInterval, execWaitMaxInterval = initial, max
+	}
+}
+
+func TestWaitExecExit(t *testing.T) {
+	defer shortenExecWait()()
+
+	apiClient := &fakeExecClient{
+		inspects: []*types.ContainerExecInspect{
+			{Running: true},
+			{Running: true},
+			{Running: false, ExitCode: 3},
+		},
+	}
+
+	execInfo, err := waitExecExit(context.Background(), apiClient, "exec")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), execInfo.ExitCode)
+}
+
+func TestWaitExecExitNeverSettle(t *testing.T) {
+	defer shortenExecWait()()
+
+	apiClient := &fakeExecClient{
+		inspects: []*types.ContainerExecInspect{{Running: true}},
+	}
+
+	_, err := waitExecExit(context.Background(), apiClient, "exec")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "still running")
+}
+
+func TestWriteExecPidFile(t *testing.T) {
+	defer shortenExecWait()()
+
+	dir, err := ioutil.TempDir("", "exec-pid-file")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	apiClient := &fakeExecClient{
+		inspects: []*types.ContainerExecInspect{
+			{Running: false},
+			{Running: true, Pid: 1234},
+		},
+	}
+
+	pidFile := filepath.Join(dir, "pid")
+	assert.NoError(t, writeExecPidFile(context.Background(), apiClient, "exec", pidFile))
+
+	content, err := ioutil.ReadFile(pidFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "1234", string(content))
+}
+
+func TestForwardExecSignals(t *testing.T) {
+	fake := &fakeExecClient{kills: make(chan string, 1)}
+
+	stop := forwardExecSignals(context.Background(), fake, "exec")
+	defer stop()
+
+	assert.NoError(t, unix.Kill(os.Getpid(), unix.SIGTERM))
+	select {
+	case sig := <-fake.kills:
+		assert.Equal(t, strconv.Itoa(int(unix.SIGTERM)), sig)
+	case <-time.After(5 * time.Second):
+		t.Fatal("signal is not forwarded to exec process")
+	}
+}
+
+func TestKillExec(t *testing.T) {
+	defer shortenExecWait()()
+
+	// without --rm, the killed exec process is not waited.
+	fake := &fakeExecClient{kills: make(chan string, 1)}
+	assert.False(t, (&ExecCommand{}).killExec(context.Background(), fake, "exec"))
+	assert.Equal(t, "KILL", <-fake.kills)
+
+	// with --rm, the exec record can be removed after the exit.
+	fake = &fakeExecClient{
+		kills: make(chan string, 1),
+		inspects: []*types.ContainerExecInspect{
+			{Running: true},
+			{Running: false, ExitCode: 137},
+		},
+	}
+	assert.True(t, (&ExecCommand{Rm: true}).killExec(context.Background(), fake, "exec"))
+	assert.Equal(t, "KILL", <-fake.kills)
+	assert.Equal(t, 1, fake.calls)
+
+	fake = &fakeExecClient{
+		kills:    make(chan string, 1),
+		inspects: []*types.ContainerExecInspect{{Running: true}},
+	}
+	assert.False(t, (&ExecCommand{Rm: true}).killExec(context.Background(), fake, "exec"))
+}
+
+func TestExecExitError(t *testing.T) {
+	err := execExitError(errors.Wrap(client.NotFoundError{}, "failed to create exec"))
+	assert.Equal(t, execNoSuchContainerExitCode, err.(ExitError).Code)
+
+	err = execExitError(noSuchContainerError{name: "foo"})
+	assert.Equal(t, ExitError{Code: execNoSuchContainerExitCode, Status: "no such container: foo"}, err)
+
+	err = execExitError(errors.Wrap(client.ConflictError{}, "failed to create exec"))
+	assert.Equal(t, execNotRunningExitCode, err.(ExitError).Code)
+
+	other := fmt.Errorf("failed to create exec: server error")
+	assert.Equal(t, other, execExitError(other))
+}
+
+func TestExecTargets(t *testing.T) {
+	apiClient := &fakeExecClient{containers: []*types.Container{
+		{ID: "aaa111", Names: []string{"foo"}},
+		{ID: "bbb222", Names: []string{"bar"}},
+	}}
+
+	// the first arg is the container, and "--" belongs to the command.
+	e := &ExecCommand{}
+	targets, command, err := e.execTargets(context.Background(), apiClient, []string{"foo", "grep", "--", "-v", "file"})
+	assert.NoError(t, err)
+	assert.Equal(t, []execTarget{{ID: "aaa111", Name: "foo"}}, targets)
+	assert.Equal(t, []string{"grep", "--", "-v", "file"}, command)
+
+	targets, command, err = e.execTargets(context.Background(), apiClient, []string{"foo", "--", "ls"})
+	assert.NoError(t, err)
+	assert.Equal(t, []execTarget{{ID: "aaa111", Name: "foo"}}, targets)
+	assert.Equal(t, []string{"--", "ls"}, command)
+
+	// the args before "--" are the containers if all of them are containers.
+	targets, command, err = e.execTargets(context.Background(), apiClient, []string{"foo", "bbb", "--", "echo", "--"})
+	assert.NoError(t, err)
+	assert.Equal(t, []execTarget{{ID: "aaa111", Name: "foo"}, {ID: "bbb222", Name: "bbb"}}, targets)
+	assert.Equal(t, []string{"echo", "--"}, command)
+
+	_, _, err = e.execTargets(context.Background(), apiClient, []string{"baz", "bar", "--", "true"})
+	assert.Equal(t, noSuchContainerError{name: "baz"}, err)
+
+	// all args are the command with --container.
+	e = &ExecCommand{Containers: []string{"foo", "bbb"}}
+	targets, command, err = e.execTargets(context.Background(), apiClient, []string{"bar", "true"})
+	assert.NoError(t, err)
+	assert.Equal(t, []execTarget{{ID: "aaa111", Name: "foo"}, {ID: "bbb222", Name: "bbb"}}, targets)
+	assert.Equal(t, []string{"bar", "true"}, command)
+
+	e = &ExecCommand{Containers: []string{"foo", "baz"}}
+	_, _, err = e.execTargets(context.Background(), apiClient, []string{"true"})
+	assert.Equal(t, noSuchContainerError{name: "baz"}, err)
+}
+
+func TestLinePrefixWriter(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		mu  sync.Mutex
+	)
+	foo := newLinePrefixWriter(&buf, "foo: ", &mu)
+	bar := newLinePrefixWriter(&buf, "bar: ", &mu)
+
+	foo.Write([]byte("hello"))
+	bar.Write([]byte("one\ntw"))
+	foo.Write([]byte(" world\n\n"))
+	bar.Write([]byte("o"))
+	assert.NoError(t, foo.Flush())
+	assert.NoError(t, bar.Flush())
+
+	assert.Equal(t, "bar: one\nfoo: hello world\nfoo: \nbar: two\n", buf.String())
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// exportDescription is used to describe export command in detail and auto generate command doc.
+var exportDescription = "Export the filesystem of a container as a tar archive. " +
+	"The data in volumes of the container is not included. The container can be running or stopped."
+
+// ExportCommand use to implement 'export' command.
+type ExportCommand struct {
+	baseCommand
+	output string
+}
+
+// Init initialize export command.
+func (e *ExportCommand) Init(c *Cli) {
+	e.cli = c
+	e.cmd = &cobra.Command{
+		Use:   "export [OPTIONS] CONTAINER",
+		Short: "Export a container's filesystem as a tar archive",
+		Long:  exportDescription,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return e.runExport(args)
+		},
+		Example: exportExample(),
+	}
+	e.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (e *ExportCommand) addFlags() {
+	flagSet := e.cmd.Flags()
+	flagSet.StringVarP(&e.output, "output", "o", "", "Write to a file, instead of STDOUT, \"-\" means STDOUT")
+}
+
+// runExport is the entry of export command.
+func (e *ExportCommand) runExport(args []string) error {
+	ctx := context.Background()
+	apiClient := e.cli.Client()
+
+	out := os.Stdout
+	if e.output != "" && e.output != "-" {
+		f, err := os.Create(e.output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	r, err := apiClient.ContainerExport(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	_, err = io.Copy(out, r)
+	return err
+}
+
+// exportExample shows examples in export command, and is used in auto-generated cli docs.
+func exportExample() string {
+	return `$ pouch export -o rootfs.tar foo
+$ pouch import -c "CMD /bin/sh" rootfs.tar foo-rootfs:v1
+sha256:0de2c33e5d7b81aa6b81bba5aacb13e2cb4e8e74e1fd9c0c6e2fd5efc4b43ba4`
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"text/template"
+
+	"github.com/alibaba/pouch/pkg/utils/templates"
+)
+
+// formatFlagUsage is the usage of --format flag of listing commands.
+const formatFlagUsage = "Pretty-print the objects using the given go template, e.g. '{{.ID}} {{.Name}}', or 'json'"
+
+// parseFormat parses the go template of --format flag, so that the invalid
+// template fails the command before it requests the daemon.
+func parseFormat(format string) (*template.Template, error) {
+	tmpl, err := templates.ParseFormat(format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse format %q: %v", format, err)
+	}
+	return tmpl, nil
+}
+
+// formatWriter prints the objects with the template, one object per line.
+type formatWriter struct {
+	out  io.Writer
+	tmpl *template.Template
+}
+
+// newFormatWriter returns a formatWriter of the --format flag, or nil if the
+// format is empty.
+func newFormatWriter(out io.Writer, format string) (*formatWriter, error) {
+	if format == "" {
+		return nil, nil
+	}
+
+	tmpl, err := parseFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	return &formatWriter{out: out, tmpl: tmpl}, nil
+}
+
+// Write prints the object with the template.
+func (w *formatWriter) Write(v interface{}) error {
+	buf := new(bytes.Buffer)
+	if err := w.tmpl.Execute(buf, v); err != nil {
+		return fmt.Errorf("failed to format: %v", err)
+	}
+	buf.WriteByte('\n')
+
+	_, err := io.Copy(w.out, buf)
+	return err
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatWriter(t *testing.T) {
+	out := new(bytes.Buffer)
+
+	w, err := newFormatWriter(out, "")
+	assert.NoError(t, err)
+	assert.Nil(t, w)
+
+	_, err = newFormatWriter(out, "{{.ID")
+	assert.Error(t, err)
+
+	w, err = newFormatWriter(out, "{{.ID}} {{upper .Name}}")
+	assert.NoError(t, err)
+	assert.NoError(t, w.Write(networkContext{ID: "b05a9b8844", Name: "bridge"}))
+	assert.NoError(t, w.Write(volumeContext{ID: "foo", Name: "foo"}))
+	assert.Equal(t, "b05a9b8844 BRIDGE\nfoo FOO\n", out.String())
+
+	out.Reset()
+	w, err = newFormatWriter(out, "json")
+	assert.NoError(t, err)
+	assert.NoError(t, w.Write(imageContext{ID: "bbc3a0323522", Name: "busybox:latest", Digest: "sha256:4b8f", Size: "703.14 KB"}))
+	assert.Equal(t, `{"ID":"bbc3a0323522","Name":"busybox:latest","Digest":"sha256:4b8f","Size":"703.14 KB"}`+"\n", out.String())
+
+	// the error of executing template is reported.
+	w, err = newFormatWriter(out, "{{.Foo}}")
+	assert.NoError(t, err)
+	assert.Error(t, w.Write(imageContext{}))
+}
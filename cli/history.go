@@ -90,15 +90,10 @@ func (h *HistoryCommand) runHistory(args []string) error {
 			imageID = stringid.TruncateID(entry.ID)
 		}
 
+		created = formatHistoryCreated(entry.Created, h.flagHuman)
 		if h.flagHuman {
-			created, err = utils.FormatTimeInterval(0, entry.Created)
-			if err != nil {
-				return err
-			}
-			created = created + " ago"
 			size = utils.FormatSize(entry.Size)
 		} else {
-			created = time.Unix(0, entry.Created).Format(time.RFC3339)
 			size = strconv.FormatInt(entry.Size, 10)
 		}
 
@@ -116,6 +111,25 @@ e1ddd7948a1c   1 week ago   /bin/sh -c #(nop)  CMD ["sh"]                   0.00
 <missing>      1 week ago   /bin/sh -c #(nop) ADD file:96fda64a6b725d4...   716.06 KB  `
 }
 
+// formatHistoryCreated formats the created time of history in nanoseconds,
+// and returns N/A if the created time is unknown.
+func formatHistoryCreated(created int64, human bool) string {
+	if created <= 0 {
+		return "N/A"
+	}
+
+	if !human {
+		return time.Unix(0, created).Format(time.RFC3339)
+	}
+
+	interval, err := utils.FormatTimeInterval(0, created)
+	if err != nil {
+		// the clocks of daemon and client may be not synchronized.
+		return "Less than a second ago"
+	}
+	return interval + " ago"
+}
+
 // ellipsis truncates a string to fit within maxlen, and appends ellipsis (...).
 // For maxlen of 3 and lower, no ellipsis is appended.
 func ellipsis(s string, maxlen int) string {
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatHistoryCreated(t *testing.T) {
+	assert.Equal(t, "N/A", formatHistoryCreated(0, true))
+	assert.Equal(t, "N/A", formatHistoryCreated(0, false))
+
+	created := time.Now().Add(-2 * time.Hour)
+	assert.Equal(t, "2 hours ago", formatHistoryCreated(created.UnixNano(), true))
+	assert.Equal(t, created.Format(time.RFC3339), formatHistoryCreated(created.UnixNano(), false))
+
+	// the created time in the future
+	assert.Equal(t, "Less than a second ago", formatHistoryCreated(time.Now().Add(time.Hour).UnixNano(), true))
+}
+
+func TestEllipsis(t *testing.T) {
+	assert.Equal(t, "foo", ellipsis("foo", 3))
+	assert.Equal(t, "fo", ellipsis("foo", 2))
+	assert.Equal(t, "foo...", ellipsis("foobarbaz", 6))
+}
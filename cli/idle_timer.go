@@ -0,0 +1,60 @@
+package main
+
+import (
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// idleTimer calls the function once there is no activity for the timeout,
+// and any read from the readers wrapped by it is taken as activity.
+type idleTimer struct {
+	timeout time.Duration
+	timer   *time.Timer
+	fired   int32
+}
+
+// newIdleTimer starts an idleTimer which calls f when it is idle for timeout.
+func newIdleTimer(timeout time.Duration, f func()) *idleTimer {
+	t := &idleTimer{timeout: timeout}
+	t.timer = time.AfterFunc(timeout, func() {
+		atomic.StoreInt32(&t.fired, 1)
+		f()
+	})
+	return t
+}
+
+// Reader wraps r, so that reading data from r resets the timer.
+func (t *idleTimer) Reader(r io.Reader) io.Reader {
+	return &idleReader{r: r, timer: t}
+}
+
+// Fired returns whether the timer has been idle for the timeout.
+func (t *idleTimer) Fired() bool {
+	return atomic.LoadInt32(&t.fired) == 1
+}
+
+// Stop stops the timer.
+func (t *idleTimer) Stop() {
+	t.timer.Stop()
+}
+
+func (t *idleTimer) reset() {
+	if !t.Fired() {
+		t.timer.Reset(t.timeout)
+	}
+}
+
+type idleReader struct {
+	r     io.Reader
+	timer *idleTimer
+}
+
+// Read implements io.Reader interface.
+func (r *idleReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.timer.reset()
+	}
+	return n, err
+}
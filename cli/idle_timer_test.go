@@ -0,0 +1,37 @@
+package main
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdleTimer(t *testing.T) {
+	fired := make(chan struct{})
+	timer := newIdleTimer(100*time.Millisecond, func() { close(fired) })
+	defer timer.Stop()
+
+	pr, pw := io.Pipe()
+	r := timer.Reader(pr)
+	go ioutil.ReadAll(r)
+
+	// keep active for a while, which is longer than the timeout.
+	for i := 0; i < 5; i++ {
+		time.Sleep(50 * time.Millisecond)
+		_, err := io.Copy(pw, strings.NewReader("a"))
+		assert.NoError(t, err)
+	}
+	assert.False(t, timer.Fired())
+
+	select {
+	case <-fired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("idle timer is not fired")
+	}
+	assert.True(t, timer.Fired())
+	pw.Close()
+}
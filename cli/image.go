@@ -24,4 +24,6 @@ func (i *ImageMgmtCommand) Init(c *Cli) {
 	}
 
 	i.cli.AddCommand(i, &ImageInspectCommand{})
+	i.cli.AddCommand(i, &ImagePruneCommand{})
+	i.cli.AddCommand(i, &HistoryCommand{})
 }
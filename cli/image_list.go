@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/types"
@@ -31,6 +32,14 @@ type displayImage struct {
 	digest string
 }
 
+// imageContext is the object of image printed by --format.
+type imageContext struct {
+	ID     string
+	Name   string
+	Digest string
+	Size   string
+}
+
 // ImagesCommand use to implement 'images' command.
 type ImagesCommand struct {
 	baseCommand
@@ -40,6 +49,7 @@ type ImagesCommand struct {
 	flagDigest  bool
 	flagNoTrunc bool
 	flagFilter  []string
+	flagFormat  string
 }
 
 // Init initialize images command.
@@ -66,6 +76,7 @@ func (i *ImagesCommand) addFlags() {
 	flagSet.BoolVar(&i.flagDigest, "digest", false, "Show images with digest")
 	flagSet.BoolVar(&i.flagNoTrunc, "no-trunc", false, "Do not truncate output")
 	flagSet.StringSliceVarP(&i.flagFilter, "filter", "f", []string{}, "Filter output based on conditions provided, filter support reference, since, before")
+	flagSet.StringVar(&i.flagFormat, "format", "", formatFlagUsage)
 }
 
 // runImages is the entry of images container command.
@@ -78,6 +89,11 @@ func (i *ImagesCommand) runImages(args []string) error {
 		return err
 	}
 
+	formatter, err := newFormatWriter(os.Stdout, i.flagFormat)
+	if err != nil {
+		return err
+	}
+
 	imageList, err := apiClient.ImageList(ctx, imageFilterArgs)
 	if err != nil {
 		return fmt.Errorf("failed to get image list: %v", err)
@@ -94,6 +110,25 @@ func (i *ImagesCommand) runImages(args []string) error {
 		return nil
 	}
 
+	dimgs := make([]displayImage, 0, len(imageList))
+	for _, img := range imageList {
+		dimgs = append(dimgs, imageInfoToDisplayImages(img, i.flagNoTrunc)...)
+	}
+
+	if formatter != nil {
+		for _, dimg := range dimgs {
+			if err := formatter.Write(imageContext{
+				ID:     dimg.id,
+				Name:   dimg.name,
+				Digest: dimg.digest,
+				Size:   dimg.size.String(),
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	display := i.cli.NewTableDisplay()
 	if i.flagDigest {
 		display.AddRow([]string{"IMAGE ID", "IMAGE NAME", "DIGEST", "SIZE"})
@@ -101,11 +136,6 @@ func (i *ImagesCommand) runImages(args []string) error {
 		display.AddRow([]string{"IMAGE ID", "IMAGE NAME", "SIZE"})
 	}
 
-	dimgs := make([]displayImage, 0, len(imageList))
-	for _, img := range imageList {
-		dimgs = append(dimgs, imageInfoToDisplayImages(img, i.flagNoTrunc)...)
-	}
-
 	for _, dimg := range dimgs {
 		if i.flagDigest {
 			display.AddRow([]string{dimg.id, dimg.name, dimg.digest, dimg.size.String()})
@@ -211,5 +241,9 @@ IMAGE ID       IMAGE NAME                                           DIGEST
 $ pouch images --no-trunc
 IMAGE ID                                                                  IMAGE NAME                                           SIZE
 sha256:2cb0d9787c4dd17ef9eb03e512923bc4db10add190d3f84af63b744e353a9b34   registry.hub.docker.com/library/hello-world:latest   6.30 KB
-sha256:4ab4c602aa5eed5528a6620ff18a1dc4faef0e1ab3a5eddeddb410714478c67f   registry.hub.docker.com/library/hello-world:linux    5.25 KB`
+sha256:4ab4c602aa5eed5528a6620ff18a1dc4faef0e1ab3a5eddeddb410714478c67f   registry.hub.docker.com/library/hello-world:linux    5.25 KB
+
+$ pouch images --format "{{.ID}} {{.Name}}"
+bbc3a0323522 docker.io/library/busybox:latest
+b81f317384d7 docker.io/library/nginx:latest`
 }
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// imagePruneDescription is used to describe image prune command in detail and auto generate command doc.
+var imagePruneDescription = "Remove unused images. By default, only the dangling images, which have no tag " +
+	"and are not used by any container, are removed. With --all, all the images not used by any container are removed."
+
+const (
+	danglingImagesPruneWarning = "WARNING! This will remove all dangling images."
+	allImagesPruneWarning      = "WARNING! This will remove all images without at least one container associated to them."
+)
+
+// ImagePruneCommand use to implement 'image prune' command.
+type ImagePruneCommand struct {
+	baseCommand
+	all    bool
+	filter []string
+	force  bool
+	dryRun bool
+}
+
+// Init initialize "image prune" command.
+func (i *ImagePruneCommand) Init(c *Cli) {
+	i.cli = c
+	i.cmd = &cobra.Command{
+		Use:   "prune [OPTIONS]",
+		Short: "Remove unused images",
+		Long:  imagePruneDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return i.runPrune(args)
+		},
+		Example: i.example(),
+	}
+	i.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (i *ImagePruneCommand) addFlags() {
+	flagSet := i.cmd.Flags()
+	flagSet.BoolVarP(&i.all, "all", "a", false, "Remove all unused images, not just dangling ones")
+	flagSet.StringSliceVar(&i.filter, "filter", nil, "Provide filter values, support until=<timestamp> and label=<key>[=<value>]")
+	flagSet.BoolVarP(&i.force, "force", "f", false, "Do not prompt for confirmation")
+	flagSet.BoolVar(&i.dryRun, "dry-run", false, "Only show the images which would be removed")
+}
+
+// runPrune is the entry of image prune command.
+func (i *ImagePruneCommand) runPrune(args []string) error {
+	ctx := context.Background()
+	apiClient := i.cli.Client()
+
+	filter, err := filters.FromFilterOpts(i.filter)
+	if err != nil {
+		return err
+	}
+	if filter.Contains("dangling") {
+		return fmt.Errorf("invalid filter dangling, use --all instead")
+	}
+	if i.all {
+		filter.Add("dangling", "false")
+	}
+
+	if !i.force && !i.dryRun {
+		warning := danglingImagesPruneWarning
+		if i.all {
+			warning = allImagesPruneWarning
+		}
+
+		if !confirm(os.Stdin, os.Stdout, warning) {
+			return nil
+		}
+	}
+
+	report, err := apiClient.ImagePrune(ctx, filter, i.dryRun)
+	if err != nil {
+		return fmt.Errorf("failed to prune images: %v", err)
+	}
+
+	if len(report.ImagesDeleted) > 0 {
+		if i.dryRun {
+			fmt.Println("Would delete images:")
+		} else {
+			fmt.Println("Deleted images:")
+		}
+		for _, id := range report.ImagesDeleted {
+			fmt.Println(id)
+		}
+		fmt.Println()
+	}
+
+	if i.dryRun {
+		fmt.Printf("Total reclaimable space: %s\n", utils.FormatSize(report.SpaceReclaimed))
+	} else {
+		fmt.Printf("Total reclaimed space: %s\n", utils.FormatSize(report.SpaceReclaimed))
+	}
+	return nil
+}
+
+// confirm prints the warning and asks user to continue, it returns true only
+// if user answers yes.
+func confirm(in io.Reader, out io.Writer, warning string) bool {
+	fmt.Fprintf(out, "%s\nAre you sure you want to continue? [y/N] ", warning)
+
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// example shows examples in image prune command, and is used in auto-generated cli docs.
+func (i *ImagePruneCommand) example() string {
+	return `$ pouch image prune -a -f --filter until=24h
+Deleted images:
+sha256:8c811b4aec35f259572d0f79207bc0678df4c736eeec50bc9fec37ed936a472a
+sha256:e216a057b1cb1efc11f8a268f37ef62083e70b1b38323ba252e25ac88904a7e8
+
+Total reclaimed space: 1.32 MB`
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirm(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected bool
+	}{
+		{input: "y\n", expected: true},
+		{input: "Yes\n", expected: true},
+		{input: " y ", expected: true},
+		{input: "n\n", expected: false},
+		{input: "\n", expected: false},
+		{input: "", expected: false},
+		{input: "yep\n", expected: false},
+	} {
+		out := &bytes.Buffer{}
+		assert.Equal(t, tc.expected, confirm(strings.NewReader(tc.input), out, "WARNING!"), tc.input)
+		assert.Equal(t, "WARNING!\nAre you sure you want to continue? [y/N] ", out.String())
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// importDescription is used to describe import command in detail and auto generate command doc.
+var importDescription = "Import the contents from a tarball, which may be compressed, to create a single-layer " +
+	"image. The tarball is usually exported from a container by export command."
+
+// ImportCommand use to implement 'import' command.
+type ImportCommand struct {
+	baseCommand
+	changes []string
+}
+
+// Init initialize import command.
+func (i *ImportCommand) Init(c *Cli) {
+	i.cli = c
+	i.cmd = &cobra.Command{
+		Use:   "import [OPTIONS] file|- REPOSITORY[:TAG]",
+		Short: "Import the contents from a tarball to create an image",
+		Long:  importDescription,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return i.runImport(args)
+		},
+		Example: importExample(),
+	}
+	i.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (i *ImportCommand) addFlags() {
+	flagSet := i.cmd.Flags()
+	flagSet.StringArrayVarP(&i.changes, "change", "c", nil, "Apply Dockerfile instruction to the created image, support CMD, ENTRYPOINT, ENV, USER and WORKDIR")
+}
+
+// runImport is the entry of import command.
+func (i *ImportCommand) runImport(args []string) error {
+	ctx := context.Background()
+	apiClient := i.cli.Client()
+
+	var in io.Reader = os.Stdin
+	if args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	image, err := apiClient.ImageImport(ctx, args[1], i.changes, in)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(image.ID)
+	return nil
+}
+
+// importExample shows examples in import command, and is used in auto-generated cli docs.
+func importExample() string {
+	return `$ pouch export foo | pouch import -c "CMD /bin/sh" -c "ENV foo=bar" - foo-rootfs:v1
+sha256:0de2c33e5d7b81aa6b81bba5aacb13e2cb4e8e74e1fd9c0c6e2fd5efc4b43ba4
+$ pouch run --rm foo-rootfs:v1 env | grep foo
+foo=bar`
+}
@@ -2,29 +2,42 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"os"
+	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/cli/inspect"
+	"github.com/alibaba/pouch/client"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 // inspectDescription is used to describe inspect command in detail and auto generate command doc.
-var inspectDescription = "Return detailed information on Pouch container"
+var inspectDescription = "Return detailed information on Pouch objects, which may be containers, images, volumes or networks. " +
+	"Without --type, the object types are tried in the order of container, image, volume and network, " +
+	"and the object of the first matched type is inspected. " +
+	"With --type, only the object of the given type is looked up."
+
+// inspectTypes is the object types supported by inspect command, in the
+// order of lookup.
+var inspectTypes = []string{"container", "image", "volume", "network"}
 
 // InspectCommand is used to implement 'inspect' command.
 type InspectCommand struct {
 	baseCommand
-	format string
+	format      string
+	inspectType string
 }
 
 // Init initializes InspectCommand command.
 func (p *InspectCommand) Init(c *Cli) {
 	p.cli = c
 	p.cmd = &cobra.Command{
-		Use:   "inspect [OPTIONS] CONTAINER",
-		Short: "Get the detailed information of container",
+		Use:   "inspect [OPTIONS] CONTAINER|IMAGE|VOLUME|NETWORK [CONTAINER|IMAGE|VOLUME|NETWORK...]",
+		Short: "Get the detailed information of containers, images, volumes or networks",
 		Long:  inspectDescription,
 		Args:  cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -38,6 +51,7 @@ func (p *InspectCommand) Init(c *Cli) {
 // addFlags adds flags for specific command.
 func (p *InspectCommand) addFlags() {
 	p.cmd.Flags().StringVarP(&p.format, "format", "f", "", "Format the output using the given go template")
+	p.cmd.Flags().StringVar(&p.inspectType, "type", "", "Only inspect the object of the given type, which is one of "+strings.Join(inspectTypes, ", "))
 }
 
 // runInspect is the entry of InspectCommand command.
@@ -45,17 +59,80 @@ func (p *InspectCommand) runInspect(args []string) error {
 	ctx := context.Background()
 	apiClient := p.cli.Client()
 
-	getRefFunc := func(ref string) (interface{}, error) {
-		res, err := apiClient.ContainerGet(ctx, ref)
-		if err != nil {
-			return nil, err
-		}
-		return convContainerJSONToInspectContainerJSON(res), nil
+	getters := map[string]inspect.GetRefFunc{
+		"container": func(ref string) (interface{}, error) {
+			res, err := apiClient.ContainerGet(ctx, ref)
+			if err != nil {
+				return nil, err
+			}
+			return convContainerJSONToInspectContainerJSON(res), nil
+		},
+		"image": func(ref string) (interface{}, error) {
+			return apiClient.ImageInspect(ctx, ref)
+		},
+		"volume": func(ref string) (interface{}, error) {
+			return apiClient.VolumeInspect(ctx, ref)
+		},
+		"network": func(ref string) (interface{}, error) {
+			return apiClient.NetworkInspect(ctx, ref)
+		},
+	}
+
+	getRefFunc, err := inspectGetRefFunc(p.inspectType, getters)
+	if err != nil {
+		return err
 	}
 
 	return inspect.Inspect(os.Stdout, args, p.format, getRefFunc)
 }
 
+// inspectGetRefFunc returns the function fetching the object of the given
+// type, or trying the types in order until one matches if the type is empty.
+func inspectGetRefFunc(objType string, getters map[string]inspect.GetRefFunc) (inspect.GetRefFunc, error) {
+	if objType != "" {
+		getter, ok := getters[objType]
+		if !ok {
+			return nil, fmt.Errorf("invalid type %q, must be one of %s", objType, strings.Join(inspectTypes, ", "))
+		}
+		return getter, nil
+	}
+
+	return func(ref string) (interface{}, error) {
+		var notFound, firstErr error
+		for _, t := range inspectTypes {
+			res, err := getters[t](ref)
+			if err == nil {
+				return res, nil
+			}
+
+			if !isNotFoundError(err) {
+				if firstErr == nil {
+					firstErr = err
+				}
+			} else if notFound == nil {
+				notFound = err
+			}
+		}
+
+		if firstErr != nil {
+			return nil, firstErr
+		}
+		// reports the not found error of the first type.
+		return nil, notFound
+	}, nil
+}
+
+// isNotFoundError returns true if the response error is 404.
+func isNotFoundError(err error) bool {
+	switch e := errors.Cause(err).(type) {
+	case client.NotFoundError:
+		return true
+	case client.RespError:
+		return e.Code() == http.StatusNotFound
+	}
+	return false
+}
+
 // inspectExample shows examples in inspect command, and is used in auto-generated cli docs.
 func inspectExample() string {
 	return `$ pouch inspect 08e
@@ -88,7 +165,9 @@ func inspectExample() string {
 	  "HostConfig": null,
 	  "HostRootPath": ""
 	}
-]`
+]
+$ pouch inspect --type image -f "{{.ID}}" busybox
+sha256:bbc3a03235220b170ba48a157dd097dd1379299370e1ed99ce976df0355d24f0`
 }
 
 type inspectContainerJSON struct {
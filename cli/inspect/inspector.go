@@ -45,7 +45,7 @@ func NewTemplateInspectorFromString(out io.Writer, tmplStr string) (Inspector, e
 		tmplStr = strings.Replace(tmplStr, ".Id", ".ID", -1)
 	}
 
-	tmpl, err := templates.Parse(tmplStr)
+	tmpl, err := templates.ParseFormat(tmplStr)
 	if err != nil {
 		return nil, errors.Errorf("Parse template String error: %s", err)
 	}
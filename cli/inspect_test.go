@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/alibaba/pouch/cli/inspect"
+	"github.com/alibaba/pouch/client"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fakeInspectGetters(objects map[string]map[string]string, failures map[string]error) map[string]inspect.GetRefFunc {
+	getters := make(map[string]inspect.GetRefFunc)
+	for _, t := range inspectTypes {
+		t := t
+		getters[t] = func(ref string) (interface{}, error) {
+			if err, ok := failures[t]; ok {
+				return nil, err
+			}
+			if obj, ok := objects[t][ref]; ok {
+				return obj, nil
+			}
+			return nil, client.NotFoundError{}
+		}
+	}
+	return getters
+}
+
+// recordInspectGetters records the types looked up by the getters.
+func recordInspectGetters(getters map[string]inspect.GetRefFunc, looked *[]string) map[string]inspect.GetRefFunc {
+	for _, typ := range inspectTypes {
+		typ, getter := typ, getters[typ]
+		getters[typ] = func(ref string) (interface{}, error) {
+			*looked = append(*looked, typ)
+			return getter(ref)
+		}
+	}
+	return getters
+}
+
+func TestInspectGetRefFunc(t *testing.T) {
+	objects := map[string]map[string]string{
+		"container": {"abc": "container abc"},
+		"image":     {"abc": "image abc", "busybox": "image busybox"},
+		"network":   {"bridge": "network bridge"},
+	}
+	var looked []string
+
+	getRef, err := inspectGetRefFunc("", recordInspectGetters(fakeInspectGetters(objects, nil), &looked))
+	assert.NoError(t, err)
+
+	res, err := getRef("busybox")
+	assert.NoError(t, err)
+	assert.Equal(t, "image busybox", res)
+	assert.Equal(t, []string{"container", "image"}, looked)
+
+	// the lookup stops at the first matched type.
+	looked = nil
+	res, err = getRef("abc")
+	assert.NoError(t, err)
+	assert.Equal(t, "container abc", res)
+	assert.Equal(t, []string{"container"}, looked)
+
+	_, err = getRef("missing")
+	assert.Equal(t, client.NotFoundError{}, err)
+
+	// the specified type only looks up the objects of the type.
+	looked = nil
+	getRef, err = inspectGetRefFunc("image", recordInspectGetters(fakeInspectGetters(objects, nil), &looked))
+	assert.NoError(t, err)
+	res, err = getRef("abc")
+	assert.NoError(t, err)
+	assert.Equal(t, "image abc", res)
+	assert.Equal(t, []string{"image"}, looked)
+
+	_, err = inspectGetRefFunc("foo", fakeInspectGetters(objects, nil))
+	assert.Error(t, err)
+
+	// the failure of other types doesn't affect the matched object.
+	getRef, err = inspectGetRefFunc("", fakeInspectGetters(objects, map[string]error{
+		"volume": fmt.Errorf("volume failure"),
+	}))
+	assert.NoError(t, err)
+	res, err = getRef("bridge")
+	assert.NoError(t, err)
+	assert.Equal(t, "network bridge", res)
+
+	_, err = getRef("missing")
+	assert.EqualError(t, err, "volume failure")
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"syscall"
+
+	"github.com/docker/docker/pkg/signal"
+	"github.com/spf13/cobra"
+)
+
+// killDescription is used to describe kill command in detail and auto generate command doc.
+var killDescription = "Send a signal to one or more running containers in Pouchd. " +
+	"The signal is sent to the main process of container, SIGKILL is sent by default. " +
+	"The signal can be specified by name with or without the SIG prefix, or by number."
+
+// KillCommand use to implement 'kill' command, it sends signal to one or more containers.
+type KillCommand struct {
+	baseCommand
+	signal   string
+	parallel int
+}
+
+// Init initialize kill command.
+func (k *KillCommand) Init(c *Cli) {
+	k.cli = c
+	k.cmd = &cobra.Command{
+		Use:   "kill [OPTIONS] CONTAINER [CONTAINER...]",
+		Short: "Kill one or more running containers",
+		Long:  killDescription,
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return k.runKill(args)
+		},
+		Example: killExample(),
+	}
+	k.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (k *KillCommand) addFlags() {
+	flagSet := k.cmd.Flags()
+	flagSet.StringVarP(&k.signal, "signal", "s", "KILL", "Signal to send to the container")
+	flagSet.IntVar(&k.parallel, "parallel", 1, "Number of containers to kill concurrently")
+}
+
+// runKill is the entry of kill command.
+func (k *KillCommand) runKill(args []string) error {
+	sig, err := parseSignal(k.signal)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	apiClient := k.cli.Client()
+
+	return runOnContainers(args, k.parallel, func(name string) error {
+		return apiClient.ContainerKill(ctx, name, strconv.Itoa(int(sig)))
+	})
+}
+
+// parseSignal parses the signal in the format of name or number, and the
+// signal must be known on the platform.
+func parseSignal(rawSignal string) (syscall.Signal, error) {
+	sig, err := signal.ParseSignal(rawSignal)
+	if err != nil || !signal.ValidSignalForPlatform(sig) {
+		return -1, fmt.Errorf("invalid signal: %s", rawSignal)
+	}
+	return sig, nil
+}
+
+// killExample shows examples in kill command, and is used in auto-generated cli docs.
+func killExample() string {
+	return `$ pouch ps
+Name   ID       Status          Created          Image                                            Runtime
+foo2   87259c   Up 25 seconds   26 seconds ago   registry.hub.docker.com/library/busybox:latest   runc
+foo1   77188c   Up 46 seconds   47 seconds ago   registry.hub.docker.com/library/busybox:latest   runc
+$ pouch kill -s TERM foo1 foo2
+foo1
+foo2`
+}
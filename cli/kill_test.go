@@ -0,0 +1,25 @@
+package main
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSignal(t *testing.T) {
+	for _, raw := range []string{"SIGTERM", "TERM", "term", "15"} {
+		sig, err := parseSignal(raw)
+		assert.NoError(t, err, raw)
+		assert.Equal(t, syscall.SIGTERM, sig, raw)
+	}
+
+	sig, err := parseSignal("KILL")
+	assert.NoError(t, err)
+	assert.Equal(t, syscall.SIGKILL, sig)
+
+	for _, raw := range []string{"", "0", "-1", "999", "SIGFOO", "FOO"} {
+		_, err := parseSignal(raw)
+		assert.Error(t, err, raw)
+	}
+}
@@ -10,7 +10,7 @@ import (
 
 // loadDescription is used to describe load command in detail and auto generate command doc.
 var loadDescription = "load a set of images by tar stream.\n" +
-	"for docker image format, no need to set the image name because pouch" +
+	"for docker image format and the images saved by pouch, no need to set the image name because pouch" +
 	" will parse image name from tar stream."
 
 // LoadCommand use to implement 'load' command.
@@ -38,7 +38,7 @@ func (l *LoadCommand) Init(c *Cli) {
 // addFlags adds flags for specific command.
 func (l *LoadCommand) addFlags() {
 	flagSet := l.cmd.Flags()
-	flagSet.StringVarP(&l.input, "input", "i", "", "Read from tar archive file, instead of STDIN")
+	flagSet.StringVarP(&l.input, "input", "i", "", "Read from tar archive file, instead of STDIN, \"-\" means STDIN")
 }
 
 // runLoad is the entry of load command.
@@ -51,7 +51,7 @@ func (l *LoadCommand) runLoad(args []string) error {
 		imageName           = ""
 	)
 
-	if l.input != "" {
+	if l.input != "" && l.input != "-" {
 		file, err := os.Open(l.input)
 		if err != nil {
 			return err
@@ -3,8 +3,12 @@ package main
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/client"
 	"github.com/alibaba/pouch/credential"
 	"github.com/alibaba/pouch/pkg/term"
 
@@ -12,14 +16,17 @@ import (
 )
 
 // loginDescription is used to describe login command and auto generate command doc.
-var loginDescription = "\nlogin to a v1/v2 registry with the provided credentials."
+var loginDescription = "\nlogin to a v1/v2 registry with the provided credentials. " +
+	"If no server is specified, the first registry mirror of daemon is used, or the default registry if no mirror is configured. " +
+	"The credentials are stored in $HOME/.pouch/config.json, or in the credential helper configured by credsStore or credHelpers of the file."
 
 // LoginCommand use to implement 'login' command.
 type LoginCommand struct {
 	baseCommand
 
-	username string
-	password string
+	username      string
+	password      string
+	passwordStdin bool
 }
 
 // Init initialize login command.
@@ -45,10 +52,28 @@ func (l *LoginCommand) addFlags() {
 
 	flagSet.StringVarP(&l.username, "username", "u", "", "username for registry")
 	flagSet.StringVarP(&l.password, "password", "p", "", "password for registry")
+	flagSet.BoolVar(&l.passwordStdin, "password-stdin", false, "read password for registry from stdin")
 }
 
 // runLogin is the entry of login command.
 func (l *LoginCommand) runLogin(args []string) error {
+	if l.passwordStdin {
+		if l.password != "" {
+			return fmt.Errorf("--password and --password-stdin are mutually exclusive")
+		}
+		if l.username == "" {
+			return fmt.Errorf("must provide --username with --password-stdin")
+		}
+
+		data, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		l.password = strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+	} else if l.password != "" {
+		fmt.Fprintln(os.Stderr, "WARNING! Using --password via the CLI is insecure. Use --password-stdin.")
+	}
+
 	auth := configureAuth(l.username, l.password)
 	if len(args) > 0 {
 		auth.ServerAddress = args[0]
@@ -57,11 +82,8 @@ func (l *LoginCommand) runLogin(args []string) error {
 	ctx := context.Background()
 	apiClient := l.cli.Client()
 
-	// error will be ignored here, cause registry address can be null.
 	if auth.ServerAddress == "" {
-		if info, err := apiClient.SystemInfo(ctx); err == nil {
-			auth.ServerAddress = info.DefaultRegistry
-		}
+		auth.ServerAddress = defaultRegistryServer(ctx, apiClient)
 	}
 
 	resp, err := apiClient.RegistryLogin(ctx, auth)
@@ -76,6 +98,31 @@ func (l *LoginCommand) runLogin(args []string) error {
 	return credential.Save(auth)
 }
 
+// defaultRegistryServer returns the first registry mirror of daemon, or the
+// default registry if no mirror is configured.
+func defaultRegistryServer(ctx context.Context, apiClient client.CommonAPIClient) string {
+	// error will be ignored here, cause registry address can be null.
+	info, err := apiClient.SystemInfo(ctx)
+	if err != nil {
+		return ""
+	}
+
+	if info.RegistryConfig != nil && len(info.RegistryConfig.Mirrors) > 0 {
+		return info.RegistryConfig.Mirrors[0]
+	}
+	return info.DefaultRegistry
+}
+
+// registryServer returns the registry of the image name. The image name
+// without registry is pulled from the default registry server of daemon.
+func registryServer(ctx context.Context, apiClient client.CommonAPIClient, name string) string {
+	idx := strings.IndexRune(name, '/')
+	if idx != -1 && strings.ContainsAny(name[:idx], ".:") {
+		return name[:idx]
+	}
+	return defaultRegistryServer(ctx, apiClient)
+}
+
 // configureAuth ensures that username and password is given.
 func configureAuth(username, password string) *types.AuthConfig {
 	if username == "" {
@@ -115,6 +162,9 @@ func disableEcho(echo bool, read *string) {
 
 // loginExample shows examples in login command, and is used in auto-generated cli docs.
 func loginExample() string {
-	return `$ pouch login -u $username -p $password
+	return `$ pouch login -u $username
+Password:
+Login Succeeded
+$ cat ~/password.txt | pouch login -u $username --password-stdin reg.pouch.io
 Login Succeeded`
 }
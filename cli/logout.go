@@ -42,13 +42,7 @@ func (l *LogoutCommand) runLogout(args []string) error {
 	}
 
 	if registry == "" {
-		ctx := context.Background()
-		info, err := l.cli.Client().SystemInfo(ctx)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Fail to get default registry: %s\n", err)
-			return err
-		}
-		registry = info.DefaultRegistry
+		registry = defaultRegistryServer(context.Background(), l.cli.Client())
 	}
 
 	if !credential.Exist(registry) {
@@ -1,16 +1,32 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/client"
+	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/utils"
 	"github.com/docker/docker/pkg/stdcopy"
 
 	"github.com/spf13/cobra"
 )
 
+const (
+	// logsReconnectTimes is the max times of reconnecting the followed logs
+	// in a row without receiving any log line.
+	logsReconnectTimes = 10
+	// logsReconnectInterval is the first backoff interval of reconnecting.
+	logsReconnectInterval = 100 * time.Millisecond
+	// logsReconnectMaxInterval is the max backoff interval of reconnecting.
+	logsReconnectMaxInterval = 5 * time.Second
+)
+
 // logsDescription is used to describe logs command in detail and auto generate command doc.
 var logsDescription = "Get container's logs"
 
@@ -23,6 +39,9 @@ type LogsCommand struct {
 	tail       string
 	until      string
 	timestamps bool
+
+	reconnect   bool
+	noReconnect bool
 }
 
 // Init initialize logs command.
@@ -50,12 +69,19 @@ func (lc *LogsCommand) addFlags() {
 	flagSet.StringVarP(&lc.tail, "tail", "", "all", "Number of lines to show from the end of the logs default \"all\"")
 	flagSet.BoolVarP(&lc.timestamps, "timestamps", "t", false, "Show timestamps")
 	flagSet.BoolVar(&lc.details, "details", false, "Show extra details provided to logs")
+	flagSet.BoolVar(&lc.reconnect, "reconnect", true, "Reconnect from the last received line if the followed logs stream is broken")
+	flagSet.BoolVar(&lc.noReconnect, "no-reconnect", false, "Do not reconnect if the followed logs stream is broken")
 }
 
 // runLogs is the entry of LogsCommand command.
 func (lc *LogsCommand) runLogs(args []string) error {
 	containerName := args[0]
 
+	flags := lc.cmd.Flags()
+	if flags.Changed("reconnect") && lc.reconnect && lc.noReconnect {
+		return fmt.Errorf("Conflicting options: --reconnect and --no-reconnect")
+	}
+
 	ctx := context.Background()
 	apiClient := lc.cli.Client()
 
@@ -70,26 +96,164 @@ func (lc *LogsCommand) runLogs(args []string) error {
 		Details:    lc.details,
 	}
 
-	body, err := apiClient.ContainerLogs(ctx, containerName, opts)
+	c, err := apiClient.ContainerGet(ctx, containerName)
 	if err != nil {
 		return err
 	}
 
-	defer body.Close()
+	// the stream with --until ends by design, so it is never reconnected.
+	if lc.follow && lc.until == "" && lc.reconnect && !lc.noReconnect {
+		return followLogs(ctx, apiClient, containerName, opts, c.Config.Tty, os.Stdout, os.Stderr)
+	}
 
-	c, err := apiClient.ContainerGet(ctx, containerName)
+	body, err := apiClient.ContainerLogs(ctx, containerName, opts)
 	if err != nil {
 		return err
 	}
+	defer body.Close()
+
+	return copyLogs(body, c.Config.Tty, os.Stdout, os.Stderr)
+}
 
-	if c.Config.Tty {
-		_, err = io.Copy(os.Stdout, body)
+// copyLogs copies the logs stream to stdout and stderr. The output is
+// written as the stream is read, so a slow consumer slows down the daemon
+// instead of being buffered.
+func copyLogs(body io.Reader, tty bool, stdout, stderr io.Writer) error {
+	var err error
+	if tty {
+		_, err = io.Copy(stdout, body)
 	} else {
-		_, err = stdcopy.StdCopy(os.Stdout, os.Stderr, body)
+		_, err = stdcopy.StdCopy(stdout, stderr, body)
+	}
+	return err
+}
+
+// followLogs follows the logs of container, and reconnects from the last
+// received line if the stream ends while the container is still running,
+// which means the stream is broken rather than finished.
+func followLogs(ctx context.Context, apiClient client.CommonAPIClient, name string, opts types.ContainerLogsOptions, tty bool, stdout, stderr io.Writer) error {
+	// the timestamps are always requested to know where to resume from, and
+	// they are stripped if not asked for.
+	cursor := &logsCursor{}
+	outWriter := &logsLineWriter{w: stdout, cursor: cursor, timestamps: opts.Timestamps}
+	errWriter := &logsLineWriter{w: stderr, cursor: cursor, timestamps: opts.Timestamps}
+	opts.Timestamps = true
+
+	interval := logsReconnectInterval
+	for attempts := 0; ; attempts++ {
+		lines := cursor.lines
+
+		body, err := apiClient.ContainerLogs(ctx, name, opts)
+		if err == nil {
+			err = copyLogs(body, tty, outWriter, errWriter)
+			body.Close()
+		}
+
+		c, getErr := apiClient.ContainerGet(ctx, name)
+		if getErr != nil || c.State == nil || !c.State.Running {
+			// the partial lines are kept until the stream is finished.
+			outWriter.flush()
+			errWriter.flush()
+			if err == nil {
+				err = getErr
+			}
+			return err
+		}
+
+		if cursor.lines > lines {
+			attempts, interval = 0, logsReconnectInterval
+		}
+		if attempts >= logsReconnectTimes {
+			return fmt.Errorf("failed to follow logs of %s after %d reconnections: %v", name, attempts, err)
+		}
+		log.With(ctx).Debugf("logs stream of %s is broken, reconnect since %v: %v", name, cursor.last, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+		if interval *= 2; interval > logsReconnectMaxInterval {
+			interval = logsReconnectMaxInterval
+		}
+
+		// the partial lines are sent again by the reconnected stream.
+		outWriter.reset()
+		errWriter.reset()
+		if !cursor.last.IsZero() {
+			opts.Since = fmt.Sprintf("%d.%09d", cursor.last.Unix(), cursor.last.Nanosecond())
+			opts.Tail = "all"
+		}
+	}
+}
+
+// logsCursor records the timestamp of the last log line written, which the
+// reconnected stream resumes from.
+type logsCursor struct {
+	last  time.Time
+	lines int
+}
+
+// logsLineWriter writes the timestamped log lines, and drops the lines
+// which are not after the cursor, since the reconnected stream starts from
+// the last written line again.
+type logsLineWriter struct {
+	w          io.Writer
+	cursor     *logsCursor
+	timestamps bool
+	buf        []byte
+}
+
+// Write implements io.Writer interface.
+func (lw *logsLineWriter) Write(p []byte) (int, error) {
+	lw.buf = append(lw.buf, p...)
+	for {
+		i := bytes.IndexByte(lw.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+
+		if err := lw.writeLine(lw.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		lw.buf = lw.buf[i+1:]
 	}
+}
+
+func (lw *logsLineWriter) writeLine(line []byte) error {
+	if i := bytes.IndexByte(line, ' '); i > 0 {
+		if ts, err := time.Parse(utils.TimeLayout, string(line[:i])); err == nil {
+			if !ts.After(lw.cursor.last) {
+				return nil
+			}
+			lw.cursor.last = ts
+			lw.cursor.lines++
+
+			if !lw.timestamps {
+				line = line[i+1:]
+			}
+		}
+	}
+
+	_, err := lw.w.Write(line)
 	return err
 }
 
+// flush writes the partial line.
+func (lw *logsLineWriter) flush() error {
+	if len(lw.buf) == 0 {
+		return nil
+	}
+	err := lw.writeLine(lw.buf)
+	lw.reset()
+	return err
+}
+
+// reset drops the partial line.
+func (lw *logsLineWriter) reset() {
+	lw.buf = lw.buf[:0]
+}
+
 // logsExample shows examples in logs command, and is used in auto-generated cli docs.
 func logsExample() string {
 	return `$ pouch ps 
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/client"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// brokenReader returns err after the data is read.
+type brokenReader struct {
+	r   io.Reader
+	err error
+}
+
+func (b *brokenReader) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	if err == io.EOF {
+		return n, b.err
+	}
+	return n, err
+}
+
+// fakeLogsClient mocks the logs related methods of client.CommonAPIClient.
+type fakeLogsClient struct {
+	client.CommonAPIClient
+
+	// streams is the sequence of logs streams returned one by one, and
+	// the container is stopped once the last one is returned.
+	streams []io.Reader
+	opts    []types.ContainerLogsOptions
+}
+
+func (f *fakeLogsClient) ContainerLogs(ctx context.Context, name string, options types.ContainerLogsOptions) (io.ReadCloser, error) {
+	f.opts = append(f.opts, options)
+	return ioutil.NopCloser(f.streams[len(f.opts)-1]), nil
+}
+
+func (f *fakeLogsClient) ContainerGet(ctx context.Context, name string) (*types.ContainerJSON, error) {
+	return &types.ContainerJSON{
+		State: &types.ContainerState{Running: len(f.opts) < len(f.streams)},
+	}, nil
+}
+
+func TestFollowLogsReconnect(t *testing.T) {
+	apiClient := &fakeLogsClient{
+		streams: []io.Reader{
+			&brokenReader{
+				r:   strings.NewReader("2018-01-01T00:00:01.000000001Z one\n2018-01-01T00:00:02Z tw"),
+				err: errors.New("connection reset by peer"),
+			},
+			// the reconnected stream starts from the last received line.
+			strings.NewReader("2018-01-01T00:00:01.000000001Z one\n2018-01-01T00:00:02Z two\n2018-01-01T00:00:03Z three"),
+		},
+	}
+
+	var stdout bytes.Buffer
+	err := followLogs(context.Background(), apiClient, "foo", types.ContainerLogsOptions{Follow: true, Tail: "1"}, true, &stdout, ioutil.Discard)
+	assert.NoError(t, err)
+	assert.Equal(t, "one\ntwo\nthree", stdout.String())
+
+	assert.Len(t, apiClient.opts, 2)
+	assert.True(t, apiClient.opts[0].Timestamps)
+	assert.Equal(t, "1", apiClient.opts[0].Tail)
+	assert.Equal(t, "1514764801.000000001", apiClient.opts[1].Since)
+	assert.Equal(t, "all", apiClient.opts[1].Tail)
+}
+
+func TestLogsLineWriterTimestamps(t *testing.T) {
+	var out bytes.Buffer
+	cursor := &logsCursor{}
+	lw := &logsLineWriter{w: &out, cursor: cursor, timestamps: true}
+
+	lw.Write([]byte("2018-01-01T00:00:01Z one\n2018-01-01T00:"))
+	lw.Write([]byte("00:01Z dup\nno timestamp\n"))
+	assert.Equal(t, "2018-01-01T00:00:01Z one\nno timestamp\n", out.String())
+	assert.Equal(t, 1, cursor.lines)
+}
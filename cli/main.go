@@ -34,6 +34,8 @@ func main() {
 	cli.AddCommand(base, &TagCommand{})
 	cli.AddCommand(base, &LoadCommand{})
 	cli.AddCommand(base, &SaveCommand{})
+	cli.AddCommand(base, &ExportCommand{})
+	cli.AddCommand(base, &ImportCommand{})
 	cli.AddCommand(base, &HistoryCommand{})
 	cli.AddCommand(base, &SearchCommand{})
 
@@ -41,18 +43,21 @@ func main() {
 	cli.AddCommand(base, &RenameCommand{})
 	cli.AddCommand(base, &PauseCommand{})
 	cli.AddCommand(base, &UnpauseCommand{})
+	cli.AddCommand(base, &KillCommand{})
 	cli.AddCommand(base, &RunCommand{})
 	cli.AddCommand(base, &LoginCommand{})
 	cli.AddCommand(base, &UpdateCommand{})
 	cli.AddCommand(base, &LogoutCommand{})
 	cli.AddCommand(base, &UpgradeCommand{})
 	cli.AddCommand(base, &TopCommand{})
+	cli.AddCommand(base, &DiffCommand{})
 	cli.AddCommand(base, &LogsCommand{})
 	cli.AddCommand(base, &RemountLxcfsCommand{})
 	cli.AddCommand(base, &WaitCommand{})
 	cli.AddCommand(base, &DaemonUpdateCommand{})
 	cli.AddCommand(base, &CheckpointCommand{})
 	cli.AddCommand(base, &EventsCommand{})
+	cli.AddCommand(base, &SystemCommand{})
 	cli.AddCommand(base, &CommitCommand{})
 	cli.AddCommand(base, &StatsCommand{})
 	cli.AddCommand(base, &BuildCommand{})
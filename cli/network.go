@@ -6,6 +6,8 @@ import (
 	"os"
 	"strings"
 
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/opts"
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/cli/inspect"
 	"github.com/alibaba/pouch/pkg/log"
@@ -43,6 +45,7 @@ func (n *NetworkCommand) Init(c *Cli) {
 	c.AddCommand(n, &NetworkListCommand{})
 	c.AddCommand(n, &NetworkConnectCommand{})
 	c.AddCommand(n, &NetworkDisconnectCommand{})
+	c.AddCommand(n, &NetworkPruneCommand{})
 }
 
 // networkCreateDescription is used to describe network create command in detail and auto generate command doc.
@@ -60,9 +63,13 @@ type NetworkCreateCommand struct {
 	ipamDriver string
 	ipamOpts   []string
 	subnet     string
+	auxAddress []string
 	enableIPv6 bool
 	options    []string
 	labels     []string
+
+	// deprecatedOptions is set by the deprecated --option, and merged into options.
+	deprecatedOptions []string
 }
 
 // Init initializes NetworkCreateCommand command.
@@ -92,10 +99,13 @@ func (n *NetworkCreateCommand) addFlags() {
 	flagSet.StringVar(&n.gateway, "gateway", "", "the gateway of network")
 	flagSet.StringVar(&n.ipRange, "ip-range", "", "the range of network's ip")
 	flagSet.StringVar(&n.subnet, "subnet", "", "the subnet of network")
+	flagSet.StringSliceVar(&n.auxAddress, "aux-address", nil, "the auxiliary ipv4 or ipv6 addresses used by network driver, format is name=ip")
 	flagSet.StringVar(&n.ipamDriver, "ipam-driver", "default", "the ipam driver of network")
 	flagSet.StringSliceVarP(&n.ipamOpts, "ipam-opt", "", nil, "the ipam driver options of network")
 	flagSet.BoolVar(&n.enableIPv6, "enable-ipv6", false, "enable ipv6 network")
-	flagSet.StringSliceVarP(&n.options, "option", "o", nil, "create network with options")
+	flagSet.StringSliceVarP(&n.options, "opt", "o", nil, "create network with driver options")
+	flagSet.StringSliceVar(&n.deprecatedOptions, "option", nil, "create network with driver options")
+	flagSet.MarkDeprecated("option", "please use --opt instead")
 	flagSet.StringSliceVarP(&n.labels, "label", "l", nil, "create network with labels")
 }
 
@@ -134,7 +144,7 @@ func (n *NetworkCreateCommand) buildNetworkCreateRequest(name string) (*types.Ne
 		return nil, fmt.Errorf("network driver cannot be empty")
 	}
 
-	options, err := parseSliceToMap(n.options)
+	options, err := parseSliceToMap(append(n.deprecatedOptions, n.options...))
 	if err != nil {
 		return nil, err
 	}
@@ -155,9 +165,14 @@ func (n *NetworkCreateCommand) buildNetworkCreateRequest(name string) (*types.Ne
 		Config:  []types.IPAMConfig{},
 	}
 
-	if n.subnet != "" || n.gateway != "" || n.ipRange != "" {
+	auxAddress, err := parseSliceToMap(n.auxAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.subnet != "" || n.gateway != "" || n.ipRange != "" || len(auxAddress) > 0 {
 		ipamConfig := types.IPAMConfig{
-			AuxAddress: make(map[string]string),
+			AuxAddress: auxAddress,
 			Subnet:     n.subnet,
 			Gateway:    n.gateway,
 			IPRange:    n.ipRange,
@@ -165,6 +180,10 @@ func (n *NetworkCreateCommand) buildNetworkCreateRequest(name string) (*types.Ne
 		ipam.Config = append(ipam.Config, ipamConfig)
 	}
 
+	if err := opts.ValidateIPAMConfigs(ipam.Config); err != nil {
+		return nil, err
+	}
+
 	networkCreate := types.NetworkCreate{
 		Driver:         n.driver,
 		EnableIPV6:     n.enableIPv6,
@@ -198,7 +217,9 @@ func parseSliceToMap(slices []string) (map[string]string, error) {
 // networkCreateExample shows examples in network create command, and is used in auto-generated cli docs.
 func networkCreateExample() string {
 	return `$ pouch network create -n pouchnet -d bridge --gateway 192.168.1.1 --subnet 192.168.1.0/24
-pouchnet: e1d541722d68dc5d133cca9e7bd8fd9338603e1763096c8e853522b60d11f7b9`
+pouchnet: e1d541722d68dc5d133cca9e7bd8fd9338603e1763096c8e853522b60d11f7b9
+$ pouch network create -d bridge --subnet 192.168.2.0/24 --ip-range 192.168.2.128/25 --aux-address router=192.168.2.5 pouchnet2
+pouchnet2: 9e7b5c1c0c0e6ac7d1e1bd4b3e9e0c1a56e7a2b0d3c5e6f1a2b3c4d5e6f7a8b9`
 }
 
 // networkRemoveDescription is used to describe network remove command in detail and auto generate command doc.
@@ -317,6 +338,17 @@ var networkListDescription = "List networks in pouchd. " +
 // NetworkListCommand is used to implement 'network list' command.
 type NetworkListCommand struct {
 	baseCommand
+
+	format string
+}
+
+// networkContext is the object of network printed by --format.
+type networkContext struct {
+	ID     string
+	Name   string
+	Driver string
+	Scope  string
+	Labels map[string]string
 }
 
 // Init initializes NetworkListCommand command.
@@ -340,7 +372,7 @@ func (n *NetworkListCommand) Init(c *Cli) {
 
 // addFlags adds flags for specific command.
 func (n *NetworkListCommand) addFlags() {
-	//TODO add flags
+	n.cmd.Flags().StringVar(&n.format, "format", "", formatFlagUsage)
 }
 
 // runNetworkList is the entry of NetworkListCommand command.
@@ -349,23 +381,42 @@ func (n *NetworkListCommand) runNetworkList(args []string) error {
 
 	ctx := context.Background()
 	apiClient := n.cli.Client()
+
+	formatter, err := newFormatWriter(os.Stdout, n.format)
+	if err != nil {
+		return err
+	}
+
 	respNetworkResource, err := apiClient.NetworkList(ctx)
 	if err != nil {
 		return err
 	}
 
 	display := n.cli.NewTableDisplay()
-	display.AddRow([]string{"NETWORK ID", "NAME", "DRIVER", "SCOPE"})
+	if formatter == nil {
+		display.AddRow([]string{"NETWORK ID", "NAME", "DRIVER", "SCOPE"})
+	}
 	for _, network := range respNetworkResource {
-		display.AddRow([]string{
-			network.ID[:10],
-			network.Name,
-			network.Driver,
-			network.Scope,
-		})
+		nc := networkContext{
+			ID:     network.ID[:10],
+			Name:   network.Name,
+			Driver: network.Driver,
+			Scope:  network.Scope,
+			Labels: network.Labels,
+		}
+
+		if formatter != nil {
+			if err := formatter.Write(nc); err != nil {
+				return err
+			}
+			continue
+		}
+		display.AddRow([]string{nc.ID, nc.Name, nc.Driver, nc.Scope})
 	}
 
-	display.Flush()
+	if formatter == nil {
+		display.Flush()
+	}
 	return nil
 }
 
@@ -376,6 +427,10 @@ NETWORK ID   NAME     DRIVER   SCOPE
 058fce03b8   none     null     local
 b05a9b8844   bridge   bridge   local
 d8684bf988   host     host     local
+$ pouch network list --format "{{.ID}} {{.Name}}"
+058fce03b8 none
+b05a9b8844 bridge
+d8684bf988 host
 `
 }
 
@@ -492,7 +547,7 @@ func (nd *NetworkDisconnectCommand) Init(c *Cli) {
 // addFlags adds flags for specific command.
 func (nd *NetworkDisconnectCommand) addFlags() {
 	// add flags
-	nd.cmd.Flags().BoolVarP(&nd.force, "force", "f", false, "Force the container to disconnect from a network")
+	nd.cmd.Flags().BoolVarP(&nd.force, "force", "f", false, "Force the container to disconnect from a network, required when it is the last network of container")
 }
 
 // runNetworkDisconnect is the entry of 'disconnect' command.
@@ -524,3 +579,75 @@ func (nd *NetworkDisconnectCommand) networkDisconnectExample() string {
 	return `$ pouch network disconnect bridge test
 container test is disconnected from network bridge successfully`
 }
+
+// networkPruneDescription is used to describe network prune command in detail and auto generate command doc.
+var networkPruneDescription = "Remove all the user-defined networks which have no endpoints attached. " +
+	"The predefined networks bridge, host and none are never removed."
+
+const networkPruneWarning = "WARNING! This will remove all custom networks not used by at least one container."
+
+// NetworkPruneCommand is used to implement 'network prune' command.
+type NetworkPruneCommand struct {
+	baseCommand
+	filter []string
+	force  bool
+}
+
+// Init initializes NetworkPruneCommand command.
+func (n *NetworkPruneCommand) Init(c *Cli) {
+	n.cli = c
+	n.cmd = &cobra.Command{
+		Use:   "prune [OPTIONS]",
+		Short: "Remove all unused networks",
+		Long:  networkPruneDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return n.runNetworkPrune(args)
+		},
+		Example: n.networkPruneExample(),
+	}
+	n.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (n *NetworkPruneCommand) addFlags() {
+	flagSet := n.cmd.Flags()
+	flagSet.StringSliceVar(&n.filter, "filter", nil, "Provide filter values, support until=<timestamp> and label=<key>[=<value>]")
+	flagSet.BoolVarP(&n.force, "force", "f", false, "Do not prompt for confirmation")
+}
+
+// runNetworkPrune is the entry of NetworkPruneCommand command.
+func (n *NetworkPruneCommand) runNetworkPrune(args []string) error {
+	ctx := context.Background()
+	apiClient := n.cli.Client()
+
+	filter, err := filters.FromFilterOpts(n.filter)
+	if err != nil {
+		return err
+	}
+
+	if !n.force && !confirm(os.Stdin, os.Stdout, networkPruneWarning) {
+		return nil
+	}
+
+	report, err := apiClient.NetworkPrune(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to prune networks: %v", err)
+	}
+
+	if len(report.NetworksDeleted) > 0 {
+		fmt.Println("Deleted Networks:")
+		for _, name := range report.NetworksDeleted {
+			fmt.Println(name)
+		}
+	}
+	return nil
+}
+
+// networkPruneExample shows examples in 'prune' command, and is used in auto-generated cli docs.
+func (n *NetworkPruneCommand) networkPruneExample() string {
+	return `$ pouch network prune -f
+Deleted Networks:
+net1
+net2`
+}
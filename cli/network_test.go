@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildNetworkCreateRequest(t *testing.T) {
+	n := &NetworkCreateCommand{
+		driver:     "bridge",
+		ipamDriver: "default",
+		subnet:     "192.168.1.0/24",
+		gateway:    "192.168.1.1",
+		ipRange:    "192.168.1.128/25",
+		auxAddress: []string{"router=192.168.1.5"},
+		options:    []string{"a=b"},
+	}
+
+	req, err := n.buildNetworkCreateRequest("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", req.Name)
+	assert.Equal(t, map[string]string{"a": "b"}, req.NetworkCreate.Options)
+	assert.Equal(t, 1, len(req.NetworkCreate.IPAM.Config))
+
+	ipamConfig := req.NetworkCreate.IPAM.Config[0]
+	assert.Equal(t, "192.168.1.0/24", ipamConfig.Subnet)
+	assert.Equal(t, "192.168.1.1", ipamConfig.Gateway)
+	assert.Equal(t, "192.168.1.128/25", ipamConfig.IPRange)
+	assert.Equal(t, map[string]string{"router": "192.168.1.5"}, ipamConfig.AuxAddress)
+
+	// the options set by the deprecated --option are merged, --opt takes precedence.
+	n.deprecatedOptions = []string{"a=c", "d=e"}
+	req, err = n.buildNetworkCreateRequest("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "b", "d": "e"}, req.NetworkCreate.Options)
+	n.deprecatedOptions = nil
+
+	// the auxiliary address should be in the subnet
+	n.auxAddress = []string{"router=192.168.2.5"}
+	_, err = n.buildNetworkCreateRequest("foo")
+	assert.Error(t, err)
+
+	// the gateway can't be set without subnet
+	n.auxAddress, n.subnet, n.ipRange = nil, "", ""
+	_, err = n.buildNetworkCreateRequest("foo")
+	assert.Error(t, err)
+}
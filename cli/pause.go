@@ -2,9 +2,6 @@ package main
 
 import (
 	"context"
-	"errors"
-	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -40,20 +37,9 @@ func (p *PauseCommand) runPause(args []string) error {
 	ctx := context.Background()
 	apiClient := p.cli.Client()
 
-	var errs []string
-	for _, name := range args {
-		if err := apiClient.ContainerPause(ctx, name); err != nil {
-			errs = append(errs, err.Error())
-			continue
-		}
-		fmt.Printf("%s\n", name)
-	}
-
-	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, "\n"))
-	}
-
-	return nil
+	return runOnContainers(args, 1, func(name string) error {
+		return apiClient.ContainerPause(ctx, name)
+	})
 }
 
 // pauseExample shows examples in pause command, and is used in auto-generated cli docs.
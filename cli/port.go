@@ -3,9 +3,13 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
+	"sort"
 	"strings"
 
+	"github.com/alibaba/pouch/apis/types"
+
 	"github.com/docker/go-connections/nat"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -50,6 +54,11 @@ func (p *PortCommand) runPort() error {
 		return err
 	}
 
+	var ports types.PortMap
+	if c.NetworkSettings != nil {
+		ports = c.NetworkSettings.Ports
+	}
+
 	if p.port != "" {
 		port := p.port
 		proto := "tcp"
@@ -64,24 +73,45 @@ func (p *PortCommand) runPort() error {
 		if err != nil {
 			return err
 		}
-		if portBindings, exists := c.NetworkSettings.Ports[string(newP)]; exists && portBindings != nil {
+		if portBindings, exists := ports[string(newP)]; exists && portBindings != nil {
 			for _, pb := range portBindings {
-				fmt.Fprintf(os.Stdout, "%s:%s\n", pb.HostIP, pb.HostPort)
+				fmt.Fprintln(os.Stdout, net.JoinHostPort(pb.HostIP, pb.HostPort))
 			}
 			return nil
 		}
 		return errors.Errorf("No public port '%s' published for %s", natPort, p.container)
 	}
 
-	for from, portBindings := range c.NetworkSettings.Ports {
-		for _, pb := range portBindings {
-			fmt.Fprintf(os.Stdout, "%s -> %s:%s\n", from, pb.HostIP, pb.HostPort)
-		}
+	for _, m := range portMappings(ports) {
+		fmt.Fprintln(os.Stdout, m)
 	}
 
 	return nil
 }
 
+// portMappings returns all the port mappings in the form of
+// "PRIVATE_PORT/PROTO -> HOST_IP:HOST_PORT", sorted by private port and proto.
+func portMappings(ports types.PortMap) []string {
+	var keys []nat.Port
+	for k := range ports {
+		keys = append(keys, nat.Port(k))
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Int() != keys[j].Int() {
+			return keys[i].Int() < keys[j].Int()
+		}
+		return keys[i].Proto() < keys[j].Proto()
+	})
+
+	var mappings []string
+	for _, k := range keys {
+		for _, pb := range ports[string(k)] {
+			mappings = append(mappings, fmt.Sprintf("%s -> %s", k, net.JoinHostPort(pb.HostIP, pb.HostPort)))
+		}
+	}
+	return mappings
+}
+
 // portExample shows examples in port command, and is used in auto-generated cli docs.
 func portExample() string {
 	return `$ pouch run -d -p 6379:6379 -p 6380:6380/udp  redis:latest
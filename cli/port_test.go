@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPortMappings(t *testing.T) {
+	ports := types.PortMap{
+		"8000/tcp":  {{HostIP: "0.0.0.0", HostPort: "8000"}},
+		"53/udp":    {{HostIP: "0.0.0.0", HostPort: "5353"}},
+		"53/tcp":    {{HostIP: "::", HostPort: "5353"}},
+		"10000/tcp": {{HostIP: "127.0.0.1", HostPort: "10000"}, {HostIP: "127.0.0.1", HostPort: "10001"}},
+		"9000/tcp":  nil,
+	}
+
+	assert.Equal(t, []string{
+		"53/tcp -> [::]:5353",
+		"53/udp -> 0.0.0.0:5353",
+		"8000/tcp -> 0.0.0.0:8000",
+		"10000/tcp -> 127.0.0.1:10000",
+		"10000/tcp -> 127.0.0.1:10001",
+	}, portMappings(ports))
+
+	assert.Nil(t, portMappings(nil))
+}
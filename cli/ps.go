@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
 	"sort"
 	"time"
 
@@ -19,6 +20,20 @@ var psDescription = "\nList Containers with container name, ID, status, creation
 // containerList is used to save the container list.
 type containerList []*types.Container
 
+// containerContext is the object of container printed by --format.
+type containerContext struct {
+	ID      string
+	Name    string
+	Names   []string
+	Image   string
+	Command string
+	Created string
+	Status  string
+	State   string
+	Runtime string
+	Labels  map[string]string
+}
+
 // PsCommand is used to implement 'ps' command.
 type PsCommand struct {
 	baseCommand
@@ -28,6 +43,7 @@ type PsCommand struct {
 	flagQuiet   bool
 	flagNoTrunc bool
 	flagFilter  []string
+	flagFormat  string
 }
 
 // Init initializes PsCommand command.
@@ -52,7 +68,8 @@ func (p *PsCommand) addFlags() {
 	flagSet.BoolVarP(&p.flagAll, "all", "a", false, "Show all containers (default shows just running)")
 	flagSet.BoolVarP(&p.flagQuiet, "quiet", "q", false, "Only show numeric IDs")
 	flagSet.BoolVar(&p.flagNoTrunc, "no-trunc", false, "Do not truncate output")
-	flagSet.StringSliceVarP(&p.flagFilter, "filter", "f", nil, "Filter output based on given conditions, support filter key [ id label name status ]")
+	flagSet.StringSliceVarP(&p.flagFilter, "filter", "f", nil, "Filter output based on given conditions, support filter key [ ancestor exited id label name status ], and label!= for negation")
+	flagSet.StringVar(&p.flagFormat, "format", "", formatFlagUsage)
 }
 
 // runPs is the entry of PsCommand command.
@@ -65,6 +82,11 @@ func (p *PsCommand) runPs(args []string) error {
 		return err
 	}
 
+	formatter, err := newFormatWriter(os.Stdout, p.flagFormat)
+	if err != nil {
+		return err
+	}
+
 	var containers containerList
 
 	option := types.ContainerListOptions{
@@ -90,25 +112,63 @@ func (p *PsCommand) runPs(args []string) error {
 	}
 
 	display := p.cli.NewTableDisplay()
-	display.AddRow([]string{"Name", "ID", "Status", "Created", "Image", "Runtime"})
+	if formatter == nil {
+		display.AddRow([]string{"Name", "ID", "Status", "Created", "Image", "Runtime"})
+	}
 
 	for _, c := range containers {
-		created, err := utils.FormatTimeInterval(c.Created, 0)
+		cc, err := newContainerContext(c, p.flagNoTrunc)
 		if err != nil {
 			return err
 		}
 
-		id := c.ID[:6]
-		if p.flagNoTrunc {
-			id = c.ID
+		if formatter != nil {
+			if err := formatter.Write(cc); err != nil {
+				return err
+			}
+			continue
 		}
+		display.AddRow([]string{cc.Name, cc.ID, cc.Status, cc.Created, cc.Image, cc.Runtime})
+	}
 
-		display.AddRow([]string{c.Names[0], id, c.Status, created + " ago", c.Image, c.HostConfig.Runtime})
+	if formatter == nil {
+		display.Flush()
 	}
-	display.Flush()
 	return nil
 }
 
+// newContainerContext converts the container into the object printed by
+// --format, which is also displayed in the table.
+func newContainerContext(c *types.Container, noTrunc bool) (*containerContext, error) {
+	created, err := utils.FormatTimeInterval(c.Created, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	id := c.ID[:6]
+	if noTrunc {
+		id = c.ID
+	}
+
+	cc := &containerContext{
+		ID:      id,
+		Names:   c.Names,
+		Image:   c.Image,
+		Command: c.Command,
+		Created: created + " ago",
+		Status:  c.Status,
+		State:   c.State,
+		Labels:  c.Labels,
+	}
+	if len(c.Names) > 0 {
+		cc.Name = c.Names[0]
+	}
+	if c.HostConfig != nil {
+		cc.Runtime = c.HostConfig.Runtime
+	}
+	return cc, nil
+}
+
 // psExample shows examples in ps command, and is used in auto-generated cli docs.
 func psExample() string {
 	return `$ pouch ps
@@ -136,6 +196,10 @@ Name   ID                                                                 Status
 foo2   692c77587b38f60bbd91d986ec3703848d72aea5030e320d4988eb02aa3f9d48   Up 1 minute   1 minute ago   docker.io/library/redis:alpine   runc
 foo    18592900006405ee64788bd108ef1de3d24dc3add73725891f4787d0f8e036f5   Up 1 minute   1 minute ago   docker.io/library/redis:alpine   runc
 
+$ pouch ps --format "{{.ID}} {{.Name}}"
+e42c68 2
+a8c2ea 1
+
 $ pouch ps --no-trunc -q
 692c77587b38f60bbd91d986ec3703848d72aea5030e320d4988eb02aa3f9d48
 18592900006405ee64788bd108ef1de3d24dc3add73725891f4787d0f8e036f5
@@ -1,14 +1,16 @@
 package main
 
 import (
-	"bufio"
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -16,9 +18,12 @@ import (
 	"github.com/alibaba/pouch/client"
 	"github.com/alibaba/pouch/credential"
 	"github.com/alibaba/pouch/pkg/jsonstream"
+	"github.com/alibaba/pouch/pkg/log"
 	"github.com/alibaba/pouch/pkg/reference"
 
 	"github.com/containerd/containerd/pkg/progress"
+	"github.com/containerd/containerd/platforms"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh/terminal"
 )
@@ -26,11 +31,16 @@ import (
 // pullDescription is used to describe pull command in detail and auto generate command doc.
 var pullDescription = "Pull an image or a repository from a registry. " +
 	"Most of your images will be created on top of a base image from the registry. " +
-	"So, you can pull and try prebuilt images contained by registry without needing to define and configure your own."
+	"So, you can pull and try prebuilt images contained by registry without needing to define and configure your own. " +
+	"The progress of every layer is displayed if the stdout is terminal, otherwise a summary line is printed after pulling. " +
+	"The image can be pinned by digest in the format of name@digest, and the resolved name@digest is printed after pulling."
 
 // PullCommand use to implement 'pull' command, it download image.
 type PullCommand struct {
 	baseCommand
+	platform   string
+	quiet      bool
+	digestFile string
 }
 
 // Init initialize pull command.
@@ -52,12 +62,96 @@ func (p *PullCommand) Init(c *Cli) {
 
 // addFlags adds flags for specific command.
 func (p *PullCommand) addFlags() {
-	// TODO: add flags here
+	flagSet := p.cmd.Flags()
+	flagSet.StringVar(&p.platform, "platform", "", "Set platform if the image is multi-platform, in the format of os/arch[/variant] (default is the platform of daemon host)")
+	flagSet.BoolVarP(&p.quiet, "quiet", "q", false, "Suppress the progress and only print the digest of image")
+	flagSet.StringVar(&p.digestFile, "digest-file", "", "Write the digest of image to the file")
 }
 
 // runPull is the entry of pull command.
 func (p *PullCommand) runPull(args []string) error {
-	return pullMissingImage(context.Background(), p.cli.Client(), args[0], true)
+	ctx := context.Background()
+	apiClient := p.cli.Client()
+
+	if err := pullImage(ctx, apiClient, args[0], p.platform, p.quiet); err != nil {
+		return err
+	}
+
+	namedRef, err := parsePullReference(args[0])
+	if err != nil {
+		return err
+	}
+	img, err := apiClient.ImageInspect(ctx, namedRef.String())
+	if err != nil {
+		return err
+	}
+	resolved, err := resolveDigest(img, namedRef.String())
+	if err != nil {
+		return err
+	}
+
+	if p.quiet {
+		fmt.Println(resolved.Digest())
+	} else {
+		fmt.Println(resolved.String())
+	}
+
+	if p.digestFile != "" {
+		if err := ioutil.WriteFile(p.digestFile, []byte(resolved.Digest().String()+"\n"), 0644); err != nil {
+			return fmt.Errorf("failed to write digest file: %v", err)
+		}
+	}
+	return nil
+}
+
+// resolveDigest returns the repository digest of image pulled by the
+// reference. If the reference is pinned by digest, the image must have the
+// same digest.
+func resolveDigest(img types.ImageInfo, image string) (reference.CanonicalDigested, error) {
+	namedRef, err := reference.Parse(image)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		resolved []reference.CanonicalDigested
+		digests  []string
+	)
+	for _, repoDigest := range img.RepoDigests {
+		ref, err := reference.Parse(repoDigest)
+		if err != nil {
+			continue
+		}
+
+		// the short name of image is completed by daemon, so only the
+		// suffix of name is compared.
+		name := ref.Name()
+		if name != namedRef.Name() && !strings.HasSuffix(name, "/"+namedRef.Name()) {
+			continue
+		}
+		if digested, ok := ref.(reference.CanonicalDigested); ok {
+			resolved = append(resolved, digested)
+			digests = append(digests, digested.Digest().String())
+		}
+	}
+
+	pinned, ok := namedRef.(reference.Digested)
+	if !ok {
+		if len(resolved) == 0 {
+			return nil, fmt.Errorf("failed to resolve the digest of image %s", image)
+		}
+		return resolved[0], nil
+	}
+
+	for _, ref := range resolved {
+		if ref.Digest() == pinned.Digest() {
+			return ref, nil
+		}
+	}
+	if len(digests) == 0 {
+		digests = []string{"none"}
+	}
+	return nil, fmt.Errorf("digest mismatch for image %s: expected %s, got %s", image, pinned.Digest(), strings.Join(digests, ", "))
 }
 
 func fetchRegistryAuth(serverAddress string) string {
@@ -74,34 +168,23 @@ func fetchRegistryAuth(serverAddress string) string {
 	return base64.URLEncoding.EncodeToString(data)
 }
 
-// bufwriter defines interface which has Write and Flush behaviors.
-type bufwriter interface {
-	Write([]byte) (int, error)
-	Flush() error
-}
-
-// showProgress shows pull progress status.
-func showProgress(body io.ReadCloser) error {
+// showProgress shows pull progress status. The progress of every layer is
+// refreshed in place if the stdout is terminal, otherwise a summary line is
+// printed after the progress finishes. Nothing is printed if quiet is true.
+func showProgress(body io.ReadCloser, quiet bool) error {
 	var (
-		output bufwriter = bufio.NewWriter(os.Stdout)
-
 		start      = time.Now()
-		isTerminal = terminal.IsTerminal(int(os.Stdout.Fd()))
+		fd         = int(os.Stdout.Fd())
+		isTerminal = !quiet && terminal.IsTerminal(fd)
+		output     = progress.NewWriter(os.Stdout)
 	)
 
-	if isTerminal {
-		output = progress.NewWriter(os.Stdout)
-	}
-
 	pos := make(map[string]int)
 	status := []jsonstream.JSONMessage{}
 
 	dec := json.NewDecoder(body)
 	for {
-		var (
-			msg  jsonstream.JSONMessage
-			msgs []jsonstream.JSONMessage
-		)
+		var msg jsonstream.JSONMessage
 
 		if err := dec.Decode(&msg); err != nil {
 			if err == io.EOF {
@@ -110,27 +193,27 @@ func showProgress(body io.ReadCloser) error {
 			return err
 		}
 
-		change := true
+		if msg.Error != nil {
+			return fmt.Errorf("failed to display progress: %s", msg.Error.Message)
+		}
+
 		if _, ok := pos[msg.ID]; !ok {
 			status = append(status, msg)
 			pos[msg.ID] = len(status) - 1
 		} else {
-			change = (status[pos[msg.ID]].Status != msg.Status)
 			status[pos[msg.ID]] = msg
 		}
 
-		// only display the new status if the stdout is not terminal
 		if !isTerminal {
-			// if the status doesn't change, skip to avoid duplicate status
-			if !change {
-				continue
-			}
-			msgs = []jsonstream.JSONMessage{msg}
-		} else {
-			msgs = status
+			continue
 		}
 
-		if err := displayImageReferenceProgress(output, isTerminal, msgs, start); err != nil {
+		// get the width on every refresh, since the terminal may be resized.
+		width, _, err := terminal.GetSize(fd)
+		if err != nil {
+			width = 0
+		}
+		if err := displayImageReferenceProgress(output, width, status, start); err != nil {
 			return fmt.Errorf("failed to display progress: %v", err)
 		}
 
@@ -138,42 +221,85 @@ func showProgress(body io.ReadCloser) error {
 			return fmt.Errorf("failed to display progress: %v", err)
 		}
 	}
+
+	if !quiet && !isTerminal {
+		fmt.Fprintln(os.Stdout, summarizeProgress(status, time.Since(start)))
+	}
 	return nil
 }
 
-// displayImageReferenceProgress uses tabwriter to show current progress status.
-func displayImageReferenceProgress(output io.Writer, isTerminal bool, msgs []jsonstream.JSONMessage, start time.Time) error {
+// displayImageReferenceProgress uses tabwriter to show current progress status,
+// the lines are truncated to the width if it is positive, so that the lines
+// are not wrapped by terminal.
+func displayImageReferenceProgress(output io.Writer, width int, msgs []jsonstream.JSONMessage, start time.Time) error {
 	var (
-		tw      = tabwriter.NewWriter(output, 1, 8, 1, ' ', 0)
+		buf     = &bytes.Buffer{}
+		tw      = tabwriter.NewWriter(buf, 1, 8, 1, ' ', 0)
 		current = int64(0)
 	)
 
 	for _, msg := range msgs {
-		if msg.Error != nil {
-			return fmt.Errorf(msg.Error.Message)
-		}
-
 		if msg.Detail != nil {
 			current += msg.Detail.Current
 		}
 
-		status := jsonstream.ProcessStatus(!isTerminal, msg)
+		status := jsonstream.ProcessStatus(false, msg)
 		if _, err := fmt.Fprint(tw, status); err != nil {
 			return err
 		}
 	}
 
-	// no need to show the total information if the stdout is not terminal
-	if isTerminal {
-		_, err := fmt.Fprintf(tw, "elapsed: %-4.1fs\ttotal: %7.6v\t(%v)\t\n",
-			time.Since(start).Seconds(),
-			progress.Bytes(current),
-			progress.NewBytesPerSecond(current, time.Since(start)))
-		if err != nil {
-			return err
+	_, err := fmt.Fprintf(tw, "elapsed: %-4.1fs\ttotal: %7.6v\t(%v)\t\n",
+		time.Since(start).Seconds(),
+		progress.Bytes(current),
+		progress.NewBytesPerSecond(current, time.Since(start)))
+	if err != nil {
+		return err
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(output, truncateLines(buf.String(), width))
+	return err
+}
+
+// truncateLines truncates every line of s to the width, s is kept as it is if
+// the width is not positive.
+func truncateLines(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if runes := []rune(line); len(runes) > width {
+			lines[i] = string(runes[:width])
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// summarizeProgress returns the summary of the final progress status, which
+// counts the objects done or existing and the total size of them.
+func summarizeProgress(msgs []jsonstream.JSONMessage, elapsed time.Duration) string {
+	var (
+		done, exists int
+		current      int64
+	)
+
+	for _, msg := range msgs {
+		switch msg.Status {
+		case jsonstream.PullStatusDone:
+			done++
+		case jsonstream.PullStatusExists:
+			exists++
+		}
+		if msg.Detail != nil {
+			current += msg.Detail.Current
 		}
 	}
-	return tw.Flush()
+	return fmt.Sprintf("%d done, %d exists, total: %v, elapsed: %.1fs", done, exists, progress.Bytes(current), elapsed.Seconds())
 }
 
 // pullExample shows examples in pull command, and is used in auto-generated cli docs.
@@ -185,32 +311,86 @@ $ pouch pull docker.io/library/redis:alpine
 $ pouch images
 IMAGE ID            IMAGE NAME                           SIZE
 bbc3a0323522        docker.io/library/busybox:latest     703.14 KB
-0153c5db97e5        docker.io/library/redis:alpine       9.63 MB`
+0153c5db97e5        docker.io/library/redis:alpine       9.63 MB
+$ pouch pull --platform linux/arm64 docker.io/library/busybox:latest
+$ pouch pull --digest-file /tmp/digest docker.io/library/busybox@sha256:141c253bc4c3fd0a201d32dc1f493bcf3fff003b6df416dea4f41046e0f37d47
+docker.io/library/busybox@sha256:141c253bc4c3fd0a201d32dc1f493bcf3fff003b6df416dea4f41046e0f37d47
+$ pouch pull -q docker.io/library/busybox:latest
+sha256:141c253bc4c3fd0a201d32dc1f493bcf3fff003b6df416dea4f41046e0f37d47`
+}
+
+const (
+	// pullAlways always pulls the image even if it is present locally.
+	pullAlways = "always"
+	// pullMissing pulls the image only if it is not present locally.
+	pullMissing = "missing"
+	// pullNever never pulls the image, and fails if it is not present locally.
+	pullNever = "never"
+)
+
+// pullImageWithPolicy prepares the image by the pull policy, which is
+// always, missing or never.
+func pullImageWithPolicy(ctx context.Context, apiClient client.CommonAPIClient, image, platform, policy string) error {
+	switch policy {
+	case pullAlways:
+		log.With(ctx).Debugf("pull policy is %s, pulling image %s", policy, image)
+		return pullMissingImage(ctx, apiClient, image, platform, true)
+	case pullMissing, pullNever:
+	default:
+		return fmt.Errorf("invalid pull policy %s: should be one of always, missing and never", policy)
+	}
+
+	present, err := localImageMatches(ctx, apiClient, image, platform)
+	if err != nil {
+		return err
+	}
+	if present {
+		log.With(ctx).Debugf("image %s is present locally, skip pulling", image)
+		return nil
+	}
+	if policy == pullNever {
+		return fmt.Errorf("image %s is not present locally and pull policy is never", image)
+	}
+
+	log.With(ctx).Debugf("image %s is not present locally, pulling it", image)
+	return pullMissingImage(ctx, apiClient, image, platform, true)
+}
+
+// localImageMatches returns true if the image is present locally and matches
+// the platform.
+func localImageMatches(ctx context.Context, apiClient client.CommonAPIClient, image, platform string) (bool, error) {
+	img, inspectError := apiClient.ImageInspect(ctx, image)
+	if inspectError == nil {
+		return imageMatchesPlatform(img, platform)
+	} else if err, ok := inspectError.(client.RespError); !ok {
+		return false, inspectError
+	} else if err.Code() != http.StatusNotFound {
+		return false, inspectError
+	}
+	return false, nil
 }
 
-// pullMissingImage pull the image if it doesn't exist.
-// When `force` is true, always pull the latest image instead of
-// using the local version
-func pullMissingImage(ctx context.Context, apiClient client.CommonAPIClient, image string, force bool) error {
+// pullMissingImage pull the image if it doesn't exist or the local one
+// doesn't match the platform. When `force` is true, always pull the latest
+// image instead of using the local version
+func pullMissingImage(ctx context.Context, apiClient client.CommonAPIClient, image, platform string, force bool) error {
 	if !force {
-		_, inspectError := apiClient.ImageInspect(ctx, image)
-		if inspectError == nil {
-			return nil
-		}
-		if err, ok := inspectError.(client.RespError); !ok {
-			return inspectError
-		} else if err.Code() != http.StatusNotFound {
-			return inspectError
+		match, err := localImageMatches(ctx, apiClient, image, platform)
+		if err != nil || match {
+			return err
 		}
 	}
+	return pullImage(ctx, apiClient, image, platform, false)
+}
 
-	namedRef, err := reference.Parse(image)
+// pullImage pulls the image from registry, the progress is not displayed if
+// quiet is true.
+func pullImage(ctx context.Context, apiClient client.CommonAPIClient, image, platform string, quiet bool) error {
+	namedRef, err := parsePullReference(image)
 	if err != nil {
 		return err
 	}
 
-	namedRef = reference.TrimTagForDigest(reference.WithDefaultTagIfMissing(namedRef))
-
 	var name, tag string
 	if reference.IsNameTagged(namedRef) {
 		name, tag = namedRef.Name(), namedRef.(reference.Tagged).Tag()
@@ -218,11 +398,53 @@ func pullMissingImage(ctx context.Context, apiClient client.CommonAPIClient, ima
 		name = namedRef.String()
 	}
 
-	responseBody, err := apiClient.ImagePull(ctx, name, tag, fetchRegistryAuth(namedRef.Name()))
+	responseBody, err := apiClient.ImagePull(ctx, name, tag, platform, fetchRegistryAuth(registryServer(ctx, apiClient, namedRef.Name())))
 	if err != nil {
 		return fmt.Errorf("failed to pull image: %v", err)
 	}
 	defer responseBody.Close()
 
-	return showProgress(responseBody)
+	if err := showProgress(responseBody, quiet); err != nil {
+		return err
+	}
+
+	// verify the content pulled by the reference pinned by digest.
+	if _, ok := namedRef.(reference.Digested); !ok {
+		return nil
+	}
+	img, err := apiClient.ImageInspect(ctx, namedRef.String())
+	if err != nil {
+		return err
+	}
+	_, err = resolveDigest(img, namedRef.String())
+	return err
+}
+
+// parsePullReference parses the image into the reference to pull, the tag is
+// defaulted to latest, and is removed if the reference has digest.
+func parsePullReference(image string) (reference.Named, error) {
+	namedRef, err := reference.Parse(image)
+	if err != nil {
+		return nil, err
+	}
+	return reference.TrimTagForDigest(reference.WithDefaultTagIfMissing(namedRef)), nil
+}
+
+// imageMatchesPlatform returns true if the platform is empty or the os and
+// architecture of image match the platform.
+func imageMatchesPlatform(img types.ImageInfo, platform string) (bool, error) {
+	if platform == "" {
+		return true, nil
+	}
+
+	p, err := platforms.Parse(platform)
+	if err != nil {
+		return false, fmt.Errorf("invalid platform %s: %v", platform, err)
+	}
+
+	actual := platforms.Normalize(ocispec.Platform{
+		OS:           img.Os,
+		Architecture: img.Architecture,
+	})
+	return actual.OS == p.OS && actual.Architecture == p.Architecture, nil
 }
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/jsonstream"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageMatchesPlatform(t *testing.T) {
+	img := types.ImageInfo{Os: "linux", Architecture: "amd64"}
+
+	for _, tc := range []struct {
+		platform string
+		expect   bool
+		hasErr   bool
+	}{
+		{platform: "", expect: true},
+		{platform: "linux/amd64", expect: true},
+		{platform: "linux/x86_64", expect: true},
+		{platform: "linux/arm64", expect: false},
+		{platform: "windows/amd64", expect: false},
+		{platform: "linux/amd64/v1/v2", hasErr: true},
+	} {
+		match, err := imageMatchesPlatform(img, tc.platform)
+		if tc.hasErr {
+			assert.Error(t, err, tc.platform)
+			continue
+		}
+		assert.NoError(t, err, tc.platform)
+		assert.Equal(t, tc.expect, match, tc.platform)
+	}
+}
+
+func TestPullImageWithInvalidPolicy(t *testing.T) {
+	err := pullImageWithPolicy(context.Background(), nil, "busybox", "", "sometimes")
+	assert.EqualError(t, err, "invalid pull policy sometimes: should be one of always, missing and never")
+}
+
+func TestTruncateLines(t *testing.T) {
+	assert.Equal(t, "abc\nde\n", truncateLines("abcdef\nde\n", 3))
+	assert.Equal(t, "杭州\n", truncateLines("杭州市\n", 2))
+	assert.Equal(t, "abcdef\n", truncateLines("abcdef\n", 0))
+}
+
+func TestSummarizeProgress(t *testing.T) {
+	msgs := []jsonstream.JSONMessage{
+		{ID: "docker.io/library/busybox:latest", Status: jsonstream.PullStatusResolved, Detail: &jsonstream.ProgressDetail{}},
+		{ID: "manifest-sha256:1", Status: jsonstream.PullStatusExists},
+		{ID: "config-sha256:2", Status: jsonstream.PullStatusDone, Detail: &jsonstream.ProgressDetail{Current: 512, Total: 512}},
+		{ID: "layer-sha256:3", Status: jsonstream.PullStatusDone, Detail: &jsonstream.ProgressDetail{Current: 1536, Total: 1536}},
+	}
+	assert.Equal(t, "2 done, 1 exists, total: 2.0 KiB, elapsed: 1.5s", summarizeProgress(msgs, 1500*time.Millisecond))
+}
+
+func TestResolveDigest(t *testing.T) {
+	img := types.ImageInfo{
+		ID: "sha256:0123",
+		RepoDigests: []string{
+			"docker.io/library/redis@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			"docker.io/library/busybox@sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+			"docker.io/library/busybox@sha256:cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc",
+		},
+	}
+
+	for _, image := range []string{"docker.io/library/busybox:latest", "busybox"} {
+		resolved, err := resolveDigest(img, image)
+		assert.NoError(t, err, image)
+		assert.Equal(t, "docker.io/library/busybox@sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", resolved.String(), image)
+	}
+
+	// the pinned digest must be one of the digests of image.
+	resolved, err := resolveDigest(img, "busybox@sha256:cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc")
+	assert.NoError(t, err)
+	assert.Equal(t, "sha256:cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc", resolved.Digest().String())
+
+	_, err = resolveDigest(img, "docker.io/library/redis@sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	assert.EqualError(t, err, "digest mismatch for image docker.io/library/redis@sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb: expected sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb, got sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+
+	_, err = resolveDigest(img, "alpine@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	assert.EqualError(t, err, "digest mismatch for image alpine@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa: expected sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa, got none")
+
+	_, err = resolveDigest(img, "alpine:latest")
+	assert.EqualError(t, err, "failed to resolve the digest of image alpine:latest")
+}
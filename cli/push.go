@@ -43,13 +43,14 @@ func (p *PushCommand) runPush(refImage string) error {
 	}
 	namedRef = reference.TrimTagForDigest(reference.WithDefaultTagIfMissing(namedRef))
 
-	responseBody, err := apiClient.ImagePush(context.TODO(), namedRef.String(), fetchRegistryAuth(namedRef.Name()))
+	ctx := context.TODO()
+	responseBody, err := apiClient.ImagePush(ctx, namedRef.String(), fetchRegistryAuth(registryServer(ctx, apiClient, namedRef.Name())))
 	if err != nil {
 		return fmt.Errorf("failed to push image: %v", err)
 	}
 	defer responseBody.Close()
 
-	return showProgress(responseBody)
+	return showProgress(responseBody, false)
 }
 
 // pushExample shows examples in push command, and is used in auto-generated cli docs.
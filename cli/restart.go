@@ -2,10 +2,7 @@ package main
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -16,7 +13,8 @@ var restartDescription = "restart one or more containers"
 // RestartCommand uses to implement 'restart' command, it restarts one or more containers.
 type RestartCommand struct {
 	baseCommand
-	timeout int
+	timeout  int
+	parallel int
 }
 
 // Init initialize restart command.
@@ -40,6 +38,7 @@ func (rc *RestartCommand) Init(c *Cli) {
 func (rc *RestartCommand) addFlags() {
 	flagSet := rc.cmd.Flags()
 	flagSet.IntVarP(&rc.timeout, "time", "t", 10, "Seconds to wait for stop before killing the container")
+	flagSet.IntVar(&rc.parallel, "parallel", 1, "Number of containers to restart concurrently")
 }
 
 // runRestart is the entry of restart command.
@@ -47,20 +46,9 @@ func (rc *RestartCommand) runRestart(args []string) error {
 	ctx := context.Background()
 	apiClient := rc.cli.Client()
 
-	var errs []string
-	for _, name := range args {
-		if err := apiClient.ContainerRestart(ctx, name, strconv.Itoa(rc.timeout)); err != nil {
-			errs = append(errs, err.Error())
-			continue
-		}
-		fmt.Printf("%s\n", name)
-	}
-
-	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, "\n"))
-	}
-
-	return nil
+	return runOnContainers(args, rc.parallel, func(name string) error {
+		return apiClient.ContainerRestart(ctx, name, strconv.Itoa(rc.timeout))
+	})
 }
 
 // restartExample shows examples in restart command, and is used in auto-generated cli docs.
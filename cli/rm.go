@@ -2,12 +2,15 @@ package main
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"strings"
+	"os"
+	"strconv"
+	"syscall"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/client"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -24,6 +27,7 @@ type RmCommand struct {
 	baseCommand
 	force         bool
 	removeVolumes bool
+	parallel      int
 }
 
 // Init initializes RmCommand command.
@@ -46,8 +50,9 @@ func (r *RmCommand) Init(c *Cli) {
 func (r *RmCommand) addFlags() {
 	flagSet := r.cmd.Flags()
 
-	flagSet.BoolVarP(&r.force, "force", "f", false, "if the container is running, force to remove it")
-	flagSet.BoolVarP(&r.removeVolumes, "volumes", "v", false, "remove container's volumes that create by the container")
+	flagSet.BoolVarP(&r.force, "force", "f", false, "if the container is running, kill and remove it")
+	flagSet.BoolVarP(&r.removeVolumes, "volumes", "v", false, "remove container's anonymous volumes that are not used by other containers, named volumes are kept")
+	flagSet.IntVar(&r.parallel, "parallel", 1, "Number of containers to remove concurrently")
 }
 
 // runRm is the entry of RmCommand command.
@@ -60,20 +65,49 @@ func (r *RmCommand) runRm(args []string) error {
 		Volumes: r.removeVolumes,
 	}
 
-	var errs []string
-	for _, name := range args {
-		if err := apiClient.ContainerRemove(ctx, name, options); err != nil {
-			errs = append(errs, err.Error())
-			continue
+	return runOnContainers(args, r.parallel, func(name string) error {
+		if r.force {
+			if err := r.kill(ctx, name); err != nil {
+				return err
+			}
 		}
-		fmt.Printf("%s\n", name)
+
+		resp, err := apiClient.ContainerRemove(ctx, name, options)
+		if err != nil {
+			return err
+		}
+		if r.removeVolumes {
+			reportVolumes(name, resp)
+		}
+		return nil
+	})
+}
+
+// kill sends SIGKILL to the container before removing it, the container
+// which is not running is ignored.
+func (r *RmCommand) kill(ctx context.Context, name string) error {
+	err := r.cli.Client().ContainerKill(ctx, name, strconv.Itoa(int(syscall.SIGKILL)))
+	if err == nil || isNotFoundError(err) {
+		// the not found error is reported by removing.
+		return nil
 	}
 
-	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, "\n"))
+	if _, ok := errors.Cause(err).(client.ConflictError); ok {
+		// the container is not running.
+		return nil
 	}
+	return fmt.Errorf("failed to kill container %s before removing: %v", name, err)
+}
 
-	return nil
+// reportVolumes reports the volumes removed with the container, and the
+// ones kept by the daemon, which are named or used by other containers.
+func reportVolumes(name string, resp *types.ContainerRemoveResp) {
+	for _, v := range resp.VolumesRemoved {
+		fmt.Printf("removed volume %s of container %s\n", v, name)
+	}
+	for _, v := range resp.VolumesKept {
+		fmt.Fprintf(os.Stderr, "volume %s of container %s is named or used by other containers, skipped\n", v, name)
+	}
 }
 
 func rmExample() string {
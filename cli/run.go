@@ -3,12 +3,11 @@ package main
 import (
 	"context"
 	"fmt"
-	"io"
 	"os"
 	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
-	"github.com/alibaba/pouch/pkg/ioutils"
+	"github.com/alibaba/pouch/pkg/term"
 
 	"github.com/spf13/cobra"
 )
@@ -21,7 +20,7 @@ var runDescription = "Create a container object in Pouchd, and start the contain
 type RunCommand struct {
 	baseCommand
 	*container
-	detachKeys string
+	detachKeys detachKeys
 	attach     bool
 	stdin      bool
 	detach     bool
@@ -51,37 +50,53 @@ func (rc *RunCommand) addFlags() {
 	c := addCommonFlags(flagSet)
 	rc.container = c
 
-	flagSet.StringVar(&rc.detachKeys, "detach-keys", "", "Override the key sequence for detaching a container")
+	flagSet.Var(&rc.detachKeys, "detach-keys", "Override the key sequence for detaching a container (default ctrl-p,ctrl-q)")
 	flagSet.BoolVarP(&rc.attach, "attach", "a", false, "Attach container's STDOUT and STDERR")
 	flagSet.BoolVarP(&rc.stdin, "interactive", "i", false, "Attach container's STDIN")
-	flagSet.BoolVarP(&rc.detach, "detach", "d", false, "Run container in background and print container ID")
-	flagSet.BoolVar(&rc.rm, "rm", false, "Automatically remove the container after it exits")
+	flagSet.BoolVarP(&rc.detach, "detach", "d", false, "Run container in background and print the full container ID")
+	flagSet.BoolVar(&rc.rm, "rm", false, "Automatically remove the container and its anonymous volumes after it exits, the detached container is removed by daemon")
 
 }
 
 // runRun is the entry of run command.
 func (rc *RunCommand) runRun(args []string) error {
+	if rc.rm && rc.restartPolicy != "" && rc.restartPolicy != "no" {
+		return fmt.Errorf("Conflicting options: --rm and --restart")
+	}
+	// check the conflicts before creating, so that no container is left
+	// behind by a failed run.
+	if (rc.attach || rc.stdin) && rc.detach {
+		return fmt.Errorf("Conflicting options: -a (or -i) and -d")
+	}
+
 	config, err := rc.config()
 	if err != nil {
 		return fmt.Errorf("failed to run container: %v", err)
 	}
+	// the detached container is removed by daemon once it exits, otherwise
+	// it is removed by client after the container exits.
+	config.HostConfig.AutoRemove = rc.rm && rc.detach
 
 	//collect all the environment variables for the container
 	config.Env, err = readKVStrings(rc.envfile, rc.env)
 	if err != nil {
-		return nil
+		return fmt.Errorf("failed to read env file: %v", err)
 	}
 	config.Image = args[0]
 	if len(args) > 1 {
 		config.Cmd = args[1:]
 	}
+	// the empty --entrypoint clears the ENTRYPOINT of image.
+	if rc.cmd.Flags().Changed("entrypoint") && len(config.Entrypoint) == 0 {
+		config.Entrypoint = []string{""}
+	}
 	containerName := rc.name
 	config.ContainerConfig.OpenStdin = rc.stdin
 
 	ctx := context.Background()
 	apiClient := rc.cli.Client()
 
-	if err := pullMissingImage(ctx, apiClient, config.Image, false); err != nil {
+	if err := pullImageWithPolicy(ctx, apiClient, config.Image, rc.platform, rc.pull); err != nil {
 		return err
 	}
 
@@ -98,66 +113,51 @@ func (rc *RunCommand) runRun(args []string) error {
 		containerName = result.Name
 	}
 
-	if (rc.attach || rc.stdin) && rc.detach {
-		return fmt.Errorf("Conflicting options: -a (or -i) and -d")
-	}
-	if rc.rm && rc.detach {
-		return fmt.Errorf("Conflicting options: --rm and -d")
-	}
-
 	// default attach container's stdout and stderr
 	if !rc.detach {
 		rc.attach = true
 	}
 
-	wait := make(chan struct{})
-
 	if err := checkTty(rc.stdin, rc.tty, os.Stdout.Fd()); err != nil {
 		return err
 	}
 
+	var streams *streamAttach
 	if rc.attach || rc.stdin {
-		if rc.tty {
-			in, out, err := setRawMode(rc.stdin, false)
-			if err != nil {
-				return fmt.Errorf("failed to set raw mode")
-			}
-			defer func() {
-				if err := restoreMode(in, out); err != nil {
-					fmt.Fprintf(os.Stderr, "failed to restore term mode")
-				}
-			}()
-		}
-
 		conn, br, err := apiClient.ContainerAttach(ctx, containerName, rc.stdin)
 		if err != nil {
 			return fmt.Errorf("failed to attach container: %v", err)
 		}
 		defer conn.Close()
 
-		go func() {
-			io.Copy(os.Stdout, br)
-			wait <- struct{}{}
-		}()
-		go func() {
-			io.Copy(conn, os.Stdin)
-			// close write if receive CTRL-D
-			if cw, ok := conn.(ioutils.CloseWriter); ok {
-				cw.CloseWrite()
-			}
-		}()
+		streams = &streamAttach{
+			conn:       conn,
+			reader:     br,
+			in:         os.Stdin,
+			out:        os.Stdout,
+			errOut:     os.Stderr,
+			escapeKeys: rc.detachKeys.Bytes(),
+			stdin:      rc.stdin,
+			stdout:     true,
+			stderr:     true,
+			tty:        rc.tty,
+			resize:     resizeContainer(apiClient, containerName),
+		}
 	}
 
 	// start container
 	if err := apiClient.ContainerStart(ctx, containerName, types.ContainerStartOptions{
-		DetachKeys: rc.detachKeys,
+		DetachKeys: rc.detachKeys.String(),
 	}); err != nil {
 		return fmt.Errorf("failed to run container %s: %v", containerName, err)
 	}
 
 	// wait the io to finish
-	if rc.attach || rc.stdin {
-		<-wait
+	if streams != nil {
+		if _, err := streams.hold(ctx); err == term.ErrEscapeDetach {
+			// detached from the container, leave it running.
+			return nil
+		}
 	} else {
 		fmt.Fprintf(os.Stdout, "%s\n", result.ID)
 	}
@@ -167,8 +167,8 @@ func (rc *RunCommand) runRun(args []string) error {
 		return err
 	}
 
-	if rc.rm {
-		if err := apiClient.ContainerRemove(ctx, containerName, &types.ContainerRemoveOptions{Force: true}); err != nil {
+	if rc.rm && !rc.detach {
+		if _, err := apiClient.ContainerRemove(ctx, containerName, &types.ContainerRemoveOptions{Force: true, Volumes: true}); err != nil {
 			return fmt.Errorf("failed to remove container %s: %v", containerName, err)
 		}
 	}
@@ -9,7 +9,8 @@ import (
 )
 
 // saveDescription is used to describe save command in detail and auto generate command doc.
-var saveDescription = "save an image to a tar archive."
+var saveDescription = "save one or more images to a tar archive. " +
+	"The images are saved in oci.v1 format with their names, so that they can be restored by load command."
 
 // SaveCommand use to implement 'save' command.
 type SaveCommand struct {
@@ -21,10 +22,10 @@ type SaveCommand struct {
 func (save *SaveCommand) Init(c *Cli) {
 	save.cli = c
 	save.cmd = &cobra.Command{
-		Use:   "save [OPTIONS] IMAGE",
-		Short: "Save an image to a tar archive or STDOUT",
+		Use:   "save [OPTIONS] IMAGE [IMAGE...]",
+		Short: "Save one or more images to a tar archive or STDOUT",
 		Long:  saveDescription,
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MinimumNArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
 			return save.runSave(args)
 		},
@@ -36,7 +37,7 @@ func (save *SaveCommand) Init(c *Cli) {
 // addFlags adds flags for specific command.
 func (save *SaveCommand) addFlags() {
 	flagSet := save.cmd.Flags()
-	flagSet.StringVarP(&save.output, "output", "o", "", "Save to a tar archive file, instead of STDOUT")
+	flagSet.StringVarP(&save.output, "output", "o", "", "Save to a tar archive file, instead of STDOUT, \"-\" means STDOUT")
 }
 
 // runSave is the entry of save command.
@@ -44,21 +45,22 @@ func (save *SaveCommand) runSave(args []string) error {
 	ctx := context.Background()
 	apiClient := save.cli.Client()
 
-	r, err := apiClient.ImageSave(ctx, args[0])
-	if err != nil {
-		return err
-	}
-	defer r.Close()
-
 	out := os.Stdout
-	if save.output != "" {
-		out, err = os.Create(save.output)
+	if save.output != "" && save.output != "-" {
+		f, err := os.Create(save.output)
 		if err != nil {
-			return nil
+			return err
 		}
-		defer out.Close()
+		defer f.Close()
+		out = f
 	}
 
+	r, err := apiClient.ImageSave(ctx, args)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
 	if _, err := io.Copy(out, r); err != nil {
 		return err
 	}
@@ -67,11 +69,18 @@ func (save *SaveCommand) runSave(args []string) error {
 
 // saveExample shows examples in save command, and is used in auto-generated cli docs.
 func saveExample() string {
-	return `$ pouch save -o busybox.tar busybox:latest
-$ pouch load -i busybox.tar foo
+	return `$ pouch save -o images.tar busybox:latest redis:latest
+$ pouch rmi busybox:latest redis:latest
+$ pouch load -i images.tar
+$ pouch images
+IMAGE ID       IMAGE NAME                                           SIZE
+8c811b4aec35   registry.hub.docker.com/library/busybox:latest       710.81 KB
+9a4e0a9a3a4b   registry.hub.docker.com/library/redis:latest         34.51 MB
+$ pouch load -i images.tar foo
 $ pouch images
 IMAGE ID       IMAGE NAME                                           SIZE
 8c811b4aec35   registry.hub.docker.com/library/busybox:latest       710.81 KB
-8c811b4aec35   foo:latest                                           710.81 KB
+9a4e0a9a3a4b   registry.hub.docker.com/library/redis:latest         34.51 MB
+9a4e0a9a3a4b   foo:latest                                           34.51 MB
 `
 }
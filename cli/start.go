@@ -4,11 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"os"
-	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/term"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh/terminal"
@@ -23,11 +22,12 @@ var startDescription = "Start one or more created container objects in Pouchd. "
 // StartCommand use to implement 'start' command, it start one or more containers.
 type StartCommand struct {
 	baseCommand
-	detachKeys string
+	detachKeys detachKeys
 	attach     bool
 	stdin      bool
 	checkpoint string
 	cpDir      string
+	parallel   int
 }
 
 // Init initialize start command.
@@ -49,11 +49,12 @@ func (s *StartCommand) Init(c *Cli) {
 // addFlags adds flags for specific command.
 func (s *StartCommand) addFlags() {
 	flagSet := s.cmd.Flags()
-	flagSet.StringVar(&s.detachKeys, "detach-keys", "", "Override the key sequence for detaching a container")
+	flagSet.Var(&s.detachKeys, "detach-keys", "Override the key sequence for detaching a container (default ctrl-p,ctrl-q)")
 	flagSet.BoolVarP(&s.attach, "attach", "a", false, "Attach container's STDOUT and STDERR")
 	flagSet.BoolVarP(&s.stdin, "interactive", "i", false, "Attach container's STDIN")
 	flagSet.StringVar(&s.checkpoint, "checkpoint", "", "Restore container state from the checkpoint")
 	flagSet.StringVar(&s.cpDir, "checkpoint-dir", "", "Directory to store checkpoints images")
+	flagSet.IntVar(&s.parallel, "parallel", 1, "Number of containers to start concurrently")
 }
 
 // runStart is the entry of start command.
@@ -62,7 +63,6 @@ func (s *StartCommand) runStart(args []string) error {
 	apiClient := s.cli.Client()
 	// attach to io.
 	if s.attach || s.stdin {
-		var wait chan struct{}
 		// If we want to attach to a container, we should make sure we only have one container.
 		if len(args) > 1 {
 			return fmt.Errorf("cannot start and attach multiple containers at once")
@@ -78,36 +78,15 @@ func (s *StartCommand) runStart(args []string) error {
 			return err
 		}
 
-		if c.Config.Tty {
-			in, out, err := setRawMode(s.stdin, false)
-			if err != nil {
-				return fmt.Errorf("failed to set raw mode")
-			}
-			defer func() {
-				if err := restoreMode(in, out); err != nil {
-					fmt.Fprintf(os.Stderr, "failed to restore term mode")
-				}
-			}()
-		}
-
 		conn, br, err := apiClient.ContainerAttach(ctx, container, s.stdin)
 		if err != nil {
 			return fmt.Errorf("failed to attach container: %v", err)
 		}
 		defer conn.Close()
 
-		wait = make(chan struct{})
-		go func() {
-			io.Copy(os.Stdout, br)
-			close(wait)
-		}()
-		go func() {
-			io.Copy(conn, os.Stdin)
-		}()
-
 		// start container
 		if err := apiClient.ContainerStart(ctx, container, types.ContainerStartOptions{
-			DetachKeys:    s.detachKeys,
+			DetachKeys:    s.detachKeys.String(),
 			CheckpointID:  s.checkpoint,
 			CheckpointDir: s.cpDir,
 		}); err != nil {
@@ -115,8 +94,22 @@ func (s *StartCommand) runStart(args []string) error {
 		}
 
 		// wait the io to finish.
-		if s.attach || s.stdin {
-			<-wait
+		streams := &streamAttach{
+			conn:       conn,
+			reader:     br,
+			in:         os.Stdin,
+			out:        os.Stdout,
+			errOut:     os.Stderr,
+			escapeKeys: s.detachKeys.Bytes(),
+			stdin:      s.stdin,
+			stdout:     true,
+			stderr:     true,
+			tty:        c.Config.Tty,
+			resize:     resizeContainer(apiClient, container),
+		}
+		if _, err := streams.hold(ctx); err == term.ErrEscapeDetach {
+			// detached from the container, leave it running.
+			return nil
 		}
 
 		info, err := apiClient.ContainerGet(ctx, container)
@@ -130,56 +123,15 @@ func (s *StartCommand) runStart(args []string) error {
 		}
 	} else {
 		// We're not going to attach to any container, so we just start as many containers as we want.
-		var errs []string
-		for _, name := range args {
-			if err := apiClient.ContainerStart(ctx, name, types.ContainerStartOptions{
-				DetachKeys:    s.detachKeys,
+		err := runOnContainers(args, s.parallel, func(name string) error {
+			return apiClient.ContainerStart(ctx, name, types.ContainerStartOptions{
+				DetachKeys:    s.detachKeys.String(),
 				CheckpointID:  s.checkpoint,
 				CheckpointDir: s.cpDir,
-			}); err != nil {
-				errs = append(errs, err.Error())
-				continue
-			}
-			fmt.Printf("%s\n", name)
-		}
-
-		if len(errs) > 0 {
-			return errors.New("failed to start containers: " + strings.Join(errs, ""))
-		}
-	}
-	return nil
-}
-
-func setRawMode(stdin, stdout bool) (*terminal.State, *terminal.State, error) {
-	var (
-		in  *terminal.State
-		out *terminal.State
-		err error
-	)
-
-	if stdin {
-		if in, err = terminal.MakeRaw(0); err != nil {
-			return nil, nil, err
-		}
-	}
-	if stdout {
-		if out, err = terminal.MakeRaw(1); err != nil {
-			return nil, nil, err
-		}
-	}
-
-	return in, out, nil
-}
-
-func restoreMode(in, out *terminal.State) error {
-	if in != nil {
-		if err := terminal.Restore(0, in); err != nil {
-			return err
-		}
-	}
-	if out != nil {
-		if err := terminal.Restore(1, out); err != nil {
-			return err
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("failed to start containers: %v", err)
 		}
 	}
 	return nil
@@ -189,7 +141,7 @@ func restoreMode(in, out *terminal.State) error {
 // from a non-tty client input stream, and if so, returns an error.
 func checkTty(attachStdin, ttyMode bool, fd uintptr) error {
 	if ttyMode && attachStdin && !terminal.IsTerminal(int(fd)) {
-		return errors.New("the input device is not a TTY")
+		return errors.New("the input device is not a TTY; remove -t or connect a terminal")
 	}
 	return nil
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -31,7 +32,7 @@ type StatsCommand struct {
 	baseCommand
 
 	noStream bool
-	//TODO: add more flags support
+	format   string
 }
 
 // Init initialize stats command.
@@ -54,6 +55,7 @@ func (stats *StatsCommand) Init(c *Cli) {
 func (stats *StatsCommand) addFlags() {
 	flagSet := stats.cmd.Flags()
 	flagSet.BoolVar(&stats.noStream, "no-stream", false, "Disable streaming stats and only pull the first result")
+	flagSet.StringVar(&stats.format, "format", "", "Print the stats in the given format, 'table' or 'json' which prints one JSON object per container per line")
 }
 
 // runStats is the entry of stats command.
@@ -62,6 +64,11 @@ func (stats *StatsCommand) runStats(args []string) error {
 	apiClient := stats.cli.Client()
 	containers := args
 
+	jsonFormat := stats.format == "json"
+	if !jsonFormat && stats.format != "" && stats.format != "table" {
+		return fmt.Errorf("invalid format %q: only 'table' and 'json' are supported", stats.format)
+	}
+
 	cStats := []*StatsEntryWithLock{}
 	waitFirst := &sync.WaitGroup{}
 	for _, name := range containers {
@@ -87,12 +94,13 @@ func (stats *StatsCommand) runStats(args []string) error {
 	}
 
 	cleanScreen := func() {
-		if !stats.noStream {
+		if !stats.noStream && !jsonFormat && !stats.cli.NoColor() {
 			fmt.Fprint(os.Stdout, "\033[2J")
 			fmt.Fprint(os.Stdout, "\033[H")
 		}
 	}
 
+	enc := json.NewEncoder(os.Stdout)
 	display := stats.cli.NewTableDisplay()
 	displayHead := []string{containerHeader, containerNameHeader, cpuPercHeader, memPercHeader,
 		memUseHeader, netIOHeader, blockIOHeader, pidsHeader}
@@ -110,6 +118,19 @@ func (stats *StatsCommand) runStats(args []string) error {
 			ccstats = append(ccstats, c.GetStatsEntry())
 		}
 
+		if jsonFormat {
+			for _, c := range ccstats {
+				if err := enc.Encode(c.JSON()); err != nil {
+					return err
+				}
+			}
+
+			if stats.noStream {
+				break
+			}
+			continue
+		}
+
 		display.AddRow(displayHead)
 		// display the stats of each container
 		for _, c := range ccstats {
@@ -134,5 +155,7 @@ func statsExample() string {
 CONTAINER ID        NAME                       CPU %               MEM USAGE / LIMIT     MEM %               NET I/O             BLOCK I/O           PIDS
 b25ae88e5b70        naughty_goldwasser         0.11%               2.559MiB / 15.23GiB   0.02%               7.32kB / 0B         0B / 0B             4
 a00670c2bdff        xenodochial_varahamihira   0.11%               2.887MiB / 15.23GiB   0.02%               13.3kB / 0B         14.7MB / 0B         4
+$ pouch stats --no-stream --format json b25ae
+{"Container":"b25ae","ID":"b25ae88e5b70","Name":"naughty_goldwasser","CPUPercentage":0.11,"MemoryUsage":2683289,"MemoryLimit":16353267712,"MemoryPercentage":0.02,"NetworkRx":7320,"NetworkTx":0,"BlockRead":0,"BlockWrite":0,"PIDs":4}
 `
 }
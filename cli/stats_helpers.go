@@ -103,6 +103,41 @@ func (s StatsEntry) PIDs() string {
 	return fmt.Sprintf("%d", s.pidsCurrent)
 }
 
+// StatsJSON is the machine-readable statistics data of a container, and the
+// sizes are in bytes.
+type StatsJSON struct {
+	Container        string
+	ID               string
+	Name             string
+	CPUPercentage    float64
+	MemoryUsage      float64
+	MemoryLimit      float64
+	MemoryPercentage float64
+	NetworkRx        float64
+	NetworkTx        float64
+	BlockRead        float64
+	BlockWrite       float64
+	PIDs             uint64
+}
+
+// JSON return the statistics data in StatsJSON
+func (s StatsEntry) JSON() StatsJSON {
+	return StatsJSON{
+		Container:        s.container,
+		ID:               s.ID(),
+		Name:             s.name,
+		CPUPercentage:    s.cpuPercentage,
+		MemoryUsage:      s.memory,
+		MemoryLimit:      s.memoryLimit,
+		MemoryPercentage: s.memoryPercentage,
+		NetworkRx:        s.networkRx,
+		NetworkTx:        s.networkTx,
+		BlockRead:        s.blockRead,
+		BlockWrite:       s.blockWrite,
+		PIDs:             s.pidsCurrent,
+	}
+}
+
 // GetStatsEntry return the StatsEntry of StatsEntryWithLock
 func (s *StatsEntryWithLock) GetStatsEntry() StatsEntry {
 	s.mutex.Lock()
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsEntryJSON(t *testing.T) {
+	entry := StatsEntry{
+		container:     "foo",
+		name:          "foo",
+		id:            "b25ae88e5b70f1c2",
+		cpuPercentage: 12.5,
+		memory:        1024,
+		memoryLimit:   4096,
+		networkRx:     10,
+		blockWrite:    20,
+		pidsCurrent:   3,
+	}
+
+	data, err := json.Marshal(entry.JSON())
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Container":"foo","ID":"b25ae88e5b70","Name":"foo","CPUPercentage":12.5,"MemoryUsage":1024,"MemoryLimit":4096,"MemoryPercentage":0,"NetworkRx":10,"NetworkTx":0,"BlockRead":0,"BlockWrite":20,"PIDs":3}`, string(data))
+}
+
+func TestCalculateCPUPercentUnix(t *testing.T) {
+	cpuStats := &types.CPUStats{
+		CPUUsage:       &types.CPUUsage{TotalUsage: 300},
+		SyetemCPUUsage: 2000,
+		OnlineCpus:     2,
+	}
+
+	// the usage is calculated by the delta between two samples.
+	assert.Equal(t, 40.0, calculateCPUPercentUnix(100, 1000, cpuStats))
+	assert.Equal(t, 0.0, calculateCPUPercentUnix(300, 2000, cpuStats))
+	assert.Equal(t, 0.0, calculateCPUPercentUnix(0, 0, nil))
+}
@@ -2,10 +2,7 @@ package main
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -18,7 +15,8 @@ var stopDescription = "Stop one or more running containers in Pouchd. Waiti
 // StopCommand use to implement 'stop' command, it stops a container.
 type StopCommand struct {
 	baseCommand
-	timeout int
+	timeout  int
+	parallel int
 }
 
 // Init initialize stop command.
@@ -40,7 +38,8 @@ func (s *StopCommand) Init(c *Cli) {
 // addFlags adds flags for specific command.
 func (s *StopCommand) addFlags() {
 	flagSet := s.cmd.Flags()
-	flagSet.IntVarP(&s.timeout, "time", "t", 10, "Seconds to wait for stop before killing it")
+	flagSet.IntVarP(&s.timeout, "time", "t", 0, "Seconds to wait for stop before killing it, the stop timeout of container is used if not set, 0 kills it immediately")
+	flagSet.IntVar(&s.parallel, "parallel", 1, "Number of containers to stop concurrently")
 }
 
 // runStop is the entry of stop command.
@@ -48,20 +47,15 @@ func (s *StopCommand) runStop(args []string) error {
 	ctx := context.Background()
 	apiClient := s.cli.Client()
 
-	var errs []string
-	for _, name := range args {
-		if err := apiClient.ContainerStop(ctx, name, strconv.Itoa(s.timeout)); err != nil {
-			errs = append(errs, err.Error())
-			continue
-		}
-		fmt.Printf("%s\n", name)
+	// use the stop timeout of container if not set.
+	timeout := ""
+	if s.cmd.Flags().Changed("time") {
+		timeout = strconv.Itoa(s.timeout)
 	}
 
-	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, "\n"))
-	}
-
-	return nil
+	return runOnContainers(args, s.parallel, func(name string) error {
+		return apiClient.ContainerStop(ctx, name, timeout)
+	})
 }
 
 // stopExample shows examples in stop command, and is used in auto-generated cli docs.
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/alibaba/pouch/client"
+	"github.com/alibaba/pouch/pkg/ioutils"
+	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/term"
+
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+const (
+	// resizeRetryTimes is the max times of retrying the first resize.
+	resizeRetryTimes = 16
+	// resizeRetryInterval is the first backoff interval of retrying resize.
+	resizeRetryInterval = 10 * time.Millisecond
+	// resizeMaxRetryInterval is the max backoff interval of retrying resize.
+	resizeMaxRetryInterval = 200 * time.Millisecond
+)
+
+const (
+	// defaultTtyWidth is the tty width used when the size can not be got
+	// from terminal or COLUMNS.
+	defaultTtyWidth = 80
+	// defaultTtyHeight is the tty height used when the size can not be got
+	// from terminal or LINES.
+	defaultTtyHeight = 24
+)
+
+// streamBytes is the number of bytes transferred on the streams.
+type streamBytes struct {
+	StdinBytes  int64
+	StdoutBytes int64
+	StderrBytes int64
+}
+
+// countingWriter counts the bytes written into w, it is safe to read the
+// count while writing.
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+// Write implements io.Writer interface.
+func (c countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	atomic.AddInt64(c.n, int64(n))
+	return n, err
+}
+
+// streamAttach holds the streams between the client and the hijacked
+// connection of a container or an exec process, it is shared by exec and
+// the attaching of run and start, so that they handle the raw mode, detach
+// keys and tty resizing in the same way.
+type streamAttach struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	in          io.Reader
+	out, errOut io.Writer
+
+	// escapeKeys is the key sequence for detaching, only used with tty.
+	escapeKeys []byte
+
+	stdin, stdout, stderr, tty bool
+
+	// multiplexed is true if STDOUT and STDERR are multiplexed in the
+	// connection by stdcopy.
+	multiplexed bool
+
+	// resize resizes the tty, it is called once the streams are held and
+	// then each time the terminal is resized.
+	resize func(ctx context.Context, width, height int) error
+
+	// rows and cols fix the size of tty if positive, the size of terminal
+	// is used for the other one, and the resizing of terminal is ignored.
+	rows, cols int
+}
+
+// hold copies the streams until the output is done, ctx is done or the
+// detach keys are read, in which case term.ErrEscapeDetach is returned. It
+// returns the bytes transferred on each stream.
+func (s *streamAttach) hold(ctx context.Context) (streamBytes, error) {
+	// the counts are loaded atomically, since the copying goroutines may be
+	// still running when returned.
+	var stdinBytes, stdoutBytes, stderrBytes int64
+	transferred := func() streamBytes {
+		return streamBytes{
+			StdinBytes:  atomic.LoadInt64(&stdinBytes),
+			StdoutBytes: atomic.LoadInt64(&stdoutBytes),
+			StderrBytes: atomic.LoadInt64(&stderrBytes),
+		}
+	}
+	out := countingWriter{w: s.out, n: &stdoutBytes}
+	errOut := countingWriter{w: s.errOut, n: &stderrBytes}
+
+	in := s.in
+	if s.stdin && s.tty {
+		if len(s.escapeKeys) > 0 {
+			in = term.NewEscapeProxy(in, s.escapeKeys)
+		}
+
+		for _, t := range []term.Terminal{term.NewInputTerminal(os.Stdin), term.NewOutputTerminal(os.Stdout)} {
+			if !t.IsTerminal() {
+				continue
+			}
+
+			restore, err := t.SetRawMode()
+			if err != nil {
+				return streamBytes{}, fmt.Errorf("failed to set raw mode")
+			}
+			defer func() {
+				if err := restore(); err != nil {
+					log.With(ctx).Warnf("failed to restore term mode: %v", err)
+				}
+			}()
+		}
+	}
+
+	stdoutDone := make(chan error, 1)
+	go func() {
+		var err error
+		switch {
+		case !s.stdout && !s.stderr:
+			// nothing is attached, wait for the connection to be closed.
+			_, err = io.Copy(ioutil.Discard, s.reader)
+		case !s.multiplexed:
+			_, err = io.Copy(out, s.reader)
+		default:
+			_, err = stdcopy.StdCopy(out, errOut, s.reader)
+		}
+		stdoutDone <- err
+	}()
+
+	// stop copying stdin once returned, since the read of os.Stdin blocks
+	// even after the process exits.
+	stopStdin := make(chan struct{})
+	defer close(stopStdin)
+
+	stdinDone := make(chan error, 1)
+	go func() {
+		if s.stdin {
+			if _, err := io.Copy(countingWriter{w: s.conn, n: &stdinBytes}, ioutils.NewCancelReader(in, stopStdin)); err == term.ErrEscapeDetach {
+				stdinDone <- err
+				return
+			}
+			// close write if receive CTRL-D
+			if cw, ok := s.conn.(ioutils.CloseWriter); ok {
+				cw.CloseWrite()
+			}
+		}
+
+		stdinDone <- nil
+	}()
+
+	if s.tty && s.resize != nil {
+		// stop resizing once the streams are done.
+		resizeCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		s.monitorTtySize(resizeCtx)
+	}
+
+	select {
+	case err := <-stdoutDone:
+		if err != nil {
+			log.With(ctx).Debugf("receive stdout error: %s", err)
+			return transferred(), err
+		}
+
+	case err := <-stdinDone:
+		if err == term.ErrEscapeDetach {
+			return transferred(), err
+		}
+
+		select {
+		case err := <-stdoutDone:
+			log.With(ctx).Debugf("receive stdout error: %s", err)
+			return transferred(), err
+		case <-ctx.Done():
+		}
+
+	case <-ctx.Done():
+	}
+
+	return transferred(), nil
+}
+
+// monitorTtySize resizes the tty to the size of terminal, and resizes it
+// again each time the terminal is resized until ctx is done, unless the size
+// is fixed.
+func (s *streamAttach) monitorTtySize(ctx context.Context) {
+	stdin := term.NewInputTerminal(os.Stdin)
+	width, height := ttySize(stdin)
+	if s.cols > 0 {
+		width = s.cols
+	}
+	if s.rows > 0 {
+		height = s.rows
+	}
+
+	if err := resizeWithRetry(ctx, s.resize, width, height); err != nil {
+		log.With(ctx).Debugf("failed to resize tty, err(%v)", err)
+	}
+	if ctx.Err() != nil || s.rows > 0 || s.cols > 0 {
+		return
+	}
+
+	resized := stdin.NotifyResize(ctx)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-resized:
+			}
+
+			width, height, err := stdin.GetSize()
+			if err != nil {
+				log.With(ctx).Debugf("failed to get tty size, err(%v)", err)
+				continue
+			}
+			if err := s.resize(ctx, width, height); err != nil {
+				log.With(ctx).Debugf("failed to resize tty, err(%v)", err)
+			}
+		}
+	}()
+}
+
+// resizeContainer returns the resize function of streamAttach for the tty
+// of container.
+func resizeContainer(apiClient client.CommonAPIClient, name string) func(ctx context.Context, width, height int) error {
+	return func(ctx context.Context, width, height int) error {
+		return apiClient.ContainerResize(ctx, name, strconv.Itoa(height), strconv.Itoa(width))
+	}
+}
+
+// ttySize returns the size of terminal t. If t is not a terminal, for
+// example stdin is a pipe in CI, it falls back to the COLUMNS and LINES
+// environment variables, and then the default 80x24.
+func ttySize(t term.Terminal) (int, int) {
+	if width, height, err := t.GetSize(); err == nil {
+		return width, height
+	}
+
+	width, height := defaultTtyWidth, defaultTtyHeight
+	if v, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && v > 0 {
+		width = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("LINES")); err == nil && v > 0 {
+		height = v
+	}
+	return width, height
+}
+
+// resizeWithRetry retries the first resize with backoff, since the process
+// may not be ready, and stops once the context is cancelled.
+func resizeWithRetry(ctx context.Context, resize func(ctx context.Context, width, height int) error, width, height int) error {
+	var err error
+
+	interval := resizeRetryInterval
+	for i := 0; i < resizeRetryTimes; i++ {
+		if err = resize(ctx, width, height); err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+		if interval *= 2; interval > resizeMaxRetryInterval {
+			interval = resizeMaxRetryInterval
+		}
+	}
+	return err
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/pkg/term"
+
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+// fakeHijackConn records CloseWrite, which is called once the stdin copy is done.
+type fakeHijackConn struct {
+	net.Conn
+	closeWrite chan struct{}
+}
+
+func (c *fakeHijackConn) CloseWrite() error {
+	close(c.closeWrite)
+	return nil
+}
+
+func TestStreamAttachExitWithStdinOpen(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	conn := &fakeHijackConn{Conn: client, closeWrite: make(chan struct{})}
+
+	// the process exits immediately, while nothing is ever typed on stdin.
+	stdin, stdinWriter := io.Pipe()
+	defer stdinWriter.Close()
+
+	streams := &streamAttach{
+		conn:        conn,
+		reader:      bufio.NewReader(strings.NewReader("")),
+		in:          stdin,
+		out:         ioutil.Discard,
+		errOut:      ioutil.Discard,
+		stdin:       true,
+		stdout:      true,
+		stderr:      true,
+		multiplexed: true,
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := streams.hold(context.Background())
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("hold should return once the process exits")
+	}
+
+	select {
+	case <-conn.closeWrite:
+	case <-time.After(time.Second):
+		t.Fatal("the stdin copy should stop once hold returns")
+	}
+}
+
+func TestStreamAttachBytes(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	conn := &fakeHijackConn{Conn: client, closeWrite: make(chan struct{})}
+
+	// the process echoes the output once the stdin is closed.
+	output, outputWriter := io.Pipe()
+	go func() {
+		buf := make([]byte, len("input"))
+		io.ReadFull(server, buf)
+		<-conn.closeWrite
+
+		stdcopy.NewStdWriter(outputWriter, stdcopy.Stdout).Write([]byte("stdout"))
+		stdcopy.NewStdWriter(outputWriter, stdcopy.Stderr).Write([]byte("err"))
+		outputWriter.Close()
+	}()
+
+	var stdout, stderr strings.Builder
+	streams := &streamAttach{
+		conn:        conn,
+		reader:      bufio.NewReader(output),
+		in:          strings.NewReader("input"),
+		out:         &stdout,
+		errOut:      &stderr,
+		stdin:       true,
+		stdout:      true,
+		stderr:      true,
+		multiplexed: true,
+	}
+
+	transferred, err := streams.hold(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "stdout", stdout.String())
+	assert.Equal(t, "err", stderr.String())
+	assert.Equal(t, streamBytes{StdinBytes: 5, StdoutBytes: 6, StderrBytes: 3}, transferred)
+}
+
+func TestStreamAttachRaw(t *testing.T) {
+	client, _ := net.Pipe()
+	defer client.Close()
+
+	// the output of attaching a container without tty is not multiplexed.
+	var stdout strings.Builder
+	streams := &streamAttach{
+		conn:   client,
+		reader: bufio.NewReader(strings.NewReader("output")),
+		out:    &stdout,
+		errOut: ioutil.Discard,
+		stdout: true,
+		stderr: true,
+	}
+
+	transferred, err := streams.hold(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "output", stdout.String())
+	assert.Equal(t, int64(6), transferred.StdoutBytes)
+}
+
+func TestResizeWithRetry(t *testing.T) {
+	resizes := 0
+	resize := func(ctx context.Context, width, height int) error {
+		resizes++
+		return nil
+	}
+	assert.NoError(t, resizeWithRetry(context.Background(), resize, 80, 24))
+	assert.Equal(t, 1, resizes)
+
+	resizes = 0
+	resize = func(ctx context.Context, width, height int) error {
+		resizes++
+		return fmt.Errorf("not ready")
+	}
+	assert.Error(t, resizeWithRetry(context.Background(), resize, 80, 24))
+	assert.Equal(t, resizeRetryTimes, resizes)
+
+	// cancelled context stops retrying immediately.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resizes = 0
+	assert.Equal(t, context.Canceled, resizeWithRetry(ctx, resize, 80, 24))
+	assert.Equal(t, 1, resizes)
+}
+
+func TestTtySize(t *testing.T) {
+	f, err := ioutil.TempFile("", "tty-size")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	defer os.Setenv("COLUMNS", os.Getenv("COLUMNS"))
+	defer os.Setenv("LINES", os.Getenv("LINES"))
+
+	os.Setenv("COLUMNS", "")
+	os.Setenv("LINES", "")
+	width, height := ttySize(term.NewInputTerminal(f))
+	assert.Equal(t, defaultTtyWidth, width)
+	assert.Equal(t, defaultTtyHeight, height)
+
+	os.Setenv("COLUMNS", "132")
+	os.Setenv("LINES", "invalid")
+	width, height = ttySize(term.NewInputTerminal(f))
+	assert.Equal(t, 132, width)
+	assert.Equal(t, defaultTtyHeight, height)
+}
+
+func TestMonitorTtySizeFixed(t *testing.T) {
+	var sizes [][2]int
+	streams := &streamAttach{
+		rows: 40,
+		cols: 100,
+		resize: func(ctx context.Context, width, height int) error {
+			sizes = append(sizes, [2]int{width, height})
+			return nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the fixed size is sent once, and SIGWINCH is not watched.
+	streams.monitorTtySize(ctx)
+	assert.NoError(t, unix.Kill(os.Getpid(), unix.SIGWINCH))
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, [][2]int{{100, 40}}, sizes)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// systemDescription is used to describe system command in detail and auto generate command doc.
+var systemDescription = "Manage Pouch system"
+
+// SystemCommand use to implement 'system' command.
+type SystemCommand struct {
+	baseCommand
+}
+
+// Init initialize "system" command.
+func (s *SystemCommand) Init(c *Cli) {
+	s.cli = c
+
+	s.cmd = &cobra.Command{
+		Use:   "system",
+		Short: "Manage system",
+		Long:  systemDescription,
+		Args:  cobra.NoArgs,
+	}
+
+	s.cli.AddCommand(s, &SystemDfCommand{})
+	s.cli.AddCommand(s, &SystemPruneCommand{})
+}
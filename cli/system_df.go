@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// systemDfDescription is used to describe system df command in detail and auto generate command doc.
+var systemDfDescription = "Show the disk space used by images, containers, volumes and build cache. " +
+	"The space of objects not used by any running container is reclaimable. " +
+	"With --verbose, every object is listed. With --format, the summary of each type " +
+	"is printed, or the whole usage if --verbose is also set."
+
+// SystemDfCommand use to implement 'system df' command.
+type SystemDfCommand struct {
+	baseCommand
+	verbose bool
+	format  string
+}
+
+// Init initialize "system df" command.
+func (s *SystemDfCommand) Init(c *Cli) {
+	s.cli = c
+	s.cmd = &cobra.Command{
+		Use:   "df [OPTIONS]",
+		Short: "Show disk usage",
+		Long:  systemDfDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return s.runDf(args)
+		},
+		Example: s.example(),
+	}
+	s.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (s *SystemDfCommand) addFlags() {
+	flagSet := s.cmd.Flags()
+	flagSet.BoolVarP(&s.verbose, "verbose", "v", false, "Show detailed usage of every object")
+	flagSet.StringVar(&s.format, "format", "", formatFlagUsage)
+}
+
+// diskUsageSummary is the disk usage of one type of objects.
+type diskUsageSummary struct {
+	Type        string
+	TotalCount  int
+	Active      int
+	Size        int64
+	Reclaimable int64
+}
+
+// summarizeDiskUsage aggregates the disk usage by type, the objects whose
+// size is unknown are counted but not sized.
+func summarizeDiskUsage(du *types.DiskUsage) []diskUsageSummary {
+	summarize := func(typ string, objs []*types.DiskUsageObject) diskUsageSummary {
+		s := diskUsageSummary{Type: typ, TotalCount: len(objs)}
+		for _, obj := range objs {
+			if obj.InUse {
+				s.Active++
+			}
+			if obj.Size <= 0 {
+				continue
+			}
+			s.Size += obj.Size
+			if !obj.InUse {
+				s.Reclaimable += obj.Size
+			}
+		}
+		return s
+	}
+
+	return []diskUsageSummary{
+		summarize("Images", du.Images),
+		summarize("Containers", du.Containers),
+		summarize("Local Volumes", du.Volumes),
+		summarize("Build Cache", du.BuildCache),
+	}
+}
+
+// runDf is the entry of system df command.
+func (s *SystemDfCommand) runDf(args []string) error {
+	fw, err := newFormatWriter(os.Stdout, s.format)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	apiClient := s.cli.Client()
+
+	du, err := apiClient.SystemDiskUsage(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get disk usage: %v", err)
+	}
+
+	summaries := summarizeDiskUsage(du)
+	if fw != nil {
+		if s.verbose {
+			return fw.Write(du)
+		}
+		for _, summary := range summaries {
+			if err := fw.Write(summary); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if s.verbose {
+		s.displayVerbose(du)
+		return nil
+	}
+
+	display := s.cli.NewTableDisplay()
+	display.AddRow([]string{"TYPE", "TOTAL", "ACTIVE", "SIZE", "RECLAIMABLE"})
+	for _, summary := range summaries {
+		reclaimable := utils.FormatSize(summary.Reclaimable)
+		if summary.Size > 0 {
+			reclaimable = fmt.Sprintf("%s (%d%%)", reclaimable, summary.Reclaimable*100/summary.Size)
+		}
+		display.AddRow([]string{
+			summary.Type,
+			strconv.Itoa(summary.TotalCount),
+			strconv.Itoa(summary.Active),
+			utils.FormatSize(summary.Size),
+			reclaimable,
+		})
+	}
+	return display.Flush()
+}
+
+// displayVerbose prints the usage of every object, grouped by type.
+func (s *SystemDfCommand) displayVerbose(du *types.DiskUsage) {
+	for _, group := range []struct {
+		title string
+		objs  []*types.DiskUsageObject
+	}{
+		{"Images space usage:", du.Images},
+		{"Containers space usage:", du.Containers},
+		{"Local Volumes space usage:", du.Volumes},
+		{"Build cache usage:", du.BuildCache},
+	} {
+		fmt.Printf("%s\n\n", group.title)
+
+		display := s.cli.NewTableDisplay()
+		display.AddRow([]string{"ID", "NAME", "SIZE", "IN USE"})
+		for _, obj := range group.objs {
+			size := "N/A"
+			if obj.Size >= 0 {
+				size = utils.FormatSize(obj.Size)
+			}
+			display.AddRow([]string{
+				utils.TruncateID(obj.ID),
+				obj.Name,
+				size,
+				strconv.FormatBool(obj.InUse),
+			})
+		}
+		display.Flush()
+		fmt.Println()
+	}
+}
+
+// example shows examples in system df command, and is used in auto-generated cli docs.
+func (s *SystemDfCommand) example() string {
+	return `$ pouch system df
+TYPE            TOTAL   ACTIVE   SIZE       RECLAIMABLE
+Images          3       1        5.41 MB    4.12 MB (76%)
+Containers      2       1        12.00 KB   4.00 KB (33%)
+Local Volumes   1       1        1.02 MB    0.00 B (0%)
+Build Cache     0       0        0.00 B     0.00 B
+
+$ pouch system df --format json
+{"Type":"Images","TotalCount":3,"Active":1,"Size":5672960,"Reclaimable":4320256}
+...`
+}
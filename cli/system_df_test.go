@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeDiskUsage(t *testing.T) {
+	du := &types.DiskUsage{
+		Images: []*types.DiskUsageObject{
+			{ID: "sha256:1", Name: "busybox:latest", Size: 100, InUse: true},
+			{ID: "sha256:2", Name: "<none>", Size: 300},
+		},
+		Containers: []*types.DiskUsageObject{
+			{ID: "c1", Name: "foo", Size: 10, InUse: true},
+			{ID: "c2", Name: "bar", Size: -1},
+		},
+		Volumes: []*types.DiskUsageObject{
+			{Name: "vol", Size: 0, InUse: true},
+		},
+	}
+
+	assert.Equal(t, []diskUsageSummary{
+		{Type: "Images", TotalCount: 2, Active: 1, Size: 400, Reclaimable: 300},
+		{Type: "Containers", TotalCount: 2, Active: 1, Size: 10, Reclaimable: 0},
+		{Type: "Local Volumes", TotalCount: 1, Active: 1, Size: 0, Reclaimable: 0},
+		{Type: "Build Cache"},
+	}, summarizeDiskUsage(du))
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// systemPruneDescription is used to describe system prune command in detail and auto generate command doc.
+var systemPruneDescription = "Remove all stopped containers, dangling images and networks not used by any container. " +
+	"With --all, all the images not used by any container are removed. With --volumes, all the volumes " +
+	"not used by any container are removed too."
+
+// SystemPruneCommand use to implement 'system prune' command.
+type SystemPruneCommand struct {
+	baseCommand
+	all     bool
+	volumes bool
+	filter  []string
+	force   bool
+}
+
+// Init initialize "system prune" command.
+func (s *SystemPruneCommand) Init(c *Cli) {
+	s.cli = c
+	s.cmd = &cobra.Command{
+		Use:   "prune [OPTIONS]",
+		Short: "Remove unused data",
+		Long:  systemPruneDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return s.runPrune(args)
+		},
+		Example: s.example(),
+	}
+	s.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (s *SystemPruneCommand) addFlags() {
+	flagSet := s.cmd.Flags()
+	flagSet.BoolVarP(&s.all, "all", "a", false, "Remove all unused images, not just dangling ones")
+	flagSet.BoolVar(&s.volumes, "volumes", false, "Remove all unused volumes")
+	flagSet.StringSliceVar(&s.filter, "filter", nil, "Provide filter values, support until=<timestamp> and label=<key>[=<value>]")
+	flagSet.BoolVarP(&s.force, "force", "f", false, "Do not prompt for confirmation")
+}
+
+// pruneResult is the objects deleted and the space reclaimed by pruning one
+// type of objects.
+type pruneResult struct {
+	typ            string
+	deleted        []string
+	spaceReclaimed int64
+	// hasSpace is false if the type of objects doesn't use disk space.
+	hasSpace bool
+}
+
+// runPrune is the entry of system prune command.
+func (s *SystemPruneCommand) runPrune(args []string) error {
+	ctx := context.Background()
+	apiClient := s.cli.Client()
+
+	filter, err := filters.FromFilterOpts(s.filter)
+	if err != nil {
+		return err
+	}
+	if filter.Contains("dangling") {
+		return fmt.Errorf("invalid filter dangling, use --all instead")
+	}
+	if s.volumes && filter.Contains("until") {
+		return fmt.Errorf("invalid filter until, which is not supported with --volumes")
+	}
+
+	if !s.force && !confirm(os.Stdin, os.Stdout, systemPruneWarning(s.all, s.volumes)) {
+		return nil
+	}
+
+	var results []pruneResult
+	defer func() {
+		printPruneResults(os.Stdout, results)
+	}()
+
+	containers, err := apiClient.ContainerPrune(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to prune containers: %v", err)
+	}
+	results = append(results, pruneResult{"Containers", containers.ContainersDeleted, containers.SpaceReclaimed, true})
+
+	networks, err := apiClient.NetworkPrune(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to prune networks: %v", err)
+	}
+	results = append(results, pruneResult{"Networks", networks.NetworksDeleted, 0, false})
+
+	if s.volumes {
+		volumes, err := apiClient.VolumePrune(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to prune volumes: %v", err)
+		}
+		results = append(results, pruneResult{"Volumes", volumes.VolumesDeleted, volumes.SpaceReclaimed, true})
+	}
+
+	// the images are pruned at last, since the images used by the deleted
+	// containers become unused.
+	if s.all {
+		filter.Add("dangling", "false")
+	}
+	images, err := apiClient.ImagePrune(ctx, filter, false)
+	if err != nil {
+		return fmt.Errorf("failed to prune images: %v", err)
+	}
+	results = append(results, pruneResult{"Images", images.ImagesDeleted, images.SpaceReclaimed, true})
+
+	return nil
+}
+
+// systemPruneWarning returns the warning listing the objects to be removed.
+func systemPruneWarning(all, volumes bool) string {
+	items := []string{
+		"all stopped containers",
+		"all networks not used by at least one container",
+	}
+	if volumes {
+		items = append(items, "all volumes not used by at least one container")
+	}
+	if all {
+		items = append(items, "all images without at least one container associated to them")
+	} else {
+		items = append(items, "all dangling images")
+	}
+	return "WARNING! This will remove:\n\t- " + strings.Join(items, "\n\t- ")
+}
+
+// printPruneResults prints the deleted objects and the space reclaimed of
+// each type, and the total reclaimed space at last.
+func printPruneResults(out io.Writer, results []pruneResult) {
+	var total int64
+	for _, r := range results {
+		if len(r.deleted) == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "Deleted %s:\n", r.typ)
+		for _, id := range r.deleted {
+			fmt.Fprintln(out, id)
+		}
+		fmt.Fprintln(out)
+	}
+
+	for _, r := range results {
+		if !r.hasSpace {
+			continue
+		}
+		fmt.Fprintf(out, "%s reclaimed space: %s\n", r.typ, utils.FormatSize(r.spaceReclaimed))
+		total += r.spaceReclaimed
+	}
+	fmt.Fprintf(out, "Total reclaimed space: %s\n", utils.FormatSize(total))
+}
+
+// example shows examples in system prune command, and is used in auto-generated cli docs.
+func (s *SystemPruneCommand) example() string {
+	return `$ pouch system prune -a -f --volumes
+Deleted Containers:
+e0b3ceb3f4b5c1a2ac4ab4fce2b5e4e1d7c8e1b6a7d3df16ed62aa1b3b05d5c1
+
+Deleted Volumes:
+pouch-volume-1
+
+Deleted Images:
+sha256:8c811b4aec35f259572d0f79207bc0678df4c736eeec50bc9fec37ed936a472a
+
+Containers reclaimed space: 12.00 KB
+Volumes reclaimed space: 1.02 MB
+Images reclaimed space: 1.32 MB
+Total reclaimed space: 2.35 MB`
+}
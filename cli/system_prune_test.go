@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemPruneWarning(t *testing.T) {
+	assert.Equal(t, "WARNING! This will remove:\n"+
+		"\t- all stopped containers\n"+
+		"\t- all networks not used by at least one container\n"+
+		"\t- all dangling images", systemPruneWarning(false, false))
+
+	assert.Equal(t, "WARNING! This will remove:\n"+
+		"\t- all stopped containers\n"+
+		"\t- all networks not used by at least one container\n"+
+		"\t- all volumes not used by at least one container\n"+
+		"\t- all images without at least one container associated to them", systemPruneWarning(true, true))
+}
+
+func TestPrintPruneResults(t *testing.T) {
+	out := &bytes.Buffer{}
+	printPruneResults(out, []pruneResult{
+		{"Containers", []string{"c1"}, 1024, true},
+		{"Networks", []string{"net1"}, 0, false},
+		{"Images", []string{}, 0, true},
+	})
+	assert.Equal(t, "Deleted Containers:\nc1\n\n"+
+		"Deleted Networks:\nnet1\n\n"+
+		"Containers reclaimed space: 1.00 KB\n"+
+		"Images reclaimed space: 0.00 B\n"+
+		"Total reclaimed space: 1.00 KB\n", out.String())
+}
@@ -2,9 +2,6 @@ package main
 
 import (
 	"context"
-	"errors"
-	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -39,20 +36,9 @@ func (p *UnpauseCommand) runUnpause(args []string) error {
 	ctx := context.Background()
 	apiClient := p.cli.Client()
 
-	var errs []string
-	for _, name := range args {
-		if err := apiClient.ContainerUnpause(ctx, name); err != nil {
-			errs = append(errs, err.Error())
-			continue
-		}
-		fmt.Printf("%s\n", name)
-	}
-
-	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, "\n"))
-	}
-
-	return nil
+	return runOnContainers(args, 1, func(name string) error {
+		return apiClient.ContainerUnpause(ctx, name)
+	})
 }
 
 // unpauseExample shows examples in unpause command, and is used in auto-generated cli docs.
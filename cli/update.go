@@ -44,6 +44,7 @@ func (uc *UpdateCommand) addFlags() {
 	flagSet.Var(&uc.blkioDeviceReadIOps, "device-read-iops", "Update read rate (io per second) from a device")
 	flagSet.Var(&uc.blkioDeviceWriteBps, "device-write-bps", "Update write rate (bytes per second) from a device")
 	flagSet.Var(&uc.blkioDeviceWriteIOps, "device-write-iops", "Update write rate (io per second) from a device")
+	flagSet.Float64Var(&uc.cpus, "cpus", 0, "Number of CPUs, which can not be set with --cpu-period and --cpu-quota")
 	flagSet.Int64Var(&uc.cpuperiod, "cpu-period", 0, "Limit CPU CFS (Completely Fair Scheduler) period, range is in [1000(1ms),1000000(1s)]")
 	flagSet.Int64Var(&uc.cpushare, "cpu-shares", 0, "CPU shares (relative weight)")
 	flagSet.Int64Var(&uc.cpuquota, "cpu-quota", 0, "Limit CPU CFS (Completely Fair Scheduler) quota")
@@ -51,8 +52,11 @@ func (uc *UpdateCommand) addFlags() {
 	flagSet.StringVar(&uc.cpusetmems, "cpuset-mems", "", "MEMs in cpuset which to allow execution (0-3, 0, 1)")
 	flagSet.StringVarP(&uc.memory, "memory", "m", "", "Container memory limit")
 	flagSet.StringVar(&uc.memorySwap, "memory-swap", "", "Container swap limit")
+	flagSet.Int64Var(&uc.pidsLimit, "pids-limit", 0, "Tune container pids limit (set -1 for unlimited)")
+	flagSet.Int64Var(&uc.oomScoreAdj, "oom-score-adj", 0, "Tune host's OOM preferences (-1000 to 1000), it takes effect on running container at once")
 	flagSet.StringSliceVarP(&uc.env, "env", "e", nil, "Update environment variables for container('--env A=' means updating env A to be empty and '--env A' means removing env A)")
-	flagSet.StringSliceVarP(&uc.labels, "label", "l", nil, "Update labels for container")
+	flagSet.StringSliceVarP(&uc.labels, "label", "l", nil, "Update labels for container('--label A=' or '--label A' means removing label A)")
+	flagSet.StringArrayVar(&uc.labelFiles, "label-file", nil, "Read in a line delimited file of labels to update")
 	flagSet.StringVar(&uc.restartPolicy, "restart", "", "Restart policy to apply when container exits")
 	flagSet.StringSliceVar(&uc.diskQuota, "disk-quota", nil, "Update disk quota for container(/=10g)")
 	flagSet.StringSliceVar(&uc.specAnnotation, "annotation", nil, "Update annotation for runtime spec")
@@ -73,6 +77,44 @@ func (uc *UpdateCommand) updateRun(args []string) error {
 		return err
 	}
 
+	nanoCPUs, err := opts.ParseCPUs(uc.cpus, uc.cpuperiod, uc.cpuquota)
+	if err != nil {
+		return err
+	}
+
+	if err := opts.ValidateCpuset(uc.cpusetcpus); err != nil {
+		return err
+	}
+
+	if err := opts.ValidateCpuset(uc.cpusetmems); err != nil {
+		return err
+	}
+
+	if err := opts.ValidatePidsLimit(uc.pidsLimit); err != nil {
+		return err
+	}
+
+	if err := opts.ValidateBlkioWeight(uc.blkioWeight); err != nil {
+		return err
+	}
+
+	labels, err := readLabelStrings(uc.labelFiles, uc.labels)
+	if err != nil {
+		return err
+	}
+	if err := opts.ValidateLabels(labels); err != nil {
+		return err
+	}
+
+	// oom score adj is only updated when the flag is set, since 0 is valid.
+	var oomScoreAdj *int64
+	if uc.cmd.Flags().Changed("oom-score-adj") {
+		if err := opts.ValidateOOMScoreAdj(uc.oomScoreAdj); err != nil {
+			return err
+		}
+		oomScoreAdj = &uc.oomScoreAdj
+	}
+
 	resource := types.Resources{
 		BlkioWeight:          uc.blkioWeight,
 		BlkioDeviceReadBps:   uc.blkioDeviceReadBps.Value(),
@@ -82,10 +124,12 @@ func (uc *UpdateCommand) updateRun(args []string) error {
 		CPUPeriod:            uc.cpuperiod,
 		CPUShares:            uc.cpushare,
 		CPUQuota:             uc.cpuquota,
+		NanoCpus:             nanoCPUs,
 		CpusetCpus:           uc.cpusetcpus,
 		CpusetMems:           uc.cpusetmems,
 		Memory:               memory,
 		MemorySwap:           memorySwap,
+		PidsLimit:            uc.pidsLimit,
 	}
 
 	restartPolicy, err := opts.ParseRestartPolicy(uc.restartPolicy)
@@ -105,11 +149,12 @@ func (uc *UpdateCommand) updateRun(args []string) error {
 
 	updateConfig := &types.UpdateConfig{
 		Env:            uc.env,
-		Label:          uc.labels,
+		Label:          labels,
 		RestartPolicy:  restartPolicy,
 		Resources:      resource,
 		DiskQuota:      diskQuota,
 		SpecAnnotation: annotation,
+		OomScoreAdj:    oomScoreAdj,
 	}
 
 	apiClient := uc.cli.Client()
@@ -125,5 +170,14 @@ $ cat /sys/fs/cgroup/memory/8649804cb63ff9713a2734d99728b9d6d5d1e4d2fbafb2b4dbdf
 $ pouch update -m 30m test-update
 $ cat /sys/fs/cgroup/memory/8649804cb63ff9713a2734d99728b9d6d5d1e4d2fbafb2b4dbdf79c6bbaef812/memory.limit_in_bytes
 31457280
+$ pouch update --cpus 1.5 --restart always test-update
+$ pouch inspect -f "{{.HostConfig.NanoCpus}} {{.HostConfig.CPUQuota}} {{.HostConfig.RestartPolicy.Name}}" test-update
+1500000000 150000 always
+$ pouch update --pids-limit 100 test-update
+$ pouch inspect -f "{{.HostConfig.PidsLimit}}" test-update
+100
+$ pouch update --oom-score-adj 100 test-update
+$ pouch inspect -f "{{.HostConfig.OomScoreAdj}}" test-update
+100
 	`
 }
@@ -42,6 +42,7 @@ func (v *VolumeCommand) Init(c *Cli) {
 	c.AddCommand(v, &VolumeRemoveCommand{})
 	c.AddCommand(v, &VolumeInspectCommand{})
 	c.AddCommand(v, &VolumeListCommand{})
+	c.AddCommand(v, &VolumePruneCommand{})
 }
 
 // RunE is the entry of VolumeCommand command.
@@ -287,6 +288,19 @@ type VolumeListCommand struct {
 	mountPoint bool
 	quiet      bool
 	filter     []string
+	format     string
+}
+
+// volumeContext is the object of volume printed by --format, the ID of
+// volume is its name.
+type volumeContext struct {
+	ID         string
+	Name       string
+	Driver     string
+	Mountpoint string
+	Scope      string
+	Size       string
+	Labels     map[string]string
 }
 
 // Init initializes VolumeListCommand command.
@@ -313,6 +327,7 @@ func (v *VolumeListCommand) addFlags() {
 	flagSet.BoolVar(&v.mountPoint, "mountpoint", false, "Display volume mountpoint")
 	flagSet.BoolVarP(&v.quiet, "quiet", "q", false, "Only display volume names")
 	flagSet.StringSliceVarP(&v.filter, "filter", "f", []string{}, "Filter output based on conditions provided, filter support driver, name, label")
+	flagSet.StringVar(&v.format, "format", "", formatFlagUsage)
 }
 
 // runVolumeList is the entry of VolumeListCommand command.
@@ -327,11 +342,36 @@ func (v *VolumeListCommand) runVolumeList(args []string) error {
 		return err
 	}
 
+	formatter, err := newFormatWriter(os.Stdout, v.format)
+	if err != nil {
+		return err
+	}
+
 	volumeList, err := apiClient.VolumeList(ctx, volumeFilterArgs)
 	if err != nil {
 		return err
 	}
 
+	if formatter != nil && !v.quiet {
+		for _, volume := range volumeList.Volumes {
+			vc := volumeContext{
+				ID:         volume.Name,
+				Name:       volume.Name,
+				Driver:     volume.Driver,
+				Mountpoint: volume.Mountpoint,
+				Scope:      volume.Scope,
+				Labels:     volume.Labels,
+			}
+			if s, ok := volume.Status["size"].(string); ok {
+				vc.Size = s
+			}
+			if err := formatter.Write(vc); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	if (v.size || v.mountPoint) && v.quiet {
 		return fmt.Errorf("Conflicting options: --size (or --mountpoint) and -q")
 	}
@@ -385,5 +425,80 @@ $ pouch volume list --quiet
 VOLUME NAME
 pouch-volume-1
 pouch-volume-2
-pouch-volume-3`
+pouch-volume-3
+$ pouch volume list --format "{{.Name}}: {{.Mountpoint}}"
+pouch-volume-1: /mnt/local/pouch-volume-1
+pouch-volume-2: /mnt/local/pouch-volume-2
+pouch-volume-3: /mnt/local/pouch-volume-3`
+}
+
+// volumePruneDescription is used to describe volume prune command in detail and auto generate command doc.
+var volumePruneDescription = "Remove all the volumes which are not used by any container."
+
+const volumePruneWarning = "WARNING! This will remove all volumes not used by at least one container."
+
+// VolumePruneCommand is used to implement 'volume prune' command.
+type VolumePruneCommand struct {
+	baseCommand
+	filter []string
+	force  bool
+}
+
+// Init initializes VolumePruneCommand command.
+func (v *VolumePruneCommand) Init(c *Cli) {
+	v.cli = c
+	v.cmd = &cobra.Command{
+		Use:   "prune [OPTIONS]",
+		Short: "Remove all unused volumes",
+		Long:  volumePruneDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return v.runVolumePrune(args)
+		},
+		Example: volumePruneExample(),
+	}
+	v.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (v *VolumePruneCommand) addFlags() {
+	flagSet := v.cmd.Flags()
+	flagSet.StringSliceVar(&v.filter, "filter", nil, "Provide filter values, support label=<key>[=<value>]")
+	flagSet.BoolVarP(&v.force, "force", "f", false, "Do not prompt for confirmation")
+}
+
+// runVolumePrune is the entry of VolumePruneCommand command.
+func (v *VolumePruneCommand) runVolumePrune(args []string) error {
+	ctx := context.Background()
+	apiClient := v.cli.Client()
+
+	filter, err := filters.FromFilterOpts(v.filter)
+	if err != nil {
+		return err
+	}
+
+	if !v.force && !confirm(os.Stdin, os.Stdout, volumePruneWarning) {
+		return nil
+	}
+
+	report, err := apiClient.VolumePrune(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to prune volumes: %v", err)
+	}
+
+	if len(report.VolumesDeleted) > 0 {
+		fmt.Println("Deleted Volumes:")
+		for _, name := range report.VolumesDeleted {
+			fmt.Println(name)
+		}
+	}
+	return nil
+}
+
+// volumePruneExample shows examples in volume prune command, and is used in auto-generated cli docs.
+func volumePruneExample() string {
+	return `$ pouch volume prune -f --filter label=env=test
+Deleted Volumes:
+pouch-volume-1
+pouch-volume-2`
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -12,11 +13,13 @@ import (
 // waitDescription is used to describe wait command in detail and auto generate command doc.
 var waitDescription = "Block until one or more containers stop, then print their exit codes. " +
 	"If container state is already stopped, the command will return exit code immediately. " +
-	"On a successful stop, the exit code of the container is returned. "
+	"On a successful stop, the exit code of the container is returned. " +
+	"If --timeout is set, the command fails if the containers do not stop in time."
 
 // WaitCommand is used to implement 'wait' command.
 type WaitCommand struct {
 	baseCommand
+	timeout time.Duration
 }
 
 // Init initializes wait command.
@@ -32,6 +35,13 @@ func (wait *WaitCommand) Init(c *Cli) {
 		},
 		Example: waitExamples(),
 	}
+	wait.addFlags()
+}
+
+// addFlags adds flags for specific command.
+func (wait *WaitCommand) addFlags() {
+	flagSet := wait.cmd.Flags()
+	flagSet.DurationVar(&wait.timeout, "timeout", 0, "Maximum time to wait for the containers to stop, 0 means no timeout")
 }
 
 // runWait is the entry of wait command.
@@ -39,10 +49,22 @@ func (wait *WaitCommand) runWait(args []string) error {
 	ctx := context.Background()
 	apiClient := wait.cli.Client()
 
+	if wait.timeout < 0 {
+		return fmt.Errorf("invalid timeout %s: must not be negative", wait.timeout)
+	}
+	if wait.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, wait.timeout)
+		defer cancel()
+	}
+
 	var errs []string
 	for _, name := range args {
 		response, err := apiClient.ContainerWait(ctx, name)
 		if err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				err = fmt.Errorf("timed out after %s waiting for container %s to stop", wait.timeout, name)
+			}
 			errs = append(errs, err.Error())
 			continue
 		}
@@ -66,5 +88,8 @@ $ pouch ps -a
 Name   ID       Status                 Created         Image                                            Runtime
 foo    f6717e   Stopped (0) 1 minute   2 minutes ago   registry.hub.docker.com/library/busybox:latest   runc
 $ pouch wait foo
-0`
+0
+$ pouch wait --timeout 10s foo bar
+0
+137`
 }
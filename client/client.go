@@ -26,6 +26,8 @@ type APIClient struct {
 	addr    string
 	baseURL string
 	HTTPCli *http.Client
+	// tlsConfig is shared by the http transport and the hijacked connections
+	tlsConfig *tls.Config
 	// version of the server talks to
 	version string
 }
@@ -50,7 +52,10 @@ func NewAPIClient(host string, tls TLSConfig) (CommonAPIClient, error) {
 		return nil, fmt.Errorf("failed to parse host %s: %v", host, err)
 	}
 
-	tlsConfig := generateTLSConfig(host, tls)
+	tlsConfig, err := generateTLSConfig(host, tls)
+	if err != nil {
+		return nil, err
+	}
 
 	httpCli := httputils.NewHTTPClient(newURL, tlsConfig, defaultTimeout, 0)
 
@@ -62,28 +67,40 @@ func NewAPIClient(host string, tls TLSConfig) (CommonAPIClient, error) {
 	}
 
 	return &APIClient{
-		proto:   newURL.Scheme,
-		addr:    addr,
-		baseURL: basePath,
-		HTTPCli: httpCli,
-		version: version,
+		proto:     newURL.Scheme,
+		addr:      addr,
+		baseURL:   basePath,
+		HTTPCli:   httpCli,
+		tlsConfig: tlsConfig,
+		version:   version,
 	}, nil
 }
 
-// generateTLSConfig configures TLS for API Client.
-func generateTLSConfig(host string, tls TLSConfig) *tls.Config {
+// generateTLSConfig configures TLS for API Client. It returns an error
+// instead of silently falling back to plain text if the client certificate
+// is only half specified or can not be loaded.
+func generateTLSConfig(host string, tls TLSConfig) (*tls.Config, error) {
+	if strings.HasPrefix(host, "unix://") {
+		return nil, nil
+	}
+
+	if (tls.Key == "") != (tls.Cert == "") {
+		return nil, fmt.Errorf("invalid TLS configuration: both --tlscert and --tlskey must be specified")
+	}
+
 	// init tls config
-	if tls.Key != "" && tls.Cert != "" && !strings.HasPrefix(host, "unix://") {
+	if tls.Key != "" && tls.Cert != "" {
 		tlsCfg, err := httputils.GenTLSConfig(tls.Key, tls.Cert, tls.CA)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "fail to parse tls config %v", err)
-			os.Exit(1)
+			return nil, fmt.Errorf("invalid TLS configuration: %v", err)
 		}
+		// the CA file verifies the daemon's certificate on client side.
+		tlsCfg.RootCAs = tlsCfg.ClientCAs
 		tlsCfg.InsecureSkipVerify = !tls.VerifyRemote
 
-		return tlsCfg
+		return tlsCfg, nil
 	}
-	return nil
+	return nil, nil
 }
 
 func generateBaseURL(u *url.URL, tls TLSConfig) string {
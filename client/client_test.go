@@ -1,7 +1,12 @@
 package client
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 
@@ -49,3 +54,67 @@ func Test_generateBaseURL(t *testing.T) {
 		})
 	}
 }
+
+func TestNewAPIClientWithInvalidTLS(t *testing.T) {
+	for _, tc := range []struct {
+		tls    TLSConfig
+		errMsg string
+	}{
+		{
+			tls:    TLSConfig{Cert: "/nonexistent/cert.pem"},
+			errMsg: "both --tlscert and --tlskey must be specified",
+		},
+		{
+			tls:    TLSConfig{Cert: "/nonexistent/cert.pem", Key: "/nonexistent/key.pem"},
+			errMsg: "/nonexistent/cert.pem",
+		},
+	} {
+		_, err := NewAPIClient("tcp://localhost:2476", tc.tls)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid TLS configuration")
+		assert.Contains(t, err.Error(), tc.errMsg)
+	}
+
+	// TLS settings are ignored for unix socket.
+	_, err := NewAPIClient("unix:///var/run/pouchd.sock", TLSConfig{Cert: "/nonexistent/cert.pem"})
+	assert.NoError(t, err)
+}
+
+func TestHijackWithTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		fmt.Fprint(conn, "HTTP/1.1 101 UPGRADED\r\nConnection: Upgrade\r\nUpgrade: tcp\r\n\r\nhello")
+	}))
+	defer server.Close()
+
+	addr := server.Listener.Addr().String()
+	client := &APIClient{
+		proto:     "tcp",
+		addr:      addr,
+		baseURL:   "https://" + addr,
+		HTTPCli:   server.Client(),
+		tlsConfig: &tls.Config{InsecureSkipVerify: true},
+	}
+
+	conn, reader, err := client.hijack(context.Background(), "/exec/1/start", nil, nil, nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	data, err := ioutil.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	// without the client side TLS config, the server certificate is refused.
+	client.tlsConfig = &tls.Config{}
+	_, _, err = client.hijack(context.Background(), "/exec/1/start", nil, nil, nil)
+	assert.Error(t, err)
+}
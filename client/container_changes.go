@@ -0,0 +1,20 @@
+package client
+
+import (
+	"context"
+
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// ContainerChanges returns the changes in the upper layer of the container filesystem.
+func (client *APIClient) ContainerChanges(ctx context.Context, name string) ([]types.ContainerChangeResponseItem, error) {
+	resp, err := client.get(ctx, "/containers/"+name+"/changes", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []types.ContainerChangeResponseItem{}
+	err = decodeBody(&changes, resp.Body)
+	ensureCloseReader(resp)
+	return changes, err
+}
@@ -36,6 +36,7 @@ func TestCommit(t *testing.T) {
 		options := types.ContainerCommitOptions{
 			Repository: req.FormValue("repo"),
 			Tag:        req.FormValue("tag"),
+			Changes:    req.URL.Query()["changes"],
 		}
 		if options.Repository != "foo" {
 			return nil, fmt.Errorf("expected Repository %s, obtain %s", "foo", options.Repository)
@@ -43,6 +44,12 @@ func TestCommit(t *testing.T) {
 		if options.Tag != "bar" {
 			return nil, fmt.Errorf("expected Tag %s, obtain %s", "bar", options.Tag)
 		}
+		if len(options.Changes) != 2 || options.Changes[1] != "ENV foo=bar" {
+			return nil, fmt.Errorf("expected Changes %v, obtain %v", []string{"CMD top", "ENV foo=bar"}, options.Changes)
+		}
+		if pause := req.FormValue("pause"); pause != "true" {
+			return nil, fmt.Errorf("expected Pause %s, obtain %s", "true", pause)
+		}
 
 		resp := types.ContainerCommitResp{
 			ID: "newid",
@@ -64,7 +71,10 @@ func TestCommit(t *testing.T) {
 
 	r, err := client.ContainerCommit(context.Background(), "id", types.ContainerCommitOptions{
 		Repository: "foo",
-		Tag:        "bar"})
+		Tag:        "bar",
+		Changes:    []string{"CMD top", "ENV foo=bar"},
+		Pause:      true,
+	})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -51,11 +51,28 @@ func (client *APIClient) CopyFromContainer(ctx context.Context, container, srcPa
 	return response.Body, stat, err
 }
 
-// CopyToContainer copies content into the container filesystem.
-func (client *APIClient) CopyToContainer(ctx context.Context, container, path string, content io.Reader) error {
+// CopyToContainerOptions holds the options of copying content into the
+// container filesystem.
+type CopyToContainerOptions struct {
+	// AllowOverwriteDirWithFile allows a directory to be replaced by a
+	// non-directory and vice versa.
+	AllowOverwriteDirWithFile bool
+	// CopyUIDGID keeps the uid/gid of the archive entries, otherwise they are
+	// owned by the root of the container.
+	CopyUIDGID bool
+}
+
+// CopyToContainer copies content into the container filesystem. The content
+// is streamed to the daemon as it is read.
+func (client *APIClient) CopyToContainer(ctx context.Context, container, path string, content io.Reader, options CopyToContainerOptions) error {
 	query := url.Values{}
-	query.Set("noOverwriteDirNonDir", "true")
 	query.Set("path", path)
+	if !options.AllowOverwriteDirWithFile {
+		query.Set("noOverwriteDirNonDir", "true")
+	}
+	if options.CopyUIDGID {
+		query.Set("copyUIDGID", "true")
+	}
 
 	apiPath := fmt.Sprintf("/containers/%s/archive", container)
 
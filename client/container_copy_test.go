@@ -0,0 +1,67 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCopyToContainerError(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, "Server error")),
+	}
+	err := client.CopyToContainer(context.Background(), "nothing", "/", strings.NewReader(""), CopyToContainerOptions{})
+	if err == nil || !strings.Contains(err.Error(), "Server error") {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestCopyToContainer(t *testing.T) {
+	expectedURL := "/containers/container_id/archive"
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+		if req.Method != "PUT" {
+			return nil, fmt.Errorf("expected PUT method, got %s", req.Method)
+		}
+
+		query := req.URL.Query()
+		if path := query.Get("path"); path != "/tmp" {
+			return nil, fmt.Errorf("expected path /tmp, got %s", path)
+		}
+		if noOverwrite := query.Get("noOverwriteDirNonDir"); noOverwrite != "true" {
+			return nil, fmt.Errorf("expected noOverwriteDirNonDir true, got %s", noOverwrite)
+		}
+		if copyUIDGID := query.Get("copyUIDGID"); copyUIDGID != "true" {
+			return nil, fmt.Errorf("expected copyUIDGID true, got %s", copyUIDGID)
+		}
+
+		content, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if string(content) != "content" {
+			return nil, fmt.Errorf("expected content 'content', got '%s'", content)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	err := client.CopyToContainer(context.Background(), "container_id", "/tmp", strings.NewReader("content"), CopyToContainerOptions{CopyUIDGID: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
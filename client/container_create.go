@@ -22,7 +22,7 @@ func (client *APIClient) ContainerCreate(ctx context.Context, config types.Conta
 
 	resp, err := client.post(ctx, "/containers/create", q, createConfig, nil)
 	if err != nil {
-		return nil, err
+		return nil, typedError(err)
 	}
 
 	container := &types.ContainerCreateResp{}
@@ -25,6 +25,16 @@ func TestContainerCreateError(t *testing.T) {
 	}
 }
 
+func TestContainerCreateNameConflict(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusConflict, "container name foo is already in use by container 0123456789ab")),
+	}
+	_, err := client.ContainerCreate(context.Background(), types.ContainerConfig{}, nil, nil, "foo")
+	if _, ok := err.(ConflictError); !ok || !strings.Contains(err.Error(), "already in use by container 0123456789ab") {
+		t.Fatalf("expected a Conflict Error, got %v", err)
+	}
+}
+
 func TestContainerCreate(t *testing.T) {
 	expectedURL := "/containers/create"
 
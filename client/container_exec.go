@@ -14,7 +14,7 @@ import (
 func (client *APIClient) ContainerCreateExec(ctx context.Context, name string, config *types.ExecCreateConfig) (*types.ExecCreateResp, error) {
 	response, err := client.post(ctx, "/containers/"+name+"/exec", url.Values{}, config, nil)
 	if err != nil {
-		return nil, err
+		return nil, typedError(err)
 	}
 
 	body := &types.ExecCreateResp{}
@@ -28,20 +28,24 @@ func (client *APIClient) ContainerCreateExec(ctx context.Context, name string, c
 func (client *APIClient) ContainerStartExec(ctx context.Context, execID string, config *types.ExecStartConfig) (net.Conn, *bufio.Reader, error) {
 	if config.Detach {
 		_, err := client.post(ctx, "/exec/"+execID+"/start", url.Values{}, config, nil)
-		return nil, nil, err
+		return nil, nil, typedError(err)
 	}
 	header := map[string][]string{
 		"Content-Type": {"text/plain"},
 	}
 
-	return client.hijack(ctx, "/exec/"+execID+"/start", url.Values{}, config, header)
+	conn, reader, err := client.hijack(ctx, "/exec/"+execID+"/start", url.Values{}, config, header)
+	if err != nil {
+		return nil, nil, typedError(err)
+	}
+	return conn, reader, nil
 }
 
 // ContainerExecInspect get exec info with a specified exec id.
 func (client *APIClient) ContainerExecInspect(ctx context.Context, execID string) (*types.ContainerExecInspect, error) {
 	resp, err := client.get(ctx, "/exec/"+execID+"/json", nil, nil)
 	if err != nil {
-		return nil, err
+		return nil, typedError(err)
 	}
 
 	body := &types.ContainerExecInspect{}
@@ -59,5 +63,24 @@ func (client *APIClient) ContainerExecResize(ctx context.Context, execID string,
 
 	resp, err := client.post(ctx, "/exec/"+execID+"/resize", query, nil, nil)
 	ensureCloseReader(resp)
-	return err
+	return typedError(err)
+}
+
+// ContainerExecRemove removes the record of an exec process which is not running.
+func (client *APIClient) ContainerExecRemove(ctx context.Context, execID string) error {
+	resp, err := client.delete(ctx, "/exec/"+execID, nil, nil)
+	ensureCloseReader(resp)
+	return typedError(err)
+}
+
+// ContainerExecKill sends signal to an exec process running inside a container.
+func (client *APIClient) ContainerExecKill(ctx context.Context, execID string, signal string) error {
+	query := url.Values{}
+	if signal != "" {
+		query.Set("signal", signal)
+	}
+
+	resp, err := client.post(ctx, "/exec/"+execID+"/kill", query, nil, nil)
+	ensureCloseReader(resp)
+	return typedError(err)
 }
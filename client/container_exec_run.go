@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/ioutils"
+
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+const (
+	// execRunInitialInterval is the first backoff interval of polling the
+	// exec process until it is not running.
+	execRunInitialInterval = 10 * time.Millisecond
+	// execRunMaxInterval is the max backoff interval of polling the exec process.
+	execRunMaxInterval = time.Second
+)
+
+// IOStreams holds the standard streams of an exec process run by ExecRun,
+// and a nil stream is not attached.
+type IOStreams struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// ExecRun creates and starts an exec process in the container, copies the
+// streams until the output is closed, and returns the exit code of the exec
+// process.
+func (client *APIClient) ExecRun(ctx context.Context, container string, config *types.ExecCreateConfig, streams IOStreams) (int, error) {
+	createConfig := *config
+	createConfig.Detach = false
+	createConfig.AttachStdin = streams.Stdin != nil
+	createConfig.AttachStdout = streams.Stdout != nil
+	createConfig.AttachStderr = streams.Stderr != nil
+
+	createResp, err := client.ContainerCreateExec(ctx, container, &createConfig)
+	if err != nil {
+		return -1, err
+	}
+
+	conn, reader, err := client.ContainerStartExec(ctx, createResp.ID, &types.ExecStartConfig{Tty: createConfig.Tty})
+	if err != nil {
+		return -1, err
+	}
+	defer conn.Close()
+
+	if streams.Stdin != nil {
+		go func() {
+			io.Copy(conn, streams.Stdin)
+			if cw, ok := conn.(ioutils.CloseWriter); ok {
+				cw.CloseWrite()
+			}
+		}()
+	}
+
+	stdout, stderr := streams.Stdout, streams.Stderr
+	if stdout == nil {
+		stdout = ioutil.Discard
+	}
+	if stderr == nil {
+		stderr = ioutil.Discard
+	}
+
+	outputDone := make(chan error, 1)
+	go func() {
+		var err error
+		if createConfig.Tty {
+			_, err = io.Copy(stdout, reader)
+		} else {
+			_, err = stdcopy.StdCopy(stdout, stderr, reader)
+		}
+		outputDone <- err
+	}()
+
+	select {
+	case err := <-outputDone:
+		if err != nil {
+			return -1, err
+		}
+	case <-ctx.Done():
+		return -1, ctx.Err()
+	}
+
+	return client.waitExecExit(ctx, createResp.ID)
+}
+
+// waitExecExit polls the exec process with backoff until it is not running,
+// and returns its exit code.
+func (client *APIClient) waitExecExit(ctx context.Context, execID string) (int, error) {
+	interval := execRunInitialInterval
+	for {
+		execInfo, err := client.ContainerExecInspect(ctx, execID)
+		if err != nil {
+			return -1, err
+		}
+
+		if !execInfo.Running {
+			return int(execInfo.ExitCode), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return -1, ctx.Err()
+		case <-time.After(interval):
+		}
+		if interval *= 2; interval > execRunMaxInterval {
+			interval = execRunMaxInterval
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecRunCreateError(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, "Server error")),
+	}
+	code, err := client.ExecRun(context.Background(), "nothing", &types.ExecCreateConfig{Cmd: []string{"true"}}, IOStreams{})
+	if err == nil || !strings.Contains(err.Error(), "Server error") {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+	assert.Equal(t, -1, code)
+}
+
+func TestWaitExecExit(t *testing.T) {
+	inspects := []types.ContainerExecInspect{
+		{ID: "exec_id", Running: true},
+		{ID: "exec_id", Running: false, ExitCode: 3},
+	}
+
+	calls := 0
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		expectedURL := "/exec/exec_id/json"
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+
+		b, err := json.Marshal(inspects[calls])
+		if err != nil {
+			return nil, err
+		}
+		calls++
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	code, err := client.waitExecExit(context.Background(), "exec_id")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, code)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/ioutils"
+
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// ExecStream holds the standard streams of an exec process started by
+// ContainerExecStartStream. The output is demultiplexed in background, so
+// Stdout and Stderr must both be drained, or the other one is blocked.
+type ExecStream struct {
+	// Stdin is the stdin of the exec process, and closing it sends EOF to
+	// the process.
+	Stdin io.WriteCloser
+	// Stdout is the stdout of the exec process, and it also carries the
+	// stderr if the exec process has a tty.
+	Stdout io.Reader
+	// Stderr is the stderr of the exec process, and it is empty if the exec
+	// process has a tty.
+	Stderr io.Reader
+
+	client *APIClient
+	execID string
+	conn   net.Conn
+	done   chan struct{}
+	err    error
+}
+
+// ContainerExecStartStream starts exec process, and returns the streams of
+// it with the output demultiplexed.
+func (client *APIClient) ContainerExecStartStream(ctx context.Context, execID string, config *types.ExecStartConfig) (*ExecStream, error) {
+	if config.Detach {
+		return nil, fmt.Errorf("failed to stream exec %s: exec process is detached", execID)
+	}
+
+	conn, reader, err := client.ContainerStartExec(ctx, execID, config)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := newExecStream(conn, reader, config.Tty)
+	stream.client, stream.execID = client, execID
+	return stream, nil
+}
+
+// newExecStream starts demultiplexing the output read from reader.
+func newExecStream(conn net.Conn, reader *bufio.Reader, tty bool) *ExecStream {
+	stdoutReader, stdoutWriter := io.Pipe()
+	stderrReader, stderrWriter := io.Pipe()
+
+	stream := &ExecStream{
+		Stdin:  &execStdin{conn: conn},
+		Stdout: stdoutReader,
+		Stderr: stderrReader,
+		conn:   conn,
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(stream.done)
+
+		var err error
+		if tty {
+			stderrWriter.Close()
+			_, err = io.Copy(stdoutWriter, reader)
+		} else {
+			_, err = stdcopy.StdCopy(stdoutWriter, stderrWriter, reader)
+		}
+
+		stream.err = err
+		stdoutWriter.CloseWithError(err)
+		stderrWriter.CloseWithError(err)
+	}()
+
+	return stream
+}
+
+// Resize changes the size of the tty of the exec process.
+func (s *ExecStream) Resize(ctx context.Context, width, height int) error {
+	return s.client.ContainerExecResize(ctx, s.execID, types.ResizeOptions{
+		Width:  int64(width),
+		Height: int64(height),
+	})
+}
+
+// Wait blocks until the output of the exec process is closed, and returns
+// the error of demultiplexing the output if any.
+func (s *ExecStream) Wait() error {
+	<-s.done
+	return s.err
+}
+
+// Close closes the connection to the exec process, and Stdout and Stderr
+// are closed once the demultiplexing stops.
+func (s *ExecStream) Close() error {
+	return s.conn.Close()
+}
+
+// execStdin closes the writing side of the connection on Close, so that the
+// output can still be read after the stdin is closed.
+type execStdin struct {
+	conn net.Conn
+}
+
+// Write implements io.Writer interface.
+func (s *execStdin) Write(p []byte) (int, error) {
+	return s.conn.Write(p)
+}
+
+// Close implements io.Closer interface.
+func (s *execStdin) Close() error {
+	if cw, ok := s.conn.(ioutils.CloseWriter); ok {
+		return cw.CloseWrite()
+	}
+	return nil
+}
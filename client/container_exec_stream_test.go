@@ -0,0 +1,84 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/stretchr/testify/assert"
+)
+
+// closeWriteConn records CloseWrite called by closing the stdin of stream.
+type closeWriteConn struct {
+	net.Conn
+	closeWrite bool
+}
+
+func (c *closeWriteConn) CloseWrite() error {
+	c.closeWrite = true
+	return nil
+}
+
+func TestContainerExecStartStreamDetach(t *testing.T) {
+	client := &APIClient{}
+	_, err := client.ContainerExecStartStream(context.Background(), "exec_id", &types.ExecStartConfig{Detach: true})
+	assert.Error(t, err)
+}
+
+func TestExecStreamDemux(t *testing.T) {
+	var output bytes.Buffer
+	stdcopy.NewStdWriter(&output, stdcopy.Stdout).Write([]byte("out"))
+	stdcopy.NewStdWriter(&output, stdcopy.Stderr).Write([]byte("err"))
+	stdcopy.NewStdWriter(&output, stdcopy.Stdout).Write([]byte("put"))
+
+	client, server := net.Pipe()
+	defer server.Close()
+	conn := &closeWriteConn{Conn: client}
+
+	stream := newExecStream(conn, bufio.NewReader(&output), false)
+	defer stream.Close()
+
+	stderr := make(chan []byte, 1)
+	go func() {
+		data, _ := ioutil.ReadAll(stream.Stderr)
+		stderr <- data
+	}()
+
+	stdout, err := ioutil.ReadAll(stream.Stdout)
+	assert.NoError(t, err)
+	assert.Equal(t, "output", string(stdout))
+	assert.Equal(t, "err", string(<-stderr))
+	assert.NoError(t, stream.Wait())
+
+	go stream.Stdin.Write([]byte("in"))
+	buf := make([]byte, 2)
+	_, err = server.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "in", string(buf))
+
+	assert.NoError(t, stream.Stdin.Close())
+	assert.True(t, conn.closeWrite)
+}
+
+func TestExecStreamTty(t *testing.T) {
+	client, server := net.Pipe()
+	defer server.Close()
+
+	stream := newExecStream(client, bufio.NewReader(bytes.NewReader([]byte("raw output"))), true)
+	defer stream.Close()
+
+	stdout, err := ioutil.ReadAll(stream.Stdout)
+	assert.NoError(t, err)
+	assert.Equal(t, "raw output", string(stdout))
+
+	stderr, err := ioutil.ReadAll(stream.Stderr)
+	assert.NoError(t, err)
+	assert.Empty(t, stderr)
+	assert.NoError(t, stream.Wait())
+}
@@ -25,6 +25,32 @@ func TestContainerCreateExecError(t *testing.T) {
 	}
 }
 
+func TestContainerCreateExecTypedError(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusNotFound, "container not found")),
+	}
+	_, err := client.ContainerCreateExec(context.Background(), "nothing", &types.ExecCreateConfig{})
+	notFound, ok := err.(NotFoundError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, notFound.Code())
+
+	client = &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusConflict, "container is not running")),
+	}
+	_, err = client.ContainerCreateExec(context.Background(), "stopped", &types.ExecCreateConfig{})
+	_, ok = err.(ConflictError)
+	assert.True(t, ok)
+	assert.Contains(t, err.Error(), "not running")
+
+	// other errors are kept as RespError.
+	client = &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, "Server error")),
+	}
+	_, err = client.ContainerCreateExec(context.Background(), "nothing", &types.ExecCreateConfig{})
+	_, ok = err.(RespError)
+	assert.True(t, ok)
+}
+
 func TestContainerCreateExec(t *testing.T) {
 	expectedURL := "/containers/container_id/exec"
 
@@ -153,3 +179,78 @@ func TestContainerExecResize(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestContainerExecKillError(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, "Server error")),
+	}
+	err := client.ContainerExecKill(context.Background(), "nothing", "")
+	if err == nil || !strings.Contains(err.Error(), "Server error") {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerExecKill(t *testing.T) {
+	expectedURL := "/exec/exec_id/kill"
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+		if req.Method != "POST" {
+			return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+		}
+		if signal := req.FormValue("signal"); signal != "SIGTERM" {
+			return nil, fmt.Errorf("expected signal = SIGTERM, got %s", signal)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	if err := client.ContainerExecKill(context.Background(), "exec_id", "SIGTERM"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestContainerExecRemoveError(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusConflict, "exec process is still running")),
+	}
+	err := client.ContainerExecRemove(context.Background(), "nothing")
+	if _, ok := err.(ConflictError); !ok || !strings.Contains(err.Error(), "still running") {
+		t.Fatalf("expected a Conflict Error, got %v", err)
+	}
+}
+
+func TestContainerExecRemove(t *testing.T) {
+	expectedURL := "/exec/exec_id"
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+		if req.Method != "DELETE" {
+			return nil, fmt.Errorf("expected DELETE method, got %s", req.Method)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	if err := client.ContainerExecRemove(context.Background(), "exec_id"); err != nil {
+		t.Fatal(err)
+	}
+}
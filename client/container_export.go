@@ -0,0 +1,15 @@
+package client
+
+import (
+	"context"
+	"io"
+)
+
+// ContainerExport requests daemon to export the container filesystem as a tar archive.
+func (client *APIClient) ContainerExport(ctx context.Context, name string) (io.ReadCloser, error) {
+	resp, err := client.get(ctx, "/containers/"+name+"/export", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// ContainerPrune requests daemon to delete the stopped containers.
+func (client *APIClient) ContainerPrune(ctx context.Context, filter filters.Args) (*types.ContainerPruneResp, error) {
+	query := url.Values{}
+
+	if filter.Len() > 0 {
+		filtersJSON, err := filters.ToParam(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		query.Set("filters", filtersJSON)
+	}
+
+	resp, err := client.post(ctx, "/containers/prune", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &types.ContainerPruneResp{}
+	err = decodeBody(report, resp.Body)
+	ensureCloseReader(resp)
+
+	return report, err
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerPruneServerError(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ContainerPrune(context.Background(), filters.NewArgs())
+	if err == nil || !strings.Contains(err.Error(), "Server error") {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerPrune(t *testing.T) {
+	expectedURL := "/containers/prune"
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+		if req.Method != "POST" {
+			return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+		}
+
+		filter, err := filters.FromParam(req.URL.Query().Get("filters"))
+		if err != nil {
+			return nil, err
+		}
+		if !filter.ExactMatch("label", "foo=bar") {
+			return nil, fmt.Errorf("expected label filter foo=bar, got %s", req.URL.Query().Get("filters"))
+		}
+
+		b, err := json.Marshal(types.ContainerPruneResp{
+			ContainersDeleted: []string{"c1", "c2"},
+			SpaceReclaimed:    1024,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	filter := filters.NewArgs()
+	filter.Add("label", "foo=bar")
+	report, err := client.ContainerPrune(context.Background(), filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"c1", "c2"}, report.ContainersDeleted)
+	assert.Equal(t, int64(1024), report.SpaceReclaimed)
+}
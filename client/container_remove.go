@@ -2,13 +2,15 @@ package client
 
 import (
 	"context"
+	"net/http"
 	"net/url"
 
 	"github.com/alibaba/pouch/apis/types"
 )
 
-// ContainerRemove removes a container.
-func (client *APIClient) ContainerRemove(ctx context.Context, name string, options *types.ContainerRemoveOptions) error {
+// ContainerRemove removes a container, the removed and kept volumes are
+// returned if removing with volumes.
+func (client *APIClient) ContainerRemove(ctx context.Context, name string, options *types.ContainerRemoveOptions) (*types.ContainerRemoveResp, error) {
 	q := url.Values{}
 	if options.Force {
 		q.Set("force", "true")
@@ -19,8 +21,13 @@ func (client *APIClient) ContainerRemove(ctx context.Context, name string, optio
 
 	resp, err := client.delete(ctx, "/containers/"+name, q, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	ensureCloseReader(resp)
-	return nil
+	defer ensureCloseReader(resp)
+
+	report := &types.ContainerRemoveResp{}
+	if resp.StatusCode == http.StatusNoContent {
+		return report, nil
+	}
+	return report, decodeBody(report, resp.Body)
 }
@@ -3,6 +3,7 @@ package client
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -10,13 +11,15 @@ import (
 	"testing"
 
 	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestContainerRemoveError(t *testing.T) {
 	client := &APIClient{
 		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, "Server error")),
 	}
-	err := client.ContainerRemove(context.Background(), "nothing", &types.ContainerRemoveOptions{Force: true})
+	_, err := client.ContainerRemove(context.Background(), "nothing", &types.ContainerRemoveOptions{Force: true})
 	if err == nil || !strings.Contains(err.Error(), "Server error") {
 		t.Fatalf("expected a Server Error, got %v", err)
 	}
@@ -26,7 +29,7 @@ func TestContainerRemoveNotFoundError(t *testing.T) {
 	client := &APIClient{
 		HTTPCli: newMockClient(errorMockResponse(http.StatusNotFound, "Not Found")),
 	}
-	err := client.ContainerRemove(context.Background(), "no container", &types.ContainerRemoveOptions{Force: true})
+	_, err := client.ContainerRemove(context.Background(), "no container", &types.ContainerRemoveOptions{Force: true})
 	if err == nil || !strings.Contains(err.Error(), "Not Found") {
 		t.Fatalf("expected a Not Found Error, got %v", err)
 	}
@@ -51,8 +54,33 @@ func TestContainerRemove(t *testing.T) {
 	client := &APIClient{
 		HTTPCli: httpClient,
 	}
-	err := client.ContainerRemove(context.Background(), "container_id", &types.ContainerRemoveOptions{Force: true})
+	_, err := client.ContainerRemove(context.Background(), "container_id", &types.ContainerRemoveOptions{Force: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestContainerRemoveWithVolumes(t *testing.T) {
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if v := req.URL.Query().Get("v"); v != "true" {
+			return nil, fmt.Errorf("v not set in URL properly. Expected 'true', got %s", v)
+		}
+		b, err := json.Marshal(types.ContainerRemoveResp{VolumesRemoved: []string{"foo"}, VolumesKept: []string{"bar"}})
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}, nil
+	})
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+	resp, err := client.ContainerRemove(context.Background(), "container_id", &types.ContainerRemoveOptions{Volumes: true})
 	if err != nil {
 		t.Fatal(err)
 	}
+	assert.Equal(t, []string{"foo"}, resp.VolumesRemoved)
+	assert.Equal(t, []string{"bar"}, resp.VolumesKept)
 }
@@ -44,3 +44,21 @@ func TestContainerStop(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestContainerStopWithoutTimeout(t *testing.T) {
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if _, ok := req.URL.Query()["t"]; ok {
+			return nil, fmt.Errorf("timeout should not be set in URL, got %s", req.URL)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+		}, nil
+	})
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+	if err := client.ContainerStop(context.Background(), "container_id", ""); err != nil {
+		t.Fatal(err)
+	}
+}
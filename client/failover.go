@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// HostsError is returned when none of the hosts can serve the request, it
+// reports the error of each host.
+type HostsError struct {
+	Hosts  []string
+	Errors []error
+}
+
+// Error implements the error interface.
+func (e *HostsError) Error() string {
+	lines := []string{"failed to connect to any of the hosts:"}
+	for i, host := range e.Hosts {
+		lines = append(lines, fmt.Sprintf("  %s: %v", host, e.Errors[i]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// NewFailoverAPIClient returns the API client of the first host which is
+// reachable, the hosts are tried in order.
+func NewFailoverAPIClient(ctx context.Context, hosts []string, tls TLSConfig) (CommonAPIClient, error) {
+	return pickHost(hosts, tls, func(apiClient CommonAPIClient) error {
+		_, err := apiClient.SystemPing(ctx)
+		if _, ok := err.(RespError); ok {
+			// the daemon responds, so it is reachable.
+			return nil
+		}
+		return err
+	})
+}
+
+// LocateContainer returns the API client of the first host which has the
+// container, the hosts are tried in order. A NotFoundError is returned if
+// all the hosts are reachable but none of them has the container.
+func LocateContainer(ctx context.Context, hosts []string, tls TLSConfig, name string) (CommonAPIClient, error) {
+	apiClient, err := pickHost(hosts, tls, func(apiClient CommonAPIClient) error {
+		_, err := apiClient.ContainerGet(ctx, name)
+		return typedError(err)
+	})
+	if err == nil {
+		return apiClient, nil
+	}
+
+	hostsErr, ok := err.(*HostsError)
+	if !ok {
+		return nil, err
+	}
+	for _, err := range hostsErr.Errors {
+		if _, ok := err.(NotFoundError); !ok {
+			return nil, hostsErr
+		}
+	}
+	return nil, NotFoundError{RespError{
+		code: http.StatusNotFound,
+		msg:  fmt.Sprintf("no such container %s on any of the hosts %s", name, strings.Join(hosts, ", ")),
+	}}
+}
+
+// pickHost returns the API client of the first host which passes the probe.
+func pickHost(hosts []string, tls TLSConfig, probe func(CommonAPIClient) error) (CommonAPIClient, error) {
+	hostsErr := &HostsError{}
+	for _, host := range hosts {
+		apiClient, err := NewAPIClient(host, tls)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := probe(apiClient); err != nil {
+			hostsErr.Hosts = append(hostsErr.Hosts, host)
+			hostsErr.Errors = append(hostsErr.Errors, err)
+			continue
+		}
+		return apiClient, nil
+	}
+	return nil, hostsErr
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newUnixServer serves the handler on a unix socket in dir, and returns the host.
+func newUnixServer(t *testing.T, dir, name string, handler http.HandlerFunc) (string, func()) {
+	path := filepath.Join(dir, name)
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener = l
+	server.Start()
+	return "unix://" + path, server.Close
+}
+
+func TestNewFailoverAPIClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "failover")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	host, stop := newUnixServer(t, dir, "pouchd.sock", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("OK"))
+	})
+	defer stop()
+	refused := "unix://" + filepath.Join(dir, "refused.sock")
+
+	apiClient, err := NewFailoverAPIClient(context.Background(), []string{refused, host}, TLSConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "pouchd.sock"), apiClient.(*APIClient).addr)
+
+	_, err = NewFailoverAPIClient(context.Background(), []string{refused}, TLSConfig{})
+	hostsErr, ok := err.(*HostsError)
+	assert.True(t, ok)
+	assert.Equal(t, []string{refused}, hostsErr.Hosts)
+	assert.True(t, strings.HasPrefix(err.Error(), "failed to connect to any of the hosts:\n  "+refused+": "))
+}
+
+func TestLocateContainer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "locate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	empty, stop := newUnixServer(t, dir, "empty.sock", func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "no such container", http.StatusNotFound)
+	})
+	defer stop()
+	owner, stop := newUnixServer(t, dir, "owner.sock", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"Id": "foo"}`))
+	})
+	defer stop()
+
+	apiClient, err := LocateContainer(context.Background(), []string{empty, owner}, TLSConfig{}, "foo")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "owner.sock"), apiClient.(*APIClient).addr)
+
+	_, err = LocateContainer(context.Background(), []string{empty}, TLSConfig{}, "foo")
+	_, ok := err.(NotFoundError)
+	assert.True(t, ok)
+
+	// the connection errors are reported.
+	refused := "unix://" + filepath.Join(dir, "refused.sock")
+	_, err = LocateContainer(context.Background(), []string{refused, empty}, TLSConfig{}, "foo")
+	_, ok = err.(*HostsError)
+	assert.True(t, ok)
+}
@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/url"
+
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// ImageImport requests daemon to create a single-layer image from the rootfs tarstream.
+func (client *APIClient) ImageImport(ctx context.Context, ref string, changes []string, reader io.Reader) (types.ImageInfo, error) {
+	image := types.ImageInfo{}
+
+	q := url.Values{}
+	q.Set("name", ref)
+	for _, change := range changes {
+		q.Add("changes", change)
+	}
+
+	headers := map[string][]string{}
+	headers["Content-Type"] = []string{"application/x-tar"}
+
+	resp, err := client.postRawData(ctx, "/images/import", q, reader, headers)
+	if err != nil {
+		return image, err
+	}
+
+	defer ensureCloseReader(resp)
+	err = decodeBody(&image, resp.Body)
+	return image, err
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+)
+
+func TestImageImportServerError(t *testing.T) {
+	expectedError := "Server error"
+
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, expectedError)),
+	}
+
+	_, err := client.ImageImport(context.Background(), "test_image_import_500", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), expectedError) {
+		t.Fatalf("expected (%v), got (%v)", expectedError, err)
+	}
+}
+
+func TestImageImportOK(t *testing.T) {
+	expectedURL := "/images/import"
+	expectedImageName := "test_image_import_ok"
+	expectedChanges := []string{"CMD /bin/sh", "ENV foo=bar"}
+	expectedID := "sha256:e216a057b1cb1efc11f8a268f37ef62083e70b1b38323ba252e25ac88904a7e8"
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+
+		if req.Method != "POST" {
+			return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+		}
+
+		if got := req.URL.Query().Get("name"); got != expectedImageName {
+			return nil, fmt.Errorf("expected (%s), got %s", expectedImageName, got)
+		}
+
+		if got := req.URL.Query()["changes"]; !reflect.DeepEqual(got, expectedChanges) {
+			return nil, fmt.Errorf("expected (%v), got %v", expectedChanges, got)
+		}
+
+		b, err := json.Marshal(types.ImageInfo{ID: expectedID})
+		if err != nil {
+			return nil, err
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	image, err := client.ImageImport(context.Background(), expectedImageName, expectedChanges, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if image.ID != expectedID {
+		t.Fatalf("expected (%s), got (%s)", expectedID, image.ID)
+	}
+}
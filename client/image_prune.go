@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// ImagePrune requests daemon to delete the images which are not used by any
+// container.
+func (client *APIClient) ImagePrune(ctx context.Context, filter filters.Args, dryRun bool) (*types.ImagePruneResp, error) {
+	query := url.Values{}
+
+	if filter.Len() > 0 {
+		filtersJSON, err := filters.ToParam(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		query.Set("filters", filtersJSON)
+	}
+
+	if dryRun {
+		query.Set("dryRun", "true")
+	}
+
+	resp, err := client.post(ctx, "/images/prune", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &types.ImagePruneResp{}
+	err = decodeBody(report, resp.Body)
+	ensureCloseReader(resp)
+
+	return report, err
+}
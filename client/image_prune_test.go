@@ -0,0 +1,78 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImagePruneServerError(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ImagePrune(context.Background(), filters.NewArgs(), false)
+	if err == nil || !strings.Contains(err.Error(), "Server error") {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestImagePrune(t *testing.T) {
+	expectedURL := "/images/prune"
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+		if req.Method != "POST" {
+			return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+		}
+
+		query := req.URL.Query()
+		if dryRun := query.Get("dryRun"); dryRun != "true" {
+			return nil, fmt.Errorf("expected dryRun true, got %s", dryRun)
+		}
+		filter, err := filters.FromParam(query.Get("filters"))
+		if err != nil {
+			return nil, err
+		}
+		if !filter.ExactMatch("dangling", "false") {
+			return nil, fmt.Errorf("expected dangling filter false, got %s", query.Get("filters"))
+		}
+
+		b, err := json.Marshal(types.ImagePruneResp{
+			ImagesDeleted:  []string{"sha256:1", "sha256:2"},
+			SpaceReclaimed: 1024,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	filter := filters.NewArgs()
+	filter.Add("dangling", "false")
+	report, err := client.ImagePrune(context.Background(), filter, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"sha256:1", "sha256:2"}, report.ImagesDeleted)
+	assert.Equal(t, int64(1024), report.SpaceReclaimed)
+}
@@ -6,11 +6,16 @@ import (
 	"net/url"
 )
 
-// ImagePull requests daemon to pull an image from registry.
-func (client *APIClient) ImagePull(ctx context.Context, name, tag, encodedAuth string) (io.ReadCloser, error) {
+// ImagePull requests daemon to pull an image from registry. The platform
+// selects the image from the manifest list, and the platform of daemon host
+// is used if it's empty.
+func (client *APIClient) ImagePull(ctx context.Context, name, tag, platform, encodedAuth string) (io.ReadCloser, error) {
 	q := url.Values{}
 	q.Set("fromImage", name)
 	q.Set("tag", tag)
+	if platform != "" {
+		q.Set("platform", platform)
+	}
 
 	headers := map[string][]string{}
 	if encodedAuth != "" {
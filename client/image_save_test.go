@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -17,14 +18,14 @@ func TestImageSaveServerError(t *testing.T) {
 		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, expectedError)),
 	}
 
-	_, err := client.ImageSave(context.Background(), "test_image_save_500")
+	_, err := client.ImageSave(context.Background(), []string{"test_image_save_500"})
 	if err == nil || !strings.Contains(err.Error(), expectedError) {
 		t.Fatalf("expected (%v), got (%v)", expectedError, err)
 	}
 }
 
 func TestImageSaveOK(t *testing.T) {
-	expectedImageName := "test_image_save_ok"
+	expectedImageNames := []string{"test_image_save_ok", "test_image_save_ok2"}
 	expectedURL := "/images/save"
 
 	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
@@ -36,8 +37,8 @@ func TestImageSaveOK(t *testing.T) {
 			return nil, fmt.Errorf("expected GET method, got %s", req.Method)
 		}
 
-		if got := req.FormValue("name"); got != expectedImageName {
-			return nil, fmt.Errorf("expected (%s), got %s", expectedImageName, got)
+		if got := req.URL.Query()["name"]; !reflect.DeepEqual(got, expectedImageNames) {
+			return nil, fmt.Errorf("expected (%v), got %v", expectedImageNames, got)
 		}
 
 		return &http.Response{
@@ -50,7 +51,7 @@ func TestImageSaveOK(t *testing.T) {
 		HTTPCli: httpClient,
 	}
 
-	if _, err := client.ImageSave(context.Background(), expectedImageName); err != nil {
+	if _, err := client.ImageSave(context.Background(), expectedImageNames); err != nil {
 		t.Fatal(err)
 	}
 }
@@ -24,18 +24,24 @@ type ContainerAPIClient interface {
 	ContainerCreate(ctx context.Context, config types.ContainerConfig, hostConfig *types.HostConfig, networkConfig *types.NetworkingConfig, containerName string) (*types.ContainerCreateResp, error)
 	ContainerStart(ctx context.Context, name string, options types.ContainerStartOptions) error
 	ContainerStop(ctx context.Context, name, timeout string) error
-	ContainerRemove(ctx context.Context, name string, options *types.ContainerRemoveOptions) error
+	ContainerRemove(ctx context.Context, name string, options *types.ContainerRemoveOptions) (*types.ContainerRemoveResp, error)
+	ContainerPrune(ctx context.Context, filter filters.Args) (*types.ContainerPruneResp, error)
 	ContainerList(ctx context.Context, option types.ContainerListOptions) ([]*types.Container, error)
 	ContainerAttach(ctx context.Context, name string, stdin bool) (net.Conn, *bufio.Reader, error)
 	ContainerCreateExec(ctx context.Context, name string, config *types.ExecCreateConfig) (*types.ExecCreateResp, error)
 	ContainerStartExec(ctx context.Context, execID string, config *types.ExecStartConfig) (net.Conn, *bufio.Reader, error)
+	ContainerExecStartStream(ctx context.Context, execID string, config *types.ExecStartConfig) (*ExecStream, error)
 	ContainerExecInspect(ctx context.Context, execID string) (*types.ContainerExecInspect, error)
 	ContainerExecResize(ctx context.Context, execID string, options types.ResizeOptions) error
+	ContainerExecKill(ctx context.Context, execID string, signal string) error
+	ContainerExecRemove(ctx context.Context, execID string) error
+	ExecRun(ctx context.Context, container string, config *types.ExecCreateConfig, streams IOStreams) (int, error)
 	ContainerGet(ctx context.Context, name string) (*types.ContainerJSON, error)
 	ContainerRename(ctx context.Context, id string, name string) error
 	ContainerRestart(ctx context.Context, name string, timeout string) error
 	ContainerPause(ctx context.Context, name string) error
 	ContainerUnpause(ctx context.Context, name string) error
+	ContainerKill(ctx context.Context, name string, signal string) error
 	ContainerUpdate(ctx context.Context, name string, config *types.UpdateConfig) error
 	ContainerUpgrade(ctx context.Context, name string, config *types.ContainerUpgradeConfig) error
 	ContainerTop(ctx context.Context, name string, arguments []string) (types.ContainerProcessList, error)
@@ -49,18 +55,22 @@ type ContainerAPIClient interface {
 	ContainerStats(ctx context.Context, name string, stream bool) (io.ReadCloser, error)
 	ContainerStatPath(ctx context.Context, name string, path string) (types.ContainerPathStat, error)
 	CopyFromContainer(ctx context.Context, container, srcPath string) (io.ReadCloser, types.ContainerPathStat, error)
-	CopyToContainer(ctx context.Context, container, path string, content io.Reader) error
+	CopyToContainer(ctx context.Context, container, path string, content io.Reader, options CopyToContainerOptions) error
+	ContainerExport(ctx context.Context, name string) (io.ReadCloser, error)
+	ContainerChanges(ctx context.Context, name string) ([]types.ContainerChangeResponseItem, error)
 }
 
 // ImageAPIClient defines methods of Image client.
 type ImageAPIClient interface {
 	ImageList(ctx context.Context, filters filters.Args) ([]types.ImageInfo, error)
 	ImageInspect(ctx context.Context, name string) (types.ImageInfo, error)
-	ImagePull(ctx context.Context, name, tag, encodedAuth string) (io.ReadCloser, error)
+	ImagePull(ctx context.Context, name, tag, platform, encodedAuth string) (io.ReadCloser, error)
 	ImageRemove(ctx context.Context, name string, force bool) error
+	ImagePrune(ctx context.Context, filter filters.Args, dryRun bool) (*types.ImagePruneResp, error)
 	ImageTag(ctx context.Context, image string, tag string) error
 	ImageLoad(ctx context.Context, name string, r io.Reader) error
-	ImageSave(ctx context.Context, imageName string) (io.ReadCloser, error)
+	ImageSave(ctx context.Context, imageNames []string) (io.ReadCloser, error)
+	ImageImport(ctx context.Context, ref string, changes []string, r io.Reader) (types.ImageInfo, error)
 	ImageHistory(ctx context.Context, name string) ([]types.HistoryResultItem, error)
 	ImagePush(ctx context.Context, ref, encodedAuth string) (io.ReadCloser, error)
 	ImageSearch(ctx context.Context, term, registry, encodedAuth string) ([]types.SearchResultItem, error)
@@ -72,6 +82,7 @@ type VolumeAPIClient interface {
 	VolumeRemove(ctx context.Context, name string) error
 	VolumeInspect(ctx context.Context, name string) (*types.VolumeInfo, error)
 	VolumeList(ctx context.Context, filter filters.Args) (*types.VolumeListResp, error)
+	VolumePrune(ctx context.Context, filter filters.Args) (*types.VolumePruneResp, error)
 }
 
 // SystemAPIClient defines methods of System client.
@@ -82,6 +93,7 @@ type SystemAPIClient interface {
 	RegistryLogin(ctx context.Context, auth *types.AuthConfig) (*types.AuthResponse, error)
 	DaemonUpdate(ctx context.Context, daemonConfig *types.DaemonUpdateConfig) error
 	Events(ctx context.Context, since string, until string, filters filters.Args) (io.ReadCloser, error)
+	SystemDiskUsage(ctx context.Context) (*types.DiskUsage, error)
 }
 
 // NetworkAPIClient defines methods of Network client.
@@ -90,6 +102,7 @@ type NetworkAPIClient interface {
 	NetworkRemove(ctx context.Context, networkID string) error
 	NetworkInspect(ctx context.Context, networkID string) (*types.NetworkInspectResp, error)
 	NetworkList(ctx context.Context) ([]types.NetworkResource, error)
+	NetworkPrune(ctx context.Context, filter filters.Args) (*types.NetworkPruneResp, error)
 	NetworkConnect(ctx context.Context, network string, req *types.NetworkConnect) error
 	NetworkDisconnect(ctx context.Context, networkID, containerID string, force bool) error
 }
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+)
+
+// NetworkPrune requests daemon to delete the networks which are not used by any
+// container.
+func (client *APIClient) NetworkPrune(ctx context.Context, filter filters.Args) (*types.NetworkPruneResp, error) {
+	query := url.Values{}
+
+	if filter.Len() > 0 {
+		filtersJSON, err := filters.ToParam(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		query.Set("filters", filtersJSON)
+	}
+
+	resp, err := client.post(ctx, "/networks/prune", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &types.NetworkPruneResp{}
+	err = decodeBody(report, resp.Body)
+	ensureCloseReader(resp)
+
+	return report, err
+}
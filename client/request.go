@@ -4,7 +4,9 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net"
@@ -30,6 +32,34 @@ func (e RespError) Code() int {
 	return e.code
 }
 
+// NotFoundError is the response error of 404, the object does not exist.
+type NotFoundError struct {
+	RespError
+}
+
+// ConflictError is the response error of 409, the request conflicts with
+// the state of object, for example the container is not running.
+type ConflictError struct {
+	RespError
+}
+
+// typedError converts the response error of 404 and 409 into NotFoundError
+// and ConflictError, so that the callers can tell them apart by type assertion.
+func typedError(err error) error {
+	respErr, ok := err.(RespError)
+	if !ok {
+		return err
+	}
+
+	switch respErr.code {
+	case http.StatusNotFound:
+		return NotFoundError{respErr}
+	case http.StatusConflict:
+		return ConflictError{respErr}
+	}
+	return err
+}
+
 // Response wraps the http.Response and other states.
 type Response struct {
 	StatusCode int
@@ -92,13 +122,33 @@ func (client *APIClient) hijack(ctx context.Context, path string, query url.Valu
 		tcpConn.SetKeepAlivePeriod(30 * time.Second)
 	}
 
+	// the hijacked connection must go through the same TLS setting as the
+	// http transport, otherwise a TLS enabled daemon refuses to upgrade it.
+	if client.tlsConfig != nil {
+		conn, err = tlsHandshake(conn, client.addr, client.tlsConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	clientconn := httputil.NewClientConn(conn, nil)
 	defer clientconn.Close()
 
-	if _, err := clientconn.Do(req); err != nil {
+	resp, err := clientconn.Do(req)
+	if err != nil {
 		return nil, nil, err
 	}
 
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		data, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, nil, RespError{code: resp.StatusCode, msg: string(data)}
+	}
+
 	rwc, br := clientconn.Hijack()
 
 	return rwc, br, nil
@@ -185,3 +235,22 @@ func objectToJSONStream(obj interface{}) (io.Reader, error) {
 
 	return nil, nil
 }
+
+// tlsHandshake upgrades conn to a TLS client connection to addr.
+func tlsHandshake(conn net.Conn, addr string, config *tls.Config) (net.Conn, error) {
+	config = config.Clone()
+	if config.ServerName == "" {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+		config.ServerName = host
+	}
+
+	tlsConn := tls.Client(conn, config)
+	if err := tlsConn.Handshake(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to do TLS handshake with %s: %v", addr, err)
+	}
+	return tlsConn, nil
+}
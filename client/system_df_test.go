@@ -0,0 +1,72 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemDiskUsageError(t *testing.T) {
+	client := &APIClient{
+		HTTPCli: newMockClient(errorMockResponse(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.SystemDiskUsage(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "Server error") {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestSystemDiskUsage(t *testing.T) {
+	expectedURL := "/system/df"
+
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(req.URL.Path, expectedURL) {
+			return nil, fmt.Errorf("expected URL '%s', got '%s'", expectedURL, req.URL)
+		}
+		if req.Method != "GET" {
+			return nil, fmt.Errorf("expected GET method, got %s", req.Method)
+		}
+
+		du := types.DiskUsage{
+			Images: []*types.DiskUsageObject{
+				{ID: "sha256:abc", Name: "busybox:latest", Size: 1024, InUse: true},
+			},
+			Volumes: []*types.DiskUsageObject{
+				{Name: "foo", Size: -1},
+			},
+		}
+		b, err := json.Marshal(du)
+		if err != nil {
+			return nil, err
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}, nil
+	})
+
+	client := &APIClient{
+		HTTPCli: httpClient,
+	}
+
+	du, err := client.SystemDiskUsage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(du.Images))
+	assert.Equal(t, "busybox:latest", du.Images[0].Name)
+	assert.Equal(t, int64(1024), du.Images[0].Size)
+	assert.True(t, du.Images[0].InUse)
+	assert.Equal(t, int64(-1), du.Volumes[0].Size)
+	assert.Empty(t, du.Containers)
+}
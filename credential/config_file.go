@@ -10,4 +10,12 @@ var (
 // ConfigFile defines configs that file needs keep.
 type ConfigFile struct {
 	AuthConfigs map[string]types.AuthConfig `json:"auths"`
+
+	// CredentialsStore is the name of credential helper used for all the
+	// registries, like "secretservice".
+	CredentialsStore string `json:"credsStore,omitempty"`
+
+	// CredentialHelpers is the credential helper used for specific registry,
+	// which overrides the CredentialsStore.
+	CredentialHelpers map[string]string `json:"credHelpers,omitempty"`
 }
@@ -4,28 +4,41 @@ import "github.com/alibaba/pouch/apis/types"
 
 // Save saves a registry credential into a credential store.
 func Save(authConfig *types.AuthConfig) error {
-	s := loadCredentialStore()
+	s := loadCredentialStore(authConfig.ServerAddress)
 	return s.Save(authConfig)
 }
 
 // Get gets a registry credential from a credential store.
 func Get(serverAddress string) (types.AuthConfig, error) {
-	s := loadCredentialStore()
+	s := loadCredentialStore(serverAddress)
 	return s.Get(serverAddress)
 }
 
 // Delete deletes a registry credential from a credential store.
 func Delete(serverAddress string) error {
-	s := loadCredentialStore()
+	s := loadCredentialStore(serverAddress)
 	return s.Delete(serverAddress)
 }
 
 // Exist determines whether a specified credential is exist in a credential store.
 func Exist(serverAddress string) bool {
-	s := loadCredentialStore()
+	s := loadCredentialStore(serverAddress)
 	return s.Exist(serverAddress)
 }
 
-func loadCredentialStore() Store {
-	return newFileStore()
+// loadCredentialStore returns the credential helper store if the credential
+// helper is configured for the registry, otherwise returns the file store.
+func loadCredentialStore(serverAddress string) Store {
+	fs := newFileStore().(*fileStore)
+	if fs.configFile == nil {
+		return fs
+	}
+
+	if helper := fs.configFile.CredentialHelpers[normalizeServerAddress(serverAddress)]; helper != "" {
+		return newNativeStore(helper, fs)
+	}
+	if fs.configFile.CredentialsStore != "" {
+		return newNativeStore(fs.configFile.CredentialsStore, fs)
+	}
+	return fs
 }
@@ -35,23 +35,14 @@ func newFileStore() Store {
 
 // Save implements Store interface.
 func (fs *fileStore) Save(authConfig *types.AuthConfig) error {
-	if fs.configFile == nil {
-		fs.configFile = &ConfigFile{
-			AuthConfigs: make(map[string]types.AuthConfig),
-		}
-	}
+	fs.init()
 
 	encodedAuth := encodeAuth(authConfig.Username, authConfig.Password)
 	if encodedAuth == "" {
 		return nil
 	}
 
-	serverAddress := authConfig.ServerAddress
-	if serverAddress == "" {
-		serverAddress = defaultRegistry
-	} else {
-		serverAddress = convertHost(serverAddress)
-	}
+	serverAddress := normalizeServerAddress(authConfig.ServerAddress)
 
 	fs.configFile.AuthConfigs[serverAddress] = types.AuthConfig{
 		Auth: encodedAuth,
@@ -67,11 +58,7 @@ func (fs *fileStore) Get(serverAddress string) (types.AuthConfig, error) {
 	}
 
 	authConfigs := fs.configFile.AuthConfigs
-	if serverAddress == "" {
-		serverAddress = defaultRegistry
-	} else {
-		serverAddress = convertHost(serverAddress)
-	}
+	serverAddress = normalizeServerAddress(serverAddress)
 	authConfig, exist := authConfigs[serverAddress]
 	if !exist {
 		return types.AuthConfig{}, nil
@@ -94,7 +81,7 @@ func (fs *fileStore) Delete(serverAddress string) error {
 		return nil
 	}
 
-	serverAddress = convertHost(serverAddress)
+	serverAddress = normalizeServerAddress(serverAddress)
 	delete(fs.configFile.AuthConfigs, serverAddress)
 	return fs.update()
 }
@@ -105,15 +92,21 @@ func (fs *fileStore) Exist(serverAddress string) bool {
 		return false
 	}
 
-	if serverAddress == "" {
-		serverAddress = defaultRegistry
-	} else {
-		serverAddress = convertHost(serverAddress)
-	}
+	serverAddress = normalizeServerAddress(serverAddress)
 	_, exist := fs.configFile.AuthConfigs[serverAddress]
 	return exist
 }
 
+// init initializes the config file if it doesn't exist.
+func (fs *fileStore) init() {
+	if fs.configFile == nil {
+		fs.configFile = &ConfigFile{}
+	}
+	if fs.configFile.AuthConfigs == nil {
+		fs.configFile.AuthConfigs = make(map[string]types.AuthConfig)
+	}
+}
+
 // update updates file store with new contents.
 func (fs *fileStore) update() error {
 	if fs.configFile == nil {
@@ -36,7 +36,8 @@ func decodeAuth(authStr string) (string, string, error) {
 		return "", "", fmt.Errorf("failed to decode auth %s", authStr)
 	}
 
-	splits := strings.Split(string(decodeBytes), ":")
+	// the password may contain colon.
+	splits := strings.SplitN(string(decodeBytes), ":", 2)
 	if len(splits) != 2 {
 		return "", "", fmt.Errorf("invalid credential config")
 	}
@@ -65,3 +66,12 @@ func convertHost(addr string) string {
 	splits := strings.SplitN(addr, "/", 2)
 	return splits[0]
 }
+
+// normalizeServerAddress returns the host of registry, which is used as the
+// key of credential, and the empty address means the default registry.
+func normalizeServerAddress(addr string) string {
+	if addr == "" {
+		return defaultRegistry
+	}
+	return convertHost(addr)
+}
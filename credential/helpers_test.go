@@ -35,6 +35,11 @@ func TestAuth(t *testing.T) {
 			password: "asddwqwe333!#",
 			pass:     true,
 		},
+		{
+			username: "foo",
+			password: "bar:baz",
+			pass:     true,
+		},
 	} {
 		authStr := encodeAuth(auth.username, auth.password)
 		if auth.pass {
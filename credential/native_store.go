@@ -0,0 +1,135 @@
+package credential
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/alibaba/pouch/apis/types"
+)
+
+const (
+	// credentialHelperPrefix is the prefix of credential helper program,
+	// which is compatible with docker credential helpers.
+	credentialHelperPrefix = "docker-credential-"
+
+	// errCredentialsNotFoundMessage is returned by credential helper if the
+	// credential doesn't exist.
+	errCredentialsNotFoundMessage = "credentials not found in native keychain"
+)
+
+// helperCredentials is the payload exchanged with credential helper.
+type helperCredentials struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+// nativeStore keeps the credentials in the external credential helper, and
+// records the server address in the file store so that the file store still
+// knows which registries have been logged in.
+type nativeStore struct {
+	program   string
+	fileStore *fileStore
+}
+
+func newNativeStore(helper string, fs *fileStore) Store {
+	return &nativeStore{
+		program:   credentialHelperPrefix + helper,
+		fileStore: fs,
+	}
+}
+
+// Save implements Store interface.
+func (ns *nativeStore) Save(authConfig *types.AuthConfig) error {
+	if authConfig.Username == "" || authConfig.Password == "" {
+		return nil
+	}
+
+	serverAddress := normalizeServerAddress(authConfig.ServerAddress)
+	if _, err := ns.execute("store", &helperCredentials{
+		ServerURL: serverAddress,
+		Username:  authConfig.Username,
+		Secret:    authConfig.Password,
+	}); err != nil {
+		return err
+	}
+
+	ns.fileStore.init()
+	ns.fileStore.configFile.AuthConfigs[serverAddress] = types.AuthConfig{}
+	return ns.fileStore.update()
+}
+
+// Get implements Store interface.
+func (ns *nativeStore) Get(serverAddress string) (types.AuthConfig, error) {
+	serverAddress = normalizeServerAddress(serverAddress)
+
+	out, err := ns.execute("get", serverAddress)
+	if err != nil {
+		if strings.Contains(err.Error(), errCredentialsNotFoundMessage) {
+			return types.AuthConfig{}, nil
+		}
+		return types.AuthConfig{}, err
+	}
+
+	var creds helperCredentials
+	if err := json.Unmarshal(out, &creds); err != nil {
+		return types.AuthConfig{}, fmt.Errorf("failed to decode the output of %s: %v", ns.program, err)
+	}
+
+	if creds.Username == "" && creds.Secret == "" {
+		return types.AuthConfig{}, nil
+	}
+	return types.AuthConfig{
+		Username:      creds.Username,
+		Password:      creds.Secret,
+		ServerAddress: serverAddress,
+	}, nil
+}
+
+// Delete implements Store interface.
+func (ns *nativeStore) Delete(serverAddress string) error {
+	serverAddress = normalizeServerAddress(serverAddress)
+	if _, err := ns.execute("erase", serverAddress); err != nil && !strings.Contains(err.Error(), errCredentialsNotFoundMessage) {
+		return err
+	}
+	return ns.fileStore.Delete(serverAddress)
+}
+
+// Exist implements Store interface.
+func (ns *nativeStore) Exist(serverAddress string) bool {
+	authConfig, err := ns.Get(serverAddress)
+	return err == nil && authConfig != (types.AuthConfig{})
+}
+
+// execute runs the credential helper with the action, the input is written
+// into the stdin of helper, which is string or json object.
+func (ns *nativeStore) execute(action string, input interface{}) ([]byte, error) {
+	var stdin []byte
+	if s, ok := input.(string); ok {
+		stdin = []byte(s)
+	} else {
+		data, err := json.Marshal(input)
+		if err != nil {
+			return nil, err
+		}
+		stdin = data
+	}
+
+	cmd := exec.Command(ns.program, action)
+	cmd.Stdin = bytes.NewReader(stdin)
+	out, err := cmd.Output()
+	if err != nil {
+		msg := strings.TrimSpace(string(out))
+		if exitErr, ok := err.(*exec.ExitError); ok && msg == "" {
+			msg = strings.TrimSpace(string(exitErr.Stderr))
+		}
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("failed to execute %s %s: %s", ns.program, action, msg)
+	}
+	return out, nil
+}
@@ -0,0 +1,101 @@
+package credential
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeHelper is a credential helper which keeps the credential in the file
+// named by server address.
+const fakeHelper = `#!/bin/sh
+dir=$(dirname $0)
+case "$1" in
+store)
+	input=$(cat)
+	server=$(echo "$input" | sed 's/.*"ServerURL":"\([^"]*\)".*/\1/')
+	echo "$input" > "$dir/$server"
+	;;
+get)
+	server=$(cat)
+	if [ ! -f "$dir/$server" ]; then
+		echo "credentials not found in native keychain"
+		exit 1
+	fi
+	cat "$dir/$server"
+	;;
+erase)
+	server=$(cat)
+	rm -f "$dir/$server"
+	;;
+esac
+`
+
+func TestNativeStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestNativeStore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	helperDir := filepath.Join(dir, "bin")
+	assert.NoError(t, os.MkdirAll(helperDir, 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(helperDir, "docker-credential-fake"), []byte(fakeHelper), 0755))
+
+	defer os.Setenv("PATH", os.Getenv("PATH"))
+	os.Setenv("PATH", helperDir+":"+os.Getenv("PATH"))
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", dir)
+
+	// the credential helper is configured for reg.pouch.io only
+	configFile := &ConfigFile{
+		CredentialHelpers: map[string]string{"reg.pouch.io": "fake"},
+	}
+	data, err := json.Marshal(configFile)
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, ".pouch"), 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, configFileName), data, 0600))
+
+	assert.False(t, Exist("reg.pouch.io"))
+
+	assert.NoError(t, Save(&types.AuthConfig{
+		Username:      "foo",
+		Password:      "bar",
+		ServerAddress: "https://reg.pouch.io/v2/",
+	}))
+	assert.True(t, Exist("reg.pouch.io"))
+
+	authConfig, err := Get("reg.pouch.io")
+	assert.NoError(t, err)
+	assert.Equal(t, types.AuthConfig{
+		Username:      "foo",
+		Password:      "bar",
+		ServerAddress: "reg.pouch.io",
+	}, authConfig)
+
+	// the password must not be stored in config file
+	data, err = ioutil.ReadFile(filepath.Join(dir, configFileName))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), encodeAuth("foo", "bar"))
+	_, err = os.Stat(filepath.Join(helperDir, "reg.pouch.io"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, Delete("reg.pouch.io"))
+	assert.False(t, Exist("reg.pouch.io"))
+
+	// the other registry uses file store
+	assert.NoError(t, Save(&types.AuthConfig{
+		Username:      "foo",
+		Password:      "bar",
+		ServerAddress: "other.pouch.io",
+	}))
+	data, err = ioutil.ReadFile(filepath.Join(dir, configFileName))
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), encodeAuth("foo", "bar"))
+}
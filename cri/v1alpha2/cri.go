@@ -323,7 +323,7 @@ func (c *CriManager) RunPodSandbox(ctx context.Context, r *runtime.RunPodSandbox
 	// If running sandbox failed, clean up the container.
 	defer func() {
 		if retErr != nil {
-			if err := c.ContainerMgr.Remove(ctx, id, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true}); err != nil {
+			if _, err := c.ContainerMgr.Remove(ctx, id, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true}); err != nil {
 				removeContainerErr = true
 				log.With(ctx).Errorf("failed to remove container when running sandbox failed %q: %v", id, err)
 			}
@@ -550,7 +550,7 @@ func (c *CriManager) RemovePodSandbox(ctx context.Context, r *runtime.RemovePodS
 
 	// Remove all containers in the sandbox.
 	for _, container := range containers {
-		if err := c.ContainerMgr.Remove(ctx, container.ID, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true}); err != nil {
+		if _, err := c.ContainerMgr.Remove(ctx, container.ID, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true}); err != nil {
 			if errtypes.IsNotfound(err) {
 				log.With(ctx).Warningf("container %q of sandbox %q not found", container.ID, podSandboxID)
 				continue
@@ -562,7 +562,7 @@ func (c *CriManager) RemovePodSandbox(ctx context.Context, r *runtime.RemovePodS
 	}
 
 	// Remove the sandbox container.
-	if err := c.ContainerMgr.Remove(ctx, podSandboxID, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true}); err != nil {
+	if _, err := c.ContainerMgr.Remove(ctx, podSandboxID, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true}); err != nil {
 		if errtypes.IsNotfound(err) {
 			log.With(ctx).Warningf("sandbox container %q not found", podSandboxID)
 		} else {
@@ -840,7 +840,7 @@ func (c *CriManager) CreateContainer(ctx context.Context, r *runtime.CreateConta
 	defer func() {
 		// If the container failed to be created, clean up the container.
 		if err != nil {
-			removeErr := c.ContainerMgr.Remove(ctx, containerID, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true})
+			_, removeErr := c.ContainerMgr.Remove(ctx, containerID, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true})
 			if removeErr != nil {
 				log.With(ctx).Errorf("failed to remove the container when creating container failed: %v", removeErr)
 			}
@@ -908,7 +908,7 @@ func (c *CriManager) RemoveContainer(ctx context.Context, r *runtime.RemoveConta
 
 	containerID := r.GetContainerId()
 
-	if err := c.ContainerMgr.Remove(ctx, containerID, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true}); err != nil {
+	if _, err := c.ContainerMgr.Remove(ctx, containerID, &apitypes.ContainerRemoveOptions{Volumes: true, Force: true}); err != nil {
 		return nil, fmt.Errorf("failed to remove container %q: %v", containerID, err)
 	}
 
@@ -1415,7 +1415,7 @@ func (c *CriManager) PullImage(ctx context.Context, r *runtime.PullImageRequest)
 		authConfig.RegistryToken = auth.GetRegistryToken()
 	}
 
-	if err := c.ImageMgr.PullImage(ctx, imageRef, authConfig, bytes.NewBuffer([]byte{})); err != nil {
+	if err := c.ImageMgr.PullImage(ctx, imageRef, "", authConfig, bytes.NewBuffer([]byte{})); err != nil {
 		return nil, err
 	}
 
@@ -45,6 +45,10 @@ var (
 
 	// cleanupTimeout is used to clean up the container/task meta data in containerd.
 	cleanupTimeout = 100 * time.Second
+
+	// killTimeout is used to wait for the container to exit after SIGKILL
+	// when the stop timeout is zero.
+	killTimeout = 10 * time.Second
 )
 
 type containerPack struct {
@@ -162,6 +166,10 @@ func (c *Client) execContainer(ctx context.Context, process *Process, timeout in
 	// make sure the closeStdinCh has been closed.
 	close(closeStdinCh)
 
+	if process.StartedHook != nil {
+		process.StartedHook(int(execProcess.Pid()))
+	}
+
 	if process.Detach {
 		go func() {
 			status := <-exitStatus
@@ -229,6 +237,50 @@ func (c *Client) ResizeExec(ctx context.Context, id string, execid string, opts
 	return execProcess.Resize(ctx, uint32(opts.Width), uint32(opts.Height))
 }
 
+// KillExec sends signal to the exec process running in the container.
+func (c *Client) KillExec(ctx context.Context, id string, execid string, signal syscall.Signal) error {
+	if err := c.killExec(ctx, id, execid, signal); err != nil {
+		return convertCtrdErr(err)
+	}
+	return nil
+}
+
+// killExec sends signal to the exec process running in the container.
+func (c *Client) killExec(ctx context.Context, id string, execid string, signal syscall.Signal) error {
+	pack, err := c.watch.get(id)
+	if err != nil {
+		return err
+	}
+
+	execProcess, err := pack.task.LoadProcess(ctx, execid, nil)
+	if err != nil {
+		return err
+	}
+
+	return execProcess.Kill(ctx, signal)
+}
+
+// KillContainer sends signal to the init process of container.
+func (c *Client) KillContainer(ctx context.Context, id string, signal syscall.Signal) error {
+	if err := c.killContainer(ctx, id, signal); err != nil {
+		return convertCtrdErr(err)
+	}
+	return nil
+}
+
+// killContainer sends signal to the init process of container.
+func (c *Client) killContainer(ctx context.Context, id string, signal syscall.Signal) error {
+	pack, err := c.watch.get(id)
+	if err != nil {
+		return err
+	}
+
+	if err := pack.task.Kill(ctx, signal); err != nil {
+		return errors.Wrapf(err, "failed to send signal %d to task", signal)
+	}
+	return nil
+}
+
 // ContainerPID returns the container's init process id.
 func (c *Client) ContainerPID(ctx context.Context, id string) (int, error) {
 	pid, err := c.containerPID(ctx, id)
@@ -394,9 +446,11 @@ func (c *Client) recoverContainer(ctx context.Context, id string, io *containeri
 	return nil
 }
 
-// DestroyContainer kill container and delete it.
-func (c *Client) DestroyContainer(ctx context.Context, id string, timeout int64) (*Message, error) {
-	msg, err := c.destroyContainer(ctx, id, timeout)
+// DestroyContainer sends the stop signal to container, kills it after
+// timeout and deletes it, SIGTERM is used if the signal is not set. The
+// container is killed immediately if the timeout is zero.
+func (c *Client) DestroyContainer(ctx context.Context, id string, signal syscall.Signal, timeout int64) (*Message, error) {
+	msg, err := c.destroyContainer(ctx, id, signal, timeout)
 	if err != nil {
 		return msg, convertCtrdErr(err)
 	}
@@ -404,7 +458,7 @@ func (c *Client) DestroyContainer(ctx context.Context, id string, timeout int64)
 }
 
 // DestroyContainer kill container and delete it.
-func (c *Client) destroyContainer(ctx context.Context, id string, timeout int64) (*Message, error) {
+func (c *Client) destroyContainer(ctx context.Context, id string, signal syscall.Signal, timeout int64) (*Message, error) {
 	// TODO(ziren): if we just want to stop a container,
 	// we may need lease to lock the snapshot of container,
 	// in case, it be deleted by gc.
@@ -436,14 +490,26 @@ func (c *Client) destroyContainer(ctx context.Context, id string, timeout int64)
 		pack.l.Unlock()
 	}()
 
+	waitTimeout := time.Duration(timeout) * time.Second
+	if waitTimeout <= 0 {
+		waitTimeout = killTimeout
+	}
 	waitExit := func() *Message {
-		return c.ProbeContainer(ctx, id, time.Duration(timeout)*time.Second)
+		return c.ProbeContainer(ctx, id, waitTimeout)
 	}
 
 	var msg *Message
 
+	if signal == 0 {
+		signal = syscall.SIGTERM
+	}
+	// no time to stop gracefully, kill it immediately.
+	if timeout <= 0 {
+		signal = syscall.SIGKILL
+	}
+
 	// TODO: set task request timeout by context timeout
-	if err := pack.task.Kill(ctx, syscall.SIGTERM, containerd.WithKillAll); err != nil {
+	if err := pack.task.Kill(ctx, signal, containerd.WithKillAll); err != nil {
 		if !errdefs.IsNotFound(err) {
 			return nil, errors.Wrap(err, "failed to kill task")
 		}
@@ -452,7 +518,7 @@ func (c *Client) destroyContainer(ctx context.Context, id string, timeout int64)
 	// wait for the task to exit.
 	msg = waitExit()
 
-	if err := msg.RawError(); err != nil && errtypes.IsTimeout(err) {
+	if err := msg.RawError(); err != nil && errtypes.IsTimeout(err) && signal != syscall.SIGKILL {
 		log.With(ctx).Infof("send signal 9 to container")
 		// timeout, use SIGKILL to retry.
 		if err := pack.task.Kill(ctx, syscall.SIGKILL, containerd.WithKillAll); err != nil {
@@ -582,7 +648,7 @@ func (c *Client) createContainer(ctx context.Context, ref, id, checkpointDir str
 	// if creating the container by specify rootfs, we no need use the image
 	if !container.RootFSProvided {
 		// get image
-		img, err := wrapperCli.client.GetImage(ctx, ref)
+		img, err := getPlatformImage(ctx, wrapperCli.client, ref)
 		if err != nil {
 			if errdefs.IsNotFound(err) {
 				return errors.Wrapf(errtypes.ErrNotfound, "image %s", ref)
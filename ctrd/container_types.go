@@ -37,4 +37,7 @@ type Process struct {
 	IO          *containerio.IO
 	P           *specs.Process
 	Detach      bool
+
+	// StartedHook is called with the pid of process once it has started.
+	StartedHook func(pid int)
 }
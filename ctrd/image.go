@@ -2,6 +2,7 @@ package ctrd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,13 +10,17 @@ import (
 	"time"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/errtypes"
 	"github.com/alibaba/pouch/pkg/jsonstream"
 	"github.com/alibaba/pouch/pkg/log"
 	"github.com/alibaba/pouch/pkg/reference"
 
 	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/content"
 	"github.com/containerd/containerd/errdefs"
 	ctrdmetaimages "github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/images/archive"
+	"github.com/containerd/containerd/platforms"
 	"github.com/containerd/containerd/remotes"
 	"github.com/containerd/containerd/remotes/docker"
 	"github.com/opencontainers/go-digest"
@@ -58,7 +63,7 @@ func (c *Client) getImage(ctx context.Context, ref string) (containerd.Image, er
 		return nil, fmt.Errorf("failed to get a containerd grpc client: %v", err)
 	}
 
-	return wrapperCli.client.GetImage(ctx, ref)
+	return getPlatformImage(ctx, wrapperCli.client, ref)
 }
 
 // ListImages lists all images.
@@ -77,7 +82,16 @@ func (c *Client) listImages(ctx context.Context, filter ...string) ([]containerd
 		return nil, fmt.Errorf("failed to get a containerd grpc client: %v", err)
 	}
 
-	return wrapperCli.client.ListImages(ctx, filter...)
+	imgs, err := wrapperCli.client.ImageService().List(ctx, filter...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]containerd.Image, 0, len(imgs))
+	for _, img := range imgs {
+		res = append(res, newImage(wrapperCli.client, img))
+	}
+	return res, nil
 }
 
 // RemoveImage deletes an image.
@@ -101,51 +115,68 @@ func (c *Client) removeImage(ctx context.Context, ref string) error {
 	return nil
 }
 
-// SaveImage saves image to tarstream
-func (c *Client) SaveImage(ctx context.Context, exporter ctrdmetaimages.Exporter, ref string) (io.ReadCloser, error) {
-	r, err := c.saveImage(ctx, exporter, ref)
+// SaveImage saves the images to the oci.v1 format tarstream.
+func (c *Client) SaveImage(ctx context.Context, refs []string) (io.ReadCloser, error) {
+	r, err := c.saveImage(ctx, refs)
 	if err != nil {
 		return r, convertCtrdErr(err)
 	}
 	return r, nil
 }
 
-// saveImage saves image to tarstream
-func (c *Client) saveImage(ctx context.Context, exporter ctrdmetaimages.Exporter, ref string) (io.ReadCloser, error) {
+// saveImage saves the images to the oci.v1 format tarstream.
+func (c *Client) saveImage(ctx context.Context, refs []string) (io.ReadCloser, error) {
 	wrapperCli, err := c.Get(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get a containerd grpc client: %v", err)
 	}
 
-	image, err := c.GetImage(ctx, ref)
-	if err != nil {
-		return nil, err
-	}
-
-	desc := image.Target()
-	// add annotations in image description
-	if desc.Annotations == nil {
-		desc.Annotations = make(map[string]string)
-	}
-	if s, exist := desc.Annotations[ocispec.AnnotationRefName]; !exist || s == "" {
-		namedRef, err := reference.Parse(ref)
+	descs := make([]ocispec.Descriptor, 0, len(refs))
+	for _, ref := range refs {
+		image, err := c.GetImage(ctx, ref)
 		if err != nil {
 			return nil, err
 		}
 
-		if reference.IsNameTagged(namedRef) {
-			desc.Annotations[ocispec.AnnotationRefName] = namedRef.(reference.Tagged).Tag()
+		desc := image.Target()
+
+		// add annotations in image description, the full reference is
+		// kept so that the image can be loaded with the same name.
+		annotations := make(map[string]string, len(desc.Annotations)+2)
+		for k, v := range desc.Annotations {
+			annotations[k] = v
+		}
+		annotations[AnnotationImageName] = ref
+
+		if s, exist := annotations[ocispec.AnnotationRefName]; !exist || s == "" {
+			namedRef, err := reference.Parse(ref)
+			if err != nil {
+				return nil, err
+			}
+
+			if reference.IsNameTagged(namedRef) {
+				annotations[ocispec.AnnotationRefName] = namedRef.(reference.Tagged).Tag()
+			}
 		}
+		desc.Annotations = annotations
+
+		descs = append(descs, desc)
 	}
 
-	return wrapperCli.client.Export(ctx, exporter, desc)
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(errors.Wrap(exportImages(ctx, wrapperCli.client.ContentStore(), descs, pw), "export failed"))
+	}()
+	return pr, nil
 }
 
-// ImportImage creates a set of images by tarstream.
+// ImportImage creates a set of images by tarstream. The refTranslator names
+// the image by the manifest descriptor in the index of tarstream, and the
+// manifest is skipped if the name is empty.
 //
 // NOTE: One tar may have several manifests.
-func (c *Client) ImportImage(ctx context.Context, reader io.Reader, opts ...containerd.ImportOpt) ([]containerd.Image, error) {
-	imgs, err := c.importImage(ctx, reader, opts...)
+func (c *Client) ImportImage(ctx context.Context, reader io.Reader, refTranslator func(ocispec.Descriptor) string) ([]containerd.Image, error) {
+	imgs, err := c.importImage(ctx, reader, refTranslator)
 	if err != nil {
 		return imgs, convertCtrdErr(err)
 	}
@@ -155,26 +186,78 @@ func (c *Client) ImportImage(ctx context.Context, reader io.Reader, opts ...cont
 // importImage creates a set of images by tarstream.
 //
 // NOTE: One tar may have several manifests.
-func (c *Client) importImage(ctx context.Context, reader io.Reader, opts ...containerd.ImportOpt) ([]containerd.Image, error) {
+func (c *Client) importImage(ctx context.Context, reader io.Reader, refTranslator func(ocispec.Descriptor) string) ([]containerd.Image, error) {
 	wrapperCli, err := c.Get(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get a containerd grpc client: %v", err)
 	}
 
-	// NOTE: The import will store the data into boltdb. But the unpack may
-	// fail. It is not transaction.
-	imgs, err := wrapperCli.client.Import(ctx, reader, opts...)
+	ctx, done, err := wrapperCli.client.WithLease(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer done(ctx)
 
+	cs := wrapperCli.client.ContentStore()
+	index, err := archive.ImportIndex(ctx, cs, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var imgs []ctrdmetaimages.Image
+	nameHandler := func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		// only name the images at top level
+		if desc.Digest != index.Digest {
+			return ctrdmetaimages.Children(ctx, cs, desc)
+		}
+
+		p, err := content.ReadBlob(ctx, cs, desc)
+		if err != nil {
+			return nil, err
+		}
+
+		var idx ocispec.Index
+		if err := json.Unmarshal(p, &idx); err != nil {
+			return nil, err
+		}
+
+		for _, m := range idx.Manifests {
+			if name := refTranslator(m); name != "" {
+				imgs = append(imgs, ctrdmetaimages.Image{
+					Name:   name,
+					Target: m,
+				})
+			}
+		}
+		return idx.Manifests, nil
+	}
+
+	if err := ctrdmetaimages.Walk(ctx, ctrdmetaimages.SetChildrenLabels(cs, nameHandler), index); err != nil {
+		return nil, err
+	}
+
+	// NOTE: The import will store the data into boltdb. But the unpack may
+	// fail. It is not transaction.
 	var (
+		is         = wrapperCli.client.ImageService()
 		res        = make([]containerd.Image, 0, len(imgs))
 		snaphotter = CurrentSnapshotterName(ctx)
 	)
 
 	for _, img := range imgs {
-		image := containerd.NewImage(wrapperCli.client, img)
+		created, err := is.Update(ctx, img, "target")
+		if err != nil {
+			if !errdefs.IsNotFound(err) {
+				return nil, err
+			}
+
+			created, err = is.Create(ctx, img)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		image := containerd.NewImage(wrapperCli.client, created)
 
 		err = image.Unpack(ctx, snaphotter)
 		if err != nil {
@@ -262,7 +345,9 @@ func (c *Client) ResolveImage(ctx context.Context, nameRef string, refs []string
 }
 
 // FetchImage fetches image content from the remote repository.
-func (c *Client) FetchImage(ctx context.Context, resolver remotes.Resolver, availableRef string, authConfig *types.AuthConfig, stream *jsonstream.JSONStream) (containerd.Image, error) {
+// The platform selects the manifest from the manifest list, and the platform
+// of daemon host is used if it's empty.
+func (c *Client) FetchImage(ctx context.Context, resolver remotes.Resolver, availableRef string, platform string, authConfig *types.AuthConfig, stream *jsonstream.JSONStream) (containerd.Image, error) {
 	wrapperCli, err := c.Get(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get a containerd grpc client: %v", err)
@@ -275,6 +360,25 @@ func (c *Client) FetchImage(ctx context.Context, resolver remotes.Resolver, avai
 		containerd.WithResolver(resolver),
 	}
 
+	if platform != "" {
+		p, err := platforms.Parse(platform)
+		if err != nil {
+			return nil, errors.Wrapf(errtypes.ErrInvalidParam, "invalid platform %s: %v", platform, err)
+		}
+
+		// make sure that the platform is provided by the image, otherwise
+		// the pull fails with a confusing not found error.
+		if err := checkPlatform(ctx, resolver, availableRef, p); err != nil {
+			return nil, err
+		}
+
+		platform = platforms.Format(p)
+		options = append(options,
+			containerd.WithPlatform(platform),
+			containerd.WithPullLabel(LabelImagePlatform, platform),
+		)
+	}
+
 	handle := func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
 		if desc.MediaType != ctrdmetaimages.MediaTypeDockerSchema1Manifest {
 			ongoing.add(desc)
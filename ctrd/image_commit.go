@@ -20,7 +20,6 @@ import (
 	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/mount"
-	"github.com/containerd/containerd/platforms"
 	"github.com/containerd/containerd/snapshots"
 	digest "github.com/opencontainers/go-digest"
 	"github.com/opencontainers/image-spec/identity"
@@ -129,7 +128,7 @@ func (c *Client) Commit(ctx context.Context, config *CommitConfig) (_ digest.Dig
 	}
 
 	// get parent image layer descriptor
-	pmfst, err := images.Manifest(ctx, cs, config.CImage.Target(), platforms.Default())
+	pmfst, err := images.Manifest(ctx, cs, config.CImage.Target(), ImagePlatformMatcher(config.CImage.Labels()))
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,158 @@
+package ctrd
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/containerd/containerd/content"
+	ctrdmetaimages "github.com/containerd/containerd/images"
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// AnnotationImageName is the annotation of manifest in the index of oci.v1
+// format tarstream, which keeps the full reference of the saved image so that
+// the image can be loaded with the same name.
+const AnnotationImageName = "io.containerd.image.name"
+
+// exportImages writes the images into the oci.v1 format tarstream. The blobs
+// shared by several images are written only once.
+func exportImages(ctx context.Context, store content.Provider, descs []ocispec.Descriptor, w io.Writer) error {
+	blobs := map[digest.Digest]ocispec.Descriptor{}
+	collectHandler := func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		blobs[desc.Digest] = desc
+		return nil, nil
+	}
+
+	handlers := ctrdmetaimages.Handlers(
+		ctrdmetaimages.ChildrenHandler(store),
+		ctrdmetaimages.HandlerFunc(collectHandler),
+	)
+	if err := ctrdmetaimages.Walk(ctx, handlers, descs...); err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+
+	// write the blobs in the order of path, just like the containerd does.
+	dgsts := make([]digest.Digest, 0, len(blobs))
+	algorithms := map[string]struct{}{}
+	for dgst := range blobs {
+		dgsts = append(dgsts, dgst)
+		algorithms[dgst.Algorithm().String()] = struct{}{}
+	}
+	sort.Slice(dgsts, func(i, j int) bool {
+		return blobPath(dgsts[i]) < blobPath(dgsts[j])
+	})
+
+	if len(algorithms) > 0 {
+		algs := make([]string, 0, len(algorithms))
+		for alg := range algorithms {
+			algs = append(algs, alg)
+		}
+		sort.Strings(algs)
+
+		if err := writeTarDir(tw, "blobs/"); err != nil {
+			return err
+		}
+		for _, alg := range algs {
+			if err := writeTarDir(tw, "blobs/"+alg+"/"); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, dgst := range dgsts {
+		if err := writeTarBlob(ctx, tw, store, blobs[dgst]); err != nil {
+			return err
+		}
+	}
+
+	index, err := json.Marshal(ocispec.Index{
+		Versioned: ocispecs.Versioned{
+			SchemaVersion: 2,
+		},
+		Manifests: descs,
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, "index.json", 0644, index); err != nil {
+		return err
+	}
+
+	layout, err := json.Marshal(ocispec.ImageLayout{
+		Version: ocispec.ImageLayoutVersion,
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, ocispec.ImageLayoutFile, 0444, layout); err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// blobPath returns the path of blob in the oci.v1 format tarstream.
+func blobPath(dgst digest.Digest) string {
+	return "blobs/" + dgst.Algorithm().String() + "/" + dgst.Hex()
+}
+
+func writeTarDir(tw *tar.Writer, name string) error {
+	return tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0755,
+		Typeflag: tar.TypeDir,
+	})
+}
+
+func writeTarFile(tw *tar.Writer, name string, mode int64, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     mode,
+		Size:     int64(len(data)),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+
+	_, err := tw.Write(data)
+	return err
+}
+
+func writeTarBlob(ctx context.Context, tw *tar.Writer, store content.Provider, desc ocispec.Descriptor) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     blobPath(desc.Digest),
+		Mode:     0444,
+		Size:     desc.Size,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+
+	r, err := store.ReaderAt(ctx, desc)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get reader of blob %s", desc.Digest)
+	}
+	defer r.Close()
+
+	// verify the digest during copying
+	dgstr := desc.Digest.Algorithm().Digester()
+	n, err := io.Copy(io.MultiWriter(tw, dgstr.Hash()), content.NewReader(r))
+	if err != nil {
+		return errors.Wrapf(err, "failed to copy blob %s to tar", desc.Digest)
+	}
+	if n != desc.Size {
+		return errors.Errorf("unexpected copy size %d for blob %s", n, desc.Digest)
+	}
+	if dgstr.Digest() != desc.Digest {
+		return errors.Errorf("unexpected digest %s copied for blob %s", dgstr.Digest(), desc.Digest)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package ctrd
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	"github.com/containerd/containerd"
+	ctrdmetaimages "github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/containerd/containerd/remotes"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// LabelImagePlatform is the label of containerd image, which records the
+// platform selected when the image is pulled. The image without the label
+// uses the platform of daemon host.
+const LabelImagePlatform = "io.alibaba.pouch.image.platform"
+
+// ImagePlatformMatcher returns the platform matcher of the image.
+func ImagePlatformMatcher(labels map[string]string) platforms.MatchComparer {
+	if v := labels[LabelImagePlatform]; v != "" {
+		if p, err := platforms.Parse(v); err == nil {
+			return platforms.Only(p)
+		}
+	}
+	return platforms.Default()
+}
+
+// newImage returns the containerd image which uses the platform recorded in
+// the labels of the image.
+func newImage(client *containerd.Client, img ctrdmetaimages.Image) containerd.Image {
+	return containerd.NewImageWithPlatform(client, img, ImagePlatformMatcher(img.Labels))
+}
+
+// checkPlatform checks that the remote image provides the platform, and
+// returns ErrInvalidParam with the available platforms if not.
+func checkPlatform(ctx context.Context, resolver remotes.Resolver, ref string, platform ocispec.Platform) error {
+	name, desc, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	available, err := remotePlatforms(ctx, fetcher, desc)
+	if err != nil {
+		return err
+	}
+
+	matcher := platforms.Only(platform)
+	formatted := make([]string, 0, len(available))
+	for _, p := range available {
+		if matcher.Match(p) {
+			return nil
+		}
+		formatted = append(formatted, platforms.Format(p))
+	}
+
+	return errors.Wrapf(errtypes.ErrInvalidParam, "image %s does not provide the platform %s, available platforms: [%s]",
+		ref, platforms.Format(platform), strings.Join(formatted, ", "))
+}
+
+// remotePlatforms returns the platforms provided by the remote manifest list
+// or manifest.
+func remotePlatforms(ctx context.Context, fetcher remotes.Fetcher, desc ocispec.Descriptor) ([]ocispec.Platform, error) {
+	switch desc.MediaType {
+	case ctrdmetaimages.MediaTypeDockerSchema2ManifestList, ocispec.MediaTypeImageIndex:
+		var idx ocispec.Index
+		if err := fetchJSON(ctx, fetcher, desc, &idx); err != nil {
+			return nil, err
+		}
+
+		res := make([]ocispec.Platform, 0, len(idx.Manifests))
+		for _, m := range idx.Manifests {
+			if m.Platform != nil {
+				res = append(res, platforms.Normalize(*m.Platform))
+			}
+		}
+		return res, nil
+	case ctrdmetaimages.MediaTypeDockerSchema2Manifest, ocispec.MediaTypeImageManifest:
+		var manifest ocispec.Manifest
+		if err := fetchJSON(ctx, fetcher, desc, &manifest); err != nil {
+			return nil, err
+		}
+
+		var config ocispec.Image
+		if err := fetchJSON(ctx, fetcher, manifest.Config, &config); err != nil {
+			return nil, err
+		}
+		return []ocispec.Platform{platforms.Normalize(ocispec.Platform{
+			OS:           config.OS,
+			Architecture: config.Architecture,
+		})}, nil
+	}
+
+	// NOTE: the legacy schema1 manifest doesn't provide platform info.
+	return []ocispec.Platform{platforms.DefaultSpec()}, nil
+}
+
+func fetchJSON(ctx context.Context, fetcher remotes.Fetcher, desc ocispec.Descriptor, v interface{}) error {
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	data, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// getPlatformImage returns the containerd image which uses the platform
+// recorded in the labels of the image.
+func getPlatformImage(ctx context.Context, client *containerd.Client, ref string) (containerd.Image, error) {
+	img, err := client.ImageService().Get(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return newImage(client, img), nil
+}
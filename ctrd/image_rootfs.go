@@ -0,0 +1,197 @@
+package ctrd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"time"
+
+	"github.com/alibaba/pouch/pkg/randomid"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/docker/docker/pkg/archive"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// ImportRootfsConfig defines options for creating an image from a rootfs tarball.
+type ImportRootfsConfig struct {
+	// reference of the new image
+	Reference string
+
+	// comment recorded in the history of the new image
+	Comment string
+
+	// image-spec format image config
+	Config ocispec.ImageConfig
+}
+
+// ImportRootfs creates a single-layer image from the rootfs tarball, which
+// may be compressed.
+func (c *Client) ImportRootfs(ctx context.Context, config *ImportRootfsConfig, reader io.Reader) (digest.Digest, error) {
+	dgst, err := c.importRootfs(ctx, config, reader)
+	if err != nil {
+		return "", convertCtrdErr(err)
+	}
+	return dgst, nil
+}
+
+// importRootfs creates a single-layer image from the rootfs tarball.
+func (c *Client) importRootfs(ctx context.Context, config *ImportRootfsConfig, reader io.Reader) (digest.Digest, error) {
+	wrapperCli, err := c.Get(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get a containerd grpc client: %v", err)
+	}
+	client := wrapperCli.client
+
+	// NOTE: make sure that gc scheduler doesn't remove content during import
+	ctx, done, err := client.WithLease(ctx)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create lease for import")
+	}
+	defer done(ctx)
+
+	cs := client.ContentStore()
+
+	// the layer is stored uncompressed so that the diffID is the digest of layer.
+	layer, err := writeRootfsLayer(ctx, cs, reader)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to write layer")
+	}
+
+	createdTime := time.Now()
+	img := ocispec.Image{
+		Architecture: runtime.GOARCH,
+		OS:           runtime.GOOS,
+		Created:      &createdTime,
+		Config:       config.Config,
+		RootFS: ocispec.RootFS{
+			Type:    "layers",
+			DiffIDs: []digest.Digest{layer.Digest},
+		},
+		History: []ocispec.History{
+			{
+				Created: &createdTime,
+				Comment: config.Comment,
+			},
+		},
+	}
+
+	imgJSON, err := json.Marshal(img)
+	if err != nil {
+		return "", err
+	}
+
+	configDesc := ocispec.Descriptor{
+		MediaType: configType,
+		Digest:    digest.FromBytes(imgJSON),
+		Size:      int64(len(imgJSON)),
+	}
+	if err := content.WriteBlob(ctx, cs, configDesc.Digest.String(), bytes.NewReader(imgJSON), configDesc); err != nil {
+		return "", errors.Wrap(err, "error writing config blob")
+	}
+
+	mfst := struct {
+		MediaType string `json:"mediaType,omitempty"`
+		ocispec.Manifest
+	}{
+		MediaType: manifestType,
+		Manifest: ocispec.Manifest{
+			Versioned: specs.Versioned{
+				SchemaVersion: 2,
+			},
+			Config: configDesc,
+			Layers: []ocispec.Descriptor{layer},
+		},
+	}
+
+	mfstJSON, err := json.MarshalIndent(mfst, "", "   ")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal manifest")
+	}
+
+	mfstDesc := ocispec.Descriptor{
+		MediaType: manifestType,
+		Digest:    digest.FromBytes(mfstJSON),
+		Size:      int64(len(mfstJSON)),
+	}
+	labels := map[string]string{
+		"containerd.io/gc.ref.content.0": configDesc.Digest.String(),
+		"containerd.io/gc.ref.content.1": layer.Digest.String(),
+	}
+	if err := content.WriteBlob(ctx, cs, mfstDesc.Digest.String(), bytes.NewReader(mfstJSON), mfstDesc, content.WithLabels(labels)); err != nil {
+		return "", errors.Wrapf(err, "error writing manifest blob %s", mfstDesc.Digest)
+	}
+
+	// register containerd image metadata.
+	is := client.ImageService()
+	created, err := is.Update(ctx, images.Image{
+		Name:      config.Reference,
+		Target:    mfstDesc,
+		CreatedAt: createdTime,
+	}, "target")
+	if err != nil {
+		if !errdefs.IsNotFound(err) {
+			return "", fmt.Errorf("failed to cover exist image %s", err)
+		}
+
+		created, err = is.Create(ctx, images.Image{
+			Name:      config.Reference,
+			Target:    mfstDesc,
+			CreatedAt: createdTime,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to create new image %s", err)
+		}
+	}
+
+	if err := containerd.NewImage(client, created).Unpack(ctx, CurrentSnapshotterName(ctx)); err != nil {
+		return "", errors.Wrapf(err, "failed to unpack image %s", config.Reference)
+	}
+
+	// pouch record config descriptor digest as image id.
+	return configDesc.Digest, nil
+}
+
+// writeRootfsLayer writes the decompressed rootfs tarball into content store
+// as an uncompressed layer.
+func writeRootfsLayer(ctx context.Context, cs content.Ingester, reader io.Reader) (ocispec.Descriptor, error) {
+	rc, err := archive.DecompressStream(reader)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	defer rc.Close()
+
+	ref := "import-rootfs-" + randomid.Generate()
+	w, err := content.OpenWriter(ctx, cs, content.WithRef(ref))
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	defer w.Close()
+
+	dgstr := digest.Canonical.Digester()
+	size, err := io.Copy(io.MultiWriter(w, dgstr.Hash()), rc)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	if err := w.Commit(ctx, size, dgstr.Digest()); err != nil {
+		if !errdefs.IsAlreadyExists(err) {
+			return ocispec.Descriptor{}, err
+		}
+	}
+
+	return ocispec.Descriptor{
+		MediaType: images.MediaTypeDockerSchema2Layer,
+		Digest:    dgstr.Digest(),
+		Size:      size,
+	}, nil
+}
@@ -3,6 +3,7 @@ package ctrd
 import (
 	"context"
 	"io"
+	"syscall"
 	"time"
 
 	"github.com/alibaba/pouch/apis/types"
@@ -17,6 +18,7 @@ import (
 	"github.com/containerd/containerd/remotes/docker"
 	"github.com/containerd/containerd/snapshots"
 	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 // APIClient defines common methods of containerd api client
@@ -35,8 +37,9 @@ type APIClient interface {
 type ContainerAPIClient interface {
 	// CreateContainer creates a containerd container and start process.
 	CreateContainer(ctx context.Context, container *Container, checkpointDir string) error
-	// DestroyContainer kill container and delete it.
-	DestroyContainer(ctx context.Context, id string, timeout int64) (*Message, error)
+	// DestroyContainer sends the stop signal to container, kills it after timeout and deletes it,
+	// the container is killed immediately if the timeout is zero.
+	DestroyContainer(ctx context.Context, id string, signal syscall.Signal, timeout int64) (*Message, error)
 	// ProbeContainer probe the container's status, if timeout <= 0, will block to receive message.
 	ProbeContainer(ctx context.Context, id string, timeout time.Duration) *Message
 	// ContainerPIDs returns the all processes's ids inside the container.
@@ -50,12 +53,16 @@ type ContainerAPIClient interface {
 	// ResizeContainer changes the size of the TTY of the exec process running
 	// in the container to the given height and width.
 	ResizeExec(ctx context.Context, id string, execid string, opts types.ResizeOptions) error
+	// KillExec sends signal to the exec process running in the container.
+	KillExec(ctx context.Context, id string, execid string, signal syscall.Signal) error
 	// RecoverContainer reload the container from metadata and watch it, if program be restarted.
 	RecoverContainer(ctx context.Context, id string, io *containerio.IO) error
 	// PauseContainer pause container.
 	PauseContainer(ctx context.Context, id string) error
 	// UnpauseContainer unpauses a container.
 	UnpauseContainer(ctx context.Context, id string) error
+	// KillContainer sends signal to the init process of container.
+	KillContainer(ctx context.Context, id string, signal syscall.Signal) error
 	// ResizeContainer changes the size of the TTY of the init process running
 	// in the container to the given height and width.
 	ResizeContainer(ctx context.Context, id string, opts types.ResizeOptions) error
@@ -80,17 +87,19 @@ type ImageAPIClient interface {
 	// ListImages returns the list of containerd.Image filtered by the given conditions.
 	ListImages(ctx context.Context, filter ...string) ([]containerd.Image, error)
 	// FetchImage fetches image content by the given reference.
-	FetchImage(ctx context.Context, resolver remotes.Resolver, ref string, authConfig *types.AuthConfig, stream *jsonstream.JSONStream) (containerd.Image, error)
+	FetchImage(ctx context.Context, resolver remotes.Resolver, ref string, platform string, authConfig *types.AuthConfig, stream *jsonstream.JSONStream) (containerd.Image, error)
 	// ResolveImage attempts to resolve the image reference into a available reference and resolver.
 	ResolveImage(ctx context.Context, nameRef string, refs []string, authConfig *types.AuthConfig, opts docker.ResolverOptions) (remotes.Resolver, string, error)
 	// RemoveImage removes the image by the given reference.
 	RemoveImage(ctx context.Context, ref string) error
 	// ImportImage creates a set of images by tarstream.
-	ImportImage(ctx context.Context, reader io.Reader, opts ...containerd.ImportOpt) ([]containerd.Image, error)
-	// SaveImage saves image to tarstream
-	SaveImage(ctx context.Context, exporter ctrdmetaimages.Exporter, ref string) (io.ReadCloser, error)
+	ImportImage(ctx context.Context, reader io.Reader, refTranslator func(ocispec.Descriptor) string) ([]containerd.Image, error)
+	// SaveImage saves images to tarstream
+	SaveImage(ctx context.Context, refs []string) (io.ReadCloser, error)
 	// Commit commits an image from a container.
 	Commit(ctx context.Context, config *CommitConfig) (digest.Digest, error)
+	// ImportRootfs creates a single-layer image from the rootfs tarball.
+	ImportRootfs(ctx context.Context, config *ImportRootfsConfig, reader io.Reader) (digest.Digest, error)
 	// PushImage pushes a image to registry
 	PushImage(ctx context.Context, ref string, authConfig *types.AuthConfig, out io.Writer) error
 }
@@ -106,6 +115,9 @@ type SnapshotAPIClient interface {
 	// GetMounts returns the mounts for the active snapshot transaction identified
 	// by key.
 	GetMounts(ctx context.Context, id string) ([]mount.Mount, error)
+	// SnapshotChanges returns the changes of the active snapshot compared with
+	// its parent.
+	SnapshotChanges(ctx context.Context, id string) ([]types.ContainerChangeResponseItem, error)
 	// GetSnapshotUsage returns the resource usage of an active or committed snapshot
 	// excluding the usage of parent snapshots.
 	GetSnapshotUsage(ctx context.Context, id string) (snapshots.Usage, error)
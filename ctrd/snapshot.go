@@ -3,12 +3,16 @@ package ctrd
 import (
 	"context"
 	"fmt"
+	"os"
 
+	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/utils"
 	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/leases"
 	"github.com/containerd/containerd/mount"
 	"github.com/containerd/containerd/snapshots"
+	"github.com/containerd/continuity/fs"
 	"github.com/opencontainers/image-spec/identity"
 )
 
@@ -48,7 +52,7 @@ func (c *Client) CreateSnapshot(ctx context.Context, id, ref string) error {
 		snSrv  = wrapperCli.client.SnapshotService(snName)
 	)
 
-	image, err := wrapperCli.client.GetImage(ctx, ref)
+	image, err := getPlatformImage(ctx, wrapperCli.client, ref)
 	if err != nil {
 		return err
 	}
@@ -135,6 +139,76 @@ func (c *Client) GetMounts(ctx context.Context, id string) ([]mount.Mount, error
 	return service.Mounts(ctx, id)
 }
 
+// SnapshotChanges returns the changes of the active snapshot compared with
+// its parent, which are the changes in the upper layer only. The kind of
+// change is 0 for modified, 1 for added and 2 for deleted.
+func (c *Client) SnapshotChanges(ctx context.Context, id string) ([]types.ContainerChangeResponseItem, error) {
+	wrapperCli, err := c.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a containerd grpc client: %v", err)
+	}
+
+	service := wrapperCli.client.SnapshotService(CurrentSnapshotterName(ctx))
+	defer service.Close()
+
+	info, err := service.Stat(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	upper, err := service.Mounts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// the snapshot without parent is compared with an empty directory.
+	var lower []mount.Mount
+	if info.Parent != "" {
+		lowerKey := fmt.Sprintf("%s-parent-view-%s", info.Parent, utils.RandString(5, "", ""))
+		lower, err = service.View(ctx, lowerKey, info.Parent)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			// NOTE: the passthrough context might be canceled.
+			if err := service.Remove(context.TODO(), lowerKey); err != nil {
+				log.With(ctx).Warnf("failed to cleanup changes lower snapshot(key=%s): %v", lowerKey, err)
+			}
+		}()
+	}
+
+	changes := []types.ContainerChangeResponseItem{}
+	changeFn := func(k fs.ChangeKind, path string, _ os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		var kind uint8
+		switch k {
+		case fs.ChangeKindModify:
+			kind = 0
+		case fs.ChangeKindAdd:
+			kind = 1
+		case fs.ChangeKindDelete:
+			kind = 2
+		default:
+			return nil
+		}
+		changes = append(changes, types.ContainerChangeResponseItem{Kind: kind, Path: path})
+		return nil
+	}
+
+	err = mount.WithTempMount(ctx, lower, func(lowerRoot string) error {
+		return mount.WithTempMount(ctx, upper, func(upperRoot string) error {
+			return fs.Changes(ctx, lowerRoot, upperRoot, changeFn)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
 // GetSnapshotUsage returns the resource usage of an active or committed snapshot
 // excluding the usage of parent snapshots.
 func (c *Client) GetSnapshotUsage(ctx context.Context, id string) (snapshots.Usage, error) {
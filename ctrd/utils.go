@@ -317,6 +317,13 @@ func toLinuxResources(resources types.Resources) (*specs.LinuxResources, error)
 		// TODO: add other fields of specs.LinuxMemory
 	}
 
+	// toLinuxPids, zero means not to change the pids limit.
+	if resources.PidsLimit != 0 {
+		r.Pids = &specs.LinuxPids{
+			Limit: resources.PidsLimit,
+		}
+	}
+
 	// TODO: add more fields.
 
 	return r, nil
@@ -89,6 +89,11 @@ type Config struct {
 	// LxcfsHome is the absolute path of lxcfs
 	LxcfsHome string `json:"lxcfs-home,omitempty"`
 
+	// ExecInitPath is the path of init binary inside containers, like tini,
+	// which the exec process is spawned under with --init. The exec init is
+	// not supported if it is empty.
+	ExecInitPath string `json:"exec-init-path,omitempty"`
+
 	// ImagxeProxy is a http proxy to pull image
 	ImageProxy string `json:"image-proxy,omitempty"`
 
@@ -91,6 +91,11 @@ func (ctrio *IO) SetLogDriver(logdriver logger.LogDriver) {
 	ctrio.logdriver = logdriver
 }
 
+// LogDriver returns the log driver of the IO, nil means no log driver.
+func (ctrio *IO) LogDriver() logger.LogDriver {
+	return ctrio.logdriver
+}
+
 // SetMaxBufferSize set the max size of buffer.
 func (ctrio *IO) SetMaxBufferSize(maxBufferSize int64) {
 	ctrio.maxBufferSize = maxBufferSize
@@ -9,9 +9,12 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
+	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/opts"
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/ctrd"
@@ -84,6 +87,9 @@ type ContainerMgr interface {
 	// Unpause a container.
 	Unpause(ctx context.Context, name string) error
 
+	// Kill sends signal to a container.
+	Kill(ctx context.Context, name string, signal syscall.Signal) error
+
 	// Using a stream to get stats of a container.
 	StreamStats(ctx context.Context, name string, config *ContainerStatsConfig) error
 
@@ -111,8 +117,9 @@ type ContainerMgr interface {
 	// Resize resizes the size of container tty.
 	Resize(ctx context.Context, name string, opts types.ResizeOptions) error
 
-	// Remove removes a container, it may be running or stopped and so on.
-	Remove(ctx context.Context, name string, option *types.ContainerRemoveOptions) error
+	// Remove removes a container, it may be running or stopped and so on,
+	// the removed and kept volumes are returned if removing with volumes.
+	Remove(ctx context.Context, name string, option *types.ContainerRemoveOptions) (*types.ContainerRemoveResp, error)
 
 	// Wait stops processing until the given container is stopped.
 	Wait(ctx context.Context, name string) (types.ContainerWaitOKBody, error)
@@ -137,6 +144,12 @@ type ContainerMgr interface {
 	// ResizeExec resizes the size of exec process's tty.
 	ResizeExec(ctx context.Context, execid string, opts types.ResizeOptions) error
 
+	// KillExec sends signal to the exec process.
+	KillExec(ctx context.Context, execid string, signal syscall.Signal) error
+
+	// RemoveExec removes the record of the exec process which is not running.
+	RemoveExec(ctx context.Context, execid string) error
+
 	// 3. The following two function is related to network management.
 	// TODO: inconsistency, Connect/Disconnect operation is in newtork_bridge.go in upper API layer.
 	// Here we encapsualted them in container manager, inconsistency exists.
@@ -174,6 +187,18 @@ type ContainerMgr interface {
 
 	// ExtractToDir extracts the given archive at the specified path in the container.
 	ExtractToDir(ctx context.Context, name, path string, copyUIDGID, noOverwriteDirNonDir bool, content io.Reader) error
+
+	// Export returns the tarstream of the container filesystem.
+	Export(ctx context.Context, name string) (io.ReadCloser, error)
+
+	// Changes returns the changes in the upper layer of the container filesystem.
+	Changes(ctx context.Context, name string) ([]types.ContainerChangeResponseItem, error)
+
+	// DiskUsage returns the disk space used by the writable layer of containers.
+	DiskUsage(ctx context.Context) ([]*types.DiskUsageObject, error)
+
+	// Prune removes all the stopped containers.
+	Prune(ctx context.Context, filter filters.Args) (*types.ContainerPruneResp, error)
 }
 
 // ContainerManager is the default implement of interface ContainerMgr.
@@ -272,6 +297,9 @@ func (mgr *ContainerManager) Restore(ctx context.Context) error {
 		return errors.Wrap(err, "failed to get container list")
 	}
 
+	// restarts are the non-running containers restarted by restart policy.
+	var restarts []*Container
+
 	for _, c := range containers {
 		id := c.Key()
 
@@ -303,6 +331,10 @@ func (mgr *ContainerManager) Restore(ctx context.Context) error {
 
 		// recover the running or paused container.
 		if !c.IsRunningOrPaused() {
+			policy := (*ContainerRestartPolicy)(c.HostConfig.RestartPolicy)
+			if !c.IsCreated() && policy != nil && policy.ShouldRestartOnStartup(c.State.ExitCode, c.HasBeenManuallyStopped, c.RestartCount) {
+				restarts = append(restarts, c)
+			}
 			continue
 		}
 
@@ -311,6 +343,8 @@ func (mgr *ContainerManager) Restore(ctx context.Context) error {
 		// Start recover the container
 		err = mgr.Client.RecoverContainer(ctx, id, cntrio)
 		if err == nil {
+			mgr.initHealthMonitor(c)
+			mgr.initPidsMonitor(c)
 			continue
 		}
 
@@ -334,6 +368,21 @@ func (mgr *ContainerManager) Restore(ctx context.Context) error {
 		}
 	}
 
+	for _, c := range restarts {
+		go func(c *Container) {
+			ctx := log.NewContext(context.Background(), map[string]interface{}{
+				"ContainerID": c.ID,
+			})
+			ctx = ctrd.WithSnapshotter(ctx, c.Config.Snapshotter)
+
+			if err := mgr.start(ctx, c, &types.ContainerStartOptions{DetachKeys: c.DetachKeys}); err != nil {
+				log.With(ctx).Errorf("failed to start container by restart policy, err(%v)", err)
+				return
+			}
+			mgr.LogContainerEvent(ctx, c, "start")
+		}(c)
+	}
+
 	return nil
 }
 
@@ -411,8 +460,8 @@ func (mgr *ContainerManager) Create(ctx context.Context, name string, config *ty
 		name = mgr.generateName(id)
 	} else if !daemon_config.ValidNamePattern.MatchString(name) {
 		return nil, fmt.Errorf("Invalid container name (%s), only %s are allowed", name, daemon_config.ValidNameChars)
-	} else if mgr.NameToID.Get(name).Exist() {
-		return nil, errors.Wrapf(errtypes.ErrAlreadyExisted, "container name %s", name)
+	} else if existing, ok := mgr.NameToID.Get(name).String(); ok {
+		return nil, errors.Wrapf(errtypes.ErrAlreadyExisted, "container name %s is already in use by container %s", name, existing)
 	}
 
 	// set hostname.
@@ -487,6 +536,9 @@ func (mgr *ContainerManager) Create(ctx context.Context, name string, config *ty
 	}); err != nil {
 		return nil, err
 	}
+	if len(container.Config.Entrypoint) == 0 && len(container.Config.Cmd) == 0 {
+		return nil, errors.Wrap(errtypes.ErrInvalidParam, "no command specified")
+	}
 
 	// set container basefs, basefs is not created in pouchd, it will created
 	// after create options passed to containerd.
@@ -535,6 +587,7 @@ func (mgr *ContainerManager) Create(ctx context.Context, name string, config *ty
 	if err != nil {
 		return nil, err
 	}
+	applyNanoCPUs(&container.HostConfig.Resources)
 
 	// store disk
 	if err := container.Write(mgr.Store); err != nil {
@@ -625,6 +678,12 @@ func (mgr *ContainerManager) Start(ctx context.Context, id string, options *type
 
 	err = mgr.start(ctx, c, options)
 	if err == nil {
+		// the retries of restart policy are counted again once the
+		// container is started by user.
+		c.Lock()
+		c.RestartCount = 0
+		c.Unlock()
+
 		mgr.LogContainerEvent(ctx, c, "start")
 	}
 
@@ -638,6 +697,7 @@ func (mgr *ContainerManager) start(ctx context.Context, c *Container, options *t
 
 	var err error
 	c.DetachKeys = options.DetachKeys
+	c.HasBeenManuallyStopped = false
 
 	// check if container's status is paused
 	if c.State.Paused {
@@ -653,6 +713,13 @@ func (mgr *ContainerManager) start(ctx context.Context, c *Container, options *t
 		return fmt.Errorf("cannot start a dead container %s", c.ID)
 	}
 
+	// restoring from checkpoint depends on criu.
+	if options.CheckpointID != "" {
+		if err := checkCRIU(); err != nil {
+			return err
+		}
+	}
+
 	attachedVolumes := map[string]struct{}{}
 	defer func() {
 		if err == nil {
@@ -870,6 +937,8 @@ func (mgr *ContainerManager) createContainerdContainer(ctx context.Context, c *C
 	}
 
 	c.SetStatusRunning(int64(pid))
+	mgr.initHealthMonitor(c)
+	mgr.initPidsMonitor(c)
 
 	// set Snapshot MergedDir
 	c.Snapshotter.Data["MergedDir"] = c.BaseFS
@@ -924,7 +993,8 @@ func (mgr *ContainerManager) ensureRootFSMounted(rootfs string, snapData map[str
 	return mount.Mount(rootfs)
 }
 
-// Stop stops a running container.
+// Stop stops a running container, the stop timeout of container is used
+// if timeout is negative.
 func (mgr *ContainerManager) Stop(ctx context.Context, name string, timeout int64) error {
 	c, err := mgr.container(name)
 	if err != nil {
@@ -943,24 +1013,37 @@ func (mgr *ContainerManager) Stop(ctx context.Context, name string, timeout int6
 	}
 	mgr.LogContainerEvent(ctx, c, "stop")
 
+	if c.HostConfig.AutoRemove {
+		mgr.removeOnExit(ctx, c)
+	}
 	return nil
 }
 
+// removeOnExit removes the container created with auto remove and its
+// anonymous volumes once the container exits.
+func (mgr *ContainerManager) removeOnExit(ctx context.Context, c *Container) {
+	if _, err := mgr.Remove(ctx, c.ID, &types.ContainerRemoveOptions{Force: true, Volumes: true}); err != nil && !errtypes.IsNotfound(err) {
+		log.With(ctx).Errorf("failed to auto remove container: %v", err)
+	}
+}
+
 func (mgr *ContainerManager) stop(ctx context.Context, c *Container, timeout int64) error {
 	c.Lock()
 	defer c.Unlock()
 
+	// the container stopped by user is not restarted by restart policy,
+	// which also cancels the pending restart of an exited container.
+	c.HasBeenManuallyStopped = true
+
 	if !c.IsRunningOrPaused() {
 		// stopping a non-running container is valid.
 		return nil
 	}
 
-	if timeout == 0 {
-		timeout = c.StopTimeout()
-	}
+	timeout = c.resolveStopTimeout(timeout)
 
 	id := c.ID
-	msg, err := mgr.Client.DestroyContainer(ctx, id, timeout)
+	msg, err := mgr.Client.DestroyContainer(ctx, id, c.StopSignal(), timeout)
 	if err != nil {
 		return errors.Wrapf(err, "failed to destroy container %s", id)
 	}
@@ -968,7 +1051,8 @@ func (mgr *ContainerManager) stop(ctx context.Context, c *Container, timeout int
 	return mgr.markStoppedAndRelease(ctx, c, msg)
 }
 
-// Restart restarts a running container.
+// Restart restarts a running container, the stop timeout of container is
+// used if timeout is negative.
 func (mgr *ContainerManager) Restart(ctx context.Context, name string, timeout int64) error {
 	c, err := mgr.container(name)
 	if err != nil {
@@ -1027,8 +1111,11 @@ func (mgr *ContainerManager) Pause(ctx context.Context, name string) error {
 }
 
 func (mgr *ContainerManager) doPause(ctx context.Context, c *Container) error {
+	if c.State.Paused {
+		return errors.Wrapf(errtypes.ErrConflict, "container %s is already paused", c.ID)
+	}
 	if !c.IsRunning() {
-		return fmt.Errorf("container's status is not running: %s", c.State.Status)
+		return errors.Wrapf(errtypes.ErrConflict, "container %s is not running, its status is %s", c.ID, c.State.Status)
 	}
 
 	if err := mgr.Client.PauseContainer(ctx, c.ID); err != nil {
@@ -1044,6 +1131,36 @@ func (mgr *ContainerManager) doPause(ctx context.Context, c *Container) error {
 	return nil
 }
 
+// Kill sends signal to the init process of a running container.
+func (mgr *ContainerManager) Kill(ctx context.Context, name string, signal syscall.Signal) error {
+	c, err := mgr.container(name)
+	if err != nil {
+		return err
+	}
+
+	ctx = log.AddFields(ctx, map[string]interface{}{"ContainerID": c.ID})
+
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.IsRunningOrPaused() {
+		return errors.Wrapf(errtypes.ErrConflict, "container %s is not running", c.ID)
+	}
+
+	// the container killed by user with the stop signal is regarded as
+	// stopped manually, which is not restarted by restart policy.
+	if signal == syscall.SIGKILL || signal == c.StopSignal() {
+		c.HasBeenManuallyStopped = true
+	}
+
+	if err := mgr.Client.KillContainer(ctx, c.ID, signal); err != nil {
+		return errors.Wrapf(err, "failed to kill container %s", c.ID)
+	}
+
+	mgr.LogContainerEventWithAttributes(ctx, c, "kill", map[string]string{"signal": strconv.Itoa(int(signal))})
+	return c.Write(mgr.Store)
+}
+
 // Unpause unpauses a paused container.
 func (mgr *ContainerManager) Unpause(ctx context.Context, name string) error {
 	c, err := mgr.container(name)
@@ -1066,7 +1183,10 @@ func (mgr *ContainerManager) Unpause(ctx context.Context, name string) error {
 
 func (mgr *ContainerManager) doUnpause(ctx context.Context, c *Container) error {
 	if !c.State.Paused {
-		return fmt.Errorf("status(%s) of container %s is not paused", c.State.Status, c.ID)
+		if c.IsRunning() {
+			return errors.Wrapf(errtypes.ErrConflict, "container %s is not paused", c.ID)
+		}
+		return errors.Wrapf(errtypes.ErrConflict, "container %s is not running, its status is %s", c.ID, c.State.Status)
 	}
 
 	if err := mgr.Client.UnpauseContainer(ctx, c.ID); err != nil {
@@ -1131,8 +1251,8 @@ func (mgr *ContainerManager) AttachCRILog(ctx context.Context, name string, logP
 
 // Rename renames a container.
 func (mgr *ContainerManager) Rename(ctx context.Context, oldName, newName string) error {
-	if mgr.NameToID.Get(newName).Exist() {
-		return errors.Wrapf(errtypes.ErrAlreadyExisted, "container name %s", newName)
+	if !daemon_config.ValidNamePattern.MatchString(newName) {
+		return fmt.Errorf("Invalid container name (%s), only %s are allowed", newName, daemon_config.ValidNameChars)
 	}
 
 	c, err := mgr.container(oldName)
@@ -1149,25 +1269,29 @@ func (mgr *ContainerManager) Rename(ctx context.Context, oldName, newName string
 		return fmt.Errorf("cannot rename a dead container %s", c.ID)
 	}
 
-	attributes := map[string]string{
-		"oldName": oldName,
-	}
-
-	if !daemon_config.ValidNamePattern.MatchString(newName) {
-		return fmt.Errorf("Invalid container name (%s), only %s are allowed", newName, daemon_config.ValidNameChars)
+	// reserve the new name first, so that concurrent create or rename
+	// can not take the same name.
+	if !mgr.NameToID.PutIfAbsent(newName, c.ID) {
+		existing, _ := mgr.NameToID.Get(newName).String()
+		return errors.Wrapf(errtypes.ErrAlreadyExisted, "container name %s is already in use by container %s", newName, existing)
 	}
 
 	name := c.Name
 	c.Name = newName
 
-	mgr.NameToID.Remove(name)
-	mgr.NameToID.Put(newName, c.ID)
-
 	if err := c.Write(mgr.Store); err != nil {
 		log.With(ctx).Errorf("failed to update meta of container %s: %v", c.ID, err)
+
+		// rollback, keep the old name.
+		c.Name = name
+		mgr.NameToID.Remove(newName)
 		return err
 	}
+	mgr.NameToID.Remove(name)
 
+	attributes := map[string]string{
+		"oldName": name,
+	}
 	mgr.LogContainerEventWithAttributes(ctx, c, "rename", attributes)
 	return nil
 }
@@ -1192,6 +1316,12 @@ func (mgr *ContainerManager) Update(ctx context.Context, name string, config *ty
 		log.With(ctx).Warnf("warnings update %s: %v", name, warnings)
 	}
 
+	if config.OomScoreAdj != nil {
+		if err := opts.ValidateOOMScoreAdj(*config.OomScoreAdj); err != nil {
+			return errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+		}
+	}
+
 	restore := false
 	oldConfig := *c.Config
 	oldHostconfig := *c.HostConfig
@@ -1224,6 +1354,9 @@ func (mgr *ContainerManager) Update(ctx context.Context, name string, config *ty
 	// but ContainerConfig.Labels is map[string]string
 	if len(config.Label) != 0 {
 		// support remove some labels
+		if err := opts.ValidateLabels(config.Label); err != nil {
+			return errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+		}
 		newLabels := opts.ParseLabels(config.Label)
 
 		for k, v := range newLabels {
@@ -1241,11 +1374,15 @@ func (mgr *ContainerManager) Update(ctx context.Context, name string, config *ty
 		return errors.Wrapf(err, "failed to update resource of container %s", c.ID)
 	}
 
-	// TODO update restartpolicy when container is running.
+	// the restart policy takes effect on the next exit of container.
 	if config.RestartPolicy != nil && config.RestartPolicy.Name != "" {
 		c.HostConfig.RestartPolicy = config.RestartPolicy
 	}
 
+	if config.OomScoreAdj != nil {
+		c.HostConfig.OomScoreAdj = *config.OomScoreAdj
+	}
+
 	// Update Env
 	newEnvSlice, err := mergeEnvSlice(config.Env, c.Config.Env)
 	if err != nil {
@@ -1281,6 +1418,15 @@ func (mgr *ContainerManager) Update(ctx context.Context, name string, config *ty
 			restore = true
 			return fmt.Errorf("failed to update resource: %s", err)
 		}
+		if config.Resources.PidsLimit != 0 {
+			mgr.initPidsMonitor(c)
+		}
+		if config.OomScoreAdj != nil {
+			if err := setOOMScoreAdj(c.State.Pid, *config.OomScoreAdj); err != nil {
+				restore = true
+				return errors.Wrapf(err, "failed to update oom score adj of container %s", c.ID)
+			}
+		}
 	}
 
 	// store disk.
@@ -1293,11 +1439,12 @@ func (mgr *ContainerManager) Update(ctx context.Context, name string, config *ty
 	return err
 }
 
-// Remove removes a container, it may be running or stopped and so on.
-func (mgr *ContainerManager) Remove(ctx context.Context, name string, options *types.ContainerRemoveOptions) error {
+// Remove removes a container, it may be running or stopped and so on. The
+// removed and kept volumes are returned if removing with volumes.
+func (mgr *ContainerManager) Remove(ctx context.Context, name string, options *types.ContainerRemoveOptions) (*types.ContainerRemoveResp, error) {
 	c, err := mgr.container(name)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	ctx = log.AddFields(ctx, map[string]interface{}{"ContainerID": c.ID})
@@ -1310,19 +1457,19 @@ func (mgr *ContainerManager) Remove(ctx context.Context, name string, options *t
 	defer c.Unlock()
 
 	if c.IsRunningOrPaused() && !options.Force {
-		return fmt.Errorf("container %s is not stopped, cannot remove it without flag force", c.ID)
+		return nil, errors.Wrapf(errtypes.ErrConflict, "cannot remove running container %s, stop the container before removing or use force", c.ID)
 	}
 
 	if c.State.Dead {
 		log.With(ctx).Warnf("container has been deleted %s", c.ID)
-		return nil
+		return nil, nil
 	}
 
 	// if the container is running, force to stop it.
 	if c.IsRunningOrPaused() && options.Force {
-		_, err := mgr.Client.DestroyContainer(ctx, c.ID, c.StopTimeout())
+		_, err := mgr.Client.DestroyContainer(ctx, c.ID, c.StopSignal(), c.StopTimeout())
 		if err != nil && !errtypes.IsNotfound(err) {
-			return errors.Wrapf(err, "failed to destroy container %s when removing", c.ID)
+			return nil, errors.Wrapf(err, "failed to destroy container %s when removing", c.ID)
 		}
 		// After stopping a running container, we should release container resource
 		c.UnsetMergedDir()
@@ -1331,10 +1478,15 @@ func (mgr *ContainerManager) Remove(ctx context.Context, name string, options *t
 		}
 	}
 
-	if err := mgr.detachVolumes(ctx, c, options.Volumes); err != nil {
+	if err := mgr.detachVolumes(ctx, c); err != nil {
 		log.With(ctx).Errorf("failed to detach volume: %v", err)
 	}
 
+	var resp *types.ContainerRemoveResp
+	if options.Volumes {
+		resp = mgr.removeVolumes(ctx, c)
+	}
+
 	// if creating the container by specify rootfs,
 	// we should umount the rootfs when delete the container.
 	if c.RootFSProvided {
@@ -1382,7 +1534,7 @@ func (mgr *ContainerManager) Remove(ctx context.Context, name string, options *t
 	}
 
 	mgr.LogContainerEvent(ctx, c, "destroy")
-	return nil
+	return resp, nil
 }
 
 func (mgr *ContainerManager) updateContainerDiskQuota(ctx context.Context, c *Container, diskQuota map[string]string) (err error) {
@@ -1440,9 +1592,15 @@ func (mgr *ContainerManager) updateContainerResources(c *Container, resources ty
 	}
 	if resources.CPUPeriod != 0 {
 		cResources.CPUPeriod = resources.CPUPeriod
+		cResources.NanoCpus = 0
 	}
 	if resources.CPUQuota == -1 || resources.CPUQuota >= 1000 {
 		cResources.CPUQuota = resources.CPUQuota
+		cResources.NanoCpus = 0
+	}
+	if resources.NanoCpus != 0 {
+		cResources.NanoCpus = resources.NanoCpus
+		applyNanoCPUs(cResources)
 	}
 	if resources.CPUShares != 0 {
 		cResources.CPUShares = resources.CPUShares
@@ -1470,6 +1628,9 @@ func (mgr *ContainerManager) updateContainerResources(c *Container, resources ty
 	if resources.KernelMemory != 0 {
 		cResources.KernelMemory = resources.KernelMemory
 	}
+	if resources.PidsLimit != 0 {
+		cResources.PidsLimit = resources.PidsLimit
+	}
 
 	return nil
 }
@@ -1480,6 +1641,10 @@ func (mgr *ContainerManager) Top(ctx context.Context, name string, psArgs string
 		psArgs = "-ef"
 	}
 
+	if err := validatePSArgs(psArgs); err != nil {
+		return nil, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
 	c, err := mgr.container(name)
 	if err != nil {
 		return nil, err
@@ -1499,9 +1664,13 @@ func (mgr *ContainerManager) Top(ctx context.Context, name string, psArgs string
 		return nil, errors.Wrapf(err, "failed to get pids of container %s", c.ID)
 	}
 
-	output, err := exec.Command("ps", strings.Split(psArgs, " ")...).Output()
+	output, err := exec.Command("ps", strings.Fields(psArgs)...).Output()
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to run ps command")
+		if ee, ok := err.(*exec.ExitError); ok {
+			err = fmt.Errorf("%v: %s", ee, strings.TrimSpace(string(ee.Stderr)))
+		}
+		// the ps arguments have been validated, so it is an internal error.
+		return nil, fmt.Errorf("failed to run ps command with arguments %q: %v", psArgs, err)
 	}
 
 	procList, err := parsePSOutput(output, pids)
@@ -1632,6 +1801,12 @@ func (mgr *ContainerManager) Disconnect(ctx context.Context, containerName, netw
 		return fmt.Errorf("failed to disconnect container from network: container %s not attach to %s", c.Name, networkName)
 	}
 
+	// disconnecting the last network leaves container without network,
+	// which is allowed only when force is set.
+	if len(c.NetworkSettings.Networks) == 1 && !force {
+		return errors.Wrapf(errtypes.ErrConflict, "container %s is only connected to network %s, use force to disconnect it", c.Name, network.Name)
+	}
+
 	endpoint := mgr.buildContainerEndpoint(ctx, c, network.Name)
 	endpoint.EndpointConfig = epConfig
 	if err := mgr.NetworkMgr.EndpointRemove(ctx, endpoint); err != nil {
@@ -1673,11 +1848,20 @@ func (mgr *ContainerManager) updateNetworkConfig(container *Container, networkID
 
 	// TODO check bridge-mode conflict
 
-	if IsUserDefined(container.HostConfig.NetworkMode) {
+	network, err := mgr.NetworkMgr.Get(context.Background(), networkIDOrName)
+	if err != nil {
+		return err
+	}
+
+	if endpointConfig == nil {
+		endpointConfig = &types.EndpointSettings{}
+	}
+
+	if !IsUserDefined(network.Name) {
 		if hasUserDefinedIPAddress(endpointConfig) {
 			return fmt.Errorf("user specified IP address is supported on user defined networks only")
 		}
-		if endpointConfig != nil && len(endpointConfig.Aliases) > 0 {
+		if len(endpointConfig.Aliases) > 0 {
 			return fmt.Errorf("network-scoped alias is supported only for containers in user defined networks")
 		}
 	} else {
@@ -1694,11 +1878,6 @@ func (mgr *ContainerManager) updateNetworkConfig(container *Container, networkID
 		}
 	}
 
-	network, err := mgr.NetworkMgr.Get(context.Background(), networkIDOrName)
-	if err != nil {
-		return err
-	}
-
 	if err := validateNetworkingConfig(network.Network, endpointConfig); err != nil {
 		return err
 	}
@@ -1913,14 +2092,11 @@ func (mgr *ContainerManager) exitedAndRelease(id string, m *ctrd.Message, cleanu
 			return nil
 		}
 
-		policy := (*ContainerRestartPolicy)(c.HostConfig.RestartPolicy)
-		keys := c.DetachKeys
-
-		if policy == nil || policy.IsNone() {
+		if c.HostConfig.AutoRemove {
+			mgr.removeOnExit(ctx, c)
 			return nil
 		}
-
-		return mgr.Start(context.TODO(), c.ID, &types.ContainerStartOptions{DetachKeys: keys})
+		return mgr.restartOnExit(c)
 	}))
 
 	return nil
@@ -0,0 +1,38 @@
+package mgr
+
+import (
+	"context"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Changes returns the changes in the upper layer of the container filesystem,
+// compared with the image of container.
+func (mgr *ContainerManager) Changes(ctx context.Context, name string) ([]types.ContainerChangeResponseItem, error) {
+	c, err := mgr.container(name)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if c.RootFSProvided {
+		return nil, pkgerrors.Wrapf(errtypes.ErrNotImplemented, "failed to get changes of container(%s) created by rootfs", c.ID)
+	}
+
+	if c.IsDead() {
+		return nil, pkgerrors.Wrapf(errtypes.ErrConflict, "failed to get changes of container(%s) which is Dead", c.ID)
+	}
+
+	ctx = ctrd.WithSnapshotter(ctx, c.Config.Snapshotter)
+	changes, err := mgr.Client.SnapshotChanges(ctx, c.SnapshotKey())
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to get changes of container(%s)", c.ID)
+	}
+	return changes, nil
+}
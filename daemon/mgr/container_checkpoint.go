@@ -6,17 +6,33 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/errtypes"
 	"github.com/alibaba/pouch/pkg/log"
+
+	"github.com/pkg/errors"
 )
 
 var (
 	checkpointConfigPath             = "config.json"
 	checkpointConfigPerm os.FileMode = 0700
+
+	// criuBinary is the binary used by runtime to checkpoint and restore containers.
+	criuBinary = "criu"
 )
 
+// checkCRIU makes sure criu is available on the daemon host, since both
+// checkpoint and restore depend on it.
+func checkCRIU() error {
+	if _, err := exec.LookPath(criuBinary); err != nil {
+		return errors.Wrapf(errtypes.ErrNotImplemented, "checkpoint and restore require %s installed on the daemon host: %v", criuBinary, err)
+	}
+	return nil
+}
+
 // getCheckpointDir gets container checkpoint directory.
 func (mgr *ContainerManager) getCheckpointDir(container, prefixDir, checkpointID string, create bool) (string, error) {
 	if prefixDir == "" {
@@ -70,6 +86,10 @@ func (mgr *ContainerManager) CreateCheckpoint(ctx context.Context, name string,
 		return fmt.Errorf("checkpoint not support on containers with tty")
 	}
 
+	if err := checkCRIU(); err != nil {
+		return err
+	}
+
 	dir, err := mgr.getCheckpointDir(c.ID, options.CheckpointDir, options.CheckpointID, true)
 	if err != nil {
 		return err
@@ -47,3 +47,13 @@ func TestRWCheckpointConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckCRIU(t *testing.T) {
+	origin := criuBinary
+	defer func() { criuBinary = origin }()
+
+	criuBinary = "criu-not-exist-for-test"
+	err := checkCRIU()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "require criu-not-exist-for-test installed on the daemon host")
+}
@@ -7,15 +7,18 @@ import (
 	"github.com/alibaba/pouch/ctrd"
 	"github.com/alibaba/pouch/pkg/errtypes"
 	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/utils"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 )
 
-// Commit commits an image from a container.
+// Commit commits an image from a container. If the repository is empty, the
+// image is named by commit-<short container id>, because every image should
+// have a reference in containerd.
 func (mgr *ContainerManager) Commit(ctx context.Context, name string, options *types.ContainerCommitOptions) (*types.ContainerCommitResp, error) {
-	if options.Repository == "" {
-		return nil, errors.Wrapf(errtypes.ErrInvalidParam, "not allow empty image reference")
+	if options.Repository == "" && options.Tag != "" {
+		return nil, errors.Wrapf(errtypes.ErrInvalidParam, "not allow tag without repository")
 	}
 	if options.Tag == "" {
 		options.Tag = "latest"
@@ -26,6 +29,10 @@ func (mgr *ContainerManager) Commit(ctx context.Context, name string, options *t
 		return nil, errors.Wrapf(err, "failed to find container(%s) to commit", name)
 	}
 
+	if options.Repository == "" {
+		options.Repository = "commit-" + utils.TruncateID(c.ID)
+	}
+
 	ctx = log.AddFields(ctx, map[string]interface{}{"ContainerID": c.ID})
 	c.Lock()
 	defer c.Unlock()
@@ -34,7 +41,7 @@ func (mgr *ContainerManager) Commit(ctx context.Context, name string, options *t
 		return nil, errors.Wrapf(errtypes.ErrConflict, "failed to commit container(%s) which is Dead", c.ID)
 	}
 
-	if c.IsRunning() {
+	if c.IsRunning() && options.Pause {
 		if err := mgr.doPause(ctx, c); err != nil {
 			return nil, errors.Wrapf(err, "failed to pause container(%s)", c.ID)
 		}
@@ -67,13 +74,18 @@ func (mgr *ContainerManager) Commit(ctx context.Context, name string, options *t
 		return nil, errors.Wrapf(err, "failed to merge config from image")
 	}
 
+	containerConfig, err := applyCommitChanges(c.Config, options.Changes)
+	if err != nil {
+		return nil, err
+	}
+
 	commitConfig := &ctrd.CommitConfig{
 		Author:          options.Author,
 		Comment:         options.Comment,
 		ContainerID:     c.ID,
 		Reference:       options.Repository + ":" + options.Tag,
 		ParentReference: pRef.String(),
-		ContainerConfig: c.Config,
+		ContainerConfig: containerConfig,
 		CImage:          img,
 		Image:           ociImage,
 	}
@@ -105,3 +117,30 @@ func (mgr *ContainerManager) Commit(ctx context.Context, name string, options *t
 	imageID := imageDigest.Hex()
 	return &types.ContainerCommitResp{ID: string(imageID[:12])}, nil
 }
+
+// applyCommitChanges returns the copy of container config with the Dockerfile
+// instructions applied, which is used as the config of the committed image.
+func applyCommitChanges(config *types.ContainerConfig, changes []string) (*types.ContainerConfig, error) {
+	if len(changes) == 0 {
+		return config, nil
+	}
+
+	imgConfig := ocispec.ImageConfig{
+		User:       config.User,
+		Env:        append([]string{}, config.Env...),
+		Entrypoint: config.Entrypoint,
+		Cmd:        config.Cmd,
+		WorkingDir: config.WorkingDir,
+	}
+	if err := applyImageChanges(&imgConfig, changes); err != nil {
+		return nil, err
+	}
+
+	newConfig := *config
+	newConfig.User = imgConfig.User
+	newConfig.Env = imgConfig.Env
+	newConfig.Entrypoint = imgConfig.Entrypoint
+	newConfig.Cmd = imgConfig.Cmd
+	newConfig.WorkingDir = imgConfig.WorkingDir
+	return &newConfig, nil
+}
@@ -0,0 +1,44 @@
+package mgr
+
+import (
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyCommitChanges(t *testing.T) {
+	config := &types.ContainerConfig{
+		Cmd:        []string{"top"},
+		Env:        []string{"PATH=/bin"},
+		User:       "root",
+		WorkingDir: "/",
+		Hostname:   "foo",
+	}
+
+	// the config is not changed without changes
+	got, err := applyCommitChanges(config, nil)
+	assert.NoError(t, err)
+	assert.True(t, got == config)
+
+	got, err = applyCommitChanges(config, []string{
+		`CMD ["sleep", "100"]`,
+		"ENV foo=bar",
+		"WORKDIR /root",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sleep", "100"}, []string(got.Cmd))
+	assert.Equal(t, []string{"PATH=/bin", "foo=bar"}, got.Env)
+	assert.Equal(t, "root", got.User)
+	assert.Equal(t, "/root", got.WorkingDir)
+	assert.Equal(t, "foo", string(got.Hostname))
+
+	// the original config is kept
+	assert.Equal(t, []string{"top"}, []string(config.Cmd))
+	assert.Equal(t, []string{"PATH=/bin"}, config.Env)
+	assert.Equal(t, "/", config.WorkingDir)
+
+	_, err = applyCommitChanges(config, []string{"EXPOSE 80"})
+	assert.Error(t, err)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/chrootarchive"
 	"github.com/docker/docker/pkg/fileutils"
+	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/mount"
 	"github.com/go-openapi/strfmt"
 	pkgerrors "github.com/pkg/errors"
@@ -49,7 +50,7 @@ func (mgr *ContainerManager) StatPath(ctx context.Context, name, path string) (s
 		if err != nil {
 			return nil, pkgerrors.Wrapf(err, "failed to attachVolumes cid(%s)", c.ID)
 		}
-		defer mgr.detachVolumes(ctx, c, false)
+		defer mgr.detachVolumes(ctx, c)
 	}
 
 	err = c.mountVolumes(ctx, running)
@@ -65,13 +66,7 @@ func (mgr *ContainerManager) StatPath(ctx context.Context, name, path string) (s
 		return nil, err
 	}
 
-	return &types.ContainerPathStat{
-		Name:  lstat.Name(),
-		Path:  absPath,
-		Size:  strconv.FormatInt(lstat.Size(), 10),
-		Mode:  uint32(lstat.Mode()),
-		Mtime: strfmt.DateTime(lstat.ModTime()),
-	}, nil
+	return newContainerPathStat(lstat, resolvedPath, absPath), nil
 }
 
 // ArchivePath return an archive and dir info at the specified path in the container.
@@ -111,7 +106,7 @@ func (mgr *ContainerManager) ArchivePath(ctx context.Context, name, path string)
 		}
 		defer func() {
 			if err0 != nil {
-				mgr.detachVolumes(ctx, c, false)
+				mgr.detachVolumes(ctx, c)
 			}
 		}()
 	}
@@ -132,13 +127,7 @@ func (mgr *ContainerManager) ArchivePath(ctx context.Context, name, path string)
 		return nil, nil, err
 	}
 
-	stat = &types.ContainerPathStat{
-		Name:  lstat.Name(),
-		Path:  absPath,
-		Size:  strconv.FormatInt(lstat.Size(), 10),
-		Mode:  uint32(lstat.Mode()),
-		Mtime: strfmt.DateTime(lstat.ModTime()),
-	}
+	stat = newContainerPathStat(lstat, resolvedPath, absPath)
 	// TODO: support follow link in container rootfs
 	copyInfo, err := archive.CopyInfoSourcePath(resolvedPath, false)
 	if err != nil {
@@ -153,7 +142,7 @@ func (mgr *ContainerManager) ArchivePath(ctx context.Context, name, path string)
 	content = ioutils.NewReadCloserWrapper(data, func() error {
 		err := data.Close()
 		if !running {
-			mgr.detachVolumes(ctx, c, false)
+			mgr.detachVolumes(ctx, c)
 		}
 		c.unmountVolumes(ctx, running)
 		mgr.Unmount(ctx, c)
@@ -192,7 +181,7 @@ func (mgr *ContainerManager) ExtractToDir(ctx context.Context, name, path string
 		if err != nil {
 			return pkgerrors.Wrapf(err, "failed to attachVolumes cid(%s)", c.ID)
 		}
-		defer mgr.detachVolumes(ctx, c, false)
+		defer mgr.detachVolumes(ctx, c)
 	}
 
 	err = c.mountVolumes(ctx, running)
@@ -229,16 +218,36 @@ func (mgr *ContainerManager) ExtractToDir(ctx context.Context, name, path string
 		return errors.New("can't extract to dir because rootfs read only")
 	}
 
-	// TODO: support copy uid/gid maps
 	opts := &archive.TarOptions{
 		NoOverwriteDirNonDir: noOverwriteDirNonDir,
 	}
+	// the extracted files are owned by the root of container unless the
+	// uid/gid of archive entries is required to be kept.
+	if !copyUIDGID {
+		opts.ChownOpts = &idtools.Identity{UID: 0, GID: 0}
+	}
 
 	mgr.LogContainerEvent(ctx, c, "extract-to-dir")
 
 	return chrootarchive.Untar(content, resolvedPath, opts)
 }
 
+// newContainerPathStat returns the stat of path in container, and the link
+// target is the one in the container if the path is a symbol link.
+func newContainerPathStat(lstat os.FileInfo, resolvedPath, absPath string) *types.ContainerPathStat {
+	stat := &types.ContainerPathStat{
+		Name:  lstat.Name(),
+		Path:  absPath,
+		Size:  strconv.FormatInt(lstat.Size(), 10),
+		Mode:  uint32(lstat.Mode()),
+		Mtime: strfmt.DateTime(lstat.ModTime()),
+	}
+	if lstat.Mode()&os.ModeSymlink != 0 {
+		stat.LinkTarget, _ = os.Readlink(resolvedPath)
+	}
+	return stat
+}
+
 func (c *Container) getResolvedPath(path string, running bool) (resolvedPath, absPath string) {
 	// consider the given path as an absolute path in the container.
 	absPath = path
@@ -4,9 +4,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"path/filepath"
+	"strings"
+	"syscall"
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/daemon/containerio"
+	"github.com/alibaba/pouch/daemon/logger"
 	"github.com/alibaba/pouch/pkg/errtypes"
 	"github.com/alibaba/pouch/pkg/log"
 	"github.com/alibaba/pouch/pkg/randomid"
@@ -26,13 +31,30 @@ func (mgr *ContainerManager) CreateExec(ctx context.Context, name string, config
 	}
 
 	if !c.State.Running {
-		return "", fmt.Errorf("container %s is not running", c.ID)
+		return "", errors.Wrapf(errtypes.ErrConflict, "container %s is not running", c.ID)
 	}
 
-	envs, err := mergeEnvSlice(config.Env, c.Config.Env)
+	envs := config.Env
+	if !config.ClearEnv {
+		if envs, err = mergeEnvSlice(config.Env, c.Config.Env); err != nil {
+			return "", err
+		}
+	}
 
-	if err != nil {
-		return "", err
+	if config.WorkingDir != "" {
+		if err := validateExecWorkingDir(c, config.WorkingDir); err != nil {
+			return "", err
+		}
+	}
+
+	if config.Init && mgr.Config.ExecInitPath == "" {
+		return "", errors.Wrapf(errtypes.ErrInvalidParam, "exec with init is not supported, exec-init-path of pouchd is not set")
+	}
+
+	if len(config.CapAdd) > 0 || len(config.CapDrop) > 0 {
+		if _, err := caps.TweakCapabilities(nil, config.CapAdd, config.CapDrop); err != nil {
+			return "", errors.Wrapf(errtypes.ErrInvalidParam, "invalid capabilities of exec process: %v", err)
+		}
 	}
 
 	execid := randomid.Generate()
@@ -58,6 +80,43 @@ func (mgr *ContainerManager) ResizeExec(ctx context.Context, execid string, opts
 	return mgr.Client.ResizeExec(ctx, execConfig.ContainerID, execid, opts)
 }
 
+// KillExec sends signal to the exec process.
+func (mgr *ContainerManager) KillExec(ctx context.Context, execid string, signal syscall.Signal) error {
+	execConfig, err := mgr.GetExecConfig(ctx, execid)
+	if err != nil {
+		return err
+	}
+
+	execConfig.Lock()
+	running := execConfig.Running
+	execConfig.Unlock()
+
+	if !running {
+		return errors.Wrapf(errtypes.ErrConflict, "exec process %s is not running", execid)
+	}
+
+	return mgr.Client.KillExec(ctx, execConfig.ContainerID, execid, signal)
+}
+
+// RemoveExec removes the record of the exec process, the exec process which
+// is still running can not be removed.
+func (mgr *ContainerManager) RemoveExec(ctx context.Context, execid string) error {
+	execConfig, err := mgr.GetExecConfig(ctx, execid)
+	if err != nil {
+		return err
+	}
+
+	execConfig.Lock()
+	defer execConfig.Unlock()
+
+	if execConfig.Running {
+		return errors.Wrapf(errtypes.ErrConflict, "exec process %s is still running", execid)
+	}
+
+	mgr.ExecProcesses.Remove(execid)
+	return nil
+}
+
 // StartExec executes a new process in container.
 // timeout = 0 means no timeout
 func (mgr *ContainerManager) StartExec(ctx context.Context, execid string, cfg *streams.AttachConfig, timeout int) (err0 error) {
@@ -94,20 +153,31 @@ func (mgr *ContainerManager) StartExec(ctx context.Context, execid string, cfg *
 		execConfig.User = c.Config.User
 	}
 
+	// the groups added to exec process are appended to the container's.
+	groups := append(append([]string{}, c.HostConfig.GroupAdd...), execConfig.GroupAdd...)
 	uid, gid, additionalGids, err := user.Get(c.GetSpecificBasePath(user.PasswdFile),
-		c.GetSpecificBasePath(user.GroupFile), execConfig.User, c.HostConfig.GroupAdd)
+		c.GetSpecificBasePath(user.GroupFile), execConfig.User, groups)
 	if err != nil {
 		execConfig.Unlock()
 		return err
 	}
 
-	cwd := c.Config.WorkingDir
+	cwd := execConfig.WorkingDir
+	if cwd == "" {
+		cwd = c.Config.WorkingDir
+	}
 	if cwd == "" {
 		cwd = "/"
 	}
 
+	args := execConfig.Cmd
+	if execConfig.Init {
+		// the init spawns the exec process as its child and reaps zombies.
+		args = append([]string{mgr.Config.ExecInitPath, "--"}, execConfig.Cmd...)
+	}
+
 	process := &specs.Process{
-		Args:     execConfig.Cmd,
+		Args:     args,
 		Terminal: execConfig.Tty,
 		Cwd:      cwd,
 		Env:      execConfig.Env,
@@ -133,6 +203,26 @@ func (mgr *ContainerManager) StartExec(ctx context.Context, execid string, cfg *
 		process.Capabilities = spec.Process.Capabilities
 	}
 
+	// capabilities of exec process are tweaked on the basis of container's.
+	if len(execConfig.CapAdd) > 0 || len(execConfig.CapDrop) > 0 {
+		var basics []string
+		if process.Capabilities != nil {
+			basics = process.Capabilities.Effective
+		}
+
+		capList, err := caps.TweakCapabilities(basics, execConfig.CapAdd, execConfig.CapDrop)
+		if err != nil {
+			execConfig.Unlock()
+			return err
+		}
+		process.Capabilities = &specs.LinuxCapabilities{
+			Effective:   capList,
+			Bounding:    capList,
+			Permitted:   capList,
+			Inheritable: capList,
+		}
+	}
+
 	// set exec process ulimit, ulimit not decided by exec config
 	if err := setupRlimits(ctx, c.HostConfig, &specs.Spec{Process: process}); err != nil {
 		execConfig.Unlock()
@@ -164,6 +254,10 @@ func (mgr *ContainerManager) StartExec(ctx context.Context, execid string, cfg *
 		return err
 	}
 
+	if execConfig.Log {
+		mgr.copyExecLog(ctx, c, eio)
+	}
+
 	attachErrCh := eio.Stream().Attach(ctx, cfg)
 	defer func() {
 		if err0 != nil {
@@ -182,12 +276,32 @@ func (mgr *ContainerManager) StartExec(ctx context.Context, execid string, cfg *
 		IO:          eio,
 		P:           process,
 		Detach:      cfg.Detach,
+		StartedHook: func(pid int) {
+			execConfig.Lock()
+			execConfig.Pid = int64(pid)
+			execConfig.Unlock()
+		},
 	}, timeout); err != nil {
 		return err
 	}
 	return <-attachErrCh
 }
 
+// copyExecLog copies the output of exec process into the log driver of
+// container, it does nothing if the container has no log driver.
+func (mgr *ContainerManager) copyExecLog(ctx context.Context, c *Container, eio *containerio.IO) {
+	cntrio := mgr.IOs.Get(c.ID)
+	if cntrio == nil || cntrio.LogDriver() == nil {
+		log.With(ctx).Warnf("container %s has no log driver, ignore logging exec output", c.ID)
+		return
+	}
+
+	logger.NewLogCopier(cntrio.LogDriver(), map[string]io.Reader{
+		"stdout": eio.Stream().NewStdoutPipe(),
+		"stderr": eio.Stream().NewStderrPipe(),
+	}).StartCopy()
+}
+
 // InspectExec returns low-level information about exec command.
 func (mgr *ContainerManager) InspectExec(ctx context.Context, execid string) (*types.ContainerExecInspect, error) {
 	execConfig, err := mgr.GetExecConfig(ctx, execid)
@@ -210,6 +324,7 @@ func (mgr *ContainerManager) InspectExec(ctx context.Context, execid string) (*t
 		// FIXME: try to use the correct running status of exec
 		Running:       execConfig.Running,
 		ExitCode:      execConfig.ExitCode,
+		Pid:           execConfig.Pid,
 		ContainerID:   execConfig.ContainerID,
 		ProcessConfig: processConfig,
 	}, nil
@@ -239,6 +354,30 @@ func (mgr *ContainerManager) CheckExecExist(ctx context.Context, name string) er
 	return err
 }
 
+// validateExecWorkingDir checks the working directory of exec process is an
+// absolute path and exists in the container.
+func validateExecWorkingDir(c *Container, dir string) error {
+	if !filepath.IsAbs(dir) {
+		return errors.Wrapf(errtypes.ErrInvalidParam, "working directory %s is not an absolute path", dir)
+	}
+
+	dir = filepath.Clean(dir)
+
+	// NOTE: the path in volumes can not be found in the rootfs, leave it
+	// to runtime to check.
+	for _, mp := range c.Mounts {
+		if dir == mp.Destination || strings.HasPrefix(dir, filepath.Clean(mp.Destination)+"/") {
+			return nil
+		}
+	}
+
+	if c.Snapshotter != nil && len(c.Snapshotter.Data) != 0 && c.GetSpecificBasePath(dir) == "" {
+		return errors.Wrapf(errtypes.ErrInvalidParam, "working directory %s does not exist in container %s", dir, c.ID)
+	}
+
+	return nil
+}
+
 func (mgr *ContainerManager) getEntrypointAndArgs(cmd []string) (string, []string) {
 	if len(cmd) == 0 {
 		return "", []string{}
@@ -0,0 +1,46 @@
+package mgr
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateExecWorkingDir(t *testing.T) {
+	rootfs, err := ioutil.TempDir("", "exec-workdir")
+	assert.NoError(t, err)
+	defer os.RemoveAll(rootfs)
+	assert.NoError(t, os.MkdirAll(filepath.Join(rootfs, "exist"), 0755))
+
+	c := &Container{
+		ID: "container",
+		Snapshotter: &types.SnapshotterData{
+			Data: map[string]string{"MergedDir": rootfs},
+		},
+		Mounts: []*types.MountPoint{{Destination: "/data"}},
+	}
+
+	for _, tc := range []struct {
+		dir     string
+		wantErr bool
+	}{
+		{dir: "/exist", wantErr: false},
+		{dir: "/exist/", wantErr: false},
+		{dir: "/data/sub", wantErr: false},
+		{dir: "/nonexist", wantErr: true},
+		{dir: "relative", wantErr: true},
+	} {
+		err := validateExecWorkingDir(c, tc.dir)
+		if tc.wantErr {
+			assert.True(t, errtypes.IsInvalidParam(err), "dir %s", tc.dir)
+		} else {
+			assert.NoError(t, err, "dir %s", tc.dir)
+		}
+	}
+}
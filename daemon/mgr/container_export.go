@@ -0,0 +1,70 @@
+package mgr
+
+import (
+	"context"
+	"io"
+
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/ioutils"
+	"github.com/alibaba/pouch/pkg/log"
+
+	"github.com/docker/docker/pkg/archive"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Export returns the tarstream of the container filesystem. The volumes are
+// not included. The rootfs of a stopped container is mounted until the
+// tarstream is closed.
+func (mgr *ContainerManager) Export(ctx context.Context, name string) (content io.ReadCloser, err0 error) {
+	c, err := mgr.container(name)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = log.AddFields(ctx, map[string]interface{}{"ContainerID": c.ID})
+
+	c.Lock()
+	defer func() {
+		if err0 != nil {
+			c.Unlock()
+		}
+	}()
+
+	if c.IsDead() {
+		return nil, pkgerrors.Wrapf(errtypes.ErrConflict, "failed to export container(%s) which is Dead", c.ID)
+	}
+
+	rootfs := c.BaseFS
+	running := c.IsRunningOrPaused()
+	if !running {
+		if err := mgr.Mount(ctx, c); err != nil {
+			return nil, pkgerrors.Wrapf(err, "failed to mount cid(%s)", c.ID)
+		}
+		defer func() {
+			if err0 != nil {
+				mgr.Unmount(ctx, c)
+			}
+		}()
+		rootfs = c.MountFS
+	}
+
+	data, err := archive.Tar(rootfs, archive.Uncompressed)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to archive rootfs of cid(%s)", c.ID)
+	}
+
+	// wait for io finish, then unmount the rootfs
+	content = ioutils.NewReadCloserWrapper(data, func() error {
+		err := data.Close()
+		if !running {
+			if err := mgr.Unmount(ctx, c); err != nil {
+				log.With(ctx).Warnf("failed to unmount rootfs after export: %v", err)
+			}
+		}
+		c.Unlock()
+		return err
+	})
+	mgr.LogContainerEvent(ctx, c, "export")
+
+	return content, nil
+}
@@ -0,0 +1,276 @@
+package mgr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/streams"
+	"github.com/alibaba/pouch/pkg/utils"
+)
+
+const (
+	// HealthStarting is the health status of container before the first
+	// successful check.
+	HealthStarting = "starting"
+	// HealthHealthy is the health status of container whose check succeeds.
+	HealthHealthy = "healthy"
+	// HealthUnhealthy is the health status of container whose check fails
+	// more than the retries.
+	HealthUnhealthy = "unhealthy"
+
+	defaultHealthInterval = 30 * time.Second
+	defaultHealthTimeout  = 30 * time.Second
+	defaultHealthRetries  = 3
+
+	// maxHealthLogEntries is the number of the latest check results kept.
+	maxHealthLogEntries = 5
+	// maxHealthOutputLen is the max length of the check output kept.
+	maxHealthOutputLen = 4096
+)
+
+// healthProbeCmd returns the command of health check, or nil if the health
+// check is disabled.
+func healthProbeCmd(test []string) []string {
+	if len(test) == 0 {
+		return nil
+	}
+
+	switch test[0] {
+	case "CMD":
+		return test[1:]
+	case "CMD-SHELL":
+		return append([]string{"/bin/sh", "-c"}, test[1:]...)
+	default:
+		// "NONE" or unknown test disables the health check.
+		return nil
+	}
+}
+
+// healthDuration returns the duration d in nanoseconds, or the default one
+// if d is zero.
+func healthDuration(d int64, defaultDuration time.Duration) time.Duration {
+	if d == 0 {
+		return defaultDuration
+	}
+	return time.Duration(d)
+}
+
+// validateHealthConfig validates the health check config of container.
+func validateHealthConfig(config *types.HealthConfig) error {
+	if config == nil {
+		return nil
+	}
+
+	for name, d := range map[string]int64{
+		"interval":     config.Interval,
+		"timeout":      config.Timeout,
+		"start period": config.StartPeriod,
+	} {
+		if d != 0 && d < int64(time.Millisecond) {
+			return fmt.Errorf("health check %s should be 0 or at least 1ms", name)
+		}
+	}
+
+	if config.Retries < 0 {
+		return fmt.Errorf("health check retries should not be negative")
+	}
+	return nil
+}
+
+// initHealthMonitor starts checking the health of the running container
+// periodically, it must be called with the lock of container held.
+func (mgr *ContainerManager) initHealthMonitor(c *Container) {
+	if c.Config.Healthcheck == nil || healthProbeCmd(c.Config.Healthcheck.Test) == nil {
+		c.State.Health = nil
+		return
+	}
+
+	stopHealthMonitor(c)
+	c.State.Health = &types.Health{Status: HealthStarting}
+
+	stop := make(chan struct{})
+	c.healthStop = stop
+	go mgr.monitorHealth(c, *c.Config.Healthcheck, stop)
+}
+
+// stopHealthMonitor stops checking the health of container, it must be
+// called with the lock of container held.
+func stopHealthMonitor(c *Container) {
+	if c.healthStop != nil {
+		close(c.healthStop)
+		c.healthStop = nil
+	}
+}
+
+// monitorHealth runs the health check every interval until it is stopped.
+func (mgr *ContainerManager) monitorHealth(c *Container, config types.HealthConfig, stop chan struct{}) {
+	ctx := log.NewContext(context.Background(), map[string]interface{}{
+		"ContainerID": c.ID,
+	})
+
+	ticker := time.NewTicker(healthDuration(config.Interval, defaultHealthInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		c.Lock()
+		paused := c.State.Paused
+		c.Unlock()
+		if paused {
+			continue
+		}
+
+		result := mgr.probeHealth(ctx, c, config)
+
+		select {
+		case <-stop:
+			// the container is stopped during the check.
+			return
+		default:
+		}
+		mgr.handleHealthResult(ctx, c, config, result)
+	}
+}
+
+// probeHealth runs the health check command in container by exec, and
+// returns the result.
+func (mgr *ContainerManager) probeHealth(ctx context.Context, c *Container, config types.HealthConfig) *types.HealthcheckResult {
+	start := time.Now()
+	result := &types.HealthcheckResult{
+		Start:    start.UTC().Format(utils.TimeLayout),
+		ExitCode: -1,
+	}
+	defer func() {
+		result.End = time.Now().UTC().Format(utils.TimeLayout)
+	}()
+
+	execid, err := mgr.CreateExec(ctx, c.ID, &types.ExecCreateConfig{
+		Cmd:          healthProbeCmd(config.Test),
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		result.Output = err.Error()
+		return result
+	}
+	defer mgr.ExecProcesses.Remove(execid)
+
+	timeout := healthDuration(config.Timeout, defaultHealthTimeout)
+	output := &limitedBuffer{max: maxHealthOutputLen}
+	attach := &streams.AttachConfig{
+		UseStdout: true,
+		Stdout:    output,
+		UseStderr: true,
+		Stderr:    output,
+	}
+
+	// the timeout of exec is in seconds.
+	if err := mgr.StartExec(ctx, execid, attach, int(math.Ceil(timeout.Seconds()))); err != nil {
+		if time.Since(start) >= timeout {
+			result.Output = fmt.Sprintf("Health check exceeded timeout (%v)", timeout)
+		} else {
+			result.Output = err.Error()
+		}
+		return result
+	}
+
+	execConfig, err := mgr.GetExecConfig(ctx, execid)
+	if err != nil {
+		result.Output = err.Error()
+		return result
+	}
+
+	execConfig.Lock()
+	result.ExitCode = execConfig.ExitCode
+	execConfig.Unlock()
+	result.Output = output.String()
+	return result
+}
+
+// handleHealthResult updates the health status of container by the result,
+// and logs an event if the status changes.
+func (mgr *ContainerManager) handleHealthResult(ctx context.Context, c *Container, config types.HealthConfig, result *types.HealthcheckResult) {
+	c.Lock()
+	defer c.Unlock()
+
+	health := c.State.Health
+	if health == nil || !c.IsRunningOrPaused() {
+		return
+	}
+
+	health.Log = append(health.Log, result)
+	if len(health.Log) > maxHealthLogEntries {
+		health.Log = health.Log[len(health.Log)-maxHealthLogEntries:]
+	}
+
+	oldStatus := health.Status
+	if result.ExitCode == 0 {
+		health.FailingStreak = 0
+		health.Status = HealthHealthy
+	} else if !inHealthStartPeriod(c, config, health) {
+		// the failures in the start period are not counted towards the
+		// retries until the container is healthy.
+		health.FailingStreak++
+
+		retries := config.Retries
+		if retries == 0 {
+			retries = defaultHealthRetries
+		}
+		if health.FailingStreak >= retries {
+			health.Status = HealthUnhealthy
+		}
+	}
+
+	if health.Status != oldStatus {
+		mgr.LogContainerEvent(ctx, c, "health_status: "+health.Status)
+	}
+
+	if err := c.Write(mgr.Store); err != nil {
+		log.With(ctx).Errorf("failed to update meta: %v", err)
+	}
+}
+
+// inHealthStartPeriod returns true if the container is still starting and
+// in the start period of health check.
+func inHealthStartPeriod(c *Container, config types.HealthConfig, health *types.Health) bool {
+	if health.Status != HealthStarting || config.StartPeriod == 0 {
+		return false
+	}
+
+	startedAt, err := time.Parse(utils.TimeLayout, c.State.StartedAt)
+	if err != nil {
+		return false
+	}
+	return time.Since(startedAt) < time.Duration(config.StartPeriod)
+}
+
+// limitedBuffer keeps at most max bytes of the written data.
+type limitedBuffer struct {
+	buf bytes.Buffer
+	max int
+}
+
+// Write implements io.Writer interface.
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if remain := b.max - b.buf.Len(); remain < len(p) {
+		p = p[:remain]
+	}
+	b.buf.Write(p)
+	return n, nil
+}
+
+// String returns the kept data.
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
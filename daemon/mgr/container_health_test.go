@@ -0,0 +1,59 @@
+package mgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthProbeCmd(t *testing.T) {
+	assert.Nil(t, healthProbeCmd(nil))
+	assert.Nil(t, healthProbeCmd([]string{"NONE"}))
+	assert.Nil(t, healthProbeCmd([]string{"UNKNOWN", "true"}))
+	assert.Equal(t, []string{"cat", "/tmp/ready"}, healthProbeCmd([]string{"CMD", "cat", "/tmp/ready"}))
+	assert.Equal(t, []string{"/bin/sh", "-c", "exit 1"}, healthProbeCmd([]string{"CMD-SHELL", "exit 1"}))
+}
+
+func TestValidateHealthConfig(t *testing.T) {
+	assert.NoError(t, validateHealthConfig(nil))
+	assert.NoError(t, validateHealthConfig(&types.HealthConfig{
+		Test:     []string{"CMD-SHELL", "true"},
+		Interval: int64(time.Second),
+		Retries:  1,
+	}))
+	assert.Error(t, validateHealthConfig(&types.HealthConfig{Interval: int64(time.Microsecond)}))
+	assert.Error(t, validateHealthConfig(&types.HealthConfig{Timeout: -1}))
+	assert.Error(t, validateHealthConfig(&types.HealthConfig{StartPeriod: 10}))
+	assert.Error(t, validateHealthConfig(&types.HealthConfig{Retries: -1}))
+}
+
+func TestInHealthStartPeriod(t *testing.T) {
+	c := &Container{
+		State: &types.ContainerState{
+			StartedAt: time.Now().Add(-time.Minute).UTC().Format(utils.TimeLayout),
+		},
+	}
+	starting := &types.Health{Status: HealthStarting}
+
+	assert.False(t, inHealthStartPeriod(c, types.HealthConfig{}, starting))
+	assert.True(t, inHealthStartPeriod(c, types.HealthConfig{StartPeriod: int64(time.Hour)}, starting))
+	assert.False(t, inHealthStartPeriod(c, types.HealthConfig{StartPeriod: int64(time.Second)}, starting))
+	assert.False(t, inHealthStartPeriod(c, types.HealthConfig{StartPeriod: int64(time.Hour)}, &types.Health{Status: HealthHealthy}))
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	b := &limitedBuffer{max: 4}
+
+	n, err := b.Write([]byte("abc"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	n, err = b.Write([]byte("def"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, "abcd", b.String())
+}
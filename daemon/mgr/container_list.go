@@ -3,17 +3,21 @@ package mgr
 import (
 	"context"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/pkg/log"
 	"github.com/alibaba/pouch/pkg/utils/filters"
 )
 
 var (
-	labelFilter  = "label"
-	idFilter     = "id"
-	nameFilter   = "name"
-	statusFilter = "status"
+	labelFilter    = "label"
+	idFilter       = "id"
+	nameFilter     = "name"
+	statusFilter   = "status"
+	ancestorFilter = "ancestor"
+	exitedFilter   = "exited"
 )
 
 // filterContext includes conditions provide for filter
@@ -21,6 +25,9 @@ type filterContext struct {
 	condition  map[string][]string
 	all        bool
 	filterFunc ContainerFilter
+
+	// ancestorIDs are the image IDs resolved from the ancestor filter.
+	ancestorIDs map[string]bool
 }
 
 // newFilterContext initials a filterContext struct, and validate option.Filter
@@ -68,63 +75,91 @@ func (fc *filterContext) matchFilter(field, value string) bool {
 	return match
 }
 
-// matchKVFilter filters map value matchs field of condition, also support
-// filter not equal, as for unequal condition `key=value`, we filter `$k=$v`
-// which $k equal key and $v not equal value.
+// matchKVFilter filters map value matchs field of condition. All the
+// conditions must be met, and the condition can be `key`, `key=value`,
+// or unequal `key!=value` which means key exists with a different value.
+// The negated condition prefixed with `!` is met if the condition is not.
 func (fc *filterContext) matchKVFilter(field string, value map[string]string) bool {
-	filters, exist := fc.condition[field]
+	conditions, exist := fc.condition[field]
 	if !exist {
 		// return true if field is not exist
 		return true
 	}
 
-	if len(filters) == 0 {
+	if len(conditions) == 0 {
 		return false
 	}
 
-	// repeat value not support, eg: {"label": []string{"a=b", "a=c"}}, c will overwrite b
-	equalKV := make(map[string]string)
-	unequalKV := make(map[string]string)
-	for _, f := range filters {
+	match := false
+	for _, f := range conditions {
 		if f == "" {
 			continue
 		}
-		splits := strings.SplitN(f, "!=", 2)
-		if len(splits) == 2 {
-			unequalKV[splits[0]] = splits[1]
-			continue
-		}
 
-		splits = strings.SplitN(f, "=", 2)
-		if len(splits) == 2 {
-			equalKV[splits[0]] = splits[1]
-			continue
+		negated := strings.HasPrefix(f, filters.NegationPrefix)
+		if matchKV(strings.TrimPrefix(f, filters.NegationPrefix), value) == negated {
+			return false
 		}
+		match = true
+	}
 
-		equalKV[f] = ""
+	return match
+}
+
+// matchKV reports whether the map value meets condition `key`, `key=value`
+// or `key!=value`.
+func matchKV(condition string, value map[string]string) bool {
+	if splits := strings.SplitN(condition, "!=", 2); len(splits) == 2 {
+		v, exist := value[splits[0]]
+		return exist && v != splits[1]
 	}
 
-	if len(equalKV) == 0 && len(unequalKV) == 0 {
-		return false
+	if splits := strings.SplitN(condition, "=", 2); len(splits) == 2 {
+		v, exist := value[splits[0]]
+		return exist && v == splits[1]
 	}
 
-	match := false
-	for k, v := range value {
-		// filter equal condition
-		if equalValue, exist := equalKV[k]; exist {
-			if equalValue == "" || equalValue == v {
-				match = true
-				break
-			}
-		}
-		// filter unequal condition
-		if unequalValue, exist := unequalKV[k]; exist && unequalValue != v {
-			match = true
-			break
+	_, exist := value[condition]
+	return exist
+}
+
+// matchAncestor filters the container which is created from the image of
+// ancestor filter, the image is matched by the resolved ID or the reference
+// given when creating the container.
+func (fc *filterContext) matchAncestor(c *Container) bool {
+	ancestors, exist := fc.condition[ancestorFilter]
+	if !exist {
+		return true
+	}
+
+	if fc.ancestorIDs[c.Image] {
+		return true
+	}
+	for _, ancestor := range ancestors {
+		if c.Config != nil && c.Config.Image == ancestor {
+			return true
 		}
 	}
+	return false
+}
 
-	return match
+// matchExited filters the exited container with the exit code of exited
+// filter.
+func (fc *filterContext) matchExited(c *Container) bool {
+	codes, exist := fc.condition[exitedFilter]
+	if !exist {
+		return true
+	}
+
+	if c.State.Status != types.StatusExited && c.State.Status != types.StatusStopped {
+		return false
+	}
+	for _, code := range codes {
+		if code == strconv.FormatInt(c.State.ExitCode, 10) {
+			return true
+		}
+	}
+	return false
 }
 
 // filter does all select container work.
@@ -163,6 +198,10 @@ func (fc *filterContext) filter(c *Container) bool {
 			match = fc.matchFilter(nameFilter, c.Name)
 		case statusFilter:
 			match = fc.matchFilter(statusFilter, string(c.State.Status))
+		case ancestorFilter:
+			match = fc.matchAncestor(c)
+		case exitedFilter:
+			match = fc.matchExited(c)
 		default:
 			continue
 		}
@@ -172,7 +211,8 @@ func (fc *filterContext) filter(c *Container) bool {
 			break
 		}
 
-		if name == statusFilter {
+		// the status and exited filter select non-running container too.
+		if name == statusFilter || name == exitedFilter {
 			statusKey = true
 		}
 	}
@@ -195,6 +235,19 @@ func (mgr *ContainerManager) List(ctx context.Context, option *ContainerListOpti
 		return nil, err
 	}
 
+	if option != nil && mgr.ImageMgr != nil {
+		fc.ancestorIDs = make(map[string]bool)
+		for _, ancestor := range option.Filter[ancestorFilter] {
+			image, err := mgr.ImageMgr.GetImage(ctx, ancestor)
+			if err != nil {
+				// the ancestor is still matched with the reference.
+				log.With(ctx).Debugf("failed to get image of ancestor filter %s: %v", ancestor, err)
+				continue
+			}
+			fc.ancestorIDs[image.ID] = true
+		}
+	}
+
 	for id, obj := range list {
 		c, ok := obj.(*Container)
 		if !ok {
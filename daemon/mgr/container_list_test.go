@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/alibaba/pouch/apis/types"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -130,13 +132,19 @@ func TestMatchKVFilter(t *testing.T) {
 			isFilter: false,
 		},
 		{
+			// all the label conditions must be met.
 			field:    "label",
 			value:    map[string]string{"foo": "a"},
-			isFilter: true,
+			isFilter: false,
 		},
 		{
 			field:    "label",
 			value:    map[string]string{"hello": "word"},
+			isFilter: false,
+		},
+		{
+			field:    "label",
+			value:    map[string]string{"foo": "a", "hello": "word"},
 			isFilter: true,
 		},
 	} {
@@ -166,7 +174,7 @@ func TestMatchKVFilter(t *testing.T) {
 		{
 			field:    "label",
 			value:    map[string]string{"a": "c"},
-			isFilter: true,
+			isFilter: false,
 		},
 		{
 			field:    "label",
@@ -176,9 +184,84 @@ func TestMatchKVFilter(t *testing.T) {
 		{
 			field:    "label",
 			value:    map[string]string{"d": "word"},
+			isFilter: false,
+		},
+		{
+			field:    "label",
+			value:    map[string]string{"a": "c", "d": "word"},
+			isFilter: true,
+		},
+	} {
+		assert.Equal(t.isFilter, fc.matchKVFilter(t.field, t.value), fmt.Sprintf("%+v", t.value))
+	}
+
+	// the negated conditions, like `label!=a=b` and `label!=foo`.
+	option = ContainerListOption{
+		Filter: map[string][]string{
+			"label": {"!a=b", "!foo", "c"},
+		},
+	}
+
+	fc, err = newFilterContext(&option)
+	assert.NoError(err)
+
+	for _, t := range []tCase{
+		{
+			field:    "label",
+			value:    map[string]string{"c": ""},
+			isFilter: true,
+		},
+		{
+			field:    "label",
+			value:    map[string]string{"a": "x", "c": ""},
 			isFilter: true,
 		},
+		{
+			field:    "label",
+			value:    map[string]string{"a": "b", "c": ""},
+			isFilter: false,
+		},
+		{
+			field:    "label",
+			value:    map[string]string{"foo": "", "c": ""},
+			isFilter: false,
+		},
+		{
+			field:    "label",
+			value:    map[string]string{"a": "x"},
+			isFilter: false,
+		},
 	} {
 		assert.Equal(t.isFilter, fc.matchKVFilter(t.field, t.value), fmt.Sprintf("%+v", t.value))
 	}
 }
+
+func TestFilterAncestorAndExited(t *testing.T) {
+	newContainer := func(image, imageID string, status types.Status, exitCode int64) *Container {
+		return &Container{
+			Image:  imageID,
+			Config: &types.ContainerConfig{Image: image},
+			State:  &types.ContainerState{Status: status, ExitCode: exitCode},
+		}
+	}
+
+	fc, err := newFilterContext(&ContainerListOption{
+		Filter: map[string][]string{
+			"ancestor": {"busybox"},
+			"exited":   {"0", "3"},
+		},
+	})
+	assert.NoError(t, err)
+	fc.ancestorIDs = map[string]bool{"sha256:busybox": true}
+
+	assert.True(t, fc.filter(newContainer("busybox", "", types.StatusExited, 3)))
+	assert.True(t, fc.filter(newContainer("docker.io/library/busybox:latest", "sha256:busybox", types.StatusStopped, 0)))
+	assert.False(t, fc.filter(newContainer("busybox", "", types.StatusExited, 1)))
+	assert.False(t, fc.filter(newContainer("busybox", "", types.StatusRunning, 0)))
+	assert.False(t, fc.filter(newContainer("redis", "sha256:redis", types.StatusExited, 0)))
+
+	_, err = newFilterContext(&ContainerListOption{
+		Filter: map[string][]string{"exited": {"foo"}},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,189 @@
+package mgr
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alibaba/pouch/pkg/log"
+)
+
+const (
+	// pidsMonitorInterval is the interval of checking whether the container
+	// hits its pids limit.
+	pidsMonitorInterval = 5 * time.Second
+)
+
+// initPidsMonitor starts watching the pids cgroup events of the running
+// container whose pids limit is set, it must be called with the lock of
+// container held.
+func (mgr *ContainerManager) initPidsMonitor(c *Container) {
+	stopPidsMonitor(c)
+	if c.HostConfig.PidsLimit <= 0 || c.State.Pid <= 0 {
+		return
+	}
+
+	stop := make(chan struct{})
+	c.pidsStop = stop
+	cgroupsPath := containerCgroupsPath(c, mgr.Config.UseSystemd())
+	go mgr.monitorPids(c, cgroupsPath, c.HostConfig.PidsLimit, stop)
+}
+
+// stopPidsMonitor stops watching the pids cgroup events of container, it must
+// be called with the lock of container held.
+func stopPidsMonitor(c *Container) {
+	if c.pidsStop != nil {
+		close(c.pidsStop)
+		c.pidsStop = nil
+	}
+}
+
+// monitorPids reports the container event every time the fork in container
+// fails due to the pids limit, until it is stopped. The pids events are read
+// without the lock of container, which is only held to publish the event.
+func (mgr *ContainerManager) monitorPids(c *Container, cgroupsPath string, limit int64, stop chan struct{}) {
+	ctx := log.NewContext(context.Background(), map[string]interface{}{
+		"ContainerID": c.ID,
+	})
+
+	eventsFile, err := pidsEventsFile(cgroupsPath)
+	if err != nil {
+		log.With(ctx).Warnf("failed to find pids cgroup of container, pids limit hits will not be reported: %v", err)
+		return
+	}
+
+	// the failures before the monitor starts have been reported.
+	last, _ := readPidsMaxEvents(eventsFile)
+
+	ticker := time.NewTicker(pidsMonitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		count, err := readPidsMaxEvents(eventsFile)
+		if err != nil || count <= last {
+			continue
+		}
+
+		c.Lock()
+		select {
+		case <-stop:
+			// the container is stopped during the check.
+			c.Unlock()
+			return
+		default:
+		}
+		log.With(ctx).Warnf("container reaches the pids limit %d, %d fork failed", limit, count-last)
+		mgr.LogContainerEventWithAttributes(ctx, c, "pids-limit", map[string]string{
+			"limit":    strconv.FormatInt(limit, 10),
+			"failures": strconv.FormatUint(count-last, 10),
+		})
+		c.Unlock()
+
+		last = count
+	}
+}
+
+// pidsEventsFile returns the path of pids.events of the container's cgroup,
+// which is under the mount point of the pids cgroup.
+func pidsEventsFile(cgroupsPath string) (string, error) {
+	data, err := ioutil.ReadFile("/proc/self/mountinfo")
+	if err != nil {
+		return "", err
+	}
+
+	mountPoint, err := parsePidsCgroupMount(string(data))
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(mountPoint, cgroupDir(cgroupsPath), "pids.events"), nil
+}
+
+// parsePidsCgroupMount returns the mount point of the pids cgroup from the
+// content of /proc/self/mountinfo, the cgroup v1 pids controller takes
+// precedence over the cgroup v2 unified hierarchy.
+func parsePidsCgroupMount(content string) (string, error) {
+	unified := ""
+	for _, line := range strings.Split(content, "\n") {
+		index := strings.Index(line, " - ")
+		if index < 0 {
+			continue
+		}
+		fields := strings.Fields(line[:index])
+		postFields := strings.Fields(line[index+3:])
+		if len(fields) < 5 || len(postFields) < 3 {
+			continue
+		}
+
+		switch postFields[0] {
+		case "cgroup2":
+			if unified == "" {
+				unified = fields[4]
+			}
+		case "cgroup":
+			for _, opt := range strings.Split(postFields[2], ",") {
+				if opt == "pids" {
+					return fields[4], nil
+				}
+			}
+		}
+	}
+
+	if unified == "" {
+		return "", fmt.Errorf("pids cgroup not found")
+	}
+	return unified, nil
+}
+
+// cgroupDir converts the cgroups path of container in the spec into the
+// directory relative to the cgroup mount point. With systemd cgroup driver,
+// the path "slice:prefix:name" is the scope "prefix-name.scope" in the slice,
+// whose parent slices are separated by dashes in its name.
+func cgroupDir(cgroupsPath string) string {
+	parts := strings.Split(cgroupsPath, ":")
+	if len(parts) != 3 {
+		return cgroupsPath
+	}
+
+	dir := "/"
+	if slice := strings.TrimSuffix(parts[0], ".slice"); slice != "" && slice != "-" {
+		prefix := ""
+		for _, component := range strings.Split(slice, "-") {
+			prefix += component
+			dir = filepath.Join(dir, prefix+".slice")
+			prefix += "-"
+		}
+	}
+	return filepath.Join(dir, parts[1]+"-"+parts[2]+".scope")
+}
+
+// readPidsMaxEvents returns the number of times that the fork fails due to
+// the pids limit.
+func readPidsMaxEvents(eventsFile string) (uint64, error) {
+	data, err := ioutil.ReadFile(eventsFile)
+	if err != nil {
+		return 0, err
+	}
+	return parsePidsMaxEvents(string(data))
+}
+
+// parsePidsMaxEvents parses the max count from the content of pids.events,
+// which is in the format of "max <count>".
+func parsePidsMaxEvents(content string) (uint64, error) {
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "max" {
+			return strconv.ParseUint(fields[1], 10, 64)
+		}
+	}
+	return 0, fmt.Errorf("invalid pids.events content %q", content)
+}
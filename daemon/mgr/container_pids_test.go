@@ -0,0 +1,49 @@
+package mgr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePidsCgroupMount(t *testing.T) {
+	mountPoint, err := parsePidsCgroupMount(`25 18 0:22 / /sys/fs/cgroup ro,nosuid,nodev,noexec shared:9 - tmpfs tmpfs ro,mode=755
+30 25 0:26 / /sys/fs/cgroup/cpu,cpuacct rw,nosuid,nodev,noexec,relatime shared:12 - cgroup cgroup rw,cpu,cpuacct
+31 25 0:27 / /sys/fs/cgroup/pids rw,nosuid,nodev,noexec,relatime shared:13 - cgroup cgroup rw,pids
+26 25 0:23 / /sys/fs/cgroup/unified rw,nosuid,nodev,noexec,relatime shared:10 - cgroup2 cgroup2 rw,nsdelegate
+`)
+	assert.NoError(t, err)
+	assert.Equal(t, "/sys/fs/cgroup/pids", mountPoint)
+
+	mountPoint, err = parsePidsCgroupMount("26 18 0:23 / /sys/fs/cgroup rw,nosuid,nodev,noexec,relatime shared:4 - cgroup2 cgroup2 rw,nsdelegate\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "/sys/fs/cgroup", mountPoint)
+
+	_, err = parsePidsCgroupMount("30 25 0:26 / /sys/fs/cgroup/cpu,cpuacct rw shared:12 - cgroup cgroup rw,cpu,cpuacct\n")
+	assert.Error(t, err)
+}
+
+func TestCgroupDir(t *testing.T) {
+	for _, tc := range []struct {
+		cgroupsPath string
+		expected    string
+	}{
+		{cgroupsPath: "/default/abc", expected: "/default/abc"},
+		{cgroupsPath: "system.slice:pouch:abc", expected: "/system.slice/pouch-abc.scope"},
+		{cgroupsPath: "a-b.slice:pouch:abc", expected: "/a.slice/a-b.slice/pouch-abc.scope"},
+		{cgroupsPath: "-.slice:pouch:abc", expected: "/pouch-abc.scope"},
+	} {
+		assert.Equal(t, tc.expected, cgroupDir(tc.cgroupsPath), tc.cgroupsPath)
+	}
+}
+
+func TestParsePidsMaxEvents(t *testing.T) {
+	count, err := parsePidsMaxEvents("max 12\n")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(12), count)
+
+	for _, content := range []string{"", "max", "max -1", "foo 1"} {
+		_, err := parsePidsMaxEvents(content)
+		assert.Error(t, err, content)
+	}
+}
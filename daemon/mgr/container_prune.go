@@ -0,0 +1,101 @@
+package mgr
+
+import (
+	"context"
+	"time"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// the filter tags set allowed when pouch container prune --filter
+var acceptedContainerPruneFilterTags = map[string]bool{
+	"until": true,
+	"label": true,
+}
+
+// containerPruneOptions is the parsed filter of prune containers.
+type containerPruneOptions struct {
+	until  time.Time
+	filter filters.Args
+}
+
+// parseContainerPruneFilter parses the filter of prune containers.
+func parseContainerPruneFilter(filter filters.Args, now time.Time) (*containerPruneOptions, error) {
+	if err := filter.Validate(acceptedContainerPruneFilterTags); err != nil {
+		return nil, pkgerrors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	until, err := parsePruneUntilFilter(filter, now)
+	if err != nil {
+		return nil, err
+	}
+	return &containerPruneOptions{until: until, filter: filter}, nil
+}
+
+// match returns true if the stopped container should be pruned, it must be
+// called with the lock of container held.
+func (opts *containerPruneOptions) match(c *Container) bool {
+	if !opts.until.IsZero() {
+		// keep the container if it is unknown when the container is created.
+		created, err := time.Parse(utils.TimeLayout, c.Created)
+		if err != nil || !created.Before(opts.until) {
+			return false
+		}
+	}
+
+	var labels map[string]string
+	if c.Config != nil {
+		labels = c.Config.Labels
+	}
+	return opts.filter.MatchKVList("label", labels)
+}
+
+// Prune removes all the stopped containers, the space reclaimed only counts
+// the writable layer of containers.
+func (mgr *ContainerManager) Prune(ctx context.Context, filter filters.Args) (*types.ContainerPruneResp, error) {
+	opts, err := parseContainerPruneFilter(filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	containers, err := mgr.List(ctx, &ContainerListOption{All: true})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &types.ContainerPruneResp{
+		ContainersDeleted: []string{},
+	}
+	for _, c := range containers {
+		c.Lock()
+		matched := !c.IsRunningOrPaused() && opts.match(c)
+		rootfsProvided, snapshotter, key := c.RootFSProvided, c.Config.Snapshotter, c.SnapshotKey()
+		c.Unlock()
+		if !matched {
+			continue
+		}
+
+		var size int64
+		if !rootfsProvided {
+			if u, err := mgr.Client.GetSnapshotUsage(ctrd.WithSnapshotter(ctx, snapshotter), key); err == nil {
+				size = u.Size
+			}
+		}
+
+		if _, err := mgr.Remove(ctx, c.ID, &types.ContainerRemoveOptions{}); err != nil {
+			log.With(ctx).Warnf("failed to remove container(%s) during prune containers: %v", c.ID, err)
+			continue
+		}
+		resp.ContainersDeleted = append(resp.ContainersDeleted, c.ID)
+		resp.SpaceReclaimed += size
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,49 @@
+package mgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerPruneOptionsMatch(t *testing.T) {
+	now := time.Now()
+	newContainer := func(created time.Time, labels map[string]string) *Container {
+		return &Container{
+			Created: created.UTC().Format(utils.TimeLayout),
+			Config:  &types.ContainerConfig{Labels: labels},
+		}
+	}
+	fresh := newContainer(now, map[string]string{"env": "test"})
+	old := newContainer(now.Add(-2*time.Hour), nil)
+
+	opts, err := parseContainerPruneFilter(filters.NewArgs(), now)
+	assert.NoError(t, err)
+	assert.True(t, opts.match(fresh))
+	assert.True(t, opts.match(old))
+
+	opts, err = parseContainerPruneFilter(filters.NewArgs(filters.Arg("until", "1h")), now)
+	assert.NoError(t, err)
+	assert.False(t, opts.match(fresh))
+	assert.True(t, opts.match(old))
+	assert.False(t, opts.match(&Container{Config: &types.ContainerConfig{}}))
+
+	opts, err = parseContainerPruneFilter(filters.NewArgs(filters.Arg("label", "env=test")), now)
+	assert.NoError(t, err)
+	assert.True(t, opts.match(fresh))
+	assert.False(t, opts.match(old))
+
+	for _, filter := range []filters.Args{
+		filters.NewArgs(filters.Arg("dangling", "true")),
+		filters.NewArgs(filters.Arg("until", "yesterday")),
+		filters.NewArgs(filters.Arg("until", "1h"), filters.Arg("until", "2h")),
+	} {
+		_, err := parseContainerPruneFilter(filter, now)
+		assert.Error(t, err)
+	}
+}
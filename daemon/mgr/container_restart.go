@@ -0,0 +1,85 @@
+package mgr
+
+import (
+	"context"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/utils"
+)
+
+const (
+	// restartBackoffInitial is the delay of the first restart by restart policy.
+	restartBackoffInitial = 100 * time.Millisecond
+	// restartBackoffMax is the max delay of restart by restart policy.
+	restartBackoffMax = time.Minute
+	// restartBackoffResetDuration is the duration of running, after which
+	// the container is regarded as healthy and the backoff is reset.
+	restartBackoffResetDuration = 10 * time.Second
+)
+
+// nextRestartDelay returns the backoff delay of restarting the exited
+// container, which doubles on each restart unless the container has been
+// running long enough.
+func (c *Container) nextRestartDelay() time.Duration {
+	startedAt, err1 := time.Parse(utils.TimeLayout, c.State.StartedAt)
+	finishedAt, err2 := time.Parse(utils.TimeLayout, c.State.FinishedAt)
+	if err1 == nil && err2 == nil && finishedAt.Sub(startedAt) >= restartBackoffResetDuration {
+		c.restartDelay = 0
+	}
+
+	if c.restartDelay == 0 {
+		c.restartDelay = restartBackoffInitial
+	} else if c.restartDelay *= 2; c.restartDelay > restartBackoffMax {
+		c.restartDelay = restartBackoffMax
+	}
+	return c.restartDelay
+}
+
+// restartOnExit restarts the exited container by its restart policy after
+// the backoff delay. The restart is cancelled if the container is started,
+// stopped or removed in the meantime.
+func (mgr *ContainerManager) restartOnExit(c *Container) error {
+	c.Lock()
+	policy := (*ContainerRestartPolicy)(c.HostConfig.RestartPolicy)
+	if policy == nil || !policy.ShouldRestart(c.State.ExitCode, c.HasBeenManuallyStopped, c.RestartCount) {
+		c.Unlock()
+		return nil
+	}
+	delay := c.nextRestartDelay()
+	keys := c.DetachKeys
+	c.Unlock()
+
+	ctx := log.NewContext(context.Background(), map[string]interface{}{
+		"ContainerID": c.ID,
+	})
+	ctx = ctrd.WithSnapshotter(ctx, c.Config.Snapshotter)
+	log.With(ctx).Infof("restart container in %v by restart policy %s", delay, policy.Name)
+
+	time.AfterFunc(delay, func() {
+		if _, err := mgr.container(c.ID); err != nil {
+			return
+		}
+
+		c.Lock()
+		if c.IsRunningOrPaused() || c.HasBeenManuallyStopped {
+			c.Unlock()
+			return
+		}
+		c.RestartCount++
+		c.Unlock()
+
+		if err := mgr.start(ctx, c, &types.ContainerStartOptions{DetachKeys: keys}); err != nil {
+			log.With(ctx).Errorf("failed to restart container by restart policy: %v", err)
+			return
+		}
+		mgr.LogContainerEvent(ctx, c, "restart")
+
+		if err := c.Write(mgr.Store); err != nil {
+			log.With(ctx).Errorf("failed to update meta: %v", err)
+		}
+	})
+	return nil
+}
@@ -0,0 +1,75 @@
+package mgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerRestartPolicyShouldRestart(t *testing.T) {
+	for _, tc := range []struct {
+		policy          ContainerRestartPolicy
+		exitCode        int64
+		manuallyStopped bool
+		restartCount    int64
+		expected        bool
+	}{
+		{policy: ContainerRestartPolicy{Name: ""}, exitCode: 1, expected: false},
+		{policy: ContainerRestartPolicy{Name: "no"}, exitCode: 1, expected: false},
+		{policy: ContainerRestartPolicy{Name: "always"}, exitCode: 0, expected: true},
+		{policy: ContainerRestartPolicy{Name: "always"}, exitCode: 0, manuallyStopped: true, expected: false},
+		{policy: ContainerRestartPolicy{Name: "unless-stopped"}, exitCode: 0, expected: true},
+		{policy: ContainerRestartPolicy{Name: "unless-stopped"}, exitCode: 1, manuallyStopped: true, expected: false},
+		{policy: ContainerRestartPolicy{Name: "on-failure"}, exitCode: 0, expected: false},
+		{policy: ContainerRestartPolicy{Name: "on-failure"}, exitCode: 1, restartCount: 100, expected: true},
+		{policy: ContainerRestartPolicy{Name: "on-failure", MaximumRetryCount: 3}, exitCode: 1, restartCount: 2, expected: true},
+		{policy: ContainerRestartPolicy{Name: "on-failure", MaximumRetryCount: 3}, exitCode: 1, restartCount: 3, expected: false},
+	} {
+		assert.Equal(t, tc.expected, tc.policy.ShouldRestart(tc.exitCode, tc.manuallyStopped, tc.restartCount), "%+v", tc)
+	}
+}
+
+func TestContainerRestartPolicyShouldRestartOnStartup(t *testing.T) {
+	for _, tc := range []struct {
+		policy          ContainerRestartPolicy
+		exitCode        int64
+		manuallyStopped bool
+		expected        bool
+	}{
+		{policy: ContainerRestartPolicy{Name: "no"}, exitCode: 1, expected: false},
+		{policy: ContainerRestartPolicy{Name: "always"}, exitCode: 0, expected: true},
+		{policy: ContainerRestartPolicy{Name: "unless-stopped"}, exitCode: 0, expected: true},
+		// only always restarts the container stopped by user on startup.
+		{policy: ContainerRestartPolicy{Name: "always"}, exitCode: 0, manuallyStopped: true, expected: true},
+		{policy: ContainerRestartPolicy{Name: "unless-stopped"}, exitCode: 0, manuallyStopped: true, expected: false},
+		{policy: ContainerRestartPolicy{Name: "on-failure"}, exitCode: 1, expected: true},
+		{policy: ContainerRestartPolicy{Name: "on-failure"}, exitCode: 1, manuallyStopped: true, expected: false},
+	} {
+		assert.Equal(t, tc.expected, tc.policy.ShouldRestartOnStartup(tc.exitCode, tc.manuallyStopped, 0), "%+v", tc)
+	}
+}
+
+func TestNextRestartDelay(t *testing.T) {
+	now := time.Now().UTC()
+	c := &Container{
+		State: &types.ContainerState{
+			StartedAt:  now.Format(utils.TimeLayout),
+			FinishedAt: now.Add(time.Second).Format(utils.TimeLayout),
+		},
+	}
+
+	assert.Equal(t, restartBackoffInitial, c.nextRestartDelay())
+	assert.Equal(t, 2*restartBackoffInitial, c.nextRestartDelay())
+	assert.Equal(t, 4*restartBackoffInitial, c.nextRestartDelay())
+
+	c.restartDelay = restartBackoffMax
+	assert.Equal(t, restartBackoffMax, c.nextRestartDelay())
+
+	// the backoff is reset once the container has been running long enough.
+	c.State.FinishedAt = now.Add(restartBackoffResetDuration).Format(utils.TimeLayout)
+	assert.Equal(t, restartBackoffInitial, c.nextRestartDelay())
+}
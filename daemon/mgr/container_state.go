@@ -61,6 +61,8 @@ func (c *Container) SetStatusStopped(exitCode int64, errMsg string) {
 	c.State.ExitCode = exitCode
 	c.State.Error = errMsg
 	c.setStatusFlags(types.StatusStopped)
+	stopHealthMonitor(c)
+	stopPidsMonitor(c)
 }
 
 // SetStatusExited sets a container to be status exited.
@@ -71,6 +73,8 @@ func (c *Container) SetStatusExited(exitCode int64, errMsg string) {
 	c.State.ExitCode = exitCode
 	c.State.Error = errMsg
 	c.setStatusFlags(types.StatusExited)
+	stopHealthMonitor(c)
+	stopPidsMonitor(c)
 }
 
 // SetStatusPaused sets a container to be status paused.
@@ -24,6 +24,10 @@ import (
 
 const nanoSecondsPerSecond = 1e9
 
+// oneShotStatsInterval is the interval between the two samples taken when
+// collecting stats once, so that the cpu usage can be calculated.
+const oneShotStatsInterval = 200 * time.Millisecond
+
 // StreamStats gets the stats from containerd side and send back to caller as a stream.
 func (mgr *ContainerManager) StreamStats(ctx context.Context, name string, config *ContainerStatsConfig) error {
 	c, err := mgr.container(name)
@@ -62,12 +66,27 @@ func (mgr *ContainerManager) StreamStats(ctx context.Context, name string, confi
 		return stats, nil
 	}
 
-	// just collect stats data once.
+	// just collect stats data once, and the first sample is only taken as
+	// the PrecpuStats of the second one.
 	if !config.Stream {
 		metrics, stats, err := mgr.Stats(ctx, name)
 		if err != nil {
 			return err
 		}
+		if _, err := wrapContainerStats(metrics, stats); err != nil {
+			return errors.Errorf("failed to wrap the containerStat: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(oneShotStatsInterval):
+		}
+
+		metrics, stats, err = mgr.Stats(ctx, name)
+		if err != nil {
+			return err
+		}
 		containerStat, err := wrapContainerStats(metrics, stats)
 		if err != nil {
 			return errors.Errorf("failed to wrap the containerStat: %v", err)
@@ -72,7 +72,7 @@ func (mgr *ContainerManager) generateMountPoints(ctx context.Context, c *Contain
 
 	defer func() {
 		if err != nil {
-			if err := mgr.detachVolumes(ctx, c, false); err != nil {
+			if err := mgr.detachVolumes(ctx, c); err != nil {
 				log.With(ctx).Errorf("failed to detach volume, err(%v)", err)
 			}
 		}
@@ -90,13 +90,19 @@ func (mgr *ContainerManager) generateMountPoints(ctx context.Context, c *Contain
 		return errors.Wrap(err, "failed to get mount point from binds")
 	}
 
-	// 3. read MountPoints from image
+	// 3. read MountPoints from long-form mounts
+	err = mgr.getMountPointFromMounts(ctx, c, volumeSet)
+	if err != nil {
+		return errors.Wrap(err, "failed to get mount point from mounts")
+	}
+
+	// 4. read MountPoints from image
 	err = mgr.getMountPointFromImage(ctx, c, volumeSet)
 	if err != nil {
 		return errors.Wrap(err, "failed to get mount point from image")
 	}
 
-	// 4. read MountPoints from Config.Volumes
+	// 5. read MountPoints from Config.Volumes
 	err = mgr.getMountPointFromVolumes(ctx, c, volumeSet)
 	if err != nil {
 		return errors.Wrap(err, "failed to get mount point from volumes")
@@ -162,27 +168,7 @@ func (mgr *ContainerManager) getMountPointFromBinds(ctx context.Context, c *Cont
 
 		if !path.IsAbs(mp.Source) {
 			// volume bind.
-			name := mp.Source
-			if _, exist := volumeSet[name]; !exist {
-				_, mp.Driver, err = mgr.attachVolume(ctx, name, c)
-				if err != nil {
-					log.With(ctx).Errorf("failed to bind volume(%s), err(%v)", name, err)
-					return errors.Wrap(err, "failed to bind volume")
-				}
-
-				volumeSet[name] = struct{}{}
-			}
-
-			volume, err := mgr.VolumeMgr.Get(ctx, name)
-			if err != nil || volume == nil {
-				log.With(ctx).Errorf("failed to get volume(%s), err(%v)", name, err)
-				return errors.Wrapf(err, "failed to get volume(%s)", name)
-			}
-			mp.Driver = volume.Driver()
-			mp.Name = name
-
-			mp.Source, err = mgr.VolumeMgr.Path(ctx, name)
-			if err != nil {
+			if err = mgr.bindVolumeMountPoint(ctx, c, mp, volumeSet); err != nil {
 				return err
 			}
 
@@ -208,6 +194,80 @@ func (mgr *ContainerManager) getMountPointFromBinds(ctx context.Context, c *Cont
 	return nil
 }
 
+func (mgr *ContainerManager) getMountPointFromMounts(ctx context.Context, c *Container, volumeSet map[string]struct{}) error {
+	log.With(ctx).Debugf("long-form mounts(%v)", c.HostConfig.Mounts)
+
+	for _, m := range c.HostConfig.Mounts {
+		if m == nil {
+			continue
+		}
+
+		// tmpfs mount has no source on host, it is set into spec directly.
+		if m.Type == types.MountTypeTmpfs {
+			continue
+		}
+
+		if opts.CheckDuplicateMountPoint(c.Mounts, m.Target) {
+			log.With(ctx).Warnf("duplicate mountpoint(%s)", m.Target)
+			continue
+		}
+
+		mp := &types.MountPoint{
+			Type:        m.Type,
+			Source:      m.Source,
+			Destination: m.Target,
+			RW:          !m.ReadOnly,
+			Named:       m.Source != "",
+		}
+		if m.BindOptions != nil {
+			mp.Propagation = m.BindOptions.Propagation
+		}
+
+		if m.Type == types.MountTypeVolume {
+			if mp.Source == "" {
+				mp.Source = randomid.Generate()
+			}
+			mp.CopyData = true
+
+			if err := mgr.bindVolumeMountPoint(ctx, c, mp, volumeSet); err != nil {
+				return err
+			}
+		}
+
+		c.Mounts = append(c.Mounts, mp)
+	}
+
+	return nil
+}
+
+// bindVolumeMountPoint attaches the volume named by the source of mount
+// point, and sets the mount point source to the path of volume.
+func (mgr *ContainerManager) bindVolumeMountPoint(ctx context.Context, c *Container, mp *types.MountPoint, volumeSet map[string]struct{}) error {
+	var err error
+
+	name := mp.Source
+	if _, exist := volumeSet[name]; !exist {
+		_, mp.Driver, err = mgr.attachVolume(ctx, name, c)
+		if err != nil {
+			log.With(ctx).Errorf("failed to bind volume(%s), err(%v)", name, err)
+			return errors.Wrap(err, "failed to bind volume")
+		}
+
+		volumeSet[name] = struct{}{}
+	}
+
+	volume, err := mgr.VolumeMgr.Get(ctx, name)
+	if err != nil || volume == nil {
+		log.With(ctx).Errorf("failed to get volume(%s), err(%v)", name, err)
+		return errors.Wrapf(err, "failed to get volume(%s)", name)
+	}
+	mp.Driver = volume.Driver()
+	mp.Name = name
+
+	mp.Source, err = mgr.VolumeMgr.Path(ctx, name)
+	return err
+}
+
 func (mgr *ContainerManager) getMountPointFromVolumes(ctx context.Context, c *Container, volumeSet map[string]struct{}) error {
 	var err error
 
@@ -267,6 +327,11 @@ func (mgr *ContainerManager) getMountPointFromImage(ctx context.Context, c *Cont
 			continue
 		}
 
+		// the volume of image is replaced by the tmpfs mount.
+		if isTmpfsMountPoint(c, dest) {
+			continue
+		}
+
 		mp := new(types.MountPoint)
 		mp.Name = name
 		mp.Destination = dest
@@ -645,7 +710,7 @@ func (mgr *ContainerManager) setDiskQuota(ctx context.Context, c *Container, upd
 	return nil
 }
 
-func (mgr *ContainerManager) detachVolumes(ctx context.Context, c *Container, remove bool) error {
+func (mgr *ContainerManager) detachVolumes(ctx context.Context, c *Container) error {
 	for _, mount := range c.Mounts {
 		name := mount.Name
 		if name == "" {
@@ -656,15 +721,36 @@ func (mgr *ContainerManager) detachVolumes(ctx context.Context, c *Container, re
 		if err != nil {
 			log.With(ctx).Warnf("failed to detach volume(%s), err(%v)", name, err)
 		}
+	}
+
+	return nil
+}
 
-		if remove && !mount.Named {
-			if err := mgr.VolumeMgr.Remove(ctx, name); err != nil && !errtypes.IsInUse(err) {
-				log.With(ctx).Warnf("failed to remove volume(%s) when remove container", name)
+// removeVolumes removes the anonymous volumes of the detached container, the
+// named volumes and the volumes still used by other containers are kept.
+func (mgr *ContainerManager) removeVolumes(ctx context.Context, c *Container) *types.ContainerRemoveResp {
+	resp := &types.ContainerRemoveResp{}
+	for _, mount := range c.Mounts {
+		name := mount.Name
+		if name == "" {
+			continue
+		}
+
+		if mount.Named {
+			resp.VolumesKept = append(resp.VolumesKept, name)
+			continue
+		}
+
+		if err := mgr.VolumeMgr.Remove(ctx, name); err != nil {
+			if !errtypes.IsInUse(err) {
+				log.With(ctx).Warnf("failed to remove volume(%s) when remove container, err(%v)", name, err)
 			}
+			resp.VolumesKept = append(resp.VolumesKept, name)
+			continue
 		}
+		resp.VolumesRemoved = append(resp.VolumesRemoved, name)
 	}
-
-	return nil
+	return resp
 }
 
 func (mgr *ContainerManager) attachVolumes(ctx context.Context, c *Container) (err0 error) {
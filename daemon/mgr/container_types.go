@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/alibaba/pouch/apis/types"
@@ -19,6 +20,7 @@ import (
 	"github.com/alibaba/pouch/pkg/utils"
 
 	"github.com/containerd/containerd/mount"
+	"github.com/docker/docker/pkg/signal"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
@@ -68,6 +70,9 @@ var (
 	// CPUPeriodWarn is warning for flag --cpu-period
 	CPUPeriodWarn = "Current Kernel does not support cpu period, discard --cpu-period"
 
+	// NanoCPUsWarn is warning for flag --cpus
+	NanoCPUsWarn = "Current Kernel does not support cpu cfs quota and period, discard --cpus"
+
 	// BlkioWeightWarn is warning for flag --blkio-weight
 	BlkioWeightWarn = "Current Kernel does not support blkio weight, discard --blkio-weight"
 
@@ -90,12 +95,72 @@ var (
 	PidsLimitWarn = "Current Kernel does not support pids cgroup, discard --pids-limit"
 )
 
+var (
+	// MemoryUpdateErr is error for updating flag --memory
+	MemoryUpdateErr = "Current Kernel does not support memory limit, can not update --memory"
+
+	// MemoryReservationUpdateErr is error for updating flag --memory-reservation
+	MemoryReservationUpdateErr = "Current Kernel does not support memory soft limit, can not update --memory-reservation"
+
+	// MemorySwapUpdateErr is error for updating flag --memory-swap
+	MemorySwapUpdateErr = "Current Kernel does not support memory swap, can not update --memory-swap"
+
+	// MemorySwappinessUpdateErr is error for updating flag --memory-swappiness
+	MemorySwappinessUpdateErr = "Current Kernel does not support memory swappiness, can not update --memory-swappiness"
+
+	// OOMKillUpdateErr is error for updating flag --oom-kill-disable
+	OOMKillUpdateErr = "Current Kernel does not support disable oom kill, can not update --oom-kill-disable"
+
+	// CpusetCpusUpdateErr is error for updating flag --cpuset-cpus
+	CpusetCpusUpdateErr = "Current Kernel does not support cpuset cpus, can not update --cpuset-cpus"
+
+	// CpusetMemsUpdateErr is error for updating flag --cpuset-mems
+	CpusetMemsUpdateErr = "Current Kernel does not support cpuset mems, can not update --cpuset-mems"
+
+	// CPUSharesUpdateErr is error for updating flag --cpu-shares
+	CPUSharesUpdateErr = "Current Kernel does not support cpu shares, can not update --cpu-shares"
+
+	// CPUQuotaUpdateErr is error for updating flag --cpu-quota
+	CPUQuotaUpdateErr = "Current Kernel does not support cpu quota, can not update --cpu-quota"
+
+	// CPUPeriodUpdateErr is error for updating flag --cpu-period
+	CPUPeriodUpdateErr = "Current Kernel does not support cpu period, can not update --cpu-period"
+
+	// NanoCPUsUpdateErr is error for updating flag --cpus
+	NanoCPUsUpdateErr = "Current Kernel does not support cpu cfs quota and period, can not update --cpus"
+
+	// BlkioWeightUpdateErr is error for updating flag --blkio-weight
+	BlkioWeightUpdateErr = "Current Kernel does not support blkio weight, can not update --blkio-weight"
+
+	// BlkioWeightDeviceUpdateErr is error for updating flag --blkio-weight-device
+	BlkioWeightDeviceUpdateErr = "Current Kernel does not support blkio weight device, can not update --blkio-weight-device"
+
+	// BlkioDeviceReadBpsUpdateErr is error for updating flag --device-read-bps
+	BlkioDeviceReadBpsUpdateErr = "Current Kernel does not support blkio device throttle read bps, can not update --device-read-bps"
+
+	// BlkioDeviceWriteBpsUpdateErr is error for updating flag --device-write-bps
+	BlkioDeviceWriteBpsUpdateErr = "Current Kernel does not support blkio device throttle write bps, can not update --device-write-bps"
+
+	// BlkioDeviceReadIOpsUpdateErr is error for updating flag --device-read-iops
+	BlkioDeviceReadIOpsUpdateErr = "Current Kernel does not support blkio device throttle read iops, can not update --device-read-iops"
+
+	// BlkioDeviceWriteIOpsUpdateErr is error for updating flag --device-write-iops
+	BlkioDeviceWriteIOpsUpdateErr = "Current Kernel does not support blkio device throttle, can not update --device-write-iops"
+
+	// PidsLimitUpdateErr is error for updating flag --pids-limit
+	PidsLimitUpdateErr = "Current Kernel does not support pids cgroup, can not update --pids-limit"
+)
+
 const (
 	// DefaultStopTimeout is the timeout (in seconds) for the syscall signal used to stop a container.
 	DefaultStopTimeout = 10
 
 	// RuntimeDir is specified name keeps runtime path script.
 	RuntimeDir = "runtimes"
+
+	// DefaultCPUPeriod is the CFS period (in microseconds) used to limit the
+	// cpus of container by NanoCpus.
+	DefaultCPUPeriod = 100000
 )
 
 // ContainerFilter defines a function to filter
@@ -121,6 +186,13 @@ type ContainerExecConfig struct {
 	// Running represents whether the exec process is running inside container.
 	Running bool
 
+	// Pid is the host pid of the exec process, 0 means it has not started.
+	Pid int64
+
+	// Log means the output of exec process is copied into the log driver
+	// of container, which is set when starting exec.
+	Log bool
+
 	// Error represents the exec process response error.
 	Error error
 
@@ -280,6 +352,20 @@ type Container struct {
 
 	// SnapshotID specify id of the snapshot that container using.
 	SnapshotID string
+
+	// HasBeenManuallyStopped is set when the container is stopped by user,
+	// and it is unset when the container is started again.
+	HasBeenManuallyStopped bool `json:"HasBeenManuallyStopped,omitempty"`
+
+	// restartDelay is the backoff delay of the next restart by the restart
+	// policy.
+	restartDelay time.Duration
+
+	// healthStop is closed to stop the health monitor of the container.
+	healthStop chan struct{}
+
+	// pidsStop is closed to stop the pids limit monitor of the container.
+	pidsStop chan struct{}
 }
 
 // Key returns container's id.
@@ -310,6 +396,27 @@ func (c *Container) StopTimeout() int64 {
 	return DefaultStopTimeout
 }
 
+// resolveStopTimeout returns the timeout used to stop the container, a
+// negative timeout means it is not set and the stop timeout of container is
+// used, zero means killing the container immediately.
+func (c *Container) resolveStopTimeout(timeout int64) int64 {
+	if timeout < 0 {
+		return c.StopTimeout()
+	}
+	return timeout
+}
+
+// StopSignal returns the signal used to stop the container, SIGTERM is used
+// if the stop signal is not set or invalid.
+func (c *Container) StopSignal() syscall.Signal {
+	if c.Config.StopSignal != "" {
+		if sig, err := signal.ParseSignal(c.Config.StopSignal); err == nil {
+			return sig
+		}
+	}
+	return syscall.SIGTERM
+}
+
 func (c *Container) merge(getconfig func() (v1.ImageConfig, error)) error {
 	imageConf, err := getconfig()
 	if err != nil {
@@ -317,8 +424,14 @@ func (c *Container) merge(getconfig func() (v1.ImageConfig, error)) error {
 	}
 
 	// If user specify the Entrypoint, no need to merge image's configuration.
-	// Otherwise use the image's configuration to fill it.
-	if len(c.Config.Entrypoint) == 0 {
+	// Otherwise use the image's configuration to fill it. The empty Entrypoint
+	// specified by user clears the image's one, so that Cmd runs directly.
+	if isEmptyEntrypoint(c.Config.Entrypoint) {
+		if len(c.Config.Cmd) == 0 {
+			c.Config.Cmd = imageConf.Cmd
+		}
+		c.Config.Entrypoint = nil
+	} else if len(c.Config.Entrypoint) == 0 {
 		if len(c.Config.Cmd) == 0 {
 			c.Config.Cmd = imageConf.Cmd
 		}
@@ -404,6 +517,12 @@ func (c *Container) FormatStatus() (string, error) {
 		status = "Up " + startAt
 		if c.State.Status == types.StatusPaused {
 			status += "(paused)"
+		} else if c.State.Health != nil {
+			if c.State.Health.Status == HealthStarting {
+				status += " (health: starting)"
+			} else {
+				status += " (" + c.State.Health.Status + ")"
+			}
 		}
 
 	case types.StatusStopped, types.StatusExited:
@@ -565,3 +684,48 @@ func (p ContainerRestartPolicy) IsNone() bool {
 func (p ContainerRestartPolicy) IsAlways() bool {
 	return p.Name == "always"
 }
+
+// IsUnlessStopped returns the container need to be restarted unless it is
+// stopped by user.
+func (p ContainerRestartPolicy) IsUnlessStopped() bool {
+	return p.Name == "unless-stopped"
+}
+
+// IsOnFailure returns the container need to be restarted on non-zero exit
+// code or not.
+func (p ContainerRestartPolicy) IsOnFailure() bool {
+	return p.Name == "on-failure"
+}
+
+// ShouldRestart returns whether the exited container should be restarted.
+// The container stopped by user is not restarted until the daemon restarts,
+// even with the always policy.
+func (p ContainerRestartPolicy) ShouldRestart(exitCode int64, hasBeenManuallyStopped bool, restartCount int64) bool {
+	if p.IsNone() || hasBeenManuallyStopped {
+		return false
+	}
+
+	switch {
+	case p.IsAlways(), p.IsUnlessStopped():
+		return true
+	case p.IsOnFailure():
+		return exitCode != 0 && (p.MaximumRetryCount == 0 || restartCount < p.MaximumRetryCount)
+	}
+	return false
+}
+
+// ShouldRestartOnStartup returns whether the exited container should be
+// restarted when the daemon starts. Unlike unless-stopped, the container with
+// the always policy is restarted even if it has been stopped by user.
+func (p ContainerRestartPolicy) ShouldRestartOnStartup(exitCode int64, hasBeenManuallyStopped bool, restartCount int64) bool {
+	if p.IsAlways() {
+		return true
+	}
+	return p.ShouldRestart(exitCode, hasBeenManuallyStopped, restartCount)
+}
+
+// isEmptyEntrypoint returns true if the Entrypoint is set to empty string
+// explicitly, which means to clear the Entrypoint of image.
+func isEmptyEntrypoint(entrypoint []string) bool {
+	return len(entrypoint) == 1 && entrypoint[0] == ""
+}
@@ -69,6 +69,30 @@ func TestContainer_FormatStatus(t *testing.T) {
 			expected: "Up 1 minute",
 			err:      nil,
 		},
+		{
+			name: "RunningHealthStarting",
+			input: &Container{
+				State: &types.ContainerState{
+					Status:    types.StatusRunning,
+					StartedAt: time.Now().Add(0 - utils.Minute).UTC().Format(utils.TimeLayout),
+					Health:    &types.Health{Status: HealthStarting},
+				},
+			},
+			expected: "Up 1 minute (health: starting)",
+			err:      nil,
+		},
+		{
+			name: "RunningUnhealthy",
+			input: &Container{
+				State: &types.ContainerState{
+					Status:    types.StatusRunning,
+					StartedAt: time.Now().Add(0 - utils.Minute).UTC().Format(utils.TimeLayout),
+					Health:    &types.Health{Status: HealthUnhealthy},
+				},
+			},
+			expected: "Up 1 minute (unhealthy)",
+			err:      nil,
+		},
 		{
 			name: "Paused",
 			input: &Container{
@@ -242,6 +266,38 @@ func TestMerge(t *testing.T) {
 				},
 			},
 		},
+		{
+			// test merge image config, when container clears the entrypoint
+			c: &Container{
+				Config: &types.ContainerConfig{
+					Entrypoint: []string{""},
+				},
+			},
+			image: v1.ImageConfig{
+				Cmd:        []string{"ia"},
+				Entrypoint: []string{"ib"},
+			},
+			expected: &types.ContainerConfig{
+				Cmd: []string{"ia"},
+			},
+		},
+		{
+			// test merge image config, when container clears the entrypoint
+			// and specifies cmd
+			c: &Container{
+				Config: &types.ContainerConfig{
+					Cmd:        []string{"ca"},
+					Entrypoint: []string{""},
+				},
+			},
+			image: v1.ImageConfig{
+				Cmd:        []string{"ia"},
+				Entrypoint: []string{"ib"},
+			},
+			expected: &types.ContainerConfig{
+				Cmd: []string{"ca"},
+			},
+		},
 	} {
 		err := tc.c.merge(func() (v1.ImageConfig, error) {
 			return tc.image, nil
@@ -256,3 +312,21 @@ func TestMerge(t *testing.T) {
 		assert.Equal(true, ret, fmt.Sprintf("test %d fails\n %+v should equal with %+v\n", idx, tc.c.Config, tc.expected))
 	}
 }
+
+func TestResolveStopTimeout(t *testing.T) {
+	zero := int64(0)
+	for _, tc := range []struct {
+		stopTimeout *int64
+		timeout     int64
+		expected    int64
+	}{
+		{stopTimeout: nil, timeout: -1, expected: DefaultStopTimeout},
+		{stopTimeout: nil, timeout: 0, expected: 0},
+		{stopTimeout: nil, timeout: 5, expected: 5},
+		{stopTimeout: &zero, timeout: -1, expected: 0},
+		{stopTimeout: &zero, timeout: 3, expected: 3},
+	} {
+		c := &Container{Config: &types.ContainerConfig{StopTimeout: tc.stopTimeout}}
+		assert.Equal(t, tc.expected, c.resolveStopTimeout(tc.timeout), "%+v", tc)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"path"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -231,6 +232,11 @@ func parseSecurityOpts(c *Container, securityOpts []string) error {
 		case "apparmor":
 			c.AppArmorProfile = value
 		case "seccomp":
+			if isInlineSeccompProfile(value) {
+				if err := opts.ValidateSeccompProfile([]byte(value)); err != nil {
+					return errors.Wrapf(errtypes.ErrInvalidParam, "invalid seccomp profile in --security-opt: %v", err)
+				}
+			}
 			c.SeccompProfile = value
 		case "label":
 			labelOpts = append(labelOpts, value)
@@ -250,6 +256,28 @@ func parseSecurityOpts(c *Container, securityOpts []string) error {
 	return nil
 }
 
+// applyNanoCPUs converts the nano CPUs into the CFS quota in the default
+// period, so that the cpus limit can be applied by runtime.
+func applyNanoCPUs(r *types.Resources) {
+	if r.NanoCpus <= 0 {
+		return
+	}
+	r.CPUPeriod = DefaultCPUPeriod
+	r.CPUQuota = r.NanoCpus * DefaultCPUPeriod / 1e9
+}
+
+// setOOMScoreAdj sets the oom score adj of the process, the processes forked
+// later inherit it.
+func setOOMScoreAdj(pid int64, score int64) error {
+	return ioutil.WriteFile(fmt.Sprintf("/proc/%d/oom_score_adj", pid), []byte(strconv.FormatInt(score, 10)), 0644)
+}
+
+// isInlineSeccompProfile returns true if the seccomp profile is the content
+// of profile in JSON instead of the profile name or path.
+func isInlineSeccompProfile(profile string) bool {
+	return strings.HasPrefix(strings.TrimSpace(profile), "{")
+}
+
 // fieldsASCII is similar to strings.Fields but only allows ASCII whitespaces
 func fieldsASCII(s string) []string {
 	fn := func(r rune) bool {
@@ -262,6 +290,21 @@ func fieldsASCII(s string) []string {
 	return strings.FieldsFunc(s, fn)
 }
 
+// psArgsPIDAliasPattern matches the ps format specifier which renames
+// another field to PID, such as "-o user=PID".
+var psArgsPIDAliasPattern = regexp.MustCompile(`\s+([^\s]*)=\s*(PID[^\s]*)`)
+
+// validatePSArgs makes sure the PID column of ps output is the real pid, since
+// the processes of container are picked out by it.
+func validatePSArgs(psArgs string) error {
+	for _, group := range psArgsPIDAliasPattern.FindAllStringSubmatch(" "+psArgs, -1) {
+		if len(group) >= 3 && group[1] != "pid" {
+			return fmt.Errorf("cannot use ps argument %q, only pid can be displayed as %s", group[0], group[2])
+		}
+	}
+	return nil
+}
+
 func parsePSOutput(output []byte, pids []int) (*types.ContainerProcessList, error) {
 	procList := &types.ContainerProcessList{}
 
@@ -284,6 +327,9 @@ func parsePSOutput(output []byte, pids []int) (*types.ContainerProcessList, erro
 			continue
 		}
 		fields := fieldsASCII(line)
+		if len(fields) < len(procList.Titles) {
+			return nil, fmt.Errorf("Unexpected ps output line '%s'", line)
+		}
 		p, err := strconv.Atoi(fields[pidIndex])
 		if err != nil {
 			return nil, fmt.Errorf("Unexpected pid '%s': %s", fields[pidIndex], err)
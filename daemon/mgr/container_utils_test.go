@@ -129,6 +129,22 @@ func Test_parseSecurityOpt(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid inline seccomp profile",
+			args: args{
+				meta:        &Container{},
+				securityOpt: `seccomp={"defaultAction":"SCMP_ACT_ALLOW"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid inline seccomp profile",
+			args: args{
+				meta:        &Container{},
+				securityOpt: `seccomp={"defaultAction":`,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -174,6 +190,15 @@ func Test_parsePSOutput(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "testParsePSOutputWithMissingFields",
+			args: args{
+				output: []byte("UID        PID  PPID  C STIME TTY          TIME CMD\nroot         1"),
+				pids:   []int{1},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -189,6 +214,23 @@ func Test_parsePSOutput(t *testing.T) {
 	}
 }
 
+func Test_validatePSArgs(t *testing.T) {
+	for _, tc := range []struct {
+		psArgs  string
+		wantErr bool
+	}{
+		{psArgs: "-ef", wantErr: false},
+		{psArgs: "aux", wantErr: false},
+		{psArgs: "-o pid,user,comm", wantErr: false},
+		{psArgs: "-o pid=PID,comm", wantErr: false},
+		{psArgs: "-o user=PID", wantErr: true},
+		{psArgs: "-ef -o ppid=PID", wantErr: true},
+	} {
+		err := validatePSArgs(tc.psArgs)
+		assert.Equal(t, tc.wantErr, err != nil, tc.psArgs)
+	}
+}
+
 func Test_mergeEnvSlice(t *testing.T) {
 	type args struct {
 		newEnv []string
@@ -286,3 +328,15 @@ func Test_mergeEnvSlice(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyNanoCPUs(t *testing.T) {
+	r := &types.Resources{NanoCpus: 1500000000}
+	applyNanoCPUs(r)
+	assert.Equal(t, int64(DefaultCPUPeriod), r.CPUPeriod)
+	assert.Equal(t, int64(150000), r.CPUQuota)
+
+	r = &types.Resources{CPUPeriod: 200000, CPUQuota: 50000}
+	applyNanoCPUs(r)
+	assert.Equal(t, int64(200000), r.CPUPeriod)
+	assert.Equal(t, int64(50000), r.CPUQuota)
+}
@@ -4,18 +4,23 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 
+	"github.com/alibaba/pouch/apis/opts"
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/daemon/logger"
 	"github.com/alibaba/pouch/daemon/logger/jsonfile"
 	"github.com/alibaba/pouch/daemon/logger/syslog"
+	"github.com/alibaba/pouch/pkg/errtypes"
 	"github.com/alibaba/pouch/pkg/log"
 	"github.com/alibaba/pouch/pkg/system"
 	"github.com/alibaba/pouch/pkg/utils"
 	"github.com/alibaba/pouch/storage/quota"
 
+	"github.com/docker/docker/daemon/caps"
+	"github.com/docker/docker/pkg/signal"
 	"github.com/docker/go-units"
 	"github.com/pkg/errors"
 )
@@ -49,6 +54,28 @@ func (mgr *ContainerManager) validateConfig(c *Container, update bool) ([]string
 		return nil, err
 	}
 
+	// validates health check config
+	if c.Config != nil {
+		if err := validateHealthConfig(c.Config.Healthcheck); err != nil {
+			return nil, err
+		}
+
+		for k := range c.Config.Labels {
+			if err := opts.ValidateLabelKey(k); err != nil {
+				return nil, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+			}
+		}
+
+		if c.Config.StopSignal != "" {
+			if _, err := signal.ParseSignal(c.Config.StopSignal); err != nil {
+				return nil, errors.Wrapf(errtypes.ErrInvalidParam, "invalid stop signal %s", c.Config.StopSignal)
+			}
+		}
+		if c.Config.StopTimeout != nil && *c.Config.StopTimeout < 0 {
+			return nil, errors.Wrapf(errtypes.ErrInvalidParam, "invalid stop timeout %d: should not be negative", *c.Config.StopTimeout)
+		}
+	}
+
 	// validates container hostconfig
 	hostConfig := c.HostConfig
 	warnings := make([]string, 0)
@@ -62,14 +89,69 @@ func (mgr *ContainerManager) validateConfig(c *Container, update bool) ([]string
 	}
 	warnings = append(warnings, warns...)
 
-	if hostConfig.OomScoreAdj < -1000 || hostConfig.OomScoreAdj > 1000 {
-		return warnings, fmt.Errorf("oom score should be in range [-1000, 1000]")
+	if err := opts.ValidateOOMScoreAdj(hostConfig.OomScoreAdj); err != nil {
+		return warnings, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
 	}
 
 	if hostConfig.ShmSize != nil && *hostConfig.ShmSize < 0 {
 		return warnings, fmt.Errorf("shm-size %d should greater than 0", *hostConfig.ShmSize)
 	}
 
+	// validates long-form mounts
+	for _, m := range hostConfig.Mounts {
+		if m == nil {
+			continue
+		}
+		if err := opts.ValidateMount(m); err != nil {
+			return warnings, fmt.Errorf("invalid mount %s: %v", m.Target, err)
+		}
+	}
+
+	// validates tmpfs mounts
+	for path, options := range hostConfig.Tmpfs {
+		if err := opts.ValidateTmpfs(path, options); err != nil {
+			return warnings, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+		}
+	}
+
+	// validates ulimits
+	if err := opts.ValidateUlimits(hostConfig.Ulimits); err != nil {
+		return warnings, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	// validates devices, sysctls and network settings, which can't be updated
+	if !update {
+		if err := validateDevices(&hostConfig.Resources); err != nil {
+			return warnings, err
+		}
+		if err := validateSysctls(hostConfig); err != nil {
+			return warnings, err
+		}
+		if c.NetworkSettings != nil {
+			if err := validateEndpointAliases(c.NetworkSettings.Networks); err != nil {
+				return warnings, err
+			}
+		}
+		for _, h := range hostConfig.ExtraHosts {
+			if err := opts.ValidateExtraHost(h); err != nil {
+				return warnings, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+			}
+		}
+		warns, err := validateDNS(hostConfig)
+		if err != nil {
+			return warnings, err
+		}
+		warnings = append(warnings, warns...)
+		if warns, err = validateCapabilities(hostConfig); err != nil {
+			return warnings, err
+		}
+		warnings = append(warnings, warns...)
+		if hostConfig.AutoRemove && hostConfig.RestartPolicy != nil &&
+			hostConfig.RestartPolicy.Name != "" && hostConfig.RestartPolicy.Name != "no" {
+			return warnings, errors.Wrap(errtypes.ErrInvalidParam, "Conflicting options: AutoRemove and RestartPolicy cannot both be set")
+		}
+	}
+
 	// validate log config
 	if err := mgr.validateLogConfig(c); err != nil {
 		return warnings, err
@@ -164,25 +246,69 @@ func validateRichMode(c *Container) error {
 	return nil
 }
 
+// validateCpuset verifies the cpuset is valid and all the requested cpus or
+// mem nodes are available on current machine.
+func validateCpuset(cpuset, available, kind string) error {
+	if err := opts.ValidateCpuset(cpuset); err != nil {
+		return err
+	}
+
+	// skip the check if we can't detect the available ones.
+	if available == "" {
+		return nil
+	}
+
+	ok, err := opts.IsCpusetAvailable(cpuset, available)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("requested %s are not available - requested %s, available: %s", kind, cpuset, available)
+	}
+	return nil
+}
+
 // validateResource verifies cgroup resources
 func validateResource(r *types.Resources, update bool) ([]string, error) {
+	if r.PidsLimit < -1 {
+		return nil, errors.Wrapf(errtypes.ErrInvalidParam, "invalid pids limit %d, should be -1 (unlimited) or greater", r.PidsLimit)
+	}
+
+	if err := validateBlkio(r); err != nil {
+		return nil, err
+	}
+
 	cgroupInfo := system.NewCgroupInfo()
 	if cgroupInfo == nil {
 		return nil, nil
 	}
 	warnings := make([]string, 0, 64)
 
+	// unsupported discards the resource unsupported by the kernel with a
+	// warning, but fails the update with updateErr since it can not be
+	// applied to the container.
+	unsupported := func(warn, updateErr string) error {
+		if update {
+			return errors.Wrap(errtypes.ErrInvalidParam, updateErr)
+		}
+		log.With(nil).Warn(warn)
+		warnings = append(warnings, warn)
+		return nil
+	}
+
 	// validates memory cgroup value
 	if cgroupInfo.Memory != nil {
 		if r.Memory > 0 && !cgroupInfo.Memory.MemoryLimit {
-			log.With(nil).Warn(MemoryWarn)
-			warnings = append(warnings, MemoryWarn)
+			if err := unsupported(MemoryWarn, MemoryUpdateErr); err != nil {
+				return warnings, err
+			}
 			r.Memory = 0
 			r.MemorySwap = 0
 		}
 		if r.MemoryReservation > 0 && !cgroupInfo.Memory.MemoryReservation {
-			log.With(nil).Warn(MemoryReservationWarn)
-			warnings = append(warnings, MemoryReservationWarn)
+			if err := unsupported(MemoryReservationWarn, MemoryReservationUpdateErr); err != nil {
+				return warnings, err
+			}
 			r.MemoryReservation = 0
 		}
 		if r.MemoryReservation != 0 && r.MemoryReservation < MinMemory {
@@ -192,8 +318,9 @@ func validateResource(r *types.Resources, update bool) ([]string, error) {
 			return warnings, fmt.Errorf("Minimum memory limit should be larger than memory reservation limit")
 		}
 		if r.MemorySwap > 0 && !cgroupInfo.Memory.MemorySwap {
-			log.With(nil).Warn(MemorySwapWarn)
-			warnings = append(warnings, MemorySwapWarn)
+			if err := unsupported(MemorySwapWarn, MemorySwapUpdateErr); err != nil {
+				return warnings, err
+			}
 			r.MemorySwap = 0
 		}
 		// cgroup not allow memory-swap less than memory limit
@@ -211,16 +338,18 @@ func validateResource(r *types.Resources, update bool) ([]string, error) {
 			warnings = append(warnings, "You should typically size your swap space to approximately 2x main memory for systems with less than 2GB of RAM")
 		}
 		if r.MemorySwappiness != nil && !cgroupInfo.Memory.MemorySwappiness {
-			log.With(nil).Warn(MemorySwappinessWarn)
-			warnings = append(warnings, MemorySwappinessWarn)
+			if err := unsupported(MemorySwappinessWarn, MemorySwappinessUpdateErr); err != nil {
+				return warnings, err
+			}
 			r.MemorySwappiness = nil
 		}
 		if r.MemorySwappiness != nil && *r.MemorySwappiness != -1 && (*r.MemorySwappiness < 0 || *r.MemorySwappiness > 100) {
 			return warnings, fmt.Errorf("MemorySwappiness should in range [0, 100] or -1 as a legacy alias of 0")
 		}
 		if r.OomKillDisable != nil && !cgroupInfo.Memory.OOMKillDisable {
-			log.With(nil).Warn(OOMKillWarn)
-			warnings = append(warnings, OOMKillWarn)
+			if err := unsupported(OOMKillWarn, OOMKillUpdateErr); err != nil {
+				return warnings, err
+			}
 			r.OomKillDisable = nil
 		}
 	}
@@ -228,23 +357,37 @@ func validateResource(r *types.Resources, update bool) ([]string, error) {
 	// validates cpu cgroup value
 	if cgroupInfo.CPU != nil {
 		if r.CpusetCpus != "" && !cgroupInfo.CPU.CpusetCpus {
-			log.With(nil).Warn(CpusetCpusWarn)
-			warnings = append(warnings, CpusetCpusWarn)
+			if err := unsupported(CpusetCpusWarn, CpusetCpusUpdateErr); err != nil {
+				return warnings, err
+			}
 			r.CpusetCpus = ""
 		}
 		if r.CpusetMems != "" && !cgroupInfo.CPU.CpusetMems {
-			log.With(nil).Warn(CpusetMemsWarn)
-			warnings = append(warnings, CpusetMemsWarn)
+			if err := unsupported(CpusetMemsWarn, CpusetMemsUpdateErr); err != nil {
+				return warnings, err
+			}
 			r.CpusetMems = ""
 		}
+		if r.CpusetCpus != "" {
+			if err := validateCpuset(r.CpusetCpus, cgroupInfo.CPU.Cpus, "CPUs"); err != nil {
+				return warnings, err
+			}
+		}
+		if r.CpusetMems != "" {
+			if err := validateCpuset(r.CpusetMems, cgroupInfo.CPU.Mems, "memory nodes"); err != nil {
+				return warnings, err
+			}
+		}
 		if r.CPUShares > 0 && !cgroupInfo.CPU.CPUShares {
-			log.With(nil).Warn(CPUSharesWarn)
-			warnings = append(warnings, CPUSharesWarn)
+			if err := unsupported(CPUSharesWarn, CPUSharesUpdateErr); err != nil {
+				return warnings, err
+			}
 			r.CPUShares = 0
 		}
 		if r.CPUQuota > 0 && !cgroupInfo.CPU.CPUQuota {
-			log.With(nil).Warn(CPUQuotaWarn)
-			warnings = append(warnings, CPUQuotaWarn)
+			if err := unsupported(CPUQuotaWarn, CPUQuotaUpdateErr); err != nil {
+				return warnings, err
+			}
 			r.CPUQuota = 0
 		}
 		// cpu.cfs_quota_us can accept value less than 0, we allow -1 and > 1000
@@ -252,45 +395,67 @@ func validateResource(r *types.Resources, update bool) ([]string, error) {
 			return warnings, fmt.Errorf("CPU cfs quota should be greater than 1ms(1000)")
 		}
 		if r.CPUPeriod > 0 && !cgroupInfo.CPU.CPUPeriod {
-			log.With(nil).Warn(CPUPeriodWarn)
-			warnings = append(warnings, CPUPeriodWarn)
+			if err := unsupported(CPUPeriodWarn, CPUPeriodUpdateErr); err != nil {
+				return warnings, err
+			}
 			r.CPUPeriod = 0
 		}
 		if r.CPUPeriod != 0 && (r.CPUPeriod < 1000 || r.CPUPeriod > 1000000) {
 			return warnings, fmt.Errorf("CPU cfs period should be in range [1000, 1000000](1ms, 1s)")
 		}
+		if r.NanoCpus > 0 && (!cgroupInfo.CPU.CPUQuota || !cgroupInfo.CPU.CPUPeriod) {
+			if err := unsupported(NanoCPUsWarn, NanoCPUsUpdateErr); err != nil {
+				return warnings, err
+			}
+			r.NanoCpus = 0
+		}
+	}
+
+	// NanoCpus is converted into CPU period and quota, which must not be set.
+	if r.NanoCpus > 0 && (r.CPUPeriod != 0 || r.CPUQuota != 0) {
+		return warnings, errors.Wrap(errtypes.ErrInvalidParam, "Conflicting options: CPUs and CPU period/quota cannot both be set")
+	}
+	// the converted CPU cfs quota should not be less than 1ms(1000) either.
+	if cpus := int64(runtime.NumCPU()); r.NanoCpus < 0 || (r.NanoCpus > 0 && r.NanoCpus*DefaultCPUPeriod/1e9 < 1000) || r.NanoCpus > cpus*1e9 {
+		return warnings, errors.Wrapf(errtypes.ErrInvalidParam, "Range of CPUs is from 0.01 to %d.00, as there are only %d CPUs available", cpus, cpus)
 	}
 
 	// validates blkio cgroup value
 	if cgroupInfo.Blkio != nil {
 		if r.BlkioWeight > 0 && !cgroupInfo.Blkio.BlkioWeight {
-			log.With(nil).Warn(BlkioWeightWarn)
-			warnings = append(warnings, BlkioWeightWarn)
+			if err := unsupported(BlkioWeightWarn, BlkioWeightUpdateErr); err != nil {
+				return warnings, err
+			}
 			r.BlkioWeight = 0
 		}
 		if len(r.BlkioWeightDevice) > 0 && !cgroupInfo.Blkio.BlkioWeightDevice {
-			log.With(nil).Warn(BlkioWeightDeviceWarn)
-			warnings = append(warnings, BlkioWeightDeviceWarn)
+			if err := unsupported(BlkioWeightDeviceWarn, BlkioWeightDeviceUpdateErr); err != nil {
+				return warnings, err
+			}
 			r.BlkioWeightDevice = []*types.WeightDevice{}
 		}
 		if len(r.BlkioDeviceReadBps) > 0 && !cgroupInfo.Blkio.BlkioDeviceReadBps {
-			log.With(nil).Warn(BlkioDeviceReadBpsWarn)
-			warnings = append(warnings, BlkioDeviceReadBpsWarn)
+			if err := unsupported(BlkioDeviceReadBpsWarn, BlkioDeviceReadBpsUpdateErr); err != nil {
+				return warnings, err
+			}
 			r.BlkioDeviceReadBps = []*types.ThrottleDevice{}
 		}
 		if len(r.BlkioDeviceWriteBps) > 0 && !cgroupInfo.Blkio.BlkioDeviceWriteBps {
-			log.With(nil).Warn(BlkioDeviceWriteBpsWarn)
-			warnings = append(warnings, BlkioDeviceWriteBpsWarn)
+			if err := unsupported(BlkioDeviceWriteBpsWarn, BlkioDeviceWriteBpsUpdateErr); err != nil {
+				return warnings, err
+			}
 			r.BlkioDeviceWriteBps = []*types.ThrottleDevice{}
 		}
 		if len(r.BlkioDeviceReadIOps) > 0 && !cgroupInfo.Blkio.BlkioDeviceReadIOps {
-			log.With(nil).Warn(BlkioDeviceReadIOpsWarn)
-			warnings = append(warnings, BlkioDeviceReadIOpsWarn)
+			if err := unsupported(BlkioDeviceReadIOpsWarn, BlkioDeviceReadIOpsUpdateErr); err != nil {
+				return warnings, err
+			}
 			r.BlkioDeviceReadIOps = []*types.ThrottleDevice{}
 		}
 		if len(r.BlkioDeviceWriteIOps) > 0 && !cgroupInfo.Blkio.BlkioDeviceWriteIOps {
-			log.With(nil).Warn(BlkioDeviceWriteIOpsWarn)
-			warnings = append(warnings, BlkioDeviceWriteIOpsWarn)
+			if err := unsupported(BlkioDeviceWriteIOpsWarn, BlkioDeviceWriteIOpsUpdateErr); err != nil {
+				return warnings, err
+			}
 			r.BlkioDeviceWriteIOps = []*types.ThrottleDevice{}
 		}
 	}
@@ -298,8 +463,9 @@ func validateResource(r *types.Resources, update bool) ([]string, error) {
 	// validates pid cgroup value
 	if cgroupInfo.Pids != nil {
 		if r.PidsLimit != 0 && !cgroupInfo.Pids.Pids {
-			log.With(nil).Warn(PidsLimitWarn)
-			warnings = append(warnings, PidsLimitWarn)
+			if err := unsupported(PidsLimitWarn, PidsLimitUpdateErr); err != nil {
+				return warnings, err
+			}
 			r.PidsLimit = 0
 		}
 	}
@@ -357,6 +523,154 @@ func (mgr *ContainerManager) validateLogConfig(c *Container) error {
 	}
 }
 
+// validateDevices checks the devices passed into container exist on the host,
+// and the device cgroup rules are valid.
+func validateDevices(r *types.Resources) error {
+	for _, d := range r.Devices {
+		if d == nil {
+			continue
+		}
+		if !opts.ValidateDeviceMode(d.CgroupPermissions) {
+			return errors.Wrapf(errtypes.ErrInvalidParam, "%s invalid device mode: %s", d.PathOnHost, d.CgroupPermissions)
+		}
+		if !filepath.IsAbs(d.PathInContainer) {
+			return errors.Wrapf(errtypes.ErrInvalidParam, "device path %s in container must be absolute", d.PathInContainer)
+		}
+		if _, err := os.Stat(d.PathOnHost); err != nil {
+			return errors.Wrapf(errtypes.ErrInvalidParam, "device %s does not exist on host: %v", d.PathOnHost, err)
+		}
+	}
+
+	if err := opts.ValidateDeviceCgroupRules(r.DeviceCgroupRules); err != nil {
+		return errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+	return nil
+}
+
+// validateBlkio checks the block IO weight and the devices of block IO limits
+// exist on host.
+func validateBlkio(r *types.Resources) error {
+	if err := opts.ValidateBlkioWeight(r.BlkioWeight); err != nil {
+		return errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	var paths []string
+	for _, d := range r.BlkioWeightDevice {
+		if d == nil {
+			continue
+		}
+		if err := opts.ValidateBlkioWeight(d.Weight); err != nil {
+			return errors.Wrapf(errtypes.ErrInvalidParam, "invalid weight of device %s: %v", d.Path, err)
+		}
+		paths = append(paths, d.Path)
+	}
+	for _, devs := range [][]*types.ThrottleDevice{
+		r.BlkioDeviceReadBps, r.BlkioDeviceWriteBps,
+		r.BlkioDeviceReadIOps, r.BlkioDeviceWriteIOps,
+	} {
+		for _, d := range devs {
+			if d != nil {
+				paths = append(paths, d.Path)
+			}
+		}
+	}
+
+	for _, p := range paths {
+		if !filepath.IsAbs(p) {
+			return errors.Wrapf(errtypes.ErrInvalidParam, "block device path %s must be absolute", p)
+		}
+		fi, err := os.Stat(p)
+		if err != nil {
+			return errors.Wrapf(errtypes.ErrInvalidParam, "block device %s does not exist on host: %v", p, err)
+		}
+		if fi.Mode()&os.ModeDevice == 0 {
+			return errors.Wrapf(errtypes.ErrInvalidParam, "%s is not a device", p)
+		}
+	}
+	return nil
+}
+
+// validateSysctls checks the sysctls are namespaced, and the namespace of
+// sysctl is not shared with host.
+func validateSysctls(hostConfig *types.HostConfig) error {
+	if err := opts.ValidateSysctls(hostConfig.Sysctls); err != nil {
+		return errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	for k := range hostConfig.Sysctls {
+		if opts.IsNetSysctl(k) && IsHost(hostConfig.NetworkMode) {
+			return errors.Wrapf(errtypes.ErrInvalidParam, "sysctl %s is not allowed in host network namespace", k)
+		}
+		if opts.IsIPCSysctl(k) && isHost(hostConfig.IpcMode) {
+			return errors.Wrapf(errtypes.ErrInvalidParam, "sysctl %s is not allowed in host ipc namespace", k)
+		}
+	}
+	return nil
+}
+
+// validateEndpointAliases checks the network-scoped aliases are only set
+// on the user defined networks.
+func validateEndpointAliases(networks map[string]*types.EndpointSettings) error {
+	for name, epConfig := range networks {
+		if epConfig != nil && len(epConfig.Aliases) > 0 && !IsUserDefined(name) {
+			return errors.Wrapf(errtypes.ErrInvalidParam, "network-scoped alias is supported only for containers in user defined networks, but got network %s", name)
+		}
+	}
+	return nil
+}
+
+// validateDNS checks the dns settings of container, which are ignored in
+// host network mode.
+func validateDNS(hostConfig *types.HostConfig) ([]string, error) {
+	if err := opts.ValidateDNS(hostConfig.DNS); err != nil {
+		return nil, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+	if err := opts.ValidateDNSSearch(hostConfig.DNSSearch); err != nil {
+		return nil, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+	if err := opts.ValidateDNSOptions(hostConfig.DNSOptions); err != nil {
+		return nil, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	var warnings []string
+	if IsHost(hostConfig.NetworkMode) &&
+		(len(hostConfig.DNS) > 0 || len(hostConfig.DNSSearch) > 0 || len(hostConfig.DNSOptions) > 0) {
+		warnings = append(warnings, "DNS settings are ignored in host network mode, the resolv.conf of host is used")
+	}
+	return warnings, nil
+}
+
+// validateCapabilities normalizes the capabilities to add and drop into the
+// format without CAP_ prefix in upper case, and makes sure they are known.
+// ALL can be used to add or drop all capabilities.
+func validateCapabilities(hostConfig *types.HostConfig) ([]string, error) {
+	hostConfig.CapAdd = normalizeCapabilities(hostConfig.CapAdd)
+	hostConfig.CapDrop = normalizeCapabilities(hostConfig.CapDrop)
+	if _, err := caps.TweakCapabilities(nil, hostConfig.CapAdd, hostConfig.CapDrop); err != nil {
+		return nil, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	var warnings []string
+	if hostConfig.Privileged && (len(hostConfig.CapAdd) > 0 || len(hostConfig.CapDrop) > 0) {
+		warnings = append(warnings, "Privileged mode grants all capabilities, --cap-add and --cap-drop are ignored")
+	}
+	return warnings, nil
+}
+
+// normalizeCapabilities converts capabilities like cap_net_admin into NET_ADMIN.
+func normalizeCapabilities(capabilities []string) []string {
+	if len(capabilities) == 0 {
+		return capabilities
+	}
+
+	normalized := make([]string, 0, len(capabilities))
+	for _, c := range capabilities {
+		c = strings.ToUpper(strings.TrimSpace(c))
+		normalized = append(normalized, strings.TrimPrefix(c, "CAP_"))
+	}
+	return normalized
+}
+
 // validateNvidiaConfig
 func validateNvidiaConfig(r *types.Resources) error {
 	if r.NvidiaConfig == nil {
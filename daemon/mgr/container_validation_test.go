@@ -2,10 +2,15 @@ package mgr
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime"
 	"testing"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/errtypes"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -175,9 +180,214 @@ func TestValidateResource(t *testing.T) {
 			warningsExpected: []string{},
 			errExpected:      fmt.Errorf("Minimal memory should greater than 4M"),
 		},
+		{
+			r: types.Resources{
+				NanoCpus:  1e9,
+				CPUPeriod: 100000,
+			},
+			update:           true,
+			warningsExpected: []string{},
+			errExpected:      errors.Wrap(errtypes.ErrInvalidParam, "Conflicting options: CPUs and CPU period/quota cannot both be set"),
+		},
+		{
+			r: types.Resources{
+				NanoCpus: 1e9,
+				CPUQuota: -1,
+			},
+			update:           true,
+			warningsExpected: []string{},
+			errExpected:      errors.Wrap(errtypes.ErrInvalidParam, "Conflicting options: CPUs and CPU period/quota cannot both be set"),
+		},
+		{
+			r: types.Resources{
+				NanoCpus: int64(runtime.NumCPU()+1) * 1e9,
+			},
+			update:           true,
+			warningsExpected: []string{},
+			errExpected:      errors.Wrapf(errtypes.ErrInvalidParam, "Range of CPUs is from 0.01 to %d.00, as there are only %d CPUs available", runtime.NumCPU(), runtime.NumCPU()),
+		},
+		{
+			r: types.Resources{
+				NanoCpus: 5e6,
+			},
+			update:           true,
+			warningsExpected: []string{},
+			errExpected:      errors.Wrapf(errtypes.ErrInvalidParam, "Range of CPUs is from 0.01 to %d.00, as there are only %d CPUs available", runtime.NumCPU(), runtime.NumCPU()),
+		},
+		{
+			r: types.Resources{
+				NanoCpus: 1e7,
+			},
+			update:           true,
+			warningsExpected: []string{},
+		},
 	} {
 		warnings, err := validateResource(&tc.r, tc.update)
 		assert.Equal(t, tc.warningsExpected, warnings)
-		assert.Equal(t, tc.errExpected, err)
+		if tc.errExpected == nil {
+			assert.NoError(t, err)
+			continue
+		}
+		assert.EqualError(t, err, tc.errExpected.Error())
+		if errtypes.IsInvalidParam(tc.errExpected) {
+			assert.True(t, errtypes.IsInvalidParam(err), err.Error())
+		}
+	}
+}
+
+func TestValidateCpuset(t *testing.T) {
+	for _, tc := range []struct {
+		cpuset    string
+		available string
+		err       string
+	}{
+		{cpuset: "0-1", available: "0-3"},
+		{cpuset: "0-1", available: ""},
+		{cpuset: "0-a", available: "0-3", err: "invalid cpuset"},
+		{cpuset: "2,4", available: "0-3", err: "requested CPUs are not available - requested 2,4, available: 0-3"},
+	} {
+		err := validateCpuset(tc.cpuset, tc.available, "CPUs")
+		if tc.err == "" {
+			assert.NoError(t, err, tc.cpuset)
+			continue
+		}
+		if assert.Error(t, err, tc.cpuset) {
+			assert.Contains(t, err.Error(), tc.err, tc.cpuset)
+		}
+	}
+}
+
+func TestValidateDevices(t *testing.T) {
+	f, err := ioutil.TempFile("", "TestValidateDevices")
+	assert.NoError(t, err)
+	f.Close()
+	defer os.Remove(f.Name())
+
+	for _, tc := range []struct {
+		r   types.Resources
+		err string
+	}{
+		{
+			r: types.Resources{
+				Devices:           []*types.DeviceMapping{{PathOnHost: f.Name(), PathInContainer: "/dev/foo", CgroupPermissions: "rw"}},
+				DeviceCgroupRules: []string{"c 13:* rwm"},
+			},
+		},
+		{
+			r:   types.Resources{Devices: []*types.DeviceMapping{{PathOnHost: f.Name(), PathInContainer: "/dev/foo", CgroupPermissions: "rwx"}}},
+			err: "invalid device mode",
+		},
+		{
+			r:   types.Resources{Devices: []*types.DeviceMapping{{PathOnHost: f.Name(), PathInContainer: "dev/foo", CgroupPermissions: "rwm"}}},
+			err: "must be absolute",
+		},
+		{
+			r:   types.Resources{Devices: []*types.DeviceMapping{{PathOnHost: f.Name() + "-nonexist", PathInContainer: "/dev/foo", CgroupPermissions: "rwm"}}},
+			err: "does not exist on host",
+		},
+		{
+			r:   types.Resources{DeviceCgroupRules: []string{"c 13 rwm"}},
+			err: "invalid device cgroup rule",
+		},
+	} {
+		err := validateDevices(&tc.r)
+		if tc.err == "" {
+			assert.NoError(t, err)
+			continue
+		}
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), tc.err)
+		}
+	}
+}
+
+func TestValidateSysctls(t *testing.T) {
+	for _, tc := range []struct {
+		hostConfig types.HostConfig
+		err        string
+	}{
+		{
+			hostConfig: types.HostConfig{
+				Sysctls:     map[string]string{"net.core.somaxconn": "1024", "kernel.shmmax": "1024"},
+				NetworkMode: "bridge",
+			},
+		},
+		{
+			hostConfig: types.HostConfig{Sysctls: map[string]string{"kernel.pid_max": "1024"}},
+			err:        "is not allowed",
+		},
+		{
+			hostConfig: types.HostConfig{
+				Sysctls:     map[string]string{"net.core.somaxconn": "1024"},
+				NetworkMode: "host",
+			},
+			err: "not allowed in host network namespace",
+		},
+		{
+			hostConfig: types.HostConfig{
+				Sysctls:     map[string]string{"kernel.shmmax": "1024"},
+				NetworkMode: "host",
+			},
+		},
+		{
+			hostConfig: types.HostConfig{
+				Sysctls:     map[string]string{"fs.mqueue.msg_max": "100"},
+				NetworkMode: "bridge",
+				IpcMode:     "host",
+			},
+			err: "not allowed in host ipc namespace",
+		},
+	} {
+		err := validateSysctls(&tc.hostConfig)
+		if tc.err == "" {
+			assert.NoError(t, err)
+			continue
+		}
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), tc.err)
+		}
+	}
+}
+
+func TestValidateCapabilities(t *testing.T) {
+	hostConfig := &types.HostConfig{
+		CapAdd:  []string{"net_admin", "CAP_SYS_TIME"},
+		CapDrop: []string{"all"},
+	}
+	warnings, err := validateCapabilities(hostConfig)
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, []string{"NET_ADMIN", "SYS_TIME"}, hostConfig.CapAdd)
+	assert.Equal(t, []string{"ALL"}, hostConfig.CapDrop)
+
+	_, err = validateCapabilities(&types.HostConfig{CapAdd: []string{"FOO"}})
+	assert.Error(t, err)
+	_, err = validateCapabilities(&types.HostConfig{CapDrop: []string{"FOO"}})
+	assert.Error(t, err)
+
+	warnings, err = validateCapabilities(&types.HostConfig{
+		Privileged: true,
+		CapDrop:    []string{"ALL"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "--cap-add and --cap-drop are ignored")
+}
+
+func TestValidateBlkio(t *testing.T) {
+	assert.NoError(t, validateBlkio(&types.Resources{BlkioWeight: 100}))
+	assert.Error(t, validateBlkio(&types.Resources{BlkioWeight: 5}))
+	assert.Error(t, validateBlkio(&types.Resources{
+		BlkioWeightDevice: []*types.WeightDevice{{Path: "/dev/sda", Weight: 1001}},
+	}))
+
+	assert.NoError(t, validateBlkio(&types.Resources{
+		BlkioDeviceWriteIOps: []*types.ThrottleDevice{{Path: "/dev/null", Rate: 1024}},
+	}))
+	for _, path := range []string{"sda", "/dev/not-exist-device", "/"} {
+		err := validateBlkio(&types.Resources{
+			BlkioDeviceReadBps: []*types.ThrottleDevice{{Path: path, Rate: 1024}},
+		})
+		assert.Error(t, err, path)
 	}
 }
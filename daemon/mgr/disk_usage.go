@@ -0,0 +1,103 @@
+package mgr
+
+import (
+	"context"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/pkg/log"
+	"github.com/alibaba/pouch/pkg/utils"
+	volumetypes "github.com/alibaba/pouch/storage/volume/types"
+)
+
+// DiskUsage returns the disk space used by the writable layer of containers,
+// the size of container whose writable layer is unknown is -1.
+func (mgr *ContainerManager) DiskUsage(ctx context.Context) ([]*types.DiskUsageObject, error) {
+	containers, err := mgr.List(ctx, &ContainerListOption{All: true})
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]*types.DiskUsageObject, 0, len(containers))
+	for _, c := range containers {
+		c.Lock()
+		obj := &types.DiskUsageObject{
+			ID:    c.ID,
+			Name:  c.Name,
+			InUse: c.IsRunningOrPaused(),
+			Size:  -1,
+		}
+		rootfsProvided, snapshotter, key := c.RootFSProvided, c.Config.Snapshotter, c.SnapshotKey()
+		c.Unlock()
+
+		if !rootfsProvided {
+			u, err := mgr.Client.GetSnapshotUsage(ctrd.WithSnapshotter(ctx, snapshotter), key)
+			if err != nil {
+				log.With(ctx).Warnf("failed to get disk usage of container(%s): %v", obj.ID, err)
+			} else {
+				obj.Size = u.Size
+			}
+		}
+		usage = append(usage, obj)
+	}
+	return usage, nil
+}
+
+// DiskUsage returns the disk space used by images, the images not used by
+// containers are reclaimable.
+func (mgr *ImageManager) DiskUsage(ctx context.Context, usedImages map[string]bool) ([]*types.DiskUsageObject, error) {
+	ctrdImgs := mgr.localStore.ListCtrdImageInfo()
+
+	usage := make([]*types.DiskUsageObject, 0, len(ctrdImgs))
+	for _, ctrdImg := range ctrdImgs {
+		id := ctrdImg.ID.String()
+
+		img, err := mgr.containerdImageToImageInfo(ctx, ctrdImg.ID)
+		if err != nil {
+			log.With(ctx).Warnf("failed to convert containerd image(%v) to ImageInfo during disk usage: %v", ctrdImg.ID, err)
+			continue
+		}
+
+		name := "<none>"
+		if len(img.RepoTags) > 0 {
+			name = img.RepoTags[0]
+		} else if len(img.RepoDigests) > 0 {
+			name = img.RepoDigests[0]
+		}
+
+		usage = append(usage, &types.DiskUsageObject{
+			ID:    id,
+			Name:  name,
+			Size:  img.Size,
+			InUse: usedImages[id],
+		})
+	}
+	return usage, nil
+}
+
+// DiskUsage returns the disk space used by volumes, the size of volume whose
+// path can not be walked on the host is -1.
+func (vm *VolumeManager) DiskUsage(ctx context.Context, usedVolumes map[string]bool) ([]*types.DiskUsageObject, error) {
+	volumes, err := vm.core.ListVolumes(ctx, filters.NewArgs())
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]*types.DiskUsageObject, 0, len(volumes))
+	for _, vol := range volumes {
+		obj := &types.DiskUsageObject{
+			Name:  vol.Name,
+			InUse: usedVolumes[vol.Name] || vol.Option(volumetypes.OptionRef) != "",
+			Size:  -1,
+		}
+
+		if size, err := utils.DirSize(vol.Path()); err != nil {
+			log.With(ctx).Warnf("failed to get disk usage of volume(%s): %v", vol.Name, err)
+		} else {
+			obj.Size = size
+		}
+		usage = append(usage, obj)
+	}
+	return usage, nil
+}
@@ -58,8 +58,10 @@ type ImageMgr interface {
 	// LookupImageReferences find possible image reference list.
 	LookupImageReferences(ref string) []string
 
-	// PullImage pulls images from specified registry.
-	PullImage(ctx context.Context, ref string, authConfig *types.AuthConfig, out io.Writer) error
+	// PullImage pulls images from specified registry. The platform selects
+	// the image from the manifest list, and the platform of daemon host is
+	// used if it's empty.
+	PullImage(ctx context.Context, ref, platform string, authConfig *types.AuthConfig, out io.Writer) error
 
 	// PushImage pushes image to specified registry.
 	PushImage(ctx context.Context, name, tag string, authConfig *types.AuthConfig, out io.Writer) error
@@ -76,6 +78,12 @@ type ImageMgr interface {
 	// RemoveImage deletes an image by reference.
 	RemoveImage(ctx context.Context, idOrRef string, force bool) error
 
+	// PruneImages deletes the images which are not used by containers.
+	PruneImages(ctx context.Context, filter filters.Args, usedImages map[string]bool, dryRun bool) (*types.ImagePruneResp, error)
+
+	// DiskUsage returns the disk space used by images.
+	DiskUsage(ctx context.Context, usedImages map[string]bool) ([]*types.DiskUsageObject, error)
+
 	// AddTag creates target ref for source image.
 	AddTag(ctx context.Context, sourceImage string, targetRef string) error
 
@@ -88,8 +96,11 @@ type ImageMgr interface {
 	// LoadImage creates a set of images by tarstream.
 	LoadImage(ctx context.Context, imageName string, tarstream io.ReadCloser) error
 
-	// SaveImage saves image to tarstream.
-	SaveImage(ctx context.Context, idOrRef string) (io.ReadCloser, error)
+	// SaveImage saves images to tarstream.
+	SaveImage(ctx context.Context, idOrRefs []string) (io.ReadCloser, error)
+
+	// ImportImage creates a single-layer image from the rootfs tarstream.
+	ImportImage(ctx context.Context, ref string, changes []string, tarstream io.Reader) (*types.ImageInfo, error)
 
 	// ImageHistory returns image history by reference.
 	ImageHistory(ctx context.Context, idOrRef string) ([]types.HistoryResultItem, error)
@@ -190,12 +201,17 @@ func (mgr *ImageManager) LookupImageReferences(ref string) []string {
 }
 
 // PullImage pulls images from specified registry.
-func (mgr *ImageManager) PullImage(ctx context.Context, ref string, authConfig *types.AuthConfig, out io.Writer) error {
+func (mgr *ImageManager) PullImage(ctx context.Context, ref, platform string, authConfig *types.AuthConfig, out io.Writer) error {
 	namedRef, err := reference.Parse(ref)
 	if err != nil {
 		return err
 	}
 
+	platform, err = normalizePlatform(platform)
+	if err != nil {
+		return err
+	}
+
 	pctx, cancel := context.WithCancel(ctx)
 	stream := jsonstream.New(out, nil)
 
@@ -228,7 +244,7 @@ func (mgr *ImageManager) PullImage(ctx context.Context, ref string, authConfig *
 	}
 	log.With(nil).Infof("pulling image name %v reference %v", namedRef.String(), availableRef)
 
-	img, err := mgr.client.FetchImage(pctx, resolver, availableRef, authConfig, stream)
+	img, err := mgr.client.FetchImage(pctx, resolver, availableRef, platform, authConfig, stream)
 	if err != nil {
 		writeStream(err)
 		return err
@@ -579,6 +595,7 @@ func (mgr *ImageManager) AddTag(ctx context.Context, sourceImage string, targetT
 	_, err = mgr.client.CreateImageReference(ctx, ctrdmetaimages.Image{
 		Name:   tagRef.String(),
 		Target: ctrdImg.Target(),
+		Labels: withImagePlatformLabel(nil, ctrdImg),
 	})
 	mgr.LogImageEvent(ctx, sourceImage, tagRef.String(), "tag")
 	return err
@@ -602,52 +619,21 @@ func (mgr *ImageManager) ImageHistory(ctx context.Context, idOrRef string) ([]ty
 	}
 
 	cs := img.ContentStore()
-	manifest, err := mgr.getManifest(ctx, cs, img, platforms.Default())
+	manifest, err := mgr.getManifest(ctx, cs, img, ctrd.ImagePlatformMatcher(img.Labels()))
 	if err != nil {
 		return nil, err
 	}
 
-	ociImageHistory := ociImage.History
-	lenOciImageHistory := len(ociImageHistory)
-	history := make([]types.HistoryResultItem, lenOciImageHistory)
-	// Note: ociImage History layers info and manifest layers info are all in order from bottom-most to top-most, but the
-	// user-interactive history is in order from top-most to top-bottom, so we need to reverse ociImage History traverse order.
-	j := len(manifest.Layers) - 1
-	for i := range ociImageHistory {
-		history[i] = types.HistoryResultItem{
-			Created:    ociImageHistory[lenOciImageHistory-i-1].Created.UnixNano(),
-			CreatedBy:  ociImageHistory[lenOciImageHistory-i-1].CreatedBy,
-			Author:     ociImageHistory[lenOciImageHistory-i-1].Author,
-			Comment:    ociImageHistory[lenOciImageHistory-i-1].Comment,
-			EmptyLayer: ociImageHistory[lenOciImageHistory-i-1].EmptyLayer,
-			ID:         "<missing>",
-			Size:       0,
-		}
-
-		// TODO: here we just set imageID of top image layer, we do nothing with the lower image ID, after pouch
-		// enables build/commit functionality, we should get local lower image(parent image) layer ID.
-		if i == 0 {
-			history[i].ID = desc.Digest.String()
-		}
-
-		// Note: number of manifest layers should be less than ociImage History messages due to the existence of empty layers.
-		// The size of these empty layers should be set to 0 by default.
-		if !history[i].EmptyLayer {
-			if j < 0 {
-				return nil, errors.New("number of manifest layers shouldn't be less than number of non-empty layer in history info")
-			}
-			info, err := cs.Info(ctx, manifest.Layers[j].Digest)
-			if err != nil {
-				return nil, err
-			}
-			history[i].Size = info.Size
-			j--
+	// layers info are in order from bottom-most to top-most.
+	layerSizes := make([]int64, 0, len(manifest.Layers))
+	for _, layer := range manifest.Layers {
+		info, err := cs.Info(ctx, layer.Digest)
+		if err != nil {
+			return nil, err
 		}
+		layerSizes = append(layerSizes, info.Size)
 	}
-	if j != -1 {
-		return nil, errors.New("number of manifest layers shouldn't be greater than number of non-empty layer in history info")
-	}
-	return history, nil
+	return imageHistory(desc.Digest, ociImage.History, layerSizes)
 }
 
 // CheckReference returns image ID and actual reference.
@@ -815,9 +801,9 @@ func (mgr *ImageManager) StoreImageReference(ctx context.Context, img containerd
 		if _, err := mgr.client.CreateImageReference(ctx, ctrdmetaimages.Image{
 			Name:   digRef.String(),
 			Target: img.Target(),
-			Labels: map[string]string{
+			Labels: withImagePlatformLabel(map[string]string{
 				labelDigestRef: "managed",
-			},
+			}, img),
 		}); err != nil && !errtypes.IsAlreadyExisted(err) {
 			return err
 		}
@@ -0,0 +1,120 @@
+package mgr
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/log"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// ImportImage creates a single-layer image from the rootfs tarstream, and the
+// changes are the Dockerfile instructions applied to the config of the image.
+func (mgr *ImageManager) ImportImage(ctx context.Context, ref string, changes []string, tarstream io.Reader) (*types.ImageInfo, error) {
+	ref = addDefaultRegistryIfMissing(ref, mgr.DefaultRegistry, mgr.DefaultNamespace)
+
+	namedRef, err := parseTagReference(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := mgr.validateTagReference(namedRef); err != nil {
+		return nil, err
+	}
+
+	var config ocispec.ImageConfig
+	if err := applyImageChanges(&config, changes); err != nil {
+		return nil, err
+	}
+
+	_, err = mgr.client.ImportRootfs(ctx, &ctrd.ImportRootfsConfig{
+		Reference: namedRef.String(),
+		Comment:   "Imported from tarball",
+		Config:    config,
+	}, tarstream)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to import image from rootfs tarstream")
+	}
+
+	img, err := mgr.client.GetImage(ctx, namedRef.String())
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to get new created image %s from containerd", namedRef.String())
+	}
+
+	if err := mgr.StoreImageReference(ctx, img); err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to store reference %s", namedRef.String())
+	}
+
+	imgInfo, err := mgr.GetImage(ctx, namedRef.String())
+	if err != nil {
+		return nil, err
+	}
+
+	log.With(ctx).Infof("import image %s(%s) from rootfs tarstream", namedRef.String(), imgInfo.ID)
+	mgr.LogImageEvent(ctx, imgInfo.ID, namedRef.String(), "import")
+	return imgInfo, nil
+}
+
+// applyImageChanges applies the Dockerfile instructions to the image config.
+// Only CMD, ENTRYPOINT, ENV, USER and WORKDIR are supported.
+func applyImageChanges(config *ocispec.ImageConfig, changes []string) error {
+	for _, change := range changes {
+		parts := strings.SplitN(strings.TrimSpace(change), " ", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+			return pkgerrors.Wrapf(errtypes.ErrInvalidParam, "invalid change %q, must be in the format of INSTRUCTION ARGUMENTS", change)
+		}
+		instruction, args := strings.ToUpper(parts[0]), strings.TrimSpace(parts[1])
+
+		switch instruction {
+		case "CMD":
+			cmd, err := parseCommandInstruction(args)
+			if err != nil {
+				return pkgerrors.Wrapf(errtypes.ErrInvalidParam, "invalid change %q: %v", change, err)
+			}
+			config.Cmd = cmd
+		case "ENTRYPOINT":
+			entrypoint, err := parseCommandInstruction(args)
+			if err != nil {
+				return pkgerrors.Wrapf(errtypes.ErrInvalidParam, "invalid change %q: %v", change, err)
+			}
+			config.Entrypoint = entrypoint
+		case "ENV":
+			env := args
+			if !strings.Contains(args, "=") {
+				kv := strings.SplitN(args, " ", 2)
+				if len(kv) != 2 {
+					return pkgerrors.Wrapf(errtypes.ErrInvalidParam, "invalid change %q, ENV must have a value", change)
+				}
+				env = kv[0] + "=" + strings.TrimSpace(kv[1])
+			}
+			config.Env = append(config.Env, env)
+		case "USER":
+			config.User = args
+		case "WORKDIR":
+			config.WorkingDir = args
+		default:
+			return pkgerrors.Wrapf(errtypes.ErrInvalidParam, "invalid change %q, unsupported instruction %s", change, instruction)
+		}
+	}
+	return nil
+}
+
+// parseCommandInstruction parses the arguments of CMD or ENTRYPOINT, which
+// may be in exec form, like ["executable", "param"], or shell form.
+func parseCommandInstruction(args string) ([]string, error) {
+	if strings.HasPrefix(args, "[") {
+		var cmd []string
+		if err := json.Unmarshal([]byte(args), &cmd); err != nil {
+			return nil, err
+		}
+		return cmd, nil
+	}
+	return []string{"/bin/sh", "-c", args}, nil
+}
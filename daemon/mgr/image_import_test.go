@@ -0,0 +1,37 @@
+package mgr
+
+import (
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyImageChanges(t *testing.T) {
+	var config ocispec.ImageConfig
+	err := applyImageChanges(&config, []string{
+		`CMD ["/bin/sh", "-c", "echo hello"]`,
+		"entrypoint /docker-entrypoint.sh",
+		"ENV foo=bar",
+		"ENV a b c",
+		"USER nobody",
+		"WORKDIR /root",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ocispec.ImageConfig{
+		Cmd:        []string{"/bin/sh", "-c", "echo hello"},
+		Entrypoint: []string{"/bin/sh", "-c", "/docker-entrypoint.sh"},
+		Env:        []string{"foo=bar", "a=b c"},
+		User:       "nobody",
+		WorkingDir: "/root",
+	}, config)
+
+	for _, change := range []string{
+		"CMD",
+		"CMD [\"echo\"",
+		"ENV foo",
+		"EXPOSE 80",
+	} {
+		assert.Error(t, applyImageChanges(&ocispec.ImageConfig{}, []string{change}), change)
+	}
+}
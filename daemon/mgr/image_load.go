@@ -6,11 +6,12 @@ import (
 	"io"
 	"time"
 
+	"github.com/alibaba/pouch/ctrd"
 	"github.com/alibaba/pouch/pkg/multierror"
 	"github.com/alibaba/pouch/pkg/reference"
 
-	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/images/archive"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	pkgerrors "github.com/pkg/errors"
 )
 
@@ -18,13 +19,16 @@ import (
 func (mgr *ImageManager) LoadImage(ctx context.Context, imageName string, tarstream io.ReadCloser) error {
 	defer tarstream.Close()
 
-	var opts []containerd.ImportOpt
+	var (
+		refTranslator func(string) string
+		renamed       bool
+	)
 
 	// NOTE: for the docker image, we should pass empty image name because
 	// the containerd will help us to get the original name.
 	if imageName == "" {
 		imageName = fmt.Sprintf("import-%s", time.Now().Format("2006-01-02"))
-		opts = append(opts, containerd.WithImageRefTranslator(archive.AddRefPrefix(imageName)))
+		refTranslator = archive.AddRefPrefix(imageName)
 	} else {
 		// When provided, filter out references which do not match
 
@@ -42,10 +46,22 @@ func (mgr *ImageManager) LoadImage(ctx context.Context, imageName string, tarstr
 		if !reference.IsNamedOnly(namedRef) {
 			return fmt.Errorf("the image name should not contains any digest or tag information")
 		}
-		opts = append(opts, containerd.WithImageRefTranslator(archive.FilterRefPrefix(imageName)))
+		refTranslator = archive.FilterRefPrefix(imageName)
+		renamed = true
 	}
 
-	imgs, err := mgr.client.ImportImage(ctx, tarstream, opts...)
+	imgs, err := mgr.client.ImportImage(ctx, tarstream, func(desc ocispec.Descriptor) string {
+		// the image saved by pouch keeps its full reference, restore it
+		// unless the image is loaded with another name.
+		if name := desc.Annotations[ctrd.AnnotationImageName]; name != "" && !renamed {
+			return name
+		}
+
+		if ref := desc.Annotations[ocispec.AnnotationRefName]; ref != "" {
+			return refTranslator(ref)
+		}
+		return ""
+	})
 	if err != nil {
 		return pkgerrors.Wrap(err, "failed to import image into containerd by tarstream")
 	}
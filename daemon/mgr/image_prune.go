@@ -0,0 +1,122 @@
+package mgr
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/log"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// the filter tags set allowed when pouch image prune --filter
+var acceptedImagePruneFilterTags = map[string]bool{
+	"dangling": true,
+	"until":    true,
+	"label":    true,
+}
+
+// imagePruneOptions is the parsed filter of prune images.
+type imagePruneOptions struct {
+	danglingOnly bool
+	until        time.Time
+	filter       filters.Args
+}
+
+// parseImagePruneFilter parses the filter of prune images.
+func parseImagePruneFilter(filter filters.Args, now time.Time) (*imagePruneOptions, error) {
+	if err := filter.Validate(acceptedImagePruneFilterTags); err != nil {
+		return nil, pkgerrors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	opts := &imagePruneOptions{
+		danglingOnly: true,
+		filter:       filter,
+	}
+
+	if dangling := filter.Get("dangling"); len(dangling) > 0 {
+		// refuse undefined behavior
+		if len(dangling) > 1 {
+			return nil, pkgerrors.Wrapf(errtypes.ErrInvalidParam, "can't use dangling filter more than one")
+		}
+
+		v, err := strconv.ParseBool(dangling[0])
+		if err != nil {
+			return nil, pkgerrors.Wrapf(errtypes.ErrInvalidParam, "invalid dangling filter %q", dangling[0])
+		}
+		opts.danglingOnly = v
+	}
+
+	until, err := parsePruneUntilFilter(filter, now)
+	if err != nil {
+		return nil, err
+	}
+	opts.until = until
+
+	return opts, nil
+}
+
+// match returns true if the image should be pruned.
+func (opts *imagePruneOptions) match(img types.ImageInfo, created *time.Time) bool {
+	if opts.danglingOnly && len(img.RepoTags) > 0 {
+		return false
+	}
+
+	// keep the image if it is unknown when the image is created.
+	if !opts.until.IsZero() && (created == nil || !created.Before(opts.until)) {
+		return false
+	}
+
+	var labels map[string]string
+	if img.Config != nil {
+		labels = img.Config.Labels
+	}
+	return opts.filter.MatchKVList("label", labels)
+}
+
+// PruneImages deletes the images which are not used by containers. By
+// default, only the dangling images, which have no tag, are deleted.
+func (mgr *ImageManager) PruneImages(ctx context.Context, filter filters.Args, usedImages map[string]bool, dryRun bool) (*types.ImagePruneResp, error) {
+	opts, err := parseImagePruneFilter(filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &types.ImagePruneResp{
+		ImagesDeleted: []string{},
+	}
+
+	for _, ctrdImg := range mgr.localStore.ListCtrdImageInfo() {
+		id := ctrdImg.ID.String()
+		if usedImages[id] {
+			continue
+		}
+
+		img, err := mgr.containerdImageToImageInfo(ctx, ctrdImg.ID)
+		if err != nil {
+			log.With(ctx).Warnf("failed to convert containerd image(%v) to ImageInfo during prune images: %v", ctrdImg.ID, err)
+			continue
+		}
+
+		if !opts.match(img, ctrdImg.OCISpec.Created) {
+			continue
+		}
+
+		if !dryRun {
+			if err := mgr.RemoveImage(ctx, id, true); err != nil {
+				log.With(ctx).Warnf("failed to remove image(%s) during prune images: %v", id, err)
+				continue
+			}
+			mgr.LogImageEvent(ctx, id, "", "delete")
+		}
+
+		resp.ImagesDeleted = append(resp.ImagesDeleted, id)
+		resp.SpaceReclaimed += img.Size
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,71 @@
+package mgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/apis/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImagePruneFilter(t *testing.T) {
+	now := time.Now()
+
+	opts, err := parseImagePruneFilter(filters.NewArgs(), now)
+	assert.NoError(t, err)
+	assert.True(t, opts.danglingOnly)
+	assert.True(t, opts.until.IsZero())
+
+	opts, err = parseImagePruneFilter(filters.NewArgs(
+		filters.Arg("dangling", "false"),
+		filters.Arg("until", "1h"),
+	), now)
+	assert.NoError(t, err)
+	assert.False(t, opts.danglingOnly)
+	assert.Equal(t, now.Add(-time.Hour).Unix(), opts.until.Unix())
+
+	for _, filter := range []filters.Args{
+		filters.NewArgs(filters.Arg("reference", "busybox")),
+		filters.NewArgs(filters.Arg("dangling", "maybe")),
+		filters.NewArgs(filters.Arg("dangling", "true"), filters.Arg("dangling", "false")),
+		filters.NewArgs(filters.Arg("until", "yesterday")),
+	} {
+		_, err := parseImagePruneFilter(filter, now)
+		assert.Error(t, err)
+	}
+}
+
+func TestImagePruneOptionsMatch(t *testing.T) {
+	now := time.Now()
+	dangling := types.ImageInfo{
+		Config: &types.ContainerConfig{Labels: map[string]string{"env": "test"}},
+	}
+	tagged := types.ImageInfo{RepoTags: []string{"busybox:latest"}}
+
+	old := now.Add(-2 * time.Hour)
+
+	opts, err := parseImagePruneFilter(filters.NewArgs(), now)
+	assert.NoError(t, err)
+	assert.True(t, opts.match(dangling, &now))
+	assert.False(t, opts.match(tagged, &now))
+
+	opts, err = parseImagePruneFilter(filters.NewArgs(filters.Arg("dangling", "false")), now)
+	assert.NoError(t, err)
+	assert.True(t, opts.match(tagged, &now))
+
+	opts, err = parseImagePruneFilter(filters.NewArgs(filters.Arg("until", "1h")), now)
+	assert.NoError(t, err)
+	assert.False(t, opts.match(dangling, &now))
+	assert.False(t, opts.match(dangling, nil))
+	assert.True(t, opts.match(dangling, &old))
+
+	opts, err = parseImagePruneFilter(filters.NewArgs(filters.Arg("label", "env=test")), now)
+	assert.NoError(t, err)
+	assert.True(t, opts.match(dangling, &now))
+
+	opts, err = parseImagePruneFilter(filters.NewArgs(filters.Arg("label", "env=prod")), now)
+	assert.NoError(t, err)
+	assert.False(t, opts.match(dangling, &now))
+}
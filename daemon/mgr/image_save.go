@@ -4,21 +4,43 @@ import (
 	"context"
 	"io"
 
-	ociimage "github.com/containerd/containerd/images/oci"
+	"github.com/alibaba/pouch/pkg/errtypes"
+
+	pkgerrors "github.com/pkg/errors"
 )
 
-// SaveImage saves image to the oci.v1 format tarstream.
-func (mgr *ImageManager) SaveImage(ctx context.Context, idOrRef string) (io.ReadCloser, error) {
-	_, _, ref, err := mgr.CheckReference(ctx, idOrRef)
-	if err != nil {
-		return nil, err
+// SaveImage saves images to the oci.v1 format tarstream.
+func (mgr *ImageManager) SaveImage(ctx context.Context, idOrRefs []string) (io.ReadCloser, error) {
+	if len(idOrRefs) == 0 {
+		return nil, pkgerrors.Wrap(errtypes.ErrInvalidParam, "no image is specified to save")
+	}
+
+	var (
+		refs   = make([]string, len(idOrRefs))
+		saved  = make(map[string]bool, len(idOrRefs))
+		toSave []string
+	)
+	for i, idOrRef := range idOrRefs {
+		_, _, ref, err := mgr.CheckReference(ctx, idOrRef)
+		if err != nil {
+			return nil, err
+		}
+
+		refs[i] = ref.String()
+		if !saved[refs[i]] {
+			saved[refs[i]] = true
+			toSave = append(toSave, refs[i])
+		}
 	}
 
-	exportedStream, err := mgr.client.SaveImage(ctx, &ociimage.V1Exporter{}, ref.String())
+	exportedStream, err := mgr.client.SaveImage(ctx, toSave)
 	if err != nil {
 		return nil, err
 	}
-	mgr.LogImageEvent(ctx, idOrRef, ref.String(), "save")
+
+	for i, idOrRef := range idOrRefs {
+		mgr.LogImageEvent(ctx, idOrRef, refs[i], "save")
+	}
 
 	return exportedStream, nil
 }
@@ -7,13 +7,17 @@ import (
 	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/ctrd"
+	"github.com/alibaba/pouch/pkg/errtypes"
 	"github.com/alibaba/pouch/pkg/reference"
 
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/content"
 	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
 	digest "github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	pkgerrors "github.com/pkg/errors"
 )
 
 var legacyDockerConfigMediaType = "application/octet-stream"
@@ -121,3 +125,80 @@ func uniqueLocatorReference(refs []reference.Named) bool {
 	}
 	return true
 }
+
+// imageHistory returns the history of image in order from top-most to
+// bottom-most. Both of the history and layerSizes are in order from
+// bottom-most to top-most, and the empty layers in history have no size.
+//
+// NOTE: the image without history info, which may be built by other tools,
+// is regarded as one history per layer.
+func imageHistory(id digest.Digest, history []ocispec.History, layerSizes []int64) ([]types.HistoryResultItem, error) {
+	if len(history) == 0 {
+		history = make([]ocispec.History, len(layerSizes))
+	}
+
+	res := make([]types.HistoryResultItem, len(history))
+	j := len(layerSizes) - 1
+	for i := range history {
+		h := history[len(history)-i-1]
+		res[i] = types.HistoryResultItem{
+			CreatedBy:  h.CreatedBy,
+			Author:     h.Author,
+			Comment:    h.Comment,
+			EmptyLayer: h.EmptyLayer,
+			ID:         "<missing>",
+		}
+		if h.Created != nil {
+			res[i].Created = h.Created.UnixNano()
+		}
+
+		// TODO: here we just set imageID of top image layer, we do nothing with the lower image ID, after pouch
+		// enables build/commit functionality, we should get local lower image(parent image) layer ID.
+		if i == 0 {
+			res[i].ID = id.String()
+		}
+
+		// Note: number of layers should be less than history messages due to the existence of empty layers.
+		if !h.EmptyLayer {
+			if j < 0 {
+				return nil, fmt.Errorf("number of manifest layers shouldn't be less than number of non-empty layer in history info")
+			}
+			res[i].Size = layerSizes[j]
+			j--
+		}
+	}
+	if j != -1 {
+		return nil, fmt.Errorf("number of manifest layers shouldn't be greater than number of non-empty layer in history info")
+	}
+	return res, nil
+}
+
+// normalizePlatform validates the platform in the format of os/arch[/variant]
+// and returns the normalized one. The empty platform means the platform of
+// daemon host.
+func normalizePlatform(platform string) (string, error) {
+	if platform == "" {
+		return "", nil
+	}
+
+	p, err := platforms.Parse(platform)
+	if err != nil {
+		return "", pkgerrors.Wrapf(errtypes.ErrInvalidParam, "invalid platform %s: %v", platform, err)
+	}
+	return platforms.Format(p), nil
+}
+
+// withImagePlatformLabel copies the platform label of the image into labels,
+// so that the new reference selects the same platform as the image.
+func withImagePlatformLabel(labels map[string]string, img containerd.Image) map[string]string {
+	platform, ok := img.Labels()[ctrd.LabelImagePlatform]
+	if !ok {
+		return labels
+	}
+
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[ctrd.LabelImagePlatform] = platform
+	return labels
+}
@@ -2,8 +2,13 @@ package mgr
 
 import (
 	"testing"
+	"time"
 
+	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/pkg/reference"
+
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -85,3 +90,59 @@ func TestUniqueLocatorReference(t *testing.T) {
 		assert.Equal(t, uniqueLocatorReference(refs), tc.expect)
 	}
 }
+
+func TestImageHistory(t *testing.T) {
+	id := digest.FromString("image")
+	created := time.Unix(0, 100)
+
+	history, err := imageHistory(id, []ocispec.History{
+		{Created: &created, CreatedBy: "/bin/sh -c #(nop) ADD file:foo in /"},
+		{CreatedBy: "/bin/sh -c #(nop)  CMD [\"sh\"]", EmptyLayer: true},
+		{Created: &created, CreatedBy: "/bin/sh -c echo foo > /foo", Comment: "foo"},
+	}, []int64{1024, 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []types.HistoryResultItem{
+		{ID: id.String(), Created: 100, CreatedBy: "/bin/sh -c echo foo > /foo", Comment: "foo", Size: 10},
+		{ID: "<missing>", CreatedBy: "/bin/sh -c #(nop)  CMD [\"sh\"]", EmptyLayer: true},
+		{ID: "<missing>", Created: 100, CreatedBy: "/bin/sh -c #(nop) ADD file:foo in /", Size: 1024},
+	}, history)
+
+	// image without history info
+	history, err = imageHistory(id, nil, []int64{1024, 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []types.HistoryResultItem{
+		{ID: id.String(), Size: 10},
+		{ID: "<missing>", Size: 1024},
+	}, history)
+
+	// mismatched layers
+	_, err = imageHistory(id, []ocispec.History{{}}, []int64{1024, 10})
+	assert.Error(t, err)
+
+	_, err = imageHistory(id, []ocispec.History{{}, {}}, []int64{1024})
+	assert.Error(t, err)
+}
+
+func TestNormalizePlatform(t *testing.T) {
+	for _, tc := range []struct {
+		platform string
+		expect   string
+		hasErr   bool
+	}{
+		{platform: "", expect: ""},
+		{platform: "linux/amd64", expect: "linux/amd64"},
+		{platform: "linux/x86_64", expect: "linux/amd64"},
+		{platform: "linux/aarch64", expect: "linux/arm64"},
+		{platform: "linux/arm/v7", expect: "linux/arm/v7"},
+		{platform: "linux/amd64/v1/v2", hasErr: true},
+		{platform: "linux/*", hasErr: true},
+	} {
+		got, err := normalizePlatform(tc.platform)
+		if tc.hasErr {
+			assert.Error(t, err, tc.platform)
+			continue
+		}
+		assert.NoError(t, err, tc.platform)
+		assert.Equal(t, tc.expect, got, tc.platform)
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/alibaba/pouch/apis/filters"
 	"github.com/alibaba/pouch/apis/opts"
 	apitypes "github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/daemon/config"
@@ -29,6 +31,12 @@ import (
 	"github.com/pkg/errors"
 )
 
+// the filter tags set allowed when pouch network prune --filter
+var acceptedNetworkPruneFilterTags = map[string]bool{
+	"until": true,
+	"label": true,
+}
+
 // NetworkMgr defines interface to manage container network.
 type NetworkMgr interface {
 	// Create is used to create network.
@@ -43,6 +51,9 @@ type NetworkMgr interface {
 	// NetworkRemove is used to delete an existing network.
 	Remove(ctx context.Context, name string) error
 
+	// Prune deletes the user-defined networks which have no endpoints.
+	Prune(ctx context.Context, filter filters.Args) (*apitypes.NetworkPruneResp, error)
+
 	// EndpointCreate is used to create network endpoint.
 	EndpointCreate(ctx context.Context, endpoint *types.Endpoint) (string, error)
 
@@ -137,6 +148,12 @@ func (nm *NetworkManager) Create(ctx context.Context, create apitypes.NetworkCre
 
 	net, err := nm.controller.NewNetwork(driver, name, id, nwOptions...)
 	if err != nil {
+		// the ipam errors, such as pool overlapping, are caused by the
+		// invalid network config.
+		switch err.(type) {
+		case networktypes.BadRequestError, networktypes.ForbiddenError:
+			return nil, errors.Wrapf(errtypes.ErrInvalidParam, "failed to create network: %v", err)
+		}
 		return nil, errors.Wrap(err, "failed to create network")
 	}
 
@@ -209,6 +226,45 @@ func (nm *NetworkManager) Remove(ctx context.Context, name string) error {
 	return nil
 }
 
+// Prune deletes the user-defined networks which have no endpoints, the
+// predefined networks are never deleted.
+func (nm *NetworkManager) Prune(ctx context.Context, filter filters.Args) (*apitypes.NetworkPruneResp, error) {
+	if err := filter.Validate(acceptedNetworkPruneFilterTags); err != nil {
+		return nil, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	until, err := parsePruneUntilFilter(filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &apitypes.NetworkPruneResp{
+		NetworksDeleted: []string{},
+	}
+	for _, nw := range nm.controller.Networks() {
+		name := nw.Name()
+		if !IsUserDefined(name) || len(nw.Endpoints()) > 0 {
+			continue
+		}
+
+		if !until.IsZero() && !nw.Info().Created().Before(until) {
+			continue
+		}
+
+		if !filter.MatchKVList("label", nw.Info().Labels()) {
+			continue
+		}
+
+		if err := nm.Remove(ctx, name); err != nil {
+			log.With(ctx).Warnf("failed to remove network(%s) during prune networks: %v", name, err)
+			continue
+		}
+		resp.NetworksDeleted = append(resp.NetworksDeleted, name)
+	}
+
+	return resp, nil
+}
+
 // GetNetworkByName returns the information of network that specified name.
 func (nm *NetworkManager) GetNetworkByName(name string) (*types.Network, error) {
 	n, err := nm.controller.NetworkByName(name)
@@ -549,6 +605,9 @@ func networkOptions(create apitypes.NetworkCreateConfig) ([]libnetwork.NetworkOp
 
 	if networkCreate.IPAM != nil {
 		ipam := networkCreate.IPAM
+		if err := opts.ValidateIPAMConfigs(ipam.Config); err != nil {
+			return nil, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+		}
 		v4Conf, v6Conf, err := getIpamConfig(ipam.Config)
 		if err != nil {
 			return nil, err
@@ -643,6 +702,10 @@ func buildSandboxOptions(config network.Config, endpoint *types.Endpoint) ([]lib
 		dns            []string
 		dnsSearch      []string
 		dnsOptions     []string
+
+		epDNS        = endpoint.DNS
+		epDNSSearch  = endpoint.DNSSearch
+		epDNSOptions = endpoint.DNSOptions
 	)
 
 	sandboxOptions = append(sandboxOptions, libnetwork.OptionHostname(string(endpoint.Hostname)), libnetwork.OptionDomainname(endpoint.Domainname))
@@ -652,9 +715,10 @@ func buildSandboxOptions(config network.Config, endpoint *types.Endpoint) ([]lib
 		if len(endpoint.ExtraHosts) == 0 {
 			sandboxOptions = append(sandboxOptions, libnetwork.OptionOriginHostsPath("/etc/hosts"))
 		}
-		if len(endpoint.DNS) == 0 && len(config.DNS) == 0 &&
-			len(endpoint.DNSSearch) == 0 && len(config.DNSSearch) == 0 &&
-			len(endpoint.DNSOptions) == 0 && len(config.DNSOptions) == 0 {
+
+		// the dns settings of container are ignored in host network mode.
+		epDNS, epDNSSearch, epDNSOptions = nil, nil, nil
+		if len(config.DNS) == 0 && len(config.DNSSearch) == 0 && len(config.DNSOptions) == 0 {
 			sandboxOptions = append(sandboxOptions, libnetwork.OptionOriginResolvConfPath("/etc/resolv.conf"))
 		}
 	} else {
@@ -665,8 +729,8 @@ func buildSandboxOptions(config network.Config, endpoint *types.Endpoint) ([]lib
 	sandboxOptions = append(sandboxOptions, libnetwork.OptionResolvConfPath(endpoint.ResolvConfPath))
 
 	// parse DNS
-	if len(endpoint.DNS) > 0 {
-		dns = endpoint.DNS
+	if len(epDNS) > 0 {
+		dns = epDNS
 	} else if len(config.DNS) > 0 {
 		dns = config.DNS
 	}
@@ -675,8 +739,8 @@ func buildSandboxOptions(config network.Config, endpoint *types.Endpoint) ([]lib
 	}
 
 	// parse DNS Search
-	if len(endpoint.DNSSearch) > 0 {
-		dnsSearch = endpoint.DNSSearch
+	if len(epDNSSearch) > 0 {
+		dnsSearch = epDNSSearch
 	} else if len(config.DNSSearch) > 0 {
 		dnsSearch = config.DNSSearch
 	}
@@ -685,8 +749,8 @@ func buildSandboxOptions(config network.Config, endpoint *types.Endpoint) ([]lib
 	}
 
 	// parse DNS Options
-	if len(endpoint.DNSOptions) > 0 {
-		dnsOptions = endpoint.DNSOptions
+	if len(epDNSOptions) > 0 {
+		dnsOptions = epDNSOptions
 	} else if len(config.DNSOptions) > 0 {
 		dnsOptions = config.DNSOptions
 	}
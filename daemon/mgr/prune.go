@@ -0,0 +1,37 @@
+package mgr
+
+import (
+	"time"
+
+	"github.com/alibaba/pouch/apis/filters"
+	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/utils"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// parsePruneUntilFilter parses the until filter of prune, only the objects
+// created before the returned time are pruned. The zero time is returned if
+// the filter is not set.
+func parsePruneUntilFilter(filter filters.Args, now time.Time) (time.Time, error) {
+	until := filter.Get("until")
+	if len(until) == 0 {
+		return time.Time{}, nil
+	}
+
+	// refuse undefined behavior
+	if len(until) > 1 {
+		return time.Time{}, pkgerrors.Wrapf(errtypes.ErrInvalidParam, "can't use until filter more than one")
+	}
+
+	ts, err := utils.GetUnixTimestamp(until[0], now)
+	if err != nil {
+		return time.Time{}, pkgerrors.Wrapf(errtypes.ErrInvalidParam, "invalid until filter %q: %v", until[0], err)
+	}
+
+	sec, nano, err := utils.ParseTimestamp(ts, 0)
+	if err != nil {
+		return time.Time{}, pkgerrors.Wrapf(errtypes.ErrInvalidParam, "invalid until filter %q: %v", until[0], err)
+	}
+	return time.Unix(sec, nano), nil
+}
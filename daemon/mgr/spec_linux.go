@@ -34,17 +34,13 @@ const (
 	defaultCgroupParent = "pouch"
 )
 
-// Setup linux-platform-sepecific specification.
-func populatePlatform(ctx context.Context, c *Container, specWrapper *SpecWrapper) error {
-	s := specWrapper.s
-	if s.Linux == nil {
-		s.Linux = &specs.Linux{}
-	}
-
+// containerCgroupsPath returns the cgroups path of container in the spec, which
+// is in the format of "slice:prefix:name" with systemd cgroup driver.
+func containerCgroupsPath(c *Container, useSystemd bool) string {
 	// same with containerd use. or make it a variable
 	// set default cgroup parent
 	cgroupsParent := "/default"
-	if specWrapper.useSystemd {
+	if useSystemd {
 		cgroupsParent = "system.slice"
 	}
 
@@ -52,11 +48,20 @@ func populatePlatform(ctx context.Context, c *Container, specWrapper *SpecWrappe
 		cgroupsParent = filepath.Clean(c.HostConfig.CgroupParent)
 	}
 
-	if specWrapper.useSystemd {
-		s.Linux.CgroupsPath = cgroupsParent + ":" + defaultCgroupParent + ":" + c.ID
-	} else {
-		s.Linux.CgroupsPath = filepath.Clean(filepath.Join("/", cgroupsParent, c.ID))
+	if useSystemd {
+		return cgroupsParent + ":" + defaultCgroupParent + ":" + c.ID
 	}
+	return filepath.Clean(filepath.Join("/", cgroupsParent, c.ID))
+}
+
+// Setup linux-platform-sepecific specification.
+func populatePlatform(ctx context.Context, c *Container, specWrapper *SpecWrapper) error {
+	s := specWrapper.s
+	if s.Linux == nil {
+		s.Linux = &specs.Linux{}
+	}
+
+	s.Linux.CgroupsPath = containerCgroupsPath(c, specWrapper.useSystemd)
 
 	s.Linux.Sysctl = c.HostConfig.Sysctls
 
@@ -250,6 +255,27 @@ func setupDevices(ctx context.Context, c *Container, s *specs.Spec) error {
 			devs = append(devs, d...)
 			devPermissions = append(devPermissions, dPermissions...)
 		}
+
+		for _, rule := range c.HostConfig.DeviceCgroupRules {
+			devType, major, minor, access, err := opts.ParseDeviceCgroupRule(rule)
+			if err != nil {
+				return err
+			}
+
+			devCgroup := specs.LinuxDeviceCgroup{
+				Allow:  true,
+				Type:   devType,
+				Access: access,
+			}
+			// the wildcard is represented as nil in spec.
+			if major >= 0 {
+				devCgroup.Major = &major
+			}
+			if minor >= 0 {
+				devCgroup.Minor = &minor
+			}
+			devPermissions = append(devPermissions, devCgroup)
+		}
 	}
 
 	s.Linux.Devices = append(s.Linux.Devices, devs...)
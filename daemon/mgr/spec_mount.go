@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/alibaba/pouch/apis/opts"
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/pkg/log"
 	"github.com/pkg/errors"
@@ -50,6 +51,12 @@ func overrideDefaultMount(mounts []specs.Mount, c *Container, s *specs.Spec) ([]
 				break
 			}
 		}
+		for _, tm := range tmpfsMounts(c) {
+			if sm.Destination == tm.Destination {
+				dup = true
+				break
+			}
+		}
 		if dup {
 			continue
 		}
@@ -110,6 +117,15 @@ func mergeContainerMount(mounts []specs.Mount, c *Container, s *specs.Spec) ([]s
 		})
 	}
 
+	for _, tm := range tmpfsMounts(c) {
+		for _, sm := range mounts {
+			if sm.Destination == tm.Destination {
+				return nil, fmt.Errorf("duplicate mount point: %s", tm.Destination)
+			}
+		}
+		mounts = append(mounts, tm)
+	}
+
 	// if disable hostfiles, we will not mount the hosts files into container.
 	if !c.Config.DisableNetworkFiles {
 		mounts = append(mounts, generateNetworkMounts(c)...)
@@ -118,6 +134,69 @@ func mergeContainerMount(mounts []specs.Mount, c *Container, s *specs.Spec) ([]s
 	return mounts, nil
 }
 
+// tmpfsMounts returns the tmpfs mounts specified by long-form mounts and
+// --tmpfs of container.
+func tmpfsMounts(c *Container) []specs.Mount {
+	if c.HostConfig == nil {
+		return nil
+	}
+
+	var mounts []specs.Mount
+	for _, m := range c.HostConfig.Mounts {
+		if m == nil || m.Type != types.MountTypeTmpfs {
+			continue
+		}
+
+		opts := []string{"nosuid", "nodev"}
+		if m.ReadOnly {
+			opts = append(opts, "ro")
+		}
+		if m.TmpfsOptions != nil && m.TmpfsOptions.SizeBytes > 0 {
+			opts = append(opts, fmt.Sprintf("size=%d", m.TmpfsOptions.SizeBytes))
+		}
+
+		mounts = append(mounts, specs.Mount{
+			Source:      "tmpfs",
+			Destination: filepath.Clean(m.Target),
+			Type:        "tmpfs",
+			Options:     opts,
+		})
+	}
+
+	paths := make([]string, 0, len(c.HostConfig.Tmpfs))
+	for path := range c.HostConfig.Tmpfs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		// the options have been validated when the container is created.
+		options, err := opts.ParseTmpfsOptions(c.HostConfig.Tmpfs[path])
+		if err != nil {
+			log.With(nil).Warnf("invalid options of tmpfs %s, ignore it: %v", path, err)
+			continue
+		}
+
+		mounts = append(mounts, specs.Mount{
+			Source:      "tmpfs",
+			Destination: filepath.Clean(path),
+			Type:        "tmpfs",
+			Options:     options,
+		})
+	}
+	return mounts
+}
+
+// isTmpfsMountPoint returns true if the destination is a tmpfs mount of container.
+func isTmpfsMountPoint(c *Container, dest string) bool {
+	for _, tm := range tmpfsMounts(c) {
+		if tm.Destination == filepath.Clean(dest) {
+			return true
+		}
+	}
+	return false
+}
+
 // setupMounts create mount spec.
 func setupMounts(ctx context.Context, c *Container, s *specs.Spec) error {
 	var (
@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/alibaba/pouch/apis/types"
+
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 )
 
@@ -39,3 +41,35 @@ func Test_sortMounts(t *testing.T) {
 		})
 	}
 }
+
+func Test_tmpfsMounts(t *testing.T) {
+	c := &Container{
+		HostConfig: &types.HostConfig{
+			Mounts: []*types.Mount{
+				{Type: "bind", Source: "/data", Target: "/data"},
+				{Type: "tmpfs", Target: "/run/", TmpfsOptions: &types.MountTmpfsOptions{SizeBytes: 1024}},
+				{Type: "tmpfs", Target: "/cache", ReadOnly: true},
+			},
+			Tmpfs: map[string]string{
+				"/tmp":  "exec,size=1m",
+				"/data": "",
+			},
+		},
+	}
+
+	want := []specs.Mount{
+		{Source: "tmpfs", Destination: "/run", Type: "tmpfs", Options: []string{"nosuid", "nodev", "size=1024"}},
+		{Source: "tmpfs", Destination: "/cache", Type: "tmpfs", Options: []string{"nosuid", "nodev", "ro"}},
+		{Source: "tmpfs", Destination: "/data", Type: "tmpfs", Options: []string{"noexec", "nosuid", "nodev", "size=67108864"}},
+		{Source: "tmpfs", Destination: "/tmp", Type: "tmpfs", Options: []string{"exec", "nosuid", "nodev", "size=1048576"}},
+	}
+	if got := tmpfsMounts(c); !reflect.DeepEqual(got, want) {
+		t.Errorf("tmpfsMounts() = %v, want %v", got, want)
+	}
+
+	for dest, want := range map[string]bool{"/tmp/": true, "/run": true, "/var": false} {
+		if got := isTmpfsMountPoint(c, dest); got != want {
+			t.Errorf("isTmpfsMountPoint(%s) = %v, want %v", dest, got, want)
+		}
+	}
+}
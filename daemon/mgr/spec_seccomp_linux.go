@@ -34,11 +34,17 @@ func setupSeccomp(ctx context.Context, c *Container, s *specs.Spec) error {
 	case ProfilePouchDefault, "":
 		s.Linux.Seccomp = seccomp.DefaultProfile(s)
 	default:
-		data, err := ioutil.ReadFile(seccompProfile)
-		if err != nil {
-			return fmt.Errorf("failed to load seccomp profile %q: %v", seccompProfile, err)
+		// the profile is shipped from client in JSON, or a profile file on
+		// the daemon host.
+		data := []byte(seccompProfile)
+		if !isInlineSeccompProfile(seccompProfile) {
+			var err error
+			data, err = ioutil.ReadFile(seccompProfile)
+			if err != nil {
+				return fmt.Errorf("failed to load seccomp profile %q: %v", seccompProfile, err)
+			}
 		}
-		err = json.Unmarshal(data, s.Linux.Seccomp)
+		err := json.Unmarshal(data, s.Linux.Seccomp)
 		if err != nil {
 			return fmt.Errorf("failed to decode seccomp profile %q: %v", seccompProfile, err)
 		}
@@ -5,8 +5,10 @@ import (
 	"strings"
 
 	"github.com/alibaba/pouch/apis/filters"
+	apitypes "github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/daemon/events"
 	"github.com/alibaba/pouch/pkg/errtypes"
+	"github.com/alibaba/pouch/pkg/log"
 	"github.com/alibaba/pouch/pkg/utils"
 	"github.com/alibaba/pouch/storage/volume"
 	"github.com/alibaba/pouch/storage/volume/types"
@@ -21,6 +23,11 @@ var acceptedVolumeFilterTags = map[string]bool{
 	"label":  true,
 }
 
+// the filter tags set allowed when pouch volume prune --filter
+var acceptedVolumePruneFilterTags = map[string]bool{
+	"label": true,
+}
+
 // VolumeMgr defines interface to manage container volume.
 type VolumeMgr interface {
 	// Create is used to create volume.
@@ -35,6 +42,12 @@ type VolumeMgr interface {
 	// Remove is used to delete an existing volume.
 	Remove(ctx context.Context, name string) error
 
+	// Prune deletes the volumes which are not used by any container.
+	Prune(ctx context.Context, filter filters.Args, usedVolumes map[string]bool) (*apitypes.VolumePruneResp, error)
+
+	// DiskUsage returns the disk space used by volumes.
+	DiskUsage(ctx context.Context, usedVolumes map[string]bool) ([]*apitypes.DiskUsageObject, error)
+
 	// Path returns the mount path of volume.
 	Path(ctx context.Context, name string) (string, error)
 
@@ -152,6 +165,39 @@ func (vm *VolumeManager) Remove(ctx context.Context, name string) error {
 	return nil
 }
 
+// Prune deletes the volumes which are not used by any container.
+func (vm *VolumeManager) Prune(ctx context.Context, filter filters.Args, usedVolumes map[string]bool) (*apitypes.VolumePruneResp, error) {
+	if err := filter.Validate(acceptedVolumePruneFilterTags); err != nil {
+		return nil, errors.Wrap(errtypes.ErrInvalidParam, err.Error())
+	}
+
+	volumes, err := vm.core.ListVolumes(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &apitypes.VolumePruneResp{
+		VolumesDeleted: []string{},
+	}
+	for _, vol := range volumes {
+		if usedVolumes[vol.Name] || vol.Option(types.OptionRef) != "" {
+			continue
+		}
+
+		// the size is counted only if the volume can be walked on the host.
+		size, _ := utils.DirSize(vol.Path())
+
+		if err := vm.Remove(ctx, vol.Name); err != nil {
+			log.With(ctx).Warnf("failed to remove volume(%s) during prune volumes: %v", vol.Name, err)
+			continue
+		}
+		resp.VolumesDeleted = append(resp.VolumesDeleted, vol.Name)
+		resp.SpaceReclaimed += size
+	}
+
+	return resp, nil
+}
+
 // Path returns the mount path of volume.
 func (vm *VolumeManager) Path(ctx context.Context, name string) (string, error) {
 	id := types.VolumeContext{
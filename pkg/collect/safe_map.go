@@ -55,6 +55,23 @@ func (m *SafeMap) Put(k string, v interface{}) {
 	m.inner[k] = v
 }
 
+// PutIfAbsent stores a key-value pair into inner map safely only if the key
+// does not exist, it returns false if the key already exists.
+func (m *SafeMap) PutIfAbsent(k string, v interface{}) bool {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.inner == nil {
+		return false
+	}
+
+	if _, ok := m.inner[k]; ok {
+		return false
+	}
+	m.inner[k] = v
+	return true
+}
+
 // Remove removes the key-value pair.
 func (m *SafeMap) Remove(k string) {
 	m.Lock()
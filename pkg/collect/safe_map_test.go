@@ -401,3 +401,17 @@ func TestInt64(t *testing.T) {
 		assert.Equal(t, ok, testCase.result.ok)
 	}
 }
+
+func TestSafeMapPutIfAbsent(t *testing.T) {
+	safeMap := NewSafeMap()
+
+	assert.True(t, safeMap.PutIfAbsent("key", "value"))
+	assert.False(t, safeMap.PutIfAbsent("key", "value1"))
+
+	v, ok := safeMap.Get("key").String()
+	assert.True(t, ok)
+	assert.Equal(t, "value", v)
+
+	safeMap.Remove("key")
+	assert.True(t, safeMap.PutIfAbsent("key", "value1"))
+}
@@ -86,6 +86,11 @@ func IsInvalidParam(err error) bool {
 	return checkError(err, codeInvalidParam)
 }
 
+// IsConflict checks the error is conflict with the state of object or not.
+func IsConflict(err error) bool {
+	return checkError(err, codeConflict)
+}
+
 // IsTimeout checks the error is time out or not.
 func IsTimeout(err error) bool {
 	return checkError(err, codeTimeout)
@@ -18,3 +18,77 @@ func NewReadCloserWrapper(r io.Reader, closeFunc func() error) io.ReadCloser {
 		closeFunc: closeFunc,
 	}
 }
+
+// cancelReadBufSize is the size of buffer used to read ahead in cancelReader.
+const cancelReadBufSize = 32 * 1024
+
+type readResult struct {
+	data []byte
+	err  error
+}
+
+// cancelReader reads from the underlying reader in another goroutine, so
+// that the blocked Read can be interrupted by closing the done channel.
+type cancelReader struct {
+	r       io.Reader
+	done    <-chan struct{}
+	results chan readResult
+	started bool
+
+	pending []byte
+	err     error
+}
+
+// NewCancelReader returns a reader which returns io.EOF once done is closed,
+// even if the Read of r is still blocked, for example on os.Stdin. The
+// blocked Read of r is left behind, and its data is dropped.
+func NewCancelReader(r io.Reader, done <-chan struct{}) io.Reader {
+	return &cancelReader{
+		r:       r,
+		done:    done,
+		results: make(chan readResult),
+	}
+}
+
+// Read implements io.Reader interface.
+func (c *cancelReader) Read(p []byte) (int, error) {
+	if !c.started {
+		c.started = true
+		go c.readAhead()
+	}
+
+	if len(c.pending) == 0 && c.err == nil {
+		select {
+		case res := <-c.results:
+			c.pending, c.err = res.data, res.err
+		case <-c.done:
+			return 0, io.EOF
+		}
+	}
+
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+	if len(c.pending) > 0 {
+		return n, nil
+	}
+	return n, c.err
+}
+
+// readAhead reads from the underlying reader until an error occurs or done
+// is closed.
+func (c *cancelReader) readAhead() {
+	for {
+		buf := make([]byte, cancelReadBufSize)
+		n, err := c.r.Read(buf)
+
+		select {
+		case c.results <- readResult{data: buf[:n], err: err}:
+		case <-c.done:
+			return
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
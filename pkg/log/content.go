@@ -26,6 +26,13 @@ func Init(debug bool) {
 	logrus.SetFormatter(formatter)
 }
 
+// DisableColors disables the colors in the log output.
+func DisableColors() {
+	if formatter, ok := logrus.StandardLogger().Formatter.(*logrus.TextFormatter); ok {
+		formatter.DisableColors = true
+	}
+}
+
 // NewContext returns new log entry, if context has old entry, it will be overwrite
 func NewContext(ctx context.Context, fields map[string]interface{}) context.Context {
 	if ctx == nil {
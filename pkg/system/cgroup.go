@@ -2,6 +2,7 @@ package system
 
 import (
 	"bufio"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
@@ -24,6 +25,10 @@ type CPUCgroupInfo struct {
 	CPUShares  bool
 	CPUPeriod  bool
 	CPUQuota   bool
+
+	// Cpus and Mems are the cpus and mem nodes available on current machine.
+	Cpus string
+	Mems string
 }
 
 // BlkioCgroupInfo defines blkio cgroup information on current machine
@@ -84,6 +89,8 @@ func getCPUCgroupInfo(root string) *CPUCgroupInfo {
 		CPUShares:  isCgroupEnable(cpuPath, "cpu.shares"),
 		CPUQuota:   isCgroupEnable(cpuPath, "cpu.cfs_quota_us"),
 		CPUPeriod:  isCgroupEnable(cpuPath, "cpu.cfs_period_us"),
+		Cpus:       readCgroupFile(cpusetPath, "cpuset.cpus"),
+		Mems:       readCgroupFile(cpusetPath, "cpuset.mems"),
 	}
 }
 
@@ -110,6 +117,14 @@ func isCgroupEnable(f ...string) bool {
 	return exist == nil
 }
 
+func readCgroupFile(f ...string) string {
+	content, err := ioutil.ReadFile(path.Join(f...))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
 func getCgroupRootMount(mountFile string) string {
 	f, err := os.Open(mountFile)
 	if err != nil {
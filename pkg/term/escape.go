@@ -0,0 +1,114 @@
+package term
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultDetachKeys is the default key sequence for detaching from a
+// container or an exec process.
+const DefaultDetachKeys = "ctrl-p,ctrl-q"
+
+// ErrEscapeDetach is returned by the reader of NewEscapeProxy when the
+// escape key sequence is read.
+var ErrEscapeDetach = errors.New("read escape sequence")
+
+// ToBytes converts a comma-separated key sequence like "ctrl-p,ctrl-q" or
+// "a,ctrl-@" into the bytes sent by the terminal.
+func ToBytes(keys string) ([]byte, error) {
+	var codes []byte
+
+	for _, key := range strings.Split(keys, ",") {
+		if len(key) == 1 {
+			codes = append(codes, key[0])
+			continue
+		}
+
+		if len(key) != len("ctrl-")+1 || !strings.HasPrefix(strings.ToLower(key), "ctrl-") {
+			return nil, fmt.Errorf("unknown key: %q", key)
+		}
+
+		c := strings.ToLower(key)[len("ctrl-")]
+		switch {
+		case c >= 'a' && c <= 'z':
+			codes = append(codes, c-'a'+1)
+		case c == '@':
+			codes = append(codes, 0)
+		case c >= '[' && c <= '_':
+			// ctrl-[ (27), ctrl-\ (28), ctrl-] (29), ctrl-^ (30), ctrl-_ (31)
+			codes = append(codes, c-'['+27)
+		default:
+			return nil, fmt.Errorf("unknown key: %q", key)
+		}
+	}
+
+	return codes, nil
+}
+
+// escapeProxy holds back the bytes matching the prefix of escape keys, and
+// returns ErrEscapeDetach when the whole escape keys are read.
+type escapeProxy struct {
+	r            io.Reader
+	escapeKeys   []byte
+	escapeKeyPos int
+	pending      []byte
+}
+
+// NewEscapeProxy returns a reader which reads from r, and returns
+// ErrEscapeDetach when the escape keys are read. The escape keys are not
+// passed to the caller, while the partial matched keys are passed through
+// once the sequence is broken.
+func NewEscapeProxy(r io.Reader, escapeKeys []byte) io.Reader {
+	return &escapeProxy{
+		r:          r,
+		escapeKeys: escapeKeys,
+	}
+}
+
+// Read implements io.Reader interface.
+func (e *escapeProxy) Read(p []byte) (int, error) {
+	if len(e.pending) > 0 {
+		n := copy(p, e.pending)
+		e.pending = e.pending[n:]
+		return n, nil
+	}
+
+	nr, err := e.r.Read(p)
+
+	out := make([]byte, 0, nr+e.escapeKeyPos)
+	for _, b := range p[:nr] {
+		if b == e.escapeKeys[e.escapeKeyPos] {
+			e.escapeKeyPos++
+			if e.escapeKeyPos == len(e.escapeKeys) {
+				e.escapeKeyPos = 0
+				return copy(p, out), ErrEscapeDetach
+			}
+			continue
+		}
+
+		// the sequence is broken, pass the held back keys through.
+		out = append(out, e.escapeKeys[:e.escapeKeyPos]...)
+		e.escapeKeyPos = 0
+
+		if b == e.escapeKeys[0] {
+			e.escapeKeyPos = 1
+			continue
+		}
+		out = append(out, b)
+	}
+
+	if err != nil && e.escapeKeyPos > 0 {
+		out = append(out, e.escapeKeys[:e.escapeKeyPos]...)
+		e.escapeKeyPos = 0
+	}
+
+	n := copy(p, out)
+	e.pending = append(e.pending, out[n:]...)
+	if len(e.pending) > 0 {
+		// deliver the pending bytes before reporting the error.
+		return n, nil
+	}
+	return n, err
+}
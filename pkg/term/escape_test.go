@@ -0,0 +1,56 @@
+package term
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToBytes(t *testing.T) {
+	for _, tc := range []struct {
+		keys     string
+		expected []byte
+		wantErr  bool
+	}{
+		{keys: DefaultDetachKeys, expected: []byte{16, 17}},
+		{keys: "ctrl-a,a", expected: []byte{1, 'a'}},
+		{keys: "ctrl-@,ctrl-[,ctrl-_", expected: []byte{0, 27, 31}},
+		{keys: "CTRL-Z", expected: []byte{26}},
+		{keys: "ctrl-", wantErr: true},
+		{keys: "ctrl-1", wantErr: true},
+		{keys: "ab", wantErr: true},
+		{keys: "a,,b", wantErr: true},
+	} {
+		codes, err := ToBytes(tc.keys)
+		if tc.wantErr {
+			assert.Error(t, err, tc.keys)
+			continue
+		}
+		assert.NoError(t, err, tc.keys)
+		assert.Equal(t, tc.expected, codes, tc.keys)
+	}
+}
+
+func TestEscapeProxy(t *testing.T) {
+	keys := []byte{16, 17}
+
+	// escape keys are detected and not passed through.
+	r := NewEscapeProxy(bytes.NewReader([]byte{'a', 'b', 16, 17, 'c'}), keys)
+	out, err := ioutil.ReadAll(r)
+	assert.Equal(t, ErrEscapeDetach, err)
+	assert.Equal(t, []byte("ab"), out)
+
+	// broken sequence is passed through.
+	r = NewEscapeProxy(bytes.NewReader([]byte{'a', 16, 'b', 16, 16, 17}), keys)
+	out, err = ioutil.ReadAll(r)
+	assert.Equal(t, ErrEscapeDetach, err)
+	assert.Equal(t, []byte{'a', 16, 'b', 16}, out)
+
+	// partial sequence at EOF is passed through.
+	r = NewEscapeProxy(bytes.NewReader([]byte{'a', 16}), keys)
+	out, err = ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{'a', 16}, out)
+}
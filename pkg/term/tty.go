@@ -0,0 +1,37 @@
+package term
+
+import (
+	"context"
+	"os"
+)
+
+// Terminal abstracts the terminal of the client which the tty of a container
+// or an exec process is attached to, so that the attaching works on both
+// Unix and Windows clients.
+type Terminal interface {
+	// IsTerminal reports whether it is connected to a terminal.
+	IsTerminal() bool
+
+	// SetRawMode puts the terminal into the mode for attaching to a tty: the
+	// input is passed through key by key without echo, and the output
+	// interprets VT escape sequences. It returns the function to restore the
+	// previous mode.
+	SetRawMode() (restore func() error, err error)
+
+	// GetSize returns the width and height of the terminal.
+	GetSize() (width, height int, err error)
+
+	// NotifyResize sends to the returned channel each time the terminal is
+	// resized, until ctx is done.
+	NotifyResize(ctx context.Context) <-chan struct{}
+}
+
+// NewInputTerminal returns the Terminal of an input file, like os.Stdin.
+func NewInputTerminal(f *os.File) Terminal {
+	return newTerminal(f, true)
+}
+
+// NewOutputTerminal returns the Terminal of an output file, like os.Stdout.
+func NewOutputTerminal(f *os.File) Terminal {
+	return newTerminal(f, false)
+}
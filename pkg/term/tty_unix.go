@@ -0,0 +1,76 @@
+// +build !windows
+
+package term
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"golang.org/x/crypto/ssh/terminal"
+	"golang.org/x/sys/unix"
+)
+
+// unixTerminal implements Terminal with termios, and watches SIGWINCH for
+// the size change.
+type unixTerminal struct {
+	fd    int
+	input bool
+}
+
+func newTerminal(f *os.File, input bool) Terminal {
+	return &unixTerminal{fd: int(f.Fd()), input: input}
+}
+
+// IsTerminal implements Terminal interface.
+func (t *unixTerminal) IsTerminal() bool {
+	return terminal.IsTerminal(t.fd)
+}
+
+// SetRawMode implements Terminal interface. The output of Unix terminal
+// interprets VT escape sequences already, so it is left untouched.
+func (t *unixTerminal) SetRawMode() (func() error, error) {
+	if !t.input {
+		return func() error { return nil }, nil
+	}
+
+	state, err := terminal.MakeRaw(t.fd)
+	if err != nil {
+		return nil, err
+	}
+	return func() error {
+		return terminal.Restore(t.fd, state)
+	}, nil
+}
+
+// GetSize implements Terminal interface.
+func (t *unixTerminal) GetSize() (int, int, error) {
+	return terminal.GetSize(t.fd)
+}
+
+// NotifyResize implements Terminal interface.
+func (t *unixTerminal) NotifyResize(ctx context.Context) <-chan struct{} {
+	resized := make(chan struct{}, 1)
+
+	sigc := make(chan os.Signal, 16)
+	signal.Notify(sigc, unix.SIGWINCH)
+	go func() {
+		defer signal.Stop(sigc)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigc:
+			}
+
+			// coalesce the resizing not handled yet.
+			select {
+			case resized <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	return resized
+}
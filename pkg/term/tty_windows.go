@@ -0,0 +1,98 @@
+// +build windows
+
+package term
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"golang.org/x/crypto/ssh/terminal"
+	"golang.org/x/sys/windows"
+)
+
+// resizePollInterval is the interval of polling the console size, since
+// there is no SIGWINCH on Windows.
+const resizePollInterval = 250 * time.Millisecond
+
+// windowsTerminal implements Terminal with the console mode, and polls the
+// console size for the size change.
+type windowsTerminal struct {
+	fd    int
+	input bool
+}
+
+func newTerminal(f *os.File, input bool) Terminal {
+	return &windowsTerminal{fd: int(f.Fd()), input: input}
+}
+
+// IsTerminal implements Terminal interface.
+func (t *windowsTerminal) IsTerminal() bool {
+	return terminal.IsTerminal(t.fd)
+}
+
+// SetRawMode implements Terminal interface. The input is set to raw mode
+// with VT input, so that the keys like arrows are sent as escape sequences,
+// and the output is set to process VT escape sequences.
+func (t *windowsTerminal) SetRawMode() (func() error, error) {
+	handle := windows.Handle(t.fd)
+
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return nil, err
+	}
+
+	raw := mode
+	if t.input {
+		raw &^= windows.ENABLE_ECHO_INPUT | windows.ENABLE_LINE_INPUT | windows.ENABLE_PROCESSED_INPUT
+		raw |= windows.ENABLE_VIRTUAL_TERMINAL_INPUT
+	} else {
+		raw |= windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING | windows.DISABLE_NEWLINE_AUTO_RETURN
+	}
+
+	if err := windows.SetConsoleMode(handle, raw); err != nil {
+		return nil, err
+	}
+	return func() error {
+		return windows.SetConsoleMode(handle, mode)
+	}, nil
+}
+
+// GetSize implements Terminal interface.
+func (t *windowsTerminal) GetSize() (int, int, error) {
+	return terminal.GetSize(t.fd)
+}
+
+// NotifyResize implements Terminal interface.
+func (t *windowsTerminal) NotifyResize(ctx context.Context) <-chan struct{} {
+	resized := make(chan struct{}, 1)
+
+	go func() {
+		width, height, _ := t.GetSize()
+
+		ticker := time.NewTicker(resizePollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			w, h, err := t.GetSize()
+			if err != nil || (w == width && h == height) {
+				continue
+			}
+			width, height = w, h
+
+			// coalesce the resizing not handled yet.
+			select {
+			case resized <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	return resized
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"strconv"
@@ -53,6 +54,13 @@ func Get(passwdPath, groupPath, username string, groups []string) (uint32, uint3
 
 	var addGroups []int
 	if len(groups) > 0 {
+		// group file has been read by GetExecUser, rewind it to resolve
+		// the group names.
+		if groupFile != nil {
+			if _, err := groupFile.Seek(0, io.SeekStart); err != nil {
+				return 0, 0, nil, err
+			}
+		}
 		addGroups, err = user.GetAdditionalGroups(groups, groupFile)
 		if err != nil {
 			return 0, 0, nil, err
@@ -2,7 +2,11 @@ package user
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -30,3 +34,26 @@ func TestGetAdditionalGids(t *testing.T) {
 	assert := assert.New(t)
 	assert.True(reflect.DeepEqual(expected, result), true)
 }
+
+func TestGetWithGroups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "user-get")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	passwd := filepath.Join(dir, "passwd")
+	group := filepath.Join(dir, "group")
+	assert.NoError(t, ioutil.WriteFile(passwd, []byte("root:x:0:0:root:/root:/bin/sh\nfoo:x:1000:1000::/home/foo:/bin/sh\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(group, []byte("root:x:0:\nfoo:x:1000:\nwheel:x:10:foo\naudio:x:29:\n"), 0644))
+
+	// secondary groups of user are picked up from group file, and the
+	// additional groups can be given in name or GID.
+	uid, gid, gids, err := Get(passwd, group, "foo", []string{"audio", "1234"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1000), uid)
+	assert.Equal(t, uint32(1000), gid)
+	sort.Slice(gids, func(i, j int) bool { return gids[i] < gids[j] })
+	assert.Equal(t, []uint32{10, 29, 1234}, gids)
+
+	_, _, _, err = Get(passwd, group, "foo", []string{"nonexist"})
+	assert.Error(t, err)
+}
@@ -3,6 +3,8 @@ package filters
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -10,26 +12,38 @@ import (
 
 // acceptedFilters defines filter key ps support
 var acceptedFilters = map[string]bool{
-	"id":     true,
-	"label":  true,
-	"name":   true,
-	"status": true,
+	"id":       true,
+	"label":    true,
+	"name":     true,
+	"status":   true,
+	"ancestor": true,
+	"exited":   true,
 
 	/*
 		// TODO(huamin.thm): the following list key should also support
 		"before":  true,
 		"since":   true,
-		"exited":  true,
 		"volume":  true,
 		"network": true,
 	*/
 }
 
+// negatedFilters defines filter key which supports negation like
+// `label!=a=b`.
+var negatedFilters = map[string]bool{
+	"label": true,
+}
+
+// NegationPrefix is the prefix of negated filter value, for example
+// `label!=a=b` is parsed as {"label": ["!a=b"]}.
+const NegationPrefix = "!"
+
 // getAcceptKeys gets all accepted filter keys
 func getAcceptKeys() (list []string) {
 	for key := range acceptedFilters {
 		list = append(list, key)
 	}
+	sort.Strings(list)
 
 	return
 }
@@ -47,19 +61,27 @@ func Parse(filter []string) (map[string][]string, error) {
 			return nil, fmt.Errorf("Bad format of filter, expected name=value")
 		}
 
-		name := splits[0]
+		name, value := splits[0], strings.TrimSpace(splits[1])
+		if strings.HasSuffix(name, NegationPrefix) {
+			name = strings.TrimSuffix(name, NegationPrefix)
+			if !negatedFilters[name] {
+				return nil, fmt.Errorf("Invalid filter %s!=%s, negation is only supported by label filter", name, value)
+			}
+			value = NegationPrefix + value
+		}
+
 		if _, ok := acceptedFilters[name]; !ok {
 			return nil, fmt.Errorf("Invalid filter %s, accepted filter key: %v", name, getAcceptKeys())
 		}
 
 		if v, exist := parsed[name]; exist {
-			parsed[name] = append(v, strings.TrimSpace(splits[1]))
+			parsed[name] = append(v, value)
 		} else {
-			parsed[name] = []string{strings.TrimSpace(splits[1])}
+			parsed[name] = []string{value}
 		}
 	}
 
-	return parsed, nil
+	return parsed, Validate(parsed)
 }
 
 // ToURLParam marshals filter as a string, used for url query
@@ -100,5 +122,11 @@ func Validate(filter map[string][]string) error {
 		}
 	}
 
+	for _, v := range filter["exited"] {
+		if _, err := strconv.Atoi(v); err != nil {
+			return fmt.Errorf("invalid filter exited=%s, the value must be an exit code", v)
+		}
+	}
+
 	return nil
 }
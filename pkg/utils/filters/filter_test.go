@@ -41,6 +41,20 @@ func TestParseFilter(t *testing.T) {
 			filter: []string{"label=a!=b", "id=aaa"},
 			ok:     true,
 		},
+		{
+			filter: []string{"label!=a=b", "label!=c", "ancestor=busybox", "exited=0"},
+			ok:     true,
+		},
+		{
+			filter:   []string{"name!=foo"},
+			ok:       false,
+			errorMsg: "negation is only supported by label filter",
+		},
+		{
+			filter:   []string{"exited=foo"},
+			ok:       false,
+			errorMsg: "the value must be an exit code",
+		},
 	} {
 		_, err := Parse(t.filter)
 		if t.ok {
@@ -51,6 +65,12 @@ func TestParseFilter(t *testing.T) {
 	}
 }
 
+func TestParseNegatedFilter(t *testing.T) {
+	filter, err := Parse([]string{"label!=a=b", "label=c", "label!=d"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"label": {"!a=b", "c", "!d"}}, filter)
+}
+
 func TestValidate(t *testing.T) {
 	type args struct {
 		filter map[string][]string
@@ -26,6 +26,15 @@ func Parse(format string) (*template.Template, error) {
 	return NewParse("", format)
 }
 
+// ParseFormat parses the format of --format option of commands, "json" is
+// the shortcut of "{{json .}}" which prints the object as a JSON object.
+func ParseFormat(format string) (*template.Template, error) {
+	if format == "json" {
+		format = "{{json .}}"
+	}
+	return Parse(format)
+}
+
 // NewParse creates a new tagged template with the basic functions
 // and parses the given format.
 func NewParse(tag, format string) (*template.Template, error) {
@@ -17,6 +17,25 @@ func TestParse(t *testing.T) {
 	assert.Equal(t, want, b.String())
 }
 
+func TestParseFormat(t *testing.T) {
+	tm, err := ParseFormat("json")
+	assert.NoError(t, err)
+
+	var b bytes.Buffer
+	assert.NoError(t, tm.Execute(&b, map[string]string{"ID": "abc"}))
+	assert.Equal(t, `{"ID":"abc"}`, b.String())
+
+	tm, err = ParseFormat("{{.ID}} {{lower .Name}}")
+	assert.NoError(t, err)
+
+	b.Reset()
+	assert.NoError(t, tm.Execute(&b, map[string]string{"ID": "abc", "Name": "Foo"}))
+	assert.Equal(t, "abc foo", b.String())
+
+	_, err = ParseFormat("{{.ID")
+	assert.Error(t, err)
+}
+
 func TestNewParse(t *testing.T) {
 	tm, err := NewParse("test", "this is a {{ . }}")
 	assert.NoError(t, err)
@@ -306,6 +306,38 @@ func IsFileExist(file string) bool {
 	return false
 }
 
+// DirSize returns the disk space used by the files under the directory, the
+// hard links of a file are counted once.
+func DirSize(dir string) (int64, error) {
+	var size int64
+	seen := make(map[uint64]bool)
+
+	err := filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			// the file may be removed during walking.
+			if os.IsNotExist(err) && path != dir {
+				return nil
+			}
+			return err
+		}
+
+		if fi.IsDir() {
+			return nil
+		}
+
+		if st, ok := fi.Sys().(*syscall.Stat_t); ok && st.Nlink > 1 {
+			if seen[st.Ino] {
+				return nil
+			}
+			seen[st.Ino] = true
+		}
+
+		size += fi.Size()
+		return nil
+	})
+	return size, err
+}
+
 // StringSliceEqual compares two string slice, ignore the order.
 // If all items in the two string slice are equal, this function will return true
 // even though there may have duplicate elements in the slice, otherwise reture false.
@@ -812,3 +812,22 @@ func TestExtractIPAndPortFromAddresses(t *testing.T) {
 		})
 	}
 }
+
+func TestDirSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestDirSize")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a"), make([]byte, 100), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "sub", "b"), make([]byte, 20), 0644))
+	// the hard link is counted only once.
+	assert.NoError(t, os.Link(filepath.Join(dir, "a"), filepath.Join(dir, "sub", "c")))
+
+	size, err := DirSize(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(120), size)
+
+	_, err = DirSize(filepath.Join(dir, "nonexist"))
+	assert.Error(t, err)
+}
@@ -108,7 +108,7 @@ func (suite *APIContainerExecSuite) TestExecCreatedContainer(c *check.C) {
 	body := request.WithJSONBody(obj)
 	resp, err := request.Post("/containers/"+cname+"/exec", body)
 	c.Assert(err, check.IsNil)
-	CheckRespStatus(c, resp, 500)
+	CheckRespStatus(c, resp, 409)
 }
 
 // TestExecPausedContainer tests creating exec on paused container return error.
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/alibaba/pouch/test/command"
@@ -37,3 +38,24 @@ func (suite *PouchBuildSuite) TestBuildMultipleStage(c *check.C) {
 	res.Assert(c, icmd.Success)
 	c.Assert(res.Stdout(), check.Equals, "Hi PouchContainer!\n")
 }
+
+// TestBuildWithOptions tests build with custom Dockerfile, build args and
+// the files excluded by .pouchignore.
+func (suite *PouchBuildSuite) TestBuildWithOptions(c *check.C) {
+	iname := fmt.Sprintf("%s:%v", strings.ToLower(c.TestName()), time.Now().UnixNano())
+
+	path := filepath.Join("testdata", "build", "args")
+	res := command.PouchRun("build", "--no-cache", "-f", filepath.Join(path, "Dockerfile.args"),
+		"--build-arg", "GREETING=hello", "-t", iname, path)
+	defer command.PouchRun("rmi", iname)
+	res.Assert(c, icmd.Success)
+
+	lines := strings.Split(strings.TrimSpace(res.Stdout()), "\n")
+	c.Assert(lines[len(lines)-1], check.Matches, `sha256:[0-9a-f]{64}`)
+
+	name := "TestBuildWithOptions"
+	res = command.PouchRun("run", "--name", name, iname)
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "hello\nDockerfile.args\nkept.txt\n")
+}
@@ -87,3 +87,33 @@ func (suite *PouchCheckpointSuite) TestCheckpointDelete(c *check.C) {
 	ret.Assert(c, icmd.Success)
 	c.Assert(ret.Stdout(), check.Equals, "")
 }
+
+// PouchCheckpointWithoutCRIUSuite is the test suite for checkpoint on hosts without criu.
+type PouchCheckpointWithoutCRIUSuite struct{}
+
+func init() {
+	check.Suite(&PouchCheckpointWithoutCRIUSuite{})
+}
+
+// SetUpTest does common setup in the beginning of each test.
+func (suite *PouchCheckpointWithoutCRIUSuite) SetUpTest(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+	SkipIfFalse(c, func() bool { return !environment.IsCRIUExist() })
+
+	PullImage(c, busyboxImage)
+}
+
+// TestCheckpointWithoutCRIU tests checkpoint and restore fail clearly without criu.
+func (suite *PouchCheckpointWithoutCRIUSuite) TestCheckpointWithoutCRIU(c *check.C) {
+	cname := "TestCheckpointWithoutCRIU"
+
+	command.PouchRun("run", "-d", "--name", cname, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	ret := command.PouchRun("checkpoint", "create", cname, "cp0")
+	c.Assert(util.PartialEqual(ret.Stderr(), "require criu installed on the daemon host"), check.IsNil)
+
+	command.PouchRun("stop", cname).Assert(c, icmd.Success)
+	ret = command.PouchRun("start", "--checkpoint", "cp0", cname)
+	c.Assert(util.PartialEqual(ret.Stderr(), "require criu installed on the daemon host"), check.IsNil)
+}
@@ -116,3 +116,39 @@ func (suite *PouchCommitSuite) TestCommitBindMount(c *check.C) {
 	ret.Assert(c, icmd.Success)
 	DelContainerForceMultyTime(c, nname)
 }
+
+// TestCommitWithChanges tests commit a container with changes and without repository.
+func (suite *PouchCommitSuite) TestCommitWithChanges(c *check.C) {
+	cname := "TestCommitWithChanges"
+
+	command.PouchRun("run", "-d", "--name", cname, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	ret := command.PouchRun("commit", "--pause=false",
+		"-c", "ENV foo=bar", "-c", `CMD ["sh", "-c", "echo $foo"]`, cname)
+	ret.Assert(c, icmd.Success)
+	imageID := strings.TrimSpace(ret.Stdout())
+	defer DelImageForceOk(c, imageID)
+
+	nname := "fromChanges"
+	ret = command.PouchRun("run", "--name", nname, imageID)
+	defer DelContainerForceMultyTime(c, nname)
+	ret.Assert(c, icmd.Success)
+	c.Assert(ret.Stdout(), check.Equals, "bar\n")
+
+	// the running container should not be paused
+	state := command.PouchRun("inspect", "-f", "{{.State.Status}}", cname).Assert(c, icmd.Success).Stdout()
+	c.Assert(strings.TrimSpace(state), check.Equals, "running")
+}
+
+// TestCommitWithInvalidChanges tests commit a container with unsupported changes.
+func (suite *PouchCommitSuite) TestCommitWithInvalidChanges(c *check.C) {
+	cname := "TestCommitWithInvalidChanges"
+
+	command.PouchRun("create", "--name", cname, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	ret := command.PouchRun("commit", "-c", "EXPOSE 80", cname, "foo:invalid")
+	c.Assert(ret.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(strings.Contains(ret.Stderr(), "unsupported instruction EXPOSE"), check.Equals, true, check.Commentf(ret.Stderr()))
+}
@@ -132,3 +132,41 @@ func (suite *PouchContainerCopySuite) TestStopContainerCopy(c *check.C) {
 	// test stopped container can start after cp
 	command.PouchRun("start", name).Assert(c, icmd.Success)
 }
+
+// TestCopyDirArchiveMode tests copying directory into directory in archive mode
+// keeps the permissions and ownership.
+func (suite *PouchContainerCopySuite) TestCopyDirArchiveMode(c *check.C) {
+	testDataPath, err := ioutil.TempDir("", "test-pouch-copy-archive")
+	c.Assert(err, check.IsNil)
+	defer os.RemoveAll(testDataPath)
+
+	srcDir := fmt.Sprintf("%s/%s", testDataPath, "src")
+	c.Assert(os.MkdirAll(srcDir, 0755), check.IsNil)
+	c.Assert(ioutil.WriteFile(srcDir+"/data.txt", []byte("test pouch cp"), 0640), check.IsNil)
+	c.Assert(os.Chown(srcDir+"/data.txt", 1000, 1000), check.IsNil)
+
+	name := "TestCopyDirArchiveMode"
+	command.PouchRun("run", "-d",
+		"--name", name,
+		busyboxImage,
+		"sh", "-c",
+		"mkdir /dst && ln -s /dst /link && top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	// copy directory into the existing directory
+	command.PouchRun("cp", "-a", srcDir, fmt.Sprintf("%s:%s", name, "/dst")).Assert(c, icmd.Success)
+	res := command.PouchRun("exec", name, "stat", "-c", "%a %u:%g", "/dst/src/data.txt")
+	res.Assert(c, icmd.Success)
+	c.Assert(util.PartialEqual(res.Stdout(), "640 1000:1000"), check.IsNil)
+
+	// the copied files are owned by root without archive mode
+	command.PouchRun("cp", srcDir+"/data.txt", fmt.Sprintf("%s:%s", name, "/dst/root.txt")).Assert(c, icmd.Success)
+	res = command.PouchRun("exec", name, "stat", "-c", "%a %u:%g", "/dst/root.txt")
+	res.Assert(c, icmd.Success)
+	c.Assert(util.PartialEqual(res.Stdout(), "640 0:0"), check.IsNil)
+
+	// copy the link target with follow link
+	localTestPath := fmt.Sprintf("%s/%s", testDataPath, "link")
+	command.PouchRun("cp", "-L", fmt.Sprintf("%s:%s", name, "/link"), localTestPath).Assert(c, icmd.Success)
+	checkFileContains(c, localTestPath+"/src/data.txt", "test pouch cp")
+}
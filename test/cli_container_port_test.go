@@ -74,3 +74,19 @@ func (suite *PouchContainerPortSuite) TestPouchPort(c *check.C) {
 		}
 	}
 }
+
+// TestPouchPortUnmapped tests pouch port fails with the unpublished port.
+func (suite *PouchContainerPortSuite) TestPouchPortUnmapped(c *check.C) {
+	name := "TestPouchPortUnmapped"
+	command.PouchRun("run", "--name", name, "-d", "-p", "8001:8001",
+		busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	ret := command.PouchRun("port", name, "8001/udp")
+	c.Assert(ret.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(strings.Contains(ret.Stderr(), "No public port '8001/udp' published"), check.Equals, true)
+
+	ret = command.PouchRun("port", name)
+	ret.Assert(c, icmd.Success)
+	c.Assert(ret.Stdout(), check.Equals, "8001/tcp -> 0.0.0.0:8001\n")
+}
@@ -450,6 +450,21 @@ func (suite *PouchCreateSuite) TestCreateWithEnvfile(c *check.C) {
 	}
 }
 
+// TestCreateWithMalformedEnvfile tests creating container with a malformed env file fails with the file and line.
+func (suite *PouchCreateSuite) TestCreateWithMalformedEnvfile(c *check.C) {
+	name := "TestCreateWithMalformedEnvfile"
+	badfile, err := util.TmpFileWithContent("TEST1=value1\nTEST2=\"unterminated")
+	if err != nil {
+		c.Fatal(err)
+	}
+	defer os.Remove(badfile)
+
+	res := command.PouchRun("create", "--name", name, "--env-file", badfile, busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, name)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(strings.Contains(res.Stderr(), badfile+":2:"), check.Equals, true)
+}
+
 // TestCreateWithWorkDir tests creating container with a workdir works.
 // TestCreateWithWorkDir tests creating container with a workdir works.
 func (suite *PouchCreateSuite) TestCreateWithWorkDir(c *check.C) {
@@ -575,6 +590,24 @@ func (suite *PouchCreateSuite) TestCreateWithNonExistImage(c *check.C) {
 	command.PouchRun("create", "--name", cname, busyboxImage).Assert(c, icmd.Success)
 }
 
+// TestCreateWithPullPolicy tests creating container with --pull.
+func (suite *PouchCreateSuite) TestCreateWithPullPolicy(c *check.C) {
+	cname := "TestCreateWithPullPolicy"
+	command.PouchRun("pull", busyboxImage).Assert(c, icmd.Success)
+
+	res := command.PouchRun("create", "--pull", "never", "--name", cname, busyboxImage)
+	defer DelContainerForceMultyTime(c, cname)
+	res.Assert(c, icmd.Success)
+
+	res = command.PouchRun("create", "--pull", "never", busyboxImage+"-not-present")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(util.PartialEqual(res.Stderr(), "is not present locally and pull policy is never"), check.IsNil)
+
+	res = command.PouchRun("create", "--pull", "sometimes", busyboxImage)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(util.PartialEqual(res.Stderr(), "invalid pull policy sometimes"), check.IsNil)
+}
+
 // TestCreateWithNonExistImage tests running container with image not exist.
 func (suite *PouchCreateSuite) TestCreateWithNvidiaConfig(c *check.C) {
 	cname := "TestCreateWithNvidiaConfig"
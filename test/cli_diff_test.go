@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchDiffSuite is the test suite for diff CLI.
+type PouchDiffSuite struct{}
+
+func init() {
+	check.Suite(&PouchDiffSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchDiffSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	environment.PruneAllContainers(apiClient)
+
+	PullImage(c, busyboxImage)
+}
+
+// TearDownTest does cleanup work in the end of each test.
+func (suite *PouchDiffSuite) TearDownTest(c *check.C) {
+}
+
+// TestDiffWorks tests "pouch diff" works on running and stopped containers.
+func (suite *PouchDiffSuite) TestDiffWorks(c *check.C) {
+	name := "TestDiffWorks"
+
+	res := command.PouchRun("run", "-d", "--name", name, busyboxImage,
+		"sh", "-c", "touch /tmp/foo && rm -rf /home && echo bar >> /etc/hosts.bak && top")
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+
+	checkDiff := func() {
+		out := command.PouchRun("diff", name).Assert(c, icmd.Success).Stdout()
+		for _, expected := range []string{"C /tmp", "A /tmp/foo", "D /home", "A /etc/hosts.bak"} {
+			c.Assert(strings.Contains(out, expected+"\n"), check.Equals, true, check.Commentf("expected %s in %s", expected, out))
+		}
+	}
+
+	checkDiff()
+
+	command.PouchRun("stop", "-t", "1", name).Assert(c, icmd.Success)
+	checkDiff()
+
+	out := command.PouchRun("diff", "--format", "json", name).Assert(c, icmd.Success).Stdout()
+	changes := []types.ContainerChangeResponseItem{}
+	c.Assert(json.Unmarshal([]byte(out), &changes), check.IsNil)
+
+	found := false
+	for _, change := range changes {
+		if change.Kind == 1 && change.Path == "/tmp/foo" {
+			found = true
+		}
+	}
+	c.Assert(found, check.Equals, true, check.Commentf("expected /tmp/foo added in %s", out))
+}
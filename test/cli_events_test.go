@@ -53,6 +53,29 @@ func (suite *PouchEventsSuite) TestEventsWorks(c *check.C) {
 	}
 }
 
+// TestEventsTimeWindowWithFilter tests "pouch events" replays the events in
+// the relative time window with filter.
+func (suite *PouchEventsSuite) TestEventsTimeWindowWithFilter(c *check.C) {
+	name := "test-events-time-window-with-filter"
+
+	res := command.PouchRun("run", "-d", "--name", name, busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+	time.Sleep(1100 * time.Millisecond)
+
+	res = command.PouchRun("events", "--since", "1m", "--until", "1s", "--filter", "type=container", "--filter", "event=start")
+	res.Assert(c, icmd.Success)
+	if out := res.Combined(); !strings.Contains(out, "start") || strings.Contains(out, "create") {
+		c.Errorf("unexpected output %s: should only contains start event\n", out)
+	}
+
+	res = command.PouchRun("events", "--since", "1s", "--until", "1m")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	if out := res.Combined(); !strings.Contains(out, "must be after since") {
+		c.Errorf("unexpected output %s: should fail with invalid time window\n", out)
+	}
+}
+
 func delEmptyStrInSlice(strSlice []string) []string {
 	if len(strSlice) == 0 {
 		return strSlice
@@ -3,8 +3,12 @@ package main
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -147,8 +151,9 @@ func (suite *PouchExecSuite) TestExecStoppedContainer(c *check.C) {
 
 	command.PouchRun("stop", "-t", "1", name).Assert(c, icmd.Success)
 
-	out := command.PouchRun("exec", name, "echo", "test").Stderr()
-	if !strings.Contains(out, "failed") {
+	res = command.PouchRun("exec", name, "echo", "test")
+	c.Assert(res.ExitCode, check.Equals, 126)
+	if out := res.Stderr(); !strings.Contains(out, "failed") {
 		c.Errorf("should fail to exec in stopped container: %s", out)
 	}
 }
@@ -292,7 +297,7 @@ func (suite *PouchExecSuite) TestExecWithTty(c *check.C) {
 	defer DelContainerForceMultyTime(c, name)
 	attachRes := command.PouchRun("exec", "-i", "-t", name, "ls")
 	errString := attachRes.Stderr()
-	assert.Equal(c, errString, "Error: the input device is not a TTY\n")
+	assert.Equal(c, errString, "Error: the input device is not a TTY; remove -t or connect a terminal\n")
 }
 
 // TestExecForCloseIO test CloseIO works.
@@ -350,3 +355,526 @@ func (suite *PouchExecSuite) TestExecWithDetach(c *check.C) {
 		c.Errorf("timeout waiting for `pouch exec` to exit")
 	}
 }
+
+// TestExecWithCmdFile tests exec with --cmd-file can work
+func (suite *PouchExecSuite) TestExecWithCmdFile(c *check.C) {
+	name := "TestExecWithCmdFile"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	script, err := ioutil.TempFile("", "pouch-exec-cmd-file")
+	c.Assert(err, check.IsNil)
+	defer os.Remove(script.Name())
+
+	_, err = script.WriteString("echo hello\nexit 3\n")
+	c.Assert(err, check.IsNil)
+	c.Assert(script.Close(), check.IsNil)
+
+	res := command.PouchRun("exec", "--cmd-file", script.Name(), name)
+	res.Assert(c, icmd.Expected{ExitCode: 3})
+	c.Assert(res.Stdout(), check.Equals, "hello\n")
+
+	// the command file should be checked before creating exec
+	command.PouchRun("exec", "--cmd-file", "/nosuchfile", name).Assert(c, icmd.Expected{
+		ExitCode: 1,
+		Err:      "failed to read command file /nosuchfile",
+	})
+}
+
+// TestExecWithWorkdirFlag tests exec with --workdir overrides the working directory of container.
+func (suite *PouchExecSuite) TestExecWithWorkdirFlag(c *check.C) {
+	name := "TestExecWithWorkdirFlag"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("exec", "-w", "/etc", name, "pwd")
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "/etc\n")
+
+	command.PouchRun("exec", "-w", "/nosuchdir", name, "pwd").Assert(c, icmd.Expected{
+		ExitCode: 1,
+		Err:      "working directory /nosuchdir does not exist",
+	})
+}
+
+// TestExecWithTimeout tests exec with --timeout kills the exec process.
+func (suite *PouchExecSuite) TestExecWithTimeout(c *check.C) {
+	name := "TestExecWithTimeout"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	command.PouchRun("exec", "--timeout", "1s", name, "sleep", "100").Assert(c, icmd.Expected{
+		ExitCode: 124,
+		Err:      "timed out after 1s",
+	})
+
+	command.PouchRun("exec", "--timeout", "10s", name, "echo", "test").Assert(c, icmd.Expected{
+		ExitCode: 0,
+		Out:      "test",
+	})
+}
+
+// TestExecWithInvalidDetachKeys tests exec with invalid --detach-keys fails.
+func (suite *PouchExecSuite) TestExecWithInvalidDetachKeys(c *check.C) {
+	name := "TestExecWithInvalidDetachKeys"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	command.PouchRun("exec", "--detach-keys", "ctrl-1", name, "ls").Assert(c, icmd.Expected{
+		ExitCode: 1,
+		Err:      "invalid detach keys",
+	})
+}
+
+// TestExecWithPidFile tests exec with --pid-file writes the pid of exec process.
+func (suite *PouchExecSuite) TestExecWithPidFile(c *check.C) {
+	name := "TestExecWithPidFile"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	pidFile := "/tmp/TestExecWithPidFile.pid"
+	defer os.Remove(pidFile)
+
+	command.PouchRun("exec", "-d", "--pid-file", pidFile, name, "sleep", "100").Assert(c, icmd.Success)
+
+	pid, err := ioutil.ReadFile(pidFile)
+	c.Assert(err, check.IsNil)
+
+	// the pid should be a process of sleep on host.
+	cmdline, err := ioutil.ReadFile(fmt.Sprintf("/proc/%s/cmdline", string(pid)))
+	c.Assert(err, check.IsNil)
+	c.Assert(strings.HasPrefix(string(cmdline), "sleep"), check.Equals, true)
+}
+
+// TestExecWithIDPrefix tests exec resolves the prefix of container id.
+func (suite *PouchExecSuite) TestExecWithIDPrefix(c *check.C) {
+	name := "TestExecWithIDPrefix"
+	res := command.PouchRun("run", "-d", "--name", name, busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+
+	id := strings.TrimSpace(res.Stdout())
+	command.PouchRun("exec", id[:8], "echo", "test").Assert(c, icmd.Expected{
+		ExitCode: 0,
+		Out:      "test",
+	})
+
+	command.PouchRun("exec", "nosuchcontainer", "echo", "test").Assert(c, icmd.Expected{
+		ExitCode: 125,
+		Err:      "no such container: nosuchcontainer",
+	})
+}
+
+// TestExecWithFormat tests exec with --format prints the exec result to stderr.
+func (suite *PouchExecSuite) TestExecWithFormat(c *check.C) {
+	name := "TestExecWithFormat"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("exec", "--format", "{{.ExitCode}}", name, "sh", "-c", "echo test; exit 3")
+	res.Assert(c, icmd.Expected{ExitCode: 3})
+	c.Assert(res.Stdout(), check.Equals, "test\n")
+	c.Assert(strings.Contains(res.Stderr(), "3\n"), check.Equals, true)
+
+	res = command.PouchRun("exec", "--format", "json", name, "echo", "test")
+	res.Assert(c, icmd.Success)
+	result := struct {
+		ExecID      string
+		ExitCode    int
+		StdoutBytes int64
+	}{}
+	c.Assert(json.Unmarshal([]byte(res.Stderr()), &result), check.IsNil)
+	c.Assert(result.ExecID, check.Not(check.Equals), "")
+	c.Assert(result.ExitCode, check.Equals, 0)
+	c.Assert(result.StdoutBytes, check.Equals, int64(len("test\n")))
+}
+
+// TestExecWithEnvFile tests exec with --env-file, and -e overrides the env file.
+func (suite *PouchExecSuite) TestExecWithEnvFile(c *check.C) {
+	name := "TestExecWithEnvFile"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	envFile, err := ioutil.TempFile("", name)
+	c.Assert(err, check.IsNil)
+	defer os.Remove(envFile.Name())
+
+	_, err = envFile.WriteString("# comment\n\nFOO=file\nBAR=file\n")
+	c.Assert(err, check.IsNil)
+	envFile.Close()
+
+	res := command.PouchRun("exec", "--env-file", envFile.Name(), "-e", "BAR=flag", name, "sh", "-c", "echo $FOO $BAR")
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "file flag\n")
+
+	res = command.PouchRun("exec", "--env-file", "/nonexistent/env", name, "true")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(strings.Contains(res.Stderr(), "/nonexistent/env"), check.Equals, true)
+}
+
+// TestExecWithClearEnv tests exec with --clear-env ignores the container's envs.
+func (suite *PouchExecSuite) TestExecWithClearEnv(c *check.C) {
+	name := "TestExecWithClearEnv"
+	command.PouchRun("run", "-d", "-e", "CONTAINER_ENV=foo", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("exec", name, "/bin/env")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "CONTAINER_ENV=foo"), check.Equals, true)
+
+	res = command.PouchRun("exec", "--clear-env", "-e", "EXEC_ENV=bar", name, "/bin/env")
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "EXEC_ENV=bar\n")
+
+	command.PouchRun("exec", "--clear-env", "--inherit-env", name, "/bin/env").Assert(c, icmd.Expected{
+		ExitCode: 1,
+		Err:      "Conflicting options: --inherit-env and --clear-env",
+	})
+}
+
+// TestExecInMultipleContainers tests exec in multiple containers given by args, --container or --filter.
+func (suite *PouchExecSuite) TestExecInMultipleContainers(c *check.C) {
+	names := []string{"TestExecInMultipleContainers1", "TestExecInMultipleContainers2"}
+	for _, name := range names {
+		command.PouchRun("run", "-d", "-l", "test=TestExecInMultipleContainers", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+		defer DelContainerForceMultyTime(c, name)
+	}
+
+	// each line of the output is prefixed with the container name.
+	res := command.PouchRun("exec", "--parallel", "2", "--container", names[0], "--container", names[1], "echo", "hello")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Count(res.Stdout(), "\n"), check.Equals, 2)
+	for _, name := range names {
+		c.Assert(res.Stdout(), check.Matches, "(?s).*"+name+": hello\n.*")
+	}
+
+	// the containers are listed before "--".
+	res = command.PouchRun("exec", names[0], names[1], "--", "sh", "-c", "echo $0", "--")
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, names[0]+": --\n"+names[1]+": --\n")
+
+	res = command.PouchRun("exec", "--filter", "label=test=TestExecInMultipleContainers", "sh", "-c", "exit 3")
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(strings.Count(res.Stderr(), "exit code 3"), check.Equals, 2)
+
+	// the summary is printed even with --quiet.
+	res = command.PouchRun("exec", "--quiet", "--filter", "label=test=TestExecInMultipleContainers", "sh", "-c", "exit 3")
+	c.Assert(res.ExitCode, check.Equals, 1)
+	c.Assert(strings.Count(res.Stderr(), "exit code 3"), check.Equals, 2)
+
+	// "--" belongs to the command if the args before it are not all containers.
+	res = command.PouchRun("exec", names[0], "sh", "-c", "echo $0", "--")
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "--\n")
+
+	command.PouchRun("exec", "-it", "--container", names[0], "--container", names[1], "true").Assert(c, icmd.Expected{
+		ExitCode: 1,
+		Err:      "Conflicting options",
+	})
+}
+
+// TestExecWithDemux tests exec with --demux keeps stdout and stderr separated with -t.
+func (suite *PouchExecSuite) TestExecWithDemux(c *check.C) {
+	name := "TestExecWithDemux"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("exec", "-t", "--demux", name, "sh", "-c", "echo out; echo err >&2")
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "out\n")
+	c.Assert(res.Stderr(), check.Equals, "err\n")
+}
+
+// TestExecWithRm tests exec with --rm removes the exec record after exit.
+func (suite *PouchExecSuite) TestExecWithRm(c *check.C) {
+	name := "TestExecWithRm"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("exec", "--rm", name, "sh", "-c", "exit 2")
+	res.Assert(c, icmd.Expected{ExitCode: 2})
+
+	execIDs, err := inspectFilter(name, ".ExecIds")
+	c.Assert(err, check.IsNil)
+	c.Assert(execIDs, check.Equals, "[]")
+
+	// the exec record of the process killed on timeout is removed as well.
+	command.PouchRun("exec", "--rm", "--timeout", "1s", name, "sleep", "100").Assert(c, icmd.Expected{
+		ExitCode: 124,
+		Err:      "timed out after 1s",
+	})
+
+	execIDs, err = inspectFilter(name, ".ExecIds")
+	c.Assert(err, check.IsNil)
+	c.Assert(execIDs, check.Equals, "[]")
+
+	command.PouchRun("exec", "--rm", "-d", name, "true").Assert(c, icmd.Expected{
+		ExitCode: 1,
+		Err:      "Conflicting options: --rm and -d",
+	})
+}
+
+// TestExecWithGroupAdd tests exec with --group-add in name and GID.
+func (suite *PouchExecSuite) TestExecWithGroupAdd(c *check.C) {
+	name := "TestExecWithGroupAdd"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("exec", "--group-add", "audio", "--group-add", "1234", name, "id", "-G")
+	res.Assert(c, icmd.Success)
+	gids := " " + strings.TrimSpace(res.Stdout()) + " "
+	c.Assert(strings.Contains(gids, " 29 "), check.Equals, true)
+	c.Assert(strings.Contains(gids, " 1234 "), check.Equals, true)
+}
+
+// TestExecWithTtyOnly tests exec with -t but without -i in a non-tty client only warns.
+func (suite *PouchExecSuite) TestExecWithTtyOnly(c *check.C) {
+	name := "TestExecWithTtyOnly"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("exec", "-t", name, "echo", "hello")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "hello"), check.Equals, true)
+	c.Assert(strings.Contains(res.Stderr(), "WARNING: the input device is not a TTY"), check.Equals, true)
+}
+
+// TestExecWithInteractiveTimeout tests exec is disconnected when idle for --interactive-timeout.
+func (suite *PouchExecSuite) TestExecWithInteractiveTimeout(c *check.C) {
+	name := "TestExecWithInteractiveTimeout"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	command.PouchRun("exec", "--interactive-timeout", "1s", name, "sleep", "100").Assert(c, icmd.Expected{
+		ExitCode: 120,
+		Err:      "disconnected",
+	})
+
+	// the output resets the idle timer.
+	command.PouchRun("exec", "--interactive-timeout", "2s", name, "sh", "-c", "for i in 1 2 3; do echo $i; sleep 1; done").Assert(c, icmd.Success)
+}
+
+// TestExecWithNoStdoutOrStderr tests exec with --no-stdout or --no-stderr.
+func (suite *PouchExecSuite) TestExecWithNoStdoutOrStderr(c *check.C) {
+	name := "TestExecWithNoStdoutOrStderr"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	script := "echo out; echo err >&2"
+
+	res := command.PouchRun("exec", "--no-stdout", name, "sh", "-c", script)
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "")
+	c.Assert(res.Stderr(), check.Equals, "err\n")
+
+	res = command.PouchRun("exec", "--no-stderr", name, "sh", "-c", script)
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "out\n")
+	c.Assert(res.Stderr(), check.Equals, "")
+
+	command.PouchRun("exec", "--no-stdout", "--no-stderr", name, "true").Assert(c, icmd.Expected{
+		ExitCode: 1,
+		Err:      "nothing is attached",
+	})
+}
+
+// TestExecWithOutput tests exec with --output and --error-output writes the output to files.
+func (suite *PouchExecSuite) TestExecWithOutput(c *check.C) {
+	name := "TestExecWithOutput"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	dir, err := ioutil.TempDir("", name)
+	c.Assert(err, check.IsNil)
+	defer os.RemoveAll(dir)
+
+	output, errOutput := filepath.Join(dir, "output"), filepath.Join(dir, "error-output")
+
+	res := command.PouchRun("exec", "--output", output, "--error-output", errOutput, name, "sh", "-c", "echo out; echo err >&2")
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "")
+
+	data, err := ioutil.ReadFile(output)
+	c.Assert(err, check.IsNil)
+	c.Assert(string(data), check.Equals, "out\n")
+
+	data, err = ioutil.ReadFile(errOutput)
+	c.Assert(err, check.IsNil)
+	c.Assert(string(data), check.Equals, "err\n")
+
+	command.PouchRun("exec", "--output", filepath.Join(dir, "nonexist", "output"), name, "true").Assert(c, icmd.Expected{
+		ExitCode: 1,
+		Err:      "failed to create output file",
+	})
+}
+
+// TestExecWithCapAddAndDrop tests exec with --cap-add and --cap-drop.
+func (suite *PouchExecSuite) TestExecWithCapAddAndDrop(c *check.C) {
+	name := "TestExecWithCapAddAndDrop"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	// NET_ADMIN is not in the default capabilities of container.
+	command.PouchRun("exec", name, "ip", "link", "add", "dummy0", "type", "dummy").Assert(c, icmd.Expected{ExitCode: 2})
+	command.PouchRun("exec", "--cap-add", "NET_ADMIN", name, "ip", "link", "add", "dummy0", "type", "dummy").Assert(c, icmd.Success)
+
+	command.PouchRun("exec", "--cap-drop", "CHOWN", name, "chown", "1", "/tmp").Assert(c, icmd.Expected{ExitCode: 1})
+
+	command.PouchRun("exec", "--cap-add", "FOO", name, "true").Assert(c, icmd.Expected{
+		ExitCode: 1,
+		Err:      "Unknown capability",
+	})
+}
+
+// TestExecWithLog tests exec with --log copies the output into container's logs.
+func (suite *PouchExecSuite) TestExecWithLog(c *check.C) {
+	name := "TestExecWithLog"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	command.PouchRun("exec", "--log", name, "echo", "logged by exec").Assert(c, icmd.Success)
+
+	res := command.PouchRun("logs", name)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "logged by exec"), check.Equals, true)
+
+	nolog := "TestExecWithLogNone"
+	command.PouchRun("run", "-d", "--log-driver", "none", "--name", nolog, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, nolog)
+
+	res = command.PouchRun("exec", "--log", nolog, "echo", "hello")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stderr(), "WARNING"), check.Equals, true)
+}
+
+// TestExecWithEnvPassthrough tests exec with -e NAME passes the variable of client through.
+func (suite *PouchExecSuite) TestExecWithEnvPassthrough(c *check.C) {
+	name := "TestExecWithEnvPassthrough"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	os.Setenv("POUCH_EXEC_PASSTHROUGH", "passed")
+	defer os.Unsetenv("POUCH_EXEC_PASSTHROUGH")
+
+	res := command.PouchRun("exec", "-e", "POUCH_EXEC_PASSTHROUGH", name, "sh", "-c", "echo $POUCH_EXEC_PASSTHROUGH")
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "passed\n")
+}
+
+// TestExecWithQuiet tests exec with --quiet only prints the output of exec process.
+func (suite *PouchExecSuite) TestExecWithQuiet(c *check.C) {
+	name := "TestExecWithQuiet"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("exec", "--quiet", "-t", name, "echo", "hello")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "hello"), check.Equals, true)
+	c.Assert(res.Stderr(), check.Equals, "")
+
+	// the exit code is kept.
+	command.PouchRun("exec", "--quiet", name, "sh", "-c", "exit 3").Assert(c, icmd.Expected{ExitCode: 3})
+}
+
+// TestExecWithRowsAndCols tests exec with --rows and --cols fixes the tty size.
+func (suite *PouchExecSuite) TestExecWithRowsAndCols(c *check.C) {
+	name := "TestExecWithRowsAndCols"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("exec", "-t", "--rows", "40", "--cols", "100", name, "stty", "size")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "40 100"), check.Equals, true)
+
+	command.PouchRun("exec", "--rows", "40", name, "stty", "size").Assert(c, icmd.Expected{
+		ExitCode: 1,
+		Err:      "Conflicting options: --rows (or --cols) without -t",
+	})
+	command.PouchRun("exec", "-t", "--cols", "0", name, "stty", "size").Assert(c, icmd.Expected{
+		ExitCode: 1,
+		Err:      "invalid --cols 0: must be positive",
+	})
+}
+
+// TestExecWithPreset tests exec with --preset loads the command and settings from the presets file.
+func (suite *PouchExecSuite) TestExecWithPreset(c *check.C) {
+	name := "TestExecWithPreset"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	home, err := ioutil.TempDir("", "exec-preset")
+	c.Assert(err, check.IsNil)
+	defer os.RemoveAll(home)
+	c.Assert(os.MkdirAll(filepath.Join(home, ".pouch"), 0755), check.IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(home, ".pouch", "exec_presets.json"), []byte(`{
+		"greet": {"cmd": ["sh", "-c", "echo $GREETING $(id -un)"], "env": ["GREETING=hello"], "user": "nobody"}
+	}`), 0644), check.IsNil)
+
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", home)
+
+	res := command.PouchRun("exec", "--preset", "greet", name)
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "hello nobody\n")
+
+	// the explicit flags win.
+	res = command.PouchRun("exec", "--preset", "greet", "-u", "root", "-e", "GREETING=hi", name)
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "hi root\n")
+
+	command.PouchRun("exec", "--preset", "nothing", name).Assert(c, icmd.Expected{
+		ExitCode: 1,
+		Err:      "available presets: [ greet ]",
+	})
+}
+
+// TestExecWithInitUnsupported tests exec with --init fails cleanly when exec-init-path of pouchd is not set.
+func (suite *PouchExecSuite) TestExecWithInitUnsupported(c *check.C) {
+	name := "TestExecWithInitUnsupported"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	command.PouchRun("exec", "--init", name, "true").Assert(c, icmd.Expected{
+		ExitCode: 1,
+		Err:      "exec with init is not supported",
+	})
+}
+
+// TestExecWithMultipleHosts tests exec fails over to the next reachable host, and --locate finds the host of container.
+func (suite *PouchExecSuite) TestExecWithMultipleHosts(c *check.C) {
+	name := "TestExecWithMultipleHosts"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	unreachable := "unix:///var/run/nonexistent-pouchd.sock"
+	command.PouchRun("-H", unreachable, "-H", environment.PouchdAddress, "exec", name, "echo", "test").Assert(c, icmd.Expected{
+		ExitCode: 0,
+		Out:      "test",
+	})
+	command.PouchRun("-H", unreachable, "-H", environment.PouchdAddress, "exec", "--locate", name, "echo", "test").Assert(c, icmd.Expected{
+		ExitCode: 0,
+		Out:      "test",
+	})
+
+	// the errors of all hosts are reported.
+	command.PouchRun("-H", unreachable, "-H", unreachable, "exec", name, "echo", "test").Assert(c, icmd.Expected{
+		ExitCode: 1,
+		Err:      "failed to connect to any of the hosts",
+	})
+}
+
+// TestExecDetachWithTty tests exec with -d and -t warns that nothing is attached.
+func (suite *PouchExecSuite) TestExecDetachWithTty(c *check.C) {
+	name := "TestExecDetachWithTty"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("exec", "-d", "-it", name, "sleep", "1")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stderr(), "-i and -t are ignored with -d"), check.Equals, true)
+
+	// the warning is suppressed by --quiet.
+	res = command.PouchRun("exec", "-d", "-t", "--quiet", name, "sleep", "1")
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stderr(), check.Equals, "")
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchExportImportSuite is the test suite for export and import CLI.
+type PouchExportImportSuite struct{}
+
+func init() {
+	check.Suite(&PouchExportImportSuite{})
+}
+
+// SetUpTest does common setup in the beginning of each test.
+func (suite *PouchExportImportSuite) SetUpTest(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	PullImage(c, busyboxImage)
+}
+
+// TestExportImportStoppedContainer tests exporting a stopped container and
+// running the imported image with changes.
+func (suite *PouchExportImportSuite) TestExportImportStoppedContainer(c *check.C) {
+	cname := "TestExportImportStoppedContainer"
+	image := "export-import:stopped"
+
+	command.PouchRun("run", "--name", cname, busyboxImage,
+		"sh", "-c", "echo hello > /foo").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	dir, err := ioutil.TempDir("", "TestExportImportStoppedContainer")
+	c.Assert(err, check.IsNil)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "rootfs.tar")
+	command.PouchRun("export", "-o", filename, cname).Assert(c, icmd.Success)
+
+	command.PouchRun("import", "-c", "CMD cat /foo", "-c", "ENV foo=bar", filename, image).Assert(c, icmd.Success)
+	defer DelImageForceOk(c, image)
+
+	nname := "TestExportImportStoppedContainerNew"
+	res := command.PouchRun("run", "--name", nname, image)
+	defer DelContainerForceMultyTime(c, nname)
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "hello\n")
+
+	res = command.PouchRun("run", "--rm", image, "sh", "-c", "echo $foo")
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "bar\n")
+}
+
+// TestExportImportRunningContainer tests exporting a running container to
+// STDOUT and importing it from STDIN.
+func (suite *PouchExportImportSuite) TestExportImportRunningContainer(c *check.C) {
+	cname := "TestExportImportRunningContainer"
+	image := "export-import:running"
+
+	command.PouchRun("run", "-d", "--name", cname, busyboxImage,
+		"sh", "-c", "echo hello > /foo && top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	stdout := bytes.NewBuffer(nil)
+	cmd := command.PouchCmd("export", cname)
+	cmd.Stdout = stdout
+	icmd.RunCmd(cmd).Assert(c, icmd.Success)
+
+	cmd = command.PouchCmd("import", "-", image)
+	cmd.Stdin = stdout
+	icmd.RunCmd(cmd).Assert(c, icmd.Success)
+	defer DelImageForceOk(c, image)
+
+	res := command.PouchRun("run", "--rm", image, "cat", "/foo")
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "hello\n")
+}
+
+// TestImportInvalidChange tests importing with unsupported change fails.
+func (suite *PouchExportImportSuite) TestImportInvalidChange(c *check.C) {
+	res := command.PouchRun("import", "-c", "EXPOSE 80", "-", "export-import:invalid")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+}
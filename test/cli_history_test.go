@@ -1,9 +1,13 @@
 package main
 
 import (
+	"strings"
+
+	"github.com/alibaba/pouch/test/command"
 	"github.com/alibaba/pouch/test/environment"
 
 	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
 )
 
 // PouchHistorySuite is the test suite for history CLI.
@@ -20,6 +24,38 @@ func (suite *PouchHistorySuite) SetUpSuite(c *check.C) {
 
 // TestHistoryWorks tests "pouch history" work.
 func (suite *PouchHistorySuite) TestHistoryWorks(c *check.C) {
-	// TODO: We shouldn't compare dockerhub's image history with a fixed string, that's too unreadable.
-	// So this test case will be done when pouch enables build functionality.
+	PullImage(c, busyboxImage)
+
+	cname := "TestHistoryWorks"
+	image := "history:works"
+
+	command.PouchRun("create", "--name", cname, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	// the imported image has only one layer with known comment.
+	res := command.PouchRun("export", cname)
+	res.Assert(c, icmd.Success)
+
+	cmd := command.PouchCmd("import", "-", image)
+	cmd.Stdin = strings.NewReader(res.Stdout())
+	res = icmd.RunCmd(cmd)
+	res.Assert(c, icmd.Success)
+	defer DelImageForceOk(c, image)
+	imageID := strings.TrimSpace(res.Stdout())
+
+	res = command.PouchRun("history", image)
+	res.Assert(c, icmd.Success)
+	lines := strings.Split(strings.TrimSpace(res.Stdout()), "\n")
+	c.Assert(len(lines), check.Equals, 2)
+	c.Assert(strings.Fields(lines[0]), check.DeepEquals, []string{"IMAGE", "CREATED", "CREATED", "BY", "SIZE", "COMMENT"})
+	c.Assert(strings.HasPrefix(lines[1], imageID[len("sha256:"):len("sha256:")+12]), check.Equals, true)
+	c.Assert(strings.HasSuffix(lines[1], "Imported from tarball"), check.Equals, true)
+
+	res = command.PouchRun("image", "history", "-q", "--no-trunc", image)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, imageID)
+
+	res = command.PouchRun("history", "-q", image)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, imageID[len("sha256:"):len("sha256:")+12])
 }
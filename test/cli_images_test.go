@@ -168,3 +168,64 @@ func (suite *PouchImagesSuite) TestLoginAndLogout(c *check.C) {
 	output = command.PouchRun("logout", testHubAddress).Stdout()
 	c.Assert(util.PartialEqual(output, "Remove login credential for registry"), check.IsNil)
 }
+
+// TestLoginWithPasswordStdin tests login with password from stdin.
+func (suite *PouchImagesSuite) TestLoginWithPasswordStdin(c *check.C) {
+	SkipIfFalse(c, environment.IsHubConnected)
+
+	cmd := command.PouchCmd("login", "-u", testHubUser, "--password-stdin", testHubAddress)
+	cmd.Stdin = strings.NewReader(testHubPasswd + "\n")
+	res := icmd.RunCmd(cmd)
+	res.Assert(c, icmd.Success)
+	c.Assert(util.PartialEqual(res.Stdout(), "Login Succeeded"), check.IsNil)
+	c.Assert(strings.Contains(res.Combined(), testHubPasswd), check.Equals, false)
+
+	output := command.PouchRun("logout", testHubAddress).Stdout()
+	c.Assert(util.PartialEqual(output, "Remove login credential for registry"), check.IsNil)
+}
+
+// TestLoginPasswordStdinWithoutUsername tests --password-stdin requires --username.
+func (suite *PouchImagesSuite) TestLoginPasswordStdinWithoutUsername(c *check.C) {
+	res := command.PouchRun("login", "--password-stdin", testHubAddress)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(util.PartialEqual(res.Stderr(), "must provide --username with --password-stdin"), check.IsNil)
+
+	res = command.PouchRun("login", "-u", testHubUser, "-p", "foo", "--password-stdin", testHubAddress)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(util.PartialEqual(res.Stderr(), "mutually exclusive"), check.IsNil)
+}
+
+// TestImagePruneAll tests "pouch image prune -a" only removes the images not used by containers.
+func (suite *PouchImagesSuite) TestImagePruneAll(c *check.C) {
+	cname := "TestImagePruneAll"
+	command.PouchRun("create", "--name", cname, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+	defer PullImage(c, helloworldImage)
+
+	busybox, err := getImageInfo(apiClient, busyboxImage)
+	c.Assert(err, check.IsNil)
+	helloworld, err := getImageInfo(apiClient, helloworldImage)
+	c.Assert(err, check.IsNil)
+
+	// dry run should not remove any image
+	out := command.PouchRun("image", "prune", "-a", "--dry-run").Assert(c, icmd.Success).Stdout()
+	c.Assert(strings.Contains(out, helloworld.ID), check.Equals, true, check.Commentf("output: %s", out))
+	c.Assert(strings.Contains(out, busybox.ID), check.Equals, false, check.Commentf("output: %s", out))
+	c.Assert(strings.Contains(out, "Total reclaimable space"), check.Equals, true)
+	command.PouchRun("image", "inspect", helloworldImage).Assert(c, icmd.Success)
+
+	out = command.PouchRun("image", "prune", "-a", "-f").Assert(c, icmd.Success).Stdout()
+	c.Assert(strings.Contains(out, helloworld.ID), check.Equals, true, check.Commentf("output: %s", out))
+	c.Assert(strings.Contains(out, "Total reclaimed space"), check.Equals, true)
+
+	res := command.PouchRun("image", "inspect", helloworldImage)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	command.PouchRun("image", "inspect", busyboxImage).Assert(c, icmd.Success)
+}
+
+// TestImagePruneInvalidFilter tests "pouch image prune" with invalid filter.
+func (suite *PouchImagesSuite) TestImagePruneInvalidFilter(c *check.C) {
+	res := command.PouchRun("image", "prune", "-f", "--filter", "reference=busybox")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*invalid filter reference.*")
+}
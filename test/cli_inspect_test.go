@@ -279,3 +279,28 @@ func (suite *PouchInspectSuite) TestContainerInspectExecIds(c *check.C) {
 	expected := fmt.Sprintf("[%v]\n", execIDs[0])
 	c.Assert(string(output), check.Equals, expected)
 }
+
+// TestInspectWithType tests that inspect restricts the lookup by --type.
+func (suite *PouchInspectSuite) TestInspectWithType(c *check.C) {
+	name := "TestInspectWithType"
+	command.PouchRun("volume", "create", "--name", name).Assert(c, icmd.Success)
+	defer command.PouchRun("volume", "rm", name)
+
+	// the volume is found without --type.
+	output := command.PouchRun("inspect", "-f", "{{.Name}}", name).Stdout()
+	c.Assert(output, check.Equals, name+"\n")
+
+	output = command.PouchRun("inspect", "--type", "volume", "-f", "{{.Name}}", name).Stdout()
+	c.Assert(output, check.Equals, name+"\n")
+
+	res := command.PouchRun("inspect", "--type", "container", name)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+
+	// multiple objects of different types.
+	output = command.PouchRun("inspect", "-f", "{{.Name}}", name, "bridge").Stdout()
+	c.Assert(output, check.Equals, name+"\nbridge\n")
+
+	res = command.PouchRun("inspect", "--type", "foo", name)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*invalid type.*")
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchKillSuite is the test suite for kill CLI.
+type PouchKillSuite struct{}
+
+func init() {
+	check.Suite(&PouchKillSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchKillSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	environment.PruneAllContainers(apiClient)
+
+	PullImage(c, busyboxImage)
+}
+
+// TearDownTest does cleanup work in the end of each test.
+func (suite *PouchKillSuite) TearDownTest(c *check.C) {
+}
+
+// TestKillWorks tests "pouch kill" sends SIGKILL to container by default.
+func (suite *PouchKillSuite) TestKillWorks(c *check.C) {
+	name := "kill-normal"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("kill", name)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, name)
+
+	command.PouchRun("wait", name).Assert(c, icmd.Success)
+	exitCode, err := inspectFilter(name, ".State.ExitCode")
+	c.Assert(err, check.IsNil)
+	c.Assert(exitCode, check.Equals, "137")
+}
+
+// TestKillWithSignal tests "pouch kill --signal" sends the given signal.
+func (suite *PouchKillSuite) TestKillWithSignal(c *check.C) {
+	name := "kill-with-signal"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage,
+		"sh", "-c", "trap 'exit 10' USR1; while true; do sleep 1; done").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	command.PouchRun("kill", "-s", "SIGUSR1", name).Assert(c, icmd.Success)
+
+	command.PouchRun("wait", name).Assert(c, icmd.Success)
+	exitCode, err := inspectFilter(name, ".State.ExitCode")
+	c.Assert(err, check.IsNil)
+	c.Assert(exitCode, check.Equals, "10")
+}
+
+// TestKillMultiContainers tests "pouch kill" continues on the error of
+// container and returns failure.
+func (suite *PouchKillSuite) TestKillMultiContainers(c *check.C) {
+	name1, name2 := "kill-multi-1", "kill-multi-2"
+	command.PouchRun("run", "-d", "--name", name1, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name1)
+	command.PouchRun("create", "--name", name2, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name2)
+
+	res := command.PouchRun("kill", "-s", "15", name2, name1, "kill-not-exist")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, name1)
+	c.Assert(strings.Contains(res.Stderr(), "is not running"), check.Equals, true)
+	c.Assert(strings.Contains(res.Stderr(), "kill-not-exist"), check.Equals, true)
+}
+
+// TestKillWithInvalidSignal tests "pouch kill" rejects the unknown signal.
+func (suite *PouchKillSuite) TestKillWithInvalidSignal(c *check.C) {
+	for _, sig := range []string{"SIGFOO", "0", "999"} {
+		res := command.PouchRun("kill", "-s", sig, "kill-not-exist")
+		c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+		c.Assert(strings.Contains(res.Stderr(), "invalid signal: "+sig), check.Equals, true)
+	}
+}
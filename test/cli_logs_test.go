@@ -178,6 +178,30 @@ func (suite *PouchLogsSuite) TestFollowMode(c *check.C) {
 	}
 }
 
+// TestFollowModeReconnect tests follow mode with reconnect keeps the output
+// same as before, and --no-reconnect conflicts with --reconnect.
+func (suite *PouchLogsSuite) TestFollowModeReconnect(c *check.C) {
+	cname := "TestCLILogs_follow_mode_reconnect"
+
+	command.PouchRun(
+		"run",
+		"-d",
+		"--name", cname,
+		busyboxImage,
+		"sh", "-c", "for i in $(seq 1 3); do sleep 1; echo hello$i; done;",
+	).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	res := command.PouchRun("logs", "-f", cname)
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "hello1\nhello2\nhello3\n")
+
+	command.PouchRun("logs", "-f", "--reconnect", "--no-reconnect", cname).Assert(c, icmd.Expected{
+		ExitCode: 1,
+		Err:      "Conflicting options: --reconnect and --no-reconnect",
+	})
+}
+
 // TestLogsOpt tests if log options could work.
 func (suite *PouchLogsSuite) TestLogsOpt(c *check.C) {
 	cname := "TestCLILogs_LogsOpt"
@@ -363,6 +363,42 @@ func (suite *PouchNetworkSuite) TestNetworkCreateWithIPAMOption(c *check.C) {
 	c.Assert(networkJSON[0].IPAM.Options["test"], check.Equals, "foo")
 }
 
+// TestNetworkCreateWithIPAMConfig creates network with subnet, gateway, ip
+// range and auxiliary addresses.
+func (suite *PouchNetworkSuite) TestNetworkCreateWithIPAMConfig(c *check.C) {
+	networkName := "TestNetworkCreateWithIPAMConfig"
+	command.PouchRun("network", "create",
+		"-d", "bridge",
+		"--subnet", "192.168.101.0/24",
+		"--gateway", "192.168.101.1",
+		"--ip-range", "192.168.101.128/25",
+		"--aux-address", "router=192.168.101.5",
+		"--opt", "com.docker.network.bridge.name=p-ipam",
+		networkName).Assert(c, icmd.Success)
+	defer command.PouchRun("network", "remove", networkName)
+
+	networkInfo := command.PouchRun("network", "inspect", networkName).Stdout()
+	networkJSON := []types.NetworkCreate{}
+	err := json.Unmarshal([]byte(networkInfo), &networkJSON)
+	if err != nil || len(networkJSON) == 0 {
+		c.Fatalf("fail to deserialize NetworkCreate: %v", err)
+	}
+	c.Assert(len(networkJSON[0].IPAM.Config), check.Equals, 1)
+	ipamConfig := networkJSON[0].IPAM.Config[0]
+	c.Assert(ipamConfig.Subnet, check.Equals, "192.168.101.0/24")
+	c.Assert(ipamConfig.Gateway, check.Equals, "192.168.101.1")
+	c.Assert(ipamConfig.IPRange, check.Equals, "192.168.101.128/25")
+	c.Assert(ipamConfig.AuxAddress["router"], check.Equals, "192.168.101.5")
+
+	// gateway out of subnet is invalid
+	res := command.PouchRun("network", "create",
+		"--subnet", "192.168.102.0/24",
+		"--gateway", "192.168.103.1",
+		networkName+"-invalid")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(strings.Contains(res.Stderr(), "not in subnet"), check.Equals, true)
+}
+
 // TestNetworkCreateDup tests creating duplicate network return error.
 func (suite *PouchNetworkSuite) TestNetworkCreateDup(c *check.C) {
 	funcname := "TestNetworkCreateDup"
@@ -428,6 +464,37 @@ func (suite *PouchNetworkSuite) TestNetworkPortMapping(c *check.C) {
 	c.Assert(success, check.Equals, true)
 }
 
+// TestNetworkPrune tests pruning networks only removes the unused user-defined ones.
+func (suite *PouchNetworkSuite) TestNetworkPrune(c *check.C) {
+	funcname := "TestNetworkPrune"
+
+	unused := funcname + "-unused"
+	used := funcname + "-used"
+	command.PouchRun("network", "create", "--name", unused, "-d", "bridge",
+		"--subnet", "172.30.1.0/24", "--label", "prune="+funcname).Assert(c, icmd.Success)
+	defer command.PouchRun("network", "remove", unused)
+	command.PouchRun("network", "create", "--name", used, "-d", "bridge",
+		"--subnet", "172.30.2.0/24", "--label", "prune="+funcname).Assert(c, icmd.Success)
+	defer command.PouchRun("network", "remove", used)
+
+	command.PouchRun("run", "-d", "--net", used, "--name", funcname, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, funcname)
+
+	res := command.PouchRun("network", "prune", "-f", "--filter", "label=prune="+funcname)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), unused), check.Equals, true)
+	c.Assert(strings.Contains(res.Stdout(), used), check.Equals, false)
+
+	command.PouchRun("network", "inspect", unused).Assert(c, icmd.Expected{ExitCode: 1})
+	command.PouchRun("network", "inspect", used).Assert(c, icmd.Success)
+
+	// the predefined networks are never pruned.
+	command.PouchRun("network", "prune", "-f").Assert(c, icmd.Success)
+	for _, name := range []string{"bridge", "host", "none"} {
+		command.PouchRun("network", "inspect", name).Assert(c, icmd.Success)
+	}
+}
+
 func createBridge(bridgeName string) (netlink.Link, error) {
 	br, err := netlink.LinkByName(bridgeName)
 	if err == nil && br != nil {
@@ -511,7 +578,12 @@ func (suite *PouchNetworkSuite) TestNetworkDisconnect(c *check.C) {
 		c.Errorf("container network mode should be 'bridge'")
 	}
 
-	command.PouchRun("network", "disconnect", "bridge", name).Assert(c, icmd.Success)
+	// disconnecting the last network requires force
+	res := command.PouchRun("network", "disconnect", "bridge", name)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(strings.Contains(res.Stderr(), "use force to disconnect it"), check.Equals, true)
+
+	command.PouchRun("network", "disconnect", "--force", "bridge", name).Assert(c, icmd.Success)
 	inspectInfo = command.PouchRun("inspect", name).Stdout()
 	metaJSON = []types.ContainerJSON{}
 	if err := json.Unmarshal([]byte(inspectInfo), &metaJSON); err != nil {
@@ -527,6 +599,55 @@ func (suite *PouchNetworkSuite) TestNetworkDisconnect(c *check.C) {
 	command.PouchRun("start", name).Assert(c, icmd.Success)
 }
 
+// TestNetworkMultipleWithAlias is to verify the container can be connected to
+// multiple networks with network-scoped aliases.
+func (suite *PouchNetworkSuite) TestNetworkMultipleWithAlias(c *check.C) {
+	name := "TestNetworkMultipleWithAlias"
+	net1, net2 := name+"-net1", name+"-net2"
+
+	command.PouchRun("network", "create", "-d", "bridge", "--subnet=172.69.0.0/24", net1).Assert(c, icmd.Success)
+	defer command.PouchRun("network", "rm", net1)
+	command.PouchRun("network", "create", "-d", "bridge", "--subnet=172.70.0.0/24", net2).Assert(c, icmd.Success)
+	defer command.PouchRun("network", "rm", net2)
+
+	// alias is only supported in user defined networks
+	res := command.PouchRun("run", "-d", "--net", "bridge", "--network-alias", "web", busyboxImage, "top")
+	c.Assert(res.Stderr(), check.NotNil)
+	c.Assert(strings.Contains(res.Stderr(), "network-scoped alias is supported only"), check.Equals, true)
+
+	command.PouchRun("run", "-d", "--name", name,
+		"--net", net1, "--net", net2,
+		"--network-alias", "web", "--network-alias", net2+":db",
+		busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	inspectInfo := command.PouchRun("inspect", name).Stdout()
+	metaJSON := []types.ContainerJSON{}
+	if err := json.Unmarshal([]byte(inspectInfo), &metaJSON); err != nil {
+		c.Errorf("failed to decode inspect output: %v", err)
+	}
+	networks := metaJSON[0].NetworkSettings.Networks
+	c.Assert(len(networks), check.Equals, 2)
+	c.Assert(networks[net1].Aliases, check.DeepEquals, []string{"web"})
+	c.Assert(networks[net2].Aliases, check.DeepEquals, []string{"db"})
+
+	// the aliases are resolved in the networks
+	client := name + "-client"
+	command.PouchRun("run", "-d", "--name", client, "--net", net2, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, client)
+	command.PouchRun("exec", client, "ping", "-c", "1", "db").Assert(c, icmd.Success)
+
+	command.PouchRun("network", "disconnect", net2, name).Assert(c, icmd.Success)
+	inspectInfo = command.PouchRun("inspect", name).Stdout()
+	metaJSON = []types.ContainerJSON{}
+	if err := json.Unmarshal([]byte(inspectInfo), &metaJSON); err != nil {
+		c.Errorf("failed to decode inspect output: %v", err)
+	}
+	_, ok := metaJSON[0].NetworkSettings.Networks[net2]
+	c.Assert(ok, check.Equals, false)
+	command.PouchRun("exec", client, "ping", "-c", "1", "db").Assert(c, icmd.Expected{ExitCode: 1})
+}
+
 // TestNetworkConnectWithRestart is to verify the 'network connect'
 // and 'network disconnect' after restart daemon.
 func (suite *PouchNetworkSuite) TestNetworkConnectWithRestart(c *check.C) {
@@ -75,7 +75,7 @@ func (suite *PouchPauseSuite) TestPauseInWrongWay(c *check.C) {
 		{
 			name:          "not running container name",
 			args:          stoppedContainerName,
-			exepctedError: "container's status is not running",
+			exepctedError: "is not running, its status is stopped",
 		},
 		{
 			name:          "unwanted flag",
@@ -91,3 +91,31 @@ func (suite *PouchPauseSuite) TestPauseInWrongWay(c *check.C) {
 		}
 	}
 }
+
+// TestPauseStateValidation tests pausing a paused container fails, and the
+// paused state is shown in ps and inspect.
+func (suite *PouchPauseSuite) TestPauseStateValidation(c *check.C) {
+	name := "pause-state-validation"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	command.PouchRun("pause", name).Assert(c, icmd.Success)
+
+	res := command.PouchRun("pause", name)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(strings.Contains(res.Stderr(), "is already paused"), check.Equals, true)
+
+	status, err := inspectFilter(name, ".State.Status")
+	c.Assert(err, check.IsNil)
+	c.Assert(status, check.Equals, "paused")
+
+	res = command.PouchRun("ps")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "(paused)"), check.Equals, true)
+
+	command.PouchRun("unpause", name).Assert(c, icmd.Success)
+
+	res = command.PouchRun("unpause", name)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(strings.Contains(res.Stderr(), "is not paused"), check.Equals, true)
+}
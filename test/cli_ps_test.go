@@ -199,6 +199,43 @@ func (suite *PouchPsSuite) TestPsFilterUnequal(c *check.C) {
 	c.Assert(exist4, check.Equals, true)
 }
 
+// TestPsFilterCombined tests "pouch ps -f" with label negation, ancestor and
+// exited filters ANDed together.
+func (suite *PouchPsSuite) TestPsFilterCombined(c *check.C) {
+	labelA := "combined-label-a"
+	command.PouchRun("run", "-d", "--name", labelA, "-l", "a=b", "-l", "env=prod", busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, labelA)
+
+	labelB := "combined-label-b"
+	command.PouchRun("run", "-d", "--name", labelB, "-l", "a=b", busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, labelB)
+
+	exited := "combined-exited"
+	command.PouchRun("run", "--name", exited, "-l", "a=b", busyboxImage, "sh", "-c", "exit 3").Assert(c, icmd.Expected{ExitCode: 3})
+	defer DelContainerForceMultyTime(c, exited)
+
+	res := command.PouchRun("ps", "-f", "label=a=b", "-f", "label!=env=prod").Assert(c, icmd.Success)
+	kv := psToKV(res.Combined())
+	_, exist1 := kv[labelA]
+	_, exist2 := kv[labelB]
+	_, exist3 := kv[exited]
+	c.Assert(exist1, check.Equals, false)
+	c.Assert(exist2, check.Equals, true)
+	c.Assert(exist3, check.Equals, false)
+
+	res = command.PouchRun("ps", "-f", "ancestor="+busyboxImage, "-f", "exited=3").Assert(c, icmd.Success)
+	kv = psToKV(res.Combined())
+	_, exist1 = kv[labelA]
+	_, exist2 = kv[labelB]
+	_, exist3 = kv[exited]
+	c.Assert(exist1, check.Equals, false)
+	c.Assert(exist2, check.Equals, false)
+	c.Assert(exist3, check.Equals, true)
+
+	result := command.PouchRun("ps", "-f", "name!=foo")
+	c.Assert(util.PartialEqual(result.Stderr(), "negation is only supported by label filter"), check.IsNil)
+}
+
 // TestPsAll tests "pouch ps -a" work
 func (suite *PouchPsSuite) TestPsAll(c *check.C) {
 	name := "ps-all"
@@ -256,6 +293,26 @@ func (suite *PouchPsSuite) TestPsNoTrunc(c *check.C) {
 	c.Assert(kv[name].id, check.Equals, containerID)
 }
 
+// TestPsFormat tests "pouch ps --format" work
+func (suite *PouchPsSuite) TestPsFormat(c *check.C) {
+	name := "ps-format"
+
+	command.PouchRun("create", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	containerID, err := inspectFilter(name, ".ID")
+	c.Assert(err, check.IsNil)
+
+	res := command.PouchRun("ps", "-a", "--no-trunc", "--format", "{{.ID}} {{.Name}}", "--filter", "name="+name)
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, containerID+" "+name+"\n")
+
+	// the invalid template fails before listing containers.
+	res = command.PouchRun("ps", "--format", "{{.ID")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*failed to parse format.*")
+}
+
 // psTable represents the table of "pouch ps" result.
 type psTable struct {
 	id      string
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"github.com/alibaba/pouch/test/command"
@@ -73,3 +75,54 @@ func (suite *PouchPullSuite) TestPullInWrongWay(c *check.C) {
 		c.Assert(res.Stderr(), check.NotNil)
 	}
 }
+
+// TestPullWithPlatform tests "pouch pull --platform" with the platforms which
+// are invalid or not provided by the image.
+func (suite *PouchPullSuite) TestPullWithPlatform(c *check.C) {
+	// pull with invalid platform
+	{
+		res := command.PouchRun("pull", "--platform", "linux/amd64/v1/v2", busyboxImage)
+		c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+		c.Assert(strings.Contains(res.Stderr(), "invalid platform"), check.Equals, true, check.Commentf(res.Stderr()))
+	}
+
+	// pull with platform not provided by the image
+	{
+		res := command.PouchRun("pull", "--platform", "plan9/mips64", busyboxImage)
+		c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+		c.Assert(strings.Contains(res.Stderr(), "does not provide the platform plan9/mips64"), check.Equals, true, check.Commentf(res.Stderr()))
+	}
+}
+
+// TestPullQuietAndSummary tests "pouch pull" prints a summary line and the
+// resolved digest when the stdout is not terminal and only the digest with
+// --quiet.
+func (suite *PouchPullSuite) TestPullQuietAndSummary(c *check.C) {
+	res := command.PouchRun("pull", busyboxImage).Assert(c, icmd.Success)
+	lines := strings.Split(strings.TrimSpace(res.Stdout()), "\n")
+	c.Assert(len(lines), check.Equals, 2, check.Commentf(res.Stdout()))
+	c.Assert(lines[0], check.Matches, `\d+ done, \d+ exists, total: .*, elapsed: .*s`)
+	c.Assert(lines[1], check.Matches, `.*@sha256:[0-9a-f]{64}`)
+
+	res = command.PouchRun("pull", "-q", busyboxImage).Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Matches, `sha256:[0-9a-f]{64}`)
+}
+
+// TestPullWithDigest tests "pouch pull" pins the image by digest and writes
+// the digest into --digest-file.
+func (suite *PouchPullSuite) TestPullWithDigest(c *check.C) {
+	digestFile := filepath.Join(c.MkDir(), "digest")
+	image := environment.BusyboxRepo + "@" + environment.BusyboxDigest
+
+	res := command.PouchRun("pull", "--digest-file", digestFile, image).Assert(c, icmd.Success)
+	lines := strings.Split(strings.TrimSpace(res.Stdout()), "\n")
+	c.Assert(lines[len(lines)-1], check.Equals, image)
+
+	data, err := ioutil.ReadFile(digestFile)
+	c.Assert(err, check.IsNil)
+	c.Assert(strings.TrimSpace(string(data)), check.Equals, environment.BusyboxDigest)
+
+	name := "TestPullWithDigest"
+	command.PouchRun("run", "--name", name, image, "true").Assert(c, icmd.Success)
+	DelContainerForceMultyTime(c, name)
+}
@@ -36,3 +36,37 @@ func (suite *PouchRenameSuite) TestRenameInvalidName(c *check.C) {
 		check.Commentf("Expected '%s', but got %q", "Invalid container name", res.Stdout())
 	}
 }
+
+// TestRenameExistingName tests renaming to an existing name fails and keeps the old name.
+func (suite *PouchRenameSuite) TestRenameExistingName(c *check.C) {
+	name := "TestRenameExistingName"
+	existing := name + "Existing"
+	command.PouchRun("create", "--name", name, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+	command.PouchRun("create", "--name", existing, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, existing)
+
+	res := command.PouchRun("rename", name, existing)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(strings.Contains(res.Stderr(), "already exist"), check.Equals, true, check.Commentf("got %q", res.Stderr()))
+
+	output := command.PouchRun("inspect", "-f", "{{.Name}}", name).Assert(c, icmd.Success).Stdout()
+	c.Assert(strings.TrimSpace(output), check.Equals, name)
+}
+
+// TestRenameWorks tests the new name takes effect immediately.
+func (suite *PouchRenameSuite) TestRenameWorks(c *check.C) {
+	name := "TestRenameWorks"
+	newName := name + "New"
+	command.PouchRun("create", "--name", name, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, newName)
+
+	command.PouchRun("rename", name, newName).Assert(c, icmd.Success)
+
+	output := command.PouchRun("inspect", "-f", "{{.Name}}", newName).Assert(c, icmd.Success).Stdout()
+	c.Assert(strings.TrimSpace(output), check.Equals, newName)
+	command.PouchRun("inspect", name).Assert(c, icmd.Expected{ExitCode: 1})
+
+	output = command.PouchRun("ps", "-a").Assert(c, icmd.Success).Stdout()
+	c.Assert(strings.Contains(output, newName), check.Equals, true)
+}
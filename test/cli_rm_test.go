@@ -66,3 +66,84 @@ func (suite *PouchRmSuite) TestContainerRmWithVolume(c *check.C) {
 	c.Assert(volumeNums, check.Equals, expectVolumeNums+1)
 	c.Assert(found, check.Equals, true)
 }
+
+// TestContainerRmWithVolumeReport tests removing container reports the
+// removed and skipped volumes.
+func (suite *PouchRmSuite) TestContainerRmWithVolumeReport(c *check.C) {
+	volumeName := "rmVolumeReport-test-volume"
+	containerName := "rmVolumeReport-test"
+	sharedName := "rmVolumeReport-test-shared"
+
+	command.PouchRun("volume", "create", "-n", volumeName).Assert(c, icmd.Success)
+	defer command.PouchRun("volume", "rm", volumeName)
+
+	command.PouchRun("create", "--name", containerName,
+		"-v", volumeName+":/mnt",
+		"-v", "/home",
+		"-v", "/data",
+		busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, containerName)
+
+	// the anonymous volume of /data is shared with another container.
+	command.PouchRun("create", "--name", sharedName, "--volumes-from", containerName, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, sharedName)
+
+	home := strings.TrimSpace(command.PouchRun("inspect", "-f",
+		`{{range .Mounts}}{{if eq .Destination "/home"}}{{.Name}}{{end}}{{end}}`, containerName).Stdout())
+	c.Assert(home, check.Not(check.Equals), "")
+
+	res := command.PouchRun("rm", "-v", containerName)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "removed volume "+home), check.Equals, true)
+	c.Assert(strings.Contains(res.Stderr(), "volume "+volumeName+" of container "+containerName+" is named or used by other containers, skipped"), check.Equals, true)
+	c.Assert(strings.Count(res.Stderr(), "is named or used by other containers, skipped"), check.Equals, 2)
+
+	command.PouchRun("volume", "inspect", volumeName).Assert(c, icmd.Success)
+}
+
+// TestContainerRmParallel tests removing containers concurrently continues
+// on the error of container.
+func (suite *PouchRmSuite) TestContainerRmParallel(c *check.C) {
+	names := []string{"rm-parallel-1", "rm-parallel-2", "rm-parallel-3"}
+	for _, name := range names {
+		command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+		defer DelContainerForceMultyTime(c, name)
+	}
+
+	command.PouchRun("stop", "--parallel", "2", names[0], names[1], names[2]).Assert(c, icmd.Success)
+
+	res := command.PouchRun("rm", "--parallel", "2", names[0], "rm-parallel-not-exist", names[1], names[2])
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(strings.Contains(res.Stderr(), "rm-parallel-not-exist"), check.Equals, true)
+	for _, name := range names {
+		c.Assert(strings.Contains(res.Stdout(), name), check.Equals, true)
+		res := command.PouchRun("inspect", name)
+		c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	}
+
+	res = command.PouchRun("rm", "--parallel", "0", names[0])
+	c.Assert(strings.Contains(res.Stderr(), "invalid parallel 0"), check.Equals, true)
+}
+
+// TestContainerRmRunning tests removing running container requires force.
+func (suite *PouchRmSuite) TestContainerRmRunning(c *check.C) {
+	name := "rm-running"
+	command.PouchRun("run", "-d", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("rm", name)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(strings.Contains(res.Stderr(), "stop the container before removing or use force"), check.Equals, true)
+
+	status, err := inspectFilter(name, ".State.Status")
+	c.Assert(err, check.IsNil)
+	c.Assert(status, check.Equals, "running")
+
+	command.PouchRun("rm", "-f", name).Assert(c, icmd.Success)
+	res = command.PouchRun("inspect", name)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+
+	// force removing the stopped container works too.
+	command.PouchRun("create", "--name", name, busyboxImage, "top").Assert(c, icmd.Success)
+	command.PouchRun("rm", "-f", name).Assert(c, icmd.Success)
+}
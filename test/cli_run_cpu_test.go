@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/alibaba/pouch/apis/types"
 	"github.com/alibaba/pouch/test/command"
@@ -91,3 +92,41 @@ func (suite *PouchRunCPUSuite) TestRunWithCPULimit(c *check.C) {
 		checkFileContains(c, path, "1000")
 	}
 }
+
+// TestRunWithInvalidCpuset tests running container with invalid or unavailable cpuset fails.
+func (suite *PouchRunCPUSuite) TestRunWithInvalidCpuset(c *check.C) {
+	name := "TestRunWithInvalidCpuset"
+
+	for _, args := range [][]string{
+		{"--cpuset-cpus", "3-1"},
+		{"--cpuset-mems", "a"},
+		{"--cpuset-cpus", "8000"},
+		{"--cpuset-mems", "8000"},
+	} {
+		cmd := append([]string{"run", "--name", name}, args...)
+		cmd = append(cmd, busyboxImage, "true")
+
+		res := command.PouchRun(cmd...)
+		c.Assert(res.ExitCode, check.Not(check.Equals), 0, check.Commentf("args %v", args))
+		DelContainerForceMultyTime(c, name)
+	}
+}
+
+// TestRunWithCPUs tests --cpus is applied by CFS period and quota.
+func (suite *PouchRunCPUSuite) TestRunWithCPUs(c *check.C) {
+	cname := "TestRunWithCPUs"
+	command.PouchRun("run", "-d", "--cpus", "0.5", "--name", cname, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	res := command.PouchRun("inspect", "-f", "{{.HostConfig.NanoCpus}} {{.HostConfig.CPUPeriod}} {{.HostConfig.CPUQuota}}", cname)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, "500000000 100000 50000")
+
+	containerID, err := inspectFilter(cname, ".ID")
+	c.Assert(err, check.IsNil)
+	checkFileContains(c, fmt.Sprintf("/sys/fs/cgroup/cpu/default/%s/cpu.cfs_quota_us", containerID), "50000")
+
+	res = command.PouchRun("run", "--cpus", "1", "--cpu-quota", "20000", busyboxImage, "true")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*Conflicting options.*")
+}
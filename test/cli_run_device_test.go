@@ -209,3 +209,79 @@ func (suite *PouchRunDeviceSuite) TestRunDeviceReadWriteBpsIops(c *check.C) {
 	path = fmt.Sprintf("%s/%s/blkio.throttle.write_iops_device", commonDir, containerID)
 	checkFileContains(c, path, "1000")
 }
+
+// TestRunDeviceNotExist is to verify --device fails with the device which
+// does not exist on host.
+func (suite *PouchRunDeviceSuite) TestRunDeviceNotExist(c *check.C) {
+	name := "TestRunDeviceNotExist"
+
+	res := command.PouchRun("run",
+		"--name", name,
+		"--device", "/dev/nonexist:/dev/nonexist",
+		busyboxImage,
+		"ls", "/dev/nonexist")
+	defer DelContainerForceMultyTime(c, name)
+
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	expected := "does not exist on host"
+	if out := res.Combined(); !strings.Contains(out, expected) {
+		c.Fatalf("Output should contain %s unexpected output %s. \n", expected, out)
+	}
+}
+
+// TestRunDeviceInspect is to verify inspect lists the devices passed into
+// container.
+func (suite *PouchRunDeviceSuite) TestRunDeviceInspect(c *check.C) {
+	if _, err := os.Stat("/dev/zero"); err != nil {
+		c.Skip("Host does not have /dev/zero")
+	}
+
+	name := "TestRunDeviceInspect"
+
+	command.PouchRun("create",
+		"--name", name,
+		"--device", "/dev/zero:/dev/testDev:rw",
+		"--device-cgroup-rule", "c 1:5 rwm",
+		busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	res := command.PouchRun("inspect", name)
+	res.Assert(c, icmd.Success)
+
+	result := []types.ContainerJSON{}
+	if err := json.Unmarshal([]byte(res.Stdout()), &result); err != nil {
+		c.Errorf("failed to decode inspect output: %v", err)
+	}
+
+	c.Assert(result[0].HostConfig.Devices, check.DeepEquals, []*types.DeviceMapping{{
+		PathOnHost:        "/dev/zero",
+		PathInContainer:   "/dev/testDev",
+		CgroupPermissions: "rw",
+	}})
+	c.Assert(result[0].HostConfig.DeviceCgroupRules, check.DeepEquals, []string{"c 1:5 rwm"})
+}
+
+// TestRunDeviceCgroupRule is to verify --device-cgroup-rule allows the
+// container to create and access the device node.
+func (suite *PouchRunDeviceSuite) TestRunDeviceCgroupRule(c *check.C) {
+	name := "TestRunDeviceCgroupRule"
+
+	// /dev/zero is the char device 1:5.
+	res := command.PouchRun("run",
+		"--name", name,
+		"--device-cgroup-rule", "c 1:5 rwm",
+		busyboxImage,
+		"sh", "-c", "mknod /dev/myzero c 1 5 && head -c 4 /dev/myzero | wc -c")
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, "4")
+
+	res = command.PouchRun("run",
+		"--name", name+"Invalid",
+		"--device-cgroup-rule", "c 1:5 rwx",
+		busyboxImage,
+		"true")
+	defer DelContainerForceMultyTime(c, name+"Invalid")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(strings.Contains(res.Combined(), "invalid device cgroup rule"), check.Equals, true)
+}
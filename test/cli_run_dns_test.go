@@ -135,3 +135,34 @@ func (suite *PouchRunDNSSuite) TestRunWithDNSRepeatFlags(c *check.C) {
 	c.Assert(err, check.IsNil)
 	c.Assert(dnsSearch, check.Equals, "[mydomain mydomain2]")
 }
+
+// TestRunWithInvalidDNSFlags tests invalid DNS related flags are rejected.
+func (suite *PouchRunDNSSuite) TestRunWithInvalidDNSFlags(c *check.C) {
+	for _, args := range [][]string{
+		{"--dns", "dns.example.com"},
+		{"--dns-search", ".example.com"},
+		{"--dns-option", "ndots: 2"},
+	} {
+		res := command.PouchRun(append(append([]string{"create"}, args...), busyboxImage)...)
+		c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+		c.Assert(strings.Contains(res.Stderr(), "invalid dns"), check.Equals, true)
+	}
+}
+
+// TestRunWithDNSFlagsInHostNetwork tests DNS related flags are ignored in host network mode.
+func (suite *PouchRunDNSSuite) TestRunWithDNSFlagsInHostNetwork(c *check.C) {
+	cname := "TestRunWithDNSFlagsInHostNetwork"
+
+	res := command.PouchRun("create", "--name", cname,
+		"--net", "host",
+		"--dns", "1.2.3.4",
+		busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, cname)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "DNS settings are ignored in host network mode"), check.Equals, true)
+
+	command.PouchRun("start", cname).Assert(c, icmd.Success)
+	res = command.PouchRun("exec", cname, "cat", "/etc/resolv.conf")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "nameserver 1.2.3.4"), check.Equals, false)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchRunHealthSuite is the test suite for run CLI with health check.
+type PouchRunHealthSuite struct{}
+
+func init() {
+	check.Suite(&PouchRunHealthSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchRunHealthSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	environment.PruneAllContainers(apiClient)
+
+	PullImage(c, busyboxImage)
+}
+
+// TearDownTest does cleanup work in the end of each test.
+func (suite *PouchRunHealthSuite) TearDownTest(c *check.C) {
+}
+
+// waitHealthStatus waits until the health status of container becomes the
+// expected one.
+func waitHealthStatus(c *check.C, name, expected string) {
+	var status string
+	for i := 0; i < 30; i++ {
+		var err error
+		status, err = inspectFilter(name, ".State.Health.Status")
+		c.Assert(err, check.IsNil)
+		if status == expected {
+			return
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	c.Fatalf("expected health status %s, got %s", expected, status)
+}
+
+// TestRunWithHealthCmd tests the container becomes healthy and then unhealthy.
+func (suite *PouchRunHealthSuite) TestRunWithHealthCmd(c *check.C) {
+	name := "TestRunWithHealthCmd"
+
+	res := command.PouchRun("run", "-d", "--name", name,
+		"--health-cmd", "cat /tmp/ready",
+		"--health-interval", "1s",
+		"--health-retries", "1",
+		busyboxImage, "sh", "-c", "touch /tmp/ready && top")
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+
+	test, err := inspectFilter(name, ".Config.Healthcheck.Test")
+	c.Assert(err, check.IsNil)
+	c.Assert(test, check.Equals, "[CMD-SHELL cat /tmp/ready]")
+
+	waitHealthStatus(c, name, "healthy")
+
+	out := command.PouchRun("ps").Assert(c, icmd.Success).Stdout()
+	c.Assert(strings.Contains(out, "(healthy)"), check.Equals, true, check.Commentf("ps output: %s", out))
+
+	command.PouchRun("exec", name, "rm", "/tmp/ready").Assert(c, icmd.Success)
+	waitHealthStatus(c, name, "unhealthy")
+}
+
+// TestRunWithHealthOptionsWithoutCmd tests health options require --health-cmd.
+func (suite *PouchRunHealthSuite) TestRunWithHealthOptionsWithoutCmd(c *check.C) {
+	name := "TestRunWithHealthOptionsWithoutCmd"
+
+	res := command.PouchRun("run", "-d", "--name", name,
+		"--health-interval", "1s", busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, name)
+
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*can only be used with --health-cmd.*")
+}
@@ -137,6 +137,35 @@ func (suite *PouchRunNetworkSuite) TestRunAddHost(c *check.C) {
 	}
 }
 
+// TestRunAddHostWithRestart is to verify the add-host entries survive the
+// container restart and are shown in inspect.
+func (suite *PouchRunNetworkSuite) TestRunAddHostWithRestart(c *check.C) {
+	name := "TestRunAddHostWithRestart"
+	command.PouchRun("run", "-d", "--name", name,
+		"--add-host=extra:86.75.30.9", "--add-host=extra6:2001:db8::1",
+		busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	command.PouchRun("restart", "-t", "1", name).Assert(c, icmd.Success)
+
+	res := command.PouchRun("exec", name, "cat", "/etc/hosts")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "86.75.30.9\textra"), check.Equals, true)
+	c.Assert(strings.Contains(res.Stdout(), "2001:db8::1\textra6"), check.Equals, true)
+
+	output := command.PouchRun("inspect", "-f", "{{.HostConfig.ExtraHosts}}", name).Stdout()
+	c.Assert(strings.TrimSpace(output), check.Equals, "[extra:86.75.30.9 extra6:2001:db8::1]")
+}
+
+// TestRunAddHostInvalid is to verify run container with invalid add-host flag fails.
+func (suite *PouchRunNetworkSuite) TestRunAddHostInvalid(c *check.C) {
+	for _, h := range []string{"extra", ":1.2.3.4", "extra:1.2.3"} {
+		res := command.PouchRun("create", "--add-host="+h, busyboxImage)
+		c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+		c.Assert(strings.Contains(res.Stderr(), "add-host"), check.Equals, true)
+	}
+}
+
 func (suite *PouchRunNetworkSuite) TestRunAddHostInHostMode(c *check.C) {
 	name := "TestRunAddHostInHostMode"
 	expectedOutput := "1.2.3.4\textra"
@@ -66,3 +66,45 @@ func (suite *PouchRunPidSuite) TestRunWithPidsLimit(c *check.C) {
 	c.Assert(err, check.IsNil)
 	c.Assert(pidsLimit, check.Equals, "10")
 }
+
+// TestUpdatePidsLimit tests updating the pids limit of running container.
+func (suite *PouchRunPidSuite) TestUpdatePidsLimit(c *check.C) {
+	// pids cgroup may not supported in inner ci
+	SkipIfFalse(c, func() bool {
+		if _, err := os.Stat("/sys/fs/cgroup/pids"); err != nil {
+			return false
+		}
+		return true
+	})
+
+	cname := "TestUpdatePidsLimit"
+	command.PouchRun("run", "-d", "--pids-limit", "10",
+		"--name", cname, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	command.PouchRun("update", "--pids-limit", "20", cname).Assert(c, icmd.Success)
+
+	pidsLimit, err := inspectFilter(cname, ".HostConfig.PidsLimit")
+	c.Assert(err, check.IsNil)
+	c.Assert(pidsLimit, check.Equals, "20")
+
+	res := command.PouchRun("exec", cname, "cat", "/sys/fs/cgroup/pids/pids.max")
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "20\n")
+
+	command.PouchRun("update", "--pids-limit", "-1", cname).Assert(c, icmd.Success)
+
+	res = command.PouchRun("exec", cname, "cat", "/sys/fs/cgroup/pids/pids.max")
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "max\n")
+}
+
+// TestRunWithInvalidPidsLimit tests that the pids limit less than -1 is rejected.
+func (suite *PouchRunPidSuite) TestRunWithInvalidPidsLimit(c *check.C) {
+	cname := "TestRunWithInvalidPidsLimit"
+	res := command.PouchRun("run", "--pids-limit", "-2",
+		"--name", cname, busyboxImage, "true")
+	defer DelContainerForceMultyTime(c, cname)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*invalid pids limit.*")
+}
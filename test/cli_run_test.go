@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"strings"
 	"time"
@@ -147,6 +148,37 @@ func (suite *PouchRunSuite) TestRunRestartPolicyNone(c *check.C) {
 	}
 }
 
+// TestRunRestartPolicyOnFailure is to verify restart policy on-failure stops
+// restarting after the max retry count.
+func (suite *PouchRunSuite) TestRunRestartPolicyOnFailure(c *check.C) {
+	name := "TestRunRestartPolicyOnFailure"
+	res := command.PouchRun("run", "-d", "--name", name, "--restart=on-failure:2",
+		busyboxImage, "sh", "-c", "exit 1")
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+
+	// the backoff delays are 100ms and 200ms.
+	time.Sleep(3 * time.Second)
+
+	output := command.PouchRun("inspect", "-f", "{{.HostConfig.RestartPolicy.Name}} {{.RestartCount}}", name).Stdout()
+	c.Assert(strings.TrimSpace(output), check.Equals, "on-failure 2")
+}
+
+// TestRunRestartPolicyAlwaysStopped is to verify the container manually
+// stopped is not restarted by restart policy always.
+func (suite *PouchRunSuite) TestRunRestartPolicyAlwaysStopped(c *check.C) {
+	name := "TestRunRestartPolicyAlwaysStopped"
+	res := command.PouchRun("run", "-d", "--name", name, "--restart=always", busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+
+	command.PouchRun("stop", "-t", "1", name).Assert(c, icmd.Success)
+	time.Sleep(2 * time.Second)
+
+	output := command.PouchRun("inspect", "-f", "{{.State.Status}}", name).Stdout()
+	c.Assert(strings.TrimSpace(output), check.Equals, "stopped")
+}
+
 // TestRunWithIPCMode is to verify --specific IPC mode when running a container.
 // TODO: test container ipc namespace mode.
 func (suite *PouchRunSuite) TestRunWithIPCMode(c *check.C) {
@@ -188,6 +220,28 @@ func (suite *PouchRunSuite) TestRunWithSysctls(c *check.C) {
 	}
 }
 
+// TestRunWithInvalidSysctls is to verify run container fails with the sysctls
+// not namespaced or not in the namespace of container.
+func (suite *PouchRunSuite) TestRunWithInvalidSysctls(c *check.C) {
+	name := "run-invalid-sysctl"
+
+	for _, tc := range []struct {
+		args     []string
+		expected string
+	}{
+		{args: []string{"--sysctl", "kernel.pid_max=4096"}, expected: "is not allowed"},
+		{args: []string{"--sysctl", "net.core.somaxconn=1024", "--net", "host"}, expected: "not allowed in host network namespace"},
+		{args: []string{"--sysctl", "kernel.shmmax=1024", "--ipc", "host"}, expected: "not allowed in host ipc namespace"},
+	} {
+		args := append([]string{"run", "--name", name}, tc.args...)
+		res := command.PouchRun(append(args, busyboxImage, "true")...)
+		DelContainerForceMultyTime(c, name)
+
+		c.Assert(res.ExitCode, check.Not(check.Equals), 0, check.Commentf("%v", tc.args))
+		c.Assert(strings.Contains(res.Combined(), tc.expected), check.Equals, true, check.Commentf(res.Combined()))
+	}
+}
+
 // TestRunWithAppArmor is to verify run container with security option AppArmor.
 func (suite *PouchRunSuite) TestRunWithAppArmor(c *check.C) {
 	appArmor := "apparmor=unconfined"
@@ -329,6 +383,99 @@ func (suite *PouchRunSuite) TestRunWithRM(c *check.C) {
 	c.Assert(util.PartialEqual(output, cname+": not found"), check.IsNil)
 }
 
+// TestRunWithRMAndVolume is to verify the anonymous volume is removed with
+// the container run with rm flag, while the named volume is kept.
+func (suite *PouchRunSuite) TestRunWithRMAndVolume(c *check.C) {
+	cname := "TestRunWithRMAndVolume"
+	volumeName := "TestRunWithRMAndVolumeNamed"
+	command.PouchRun("volume", "create", "--name", volumeName).Assert(c, icmd.Success)
+	defer command.PouchRun("volume", "rm", volumeName)
+
+	res := command.PouchRun("volume", "ls", "-q")
+	res.Assert(c, icmd.Success)
+	before := strings.Fields(res.Stdout())
+
+	res = command.PouchRun("run", "--rm", "--name", cname,
+		"-v", "/anonymous", "-v", volumeName+":/named",
+		busyboxImage, "true")
+	defer DelContainerForceMultyTime(c, cname)
+	res.Assert(c, icmd.Success)
+
+	res = command.PouchRun("volume", "ls", "-q")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Fields(res.Stdout()), check.DeepEquals, before)
+}
+
+// TestRunDetachWithRM is to verify the detached container with rm flag is
+// removed by daemon once it exits.
+func (suite *PouchRunSuite) TestRunDetachWithRM(c *check.C) {
+	cname := "TestRunDetachWithRM"
+	command.PouchRun("run", "-d", "--rm", "--name", cname, busyboxImage, "sleep", "1").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	autoRemove, err := inspectFilter(cname, ".HostConfig.AutoRemove")
+	c.Assert(err, check.IsNil)
+	c.Assert(autoRemove, check.Equals, "true")
+
+	removed := false
+	for i := 0; i < 30; i++ {
+		if res := command.PouchRun("inspect", cname); res.ExitCode != 0 {
+			removed = true
+			break
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	c.Assert(removed, check.Equals, true)
+
+	res := command.PouchRun("run", "-d", "--rm", "--restart", "always", busyboxImage, "top")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*Conflicting options: --rm and --restart.*")
+}
+
+// TestRunDetachPrintID is to verify the detached run prints the full ID of
+// container and the used name is rejected with the ID of its owner.
+func (suite *PouchRunSuite) TestRunDetachPrintID(c *check.C) {
+	cname := "TestRunDetachPrintID"
+	res := command.PouchRun("run", "-d", "--name", cname, busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, cname)
+	res.Assert(c, icmd.Success)
+
+	id := strings.TrimSpace(res.Stdout())
+	c.Assert(len(id), check.Equals, 64)
+
+	output, err := inspectFilter(cname, ".ID")
+	c.Assert(err, check.IsNil)
+	c.Assert(output, check.Equals, id)
+
+	res = command.PouchRun("run", "-d", "--name", cname, busyboxImage, "top")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(util.PartialEqual(res.Stderr(), "container name "+cname+" is already in use by container "+id), check.IsNil)
+}
+
+// TestRunWithEntrypoint is to verify the entrypoint is overridden or cleared
+// by --entrypoint, and the args are appended to entrypoint.
+func (suite *PouchRunSuite) TestRunWithEntrypoint(c *check.C) {
+	cname := "TestRunWithEntrypoint"
+	res := command.PouchRun("run", "--name", cname, "--entrypoint", "echo", busyboxImage, "hello")
+	defer DelContainerForceMultyTime(c, cname)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, "hello")
+
+	output, err := inspectFilter(cname, ".Config.Entrypoint")
+	c.Assert(err, check.IsNil)
+	c.Assert(output, check.Equals, "[echo]")
+
+	cleared := cname + "Cleared"
+	res = command.PouchRun("run", "--name", cleared, "--entrypoint", "", busyboxImage, "echo", "cleared")
+	defer DelContainerForceMultyTime(c, cleared)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, "cleared")
+
+	output, err = inspectFilter(cleared, ".Config.Entrypoint")
+	c.Assert(err, check.IsNil)
+	c.Assert(output, check.Equals, "[]")
+}
+
 // TestRunWithDisableNetworkFiles is to verify running container with disable-network-files flag.
 func (suite *PouchRunSuite) TestRunWithDisableNetworkFiles(c *check.C) {
 	// Run a container with disable-network-files flag
@@ -479,6 +626,19 @@ func (suite *PouchRunSuite) TestRunWithEnvfile(c *check.C) {
 	c.Assert(strings.TrimSpace(ret.Stdout()), check.Equals, "value2-")
 }
 
+// TestRunWithMalformedEnvfile tests running container with a malformed env file fails with the file and line.
+func (suite *PouchRunSuite) TestRunWithMalformedEnvfile(c *check.C) {
+	badfile, err := util.TmpFileWithContent("TEST1=value1\nBAD KEY=value")
+	if err != nil {
+		c.Fatal(err)
+	}
+	defer os.Remove(badfile)
+
+	res := command.PouchRun("run", "--rm", "--env-file", badfile, busyboxImage, "true")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(strings.Contains(res.Stderr(), badfile+":2:"), check.Equals, true)
+}
+
 // TestRunWithTty tests running container with -tty flag and attach stdin in a non-tty client.
 func (suite *PouchRunSuite) TestRunWithTty(c *check.C) {
 	name := "TestRunWithTty"
@@ -486,5 +646,71 @@ func (suite *PouchRunSuite) TestRunWithTty(c *check.C) {
 	defer DelContainerForceMultyTime(c, name)
 
 	errString := res.Stderr()
-	assert.Equal(c, errString, "Error: the input device is not a TTY\n")
+	assert.Equal(c, errString, "Error: the input device is not a TTY; remove -t or connect a terminal\n")
+}
+
+// TestRunWithSeccompProfile is to verify run container with a local seccomp
+// profile file.
+func (suite *PouchRunSuite) TestRunWithSeccompProfile(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+	if !strings.Contains(command.PouchRun("info").Stdout(), "seccomp") {
+		c.Skip("seccomp is not supported by daemon")
+	}
+	name := "run-seccomp-profile"
+
+	tmpFile, err := ioutil.TempFile("", "seccomp-profile")
+	c.Assert(err, check.IsNil)
+	defer os.Remove(tmpFile.Name())
+	_, err = tmpFile.WriteString(`{"defaultAction": "SCMP_ACT_ALLOW", "syscalls": [{"names": ["chmod", "fchmodat"], "action": "SCMP_ACT_ERRNO"}]}`)
+	c.Assert(err, check.IsNil)
+	tmpFile.Close()
+
+	res := command.PouchRun("run", "--name", name,
+		"--security-opt", "seccomp="+tmpFile.Name(),
+		"--security-opt", "no-new-privileges",
+		busyboxImage, "chmod", "400", "/etc/hostname")
+	defer DelContainerForceMultyTime(c, name)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Combined(), check.Matches, "(?s).*Operation not permitted.*")
+
+	// unknown option key and invalid profile should fail on client side.
+	res = command.PouchRun("run", "--rm", "--security-opt", "foo=bar", busyboxImage, "true")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*unknown key foo.*")
+
+	c.Assert(ioutil.WriteFile(tmpFile.Name(), []byte("{"), 0644), check.IsNil)
+	res = command.PouchRun("run", "--rm", "--security-opt", "seccomp="+tmpFile.Name(), busyboxImage, "true")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*invalid seccomp profile.*")
+}
+
+// TestRunWithCapDropAll tests dropping all capabilities and adding back the specific ones.
+func (suite *PouchRunSuite) TestRunWithCapDropAll(c *check.C) {
+	name := "run-cap-drop-all"
+
+	res := command.PouchRun("run", "--name", name,
+		"--cap-drop", "ALL", "--cap-add", "cap_chown",
+		busyboxImage, "sh", "-c", "grep CapEff /proc/self/status")
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Matches, `CapEff:\s+0000000000000001\s*`)
+
+	output, err := inspectFilter(name, ".HostConfig.CapAdd")
+	c.Assert(err, check.IsNil)
+	c.Assert(output, check.Equals, "[CHOWN]")
+
+	res = command.PouchRun("create", "--cap-add", "FOO", busyboxImage)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, `(?s).*Unknown capability to add.*`)
+}
+
+// TestRunPrivilegedWithCapabilities tests the warning of capability flags
+// overridden by privileged mode.
+func (suite *PouchRunSuite) TestRunPrivilegedWithCapabilities(c *check.C) {
+	name := "run-privileged-with-capabilities"
+
+	res := command.PouchRun("create", "--name", name, "--privileged", "--cap-drop", "ALL", busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), "--cap-add and --cap-drop are ignored"), check.Equals, true)
 }
@@ -52,3 +52,36 @@ func (suite *PouchRunUlimitSuite) TestRunWithUlimit(c *check.C) {
 	c.Assert(int(ul.Hard), check.Equals, 256)
 	c.Assert(int(ul.Soft), check.Equals, 256)
 }
+
+// TestRunWithMultipleUlimits tests running container with repeated --ulimit flags.
+func (suite *PouchRunUlimitSuite) TestRunWithMultipleUlimits(c *check.C) {
+	cname := "TestRunWithMultipleUlimits"
+	res := command.PouchRun("run", "--ulimit", "nproc=256", "--ulimit", "nofile=512:1024",
+		"--ulimit", "core=0", "--name", cname, busyboxImage, "sh", "-c", "ulimit -p -n -c")
+	defer DelContainerForceMultyTime(c, cname)
+	res.Assert(c, icmd.Success)
+
+	output := command.PouchRun("inspect", cname).Stdout()
+	result := []types.ContainerJSON{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		c.Errorf("failed to decode inspect output: %v", err)
+	}
+
+	ulimits := result[0].HostConfig.Ulimits
+	c.Assert(len(ulimits), check.Equals, 3)
+	c.Assert(*ulimits[0], check.Equals, types.Ulimit{Name: "core", Soft: 0, Hard: 0})
+	c.Assert(*ulimits[1], check.Equals, types.Ulimit{Name: "nofile", Soft: 512, Hard: 1024})
+	c.Assert(*ulimits[2], check.Equals, types.Ulimit{Name: "nproc", Soft: 256, Hard: 256})
+}
+
+// TestRunWithInvalidUlimit tests that the invalid ulimit is rejected.
+func (suite *PouchRunUlimitSuite) TestRunWithInvalidUlimit(c *check.C) {
+	cname := "TestRunWithInvalidUlimit"
+	defer DelContainerForceMultyTime(c, cname)
+
+	for _, ulimit := range []string{"foo=1", "nofile=-2", "nofile=1024:512", "nofile=1:2:3"} {
+		res := command.PouchRun("run", "--ulimit", ulimit, "--name", cname, busyboxImage, "true")
+		c.Assert(res.ExitCode, check.Not(check.Equals), 0, check.Commentf("ulimit %s", ulimit))
+		c.Assert(res.Stderr(), check.Matches, "(?s).*ulimit.*", check.Commentf("ulimit %s", ulimit))
+	}
+}
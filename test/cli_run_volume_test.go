@@ -608,3 +608,102 @@ func (suite *PouchRunVolumeSuite) TestWorkingDirWithVolume(c *check.C) {
 		c.Fatalf("working not empty (%s)", stdout)
 	}
 }
+
+// TestRunWithMountTmpfs is to verify run container with --mount type=tmpfs works.
+func (suite *PouchRunVolumeSuite) TestRunWithMountTmpfs(c *check.C) {
+	cname := "TestRunWithMountTmpfs"
+
+	res := command.PouchRun("run", "--name", cname,
+		"--mount", "type=tmpfs,target=/mnt/tmpfs,tmpfs-size=1m",
+		busyboxImage, "sh", "-c", "grep /mnt/tmpfs /proc/mounts")
+	defer DelContainerForceMultyTime(c, cname)
+	res.Assert(c, icmd.Success)
+
+	out := res.Stdout()
+	c.Assert(strings.Contains(out, "tmpfs /mnt/tmpfs tmpfs"), check.Equals, true, check.Commentf("mounts: %s", out))
+	c.Assert(strings.Contains(out, "size=1024k"), check.Equals, true, check.Commentf("mounts: %s", out))
+}
+
+// TestRunWithTmpfs is to verify run container with multiple --tmpfs works.
+func (suite *PouchRunVolumeSuite) TestRunWithTmpfs(c *check.C) {
+	cname := "TestRunWithTmpfs"
+
+	res := command.PouchRun("run", "--name", cname,
+		"--tmpfs", "/mnt/tmpfs1",
+		"--tmpfs", "/mnt/tmpfs2:exec,size=1m,mode=1777",
+		busyboxImage, "sh", "-c", "grep /mnt/tmpfs /proc/mounts")
+	defer DelContainerForceMultyTime(c, cname)
+	res.Assert(c, icmd.Success)
+
+	lines := strings.Split(strings.TrimSpace(res.Stdout()), "\n")
+	c.Assert(lines, check.HasLen, 2, check.Commentf("mounts: %s", res.Stdout()))
+	c.Assert(lines[0], check.Matches, "tmpfs /mnt/tmpfs1 tmpfs .*nosuid,nodev,noexec.*size=65536k.*")
+	c.Assert(lines[1], check.Matches, "tmpfs /mnt/tmpfs2 tmpfs .*size=1024k,mode=1777.*")
+	c.Assert(strings.Contains(lines[1], "noexec"), check.Equals, false, check.Commentf("mounts: %s", lines[1]))
+
+	output := command.PouchRun("inspect", "-f", "{{json .HostConfig.Tmpfs}}", cname).Stdout()
+	c.Assert(strings.TrimSpace(output), check.Equals, `{"/mnt/tmpfs1":"","/mnt/tmpfs2":"exec,size=1m,mode=1777"}`)
+}
+
+// TestRunWithInvalidTmpfs is to verify run container with invalid --tmpfs fails.
+func (suite *PouchRunVolumeSuite) TestRunWithInvalidTmpfs(c *check.C) {
+	cname := "TestRunWithInvalidTmpfs"
+
+	for _, tmpfs := range []string{"mnt", "/mnt:size=foo", "/mnt:foo"} {
+		res := command.PouchRun("run", "--name", cname, "--tmpfs", tmpfs, busyboxImage, "true")
+		DelContainerForceMultyTime(c, cname)
+		c.Assert(res.ExitCode, check.Not(check.Equals), 0, check.Commentf(tmpfs))
+		c.Assert(res.Stderr(), check.Matches, "(?s).*tmpfs.*", check.Commentf(tmpfs))
+	}
+}
+
+// TestRunWithMountReadonlyBind is to verify run container with --mount type=bind,readonly works.
+func (suite *PouchRunVolumeSuite) TestRunWithMountReadonlyBind(c *check.C) {
+	cname := "TestRunWithMountReadonlyBind"
+
+	res := command.PouchRun("run", "--name", cname,
+		"--mount", "type=bind,source=/tmp,target=/mnt/bind,readonly",
+		busyboxImage, "touch", "/mnt/bind/"+cname)
+	defer DelContainerForceMultyTime(c, cname)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Combined(), check.Matches, "(?s).*Read-only file system.*")
+}
+
+// TestRunWithMountInvalid is to verify the error of invalid --mount points at the offending token.
+func (suite *PouchRunVolumeSuite) TestRunWithMountInvalid(c *check.C) {
+	cname := "TestRunWithMountInvalid"
+
+	res := command.PouchRun("run", "--name", cname,
+		"--mount", "type=tmpfs,target=/mnt,bind-propagation=shared",
+		busyboxImage, "true")
+	defer DelContainerForceMultyTime(c, cname)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, `(?s).*field "bind-propagation" is not allowed for tmpfs mount.*`)
+}
+
+// TestRunWithReadOnly is to verify run container with --read-only makes rootfs
+// read only, while volumes and tmpfs mounts remain writable.
+func (suite *PouchRunVolumeSuite) TestRunWithReadOnly(c *check.C) {
+	cname := "TestRunWithReadOnly"
+	volumeName := "TestRunWithReadOnlyVolume"
+
+	command.PouchRun("volume", "create", "--name", volumeName).Assert(c, icmd.Success)
+	defer command.PouchRun("volume", "rm", volumeName)
+
+	command.PouchRun("run", "-d", "--name", cname, "--read-only",
+		"-v", volumeName+":/mnt/volume",
+		"--tmpfs", "/mnt/tmpfs",
+		busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	output, err := inspectFilter(cname, ".HostConfig.ReadonlyRootfs")
+	c.Assert(err, check.IsNil)
+	c.Assert(output, check.Equals, "true")
+
+	res := command.PouchRun("exec", cname, "touch", "/foo")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Combined(), check.Matches, "(?s).*Read-only file system.*")
+
+	command.PouchRun("exec", cname, "touch", "/mnt/volume/foo").Assert(c, icmd.Success)
+	command.PouchRun("exec", cname, "touch", "/mnt/tmpfs/foo").Assert(c, icmd.Success)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"io/ioutil"
 	"os"
@@ -95,3 +96,44 @@ func (suite *PouchSaveLoadSuite) TestSaveLoadWorks(c *check.C) {
 	c.Assert(before[0].CreatedAt, check.Equals, after[0].CreatedAt)
 	c.Assert(before[0].Size, check.Equals, after[0].Size)
 }
+
+// TestSaveLoadMultiImages tests "pouch save" multiple images and "pouch load"
+// restores the original image names.
+func (suite *PouchSaveLoadSuite) TestSaveLoadMultiImages(c *check.C) {
+	PullImage(c, busyboxImage125)
+	PullImage(c, helloworldImage)
+
+	dir, err := ioutil.TempDir("", "TestSaveLoadMultiImages")
+	if err != nil {
+		c.Errorf("failed to create a new temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "images.tar")
+	command.PouchRun("save", "-o", filename, busyboxImage125, helloworldImage).Assert(c, icmd.Success)
+
+	command.PouchRun("rmi", busyboxImage125, helloworldImage).Assert(c, icmd.Success)
+
+	command.PouchRun("load", "-i", filename).Assert(c, icmd.Success)
+
+	command.PouchRun("image", "inspect", busyboxImage125).Assert(c, icmd.Success)
+	command.PouchRun("image", "inspect", helloworldImage).Assert(c, icmd.Success)
+}
+
+// TestSaveLoadWithStdio tests "pouch save -o -" and "pouch load -i -" work.
+func (suite *PouchSaveLoadSuite) TestSaveLoadWithStdio(c *check.C) {
+	PullImage(c, helloworldImage)
+
+	stdout := bytes.NewBuffer(nil)
+	cmd := command.PouchCmd("save", "-o", "-", helloworldImage)
+	cmd.Stdout = stdout
+	icmd.RunCmd(cmd).Assert(c, icmd.Success)
+
+	command.PouchRun("rmi", helloworldImage).Assert(c, icmd.Success)
+
+	cmd = command.PouchCmd("load", "-i", "-")
+	cmd.Stdin = stdout
+	icmd.RunCmd(cmd).Assert(c, icmd.Success)
+
+	command.PouchRun("image", "inspect", helloworldImage).Assert(c, icmd.Success)
+}
@@ -348,7 +348,7 @@ func (suite *PouchStartSuite) TestStartWithTty(c *check.C) {
 
 	attachRes := command.PouchRun("start", "-a", "-i", name)
 	errString := attachRes.Stderr()
-	assert.Equal(c, errString, "Error: the input device is not a TTY\n")
+	assert.Equal(c, errString, "Error: the input device is not a TTY; remove -t or connect a terminal\n")
 }
 
 // TestStartMultiContainers tries to start more than one container.
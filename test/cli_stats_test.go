@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"strings"
 	"time"
 
@@ -45,3 +46,28 @@ func (s *PouchStatsSuite) TestStatsNoStream(c *check.C) {
 		c.Fatalf("container name not present in the stats output, %s", res.Stdout())
 	}
 }
+
+func (s *PouchStatsSuite) TestStatsNoStreamJSON(c *check.C) {
+	cnames := []string{"TestStatsNoStreamJSON1", "TestStatsNoStreamJSON2"}
+	for _, cname := range cnames {
+		command.PouchRun("run", "-d", "--name", cname, busyboxImage, "top").Assert(c, icmd.Success)
+		defer DelContainerForceMultyTime(c, cname)
+	}
+
+	cmd := command.PouchCmd(append([]string{"stats", "--no-stream", "--format", "json"}, cnames...)...)
+	res := icmd.StartCmd(cmd)
+	res = icmd.WaitOnCmd(3*time.Second, res)
+	res.Assert(c, icmd.Success)
+
+	lines := strings.Split(strings.TrimSpace(res.Stdout()), "\n")
+	c.Assert(len(lines), check.Equals, len(cnames))
+	for i, line := range lines {
+		var stats map[string]interface{}
+		c.Assert(json.Unmarshal([]byte(line), &stats), check.IsNil)
+		c.Assert(stats["Name"], check.Equals, cnames[i])
+		for _, key := range []string{"CPUPercentage", "MemoryUsage", "NetworkRx", "BlockRead"} {
+			_, ok := stats[key]
+			c.Assert(ok, check.Equals, true)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"time"
+
 	"github.com/alibaba/pouch/test/command"
 	"github.com/alibaba/pouch/test/environment"
 
@@ -131,3 +133,63 @@ func (suite *PouchStopSuite) TestAutoStopPidValue(c *check.C) {
 	c.Assert(err, check.IsNil)
 	c.Assert(pid, check.Equals, "0")
 }
+
+// TestStopWithStopSignal ensures the stop signal of container is used to stop it.
+func (suite *PouchStopSuite) TestStopWithStopSignal(c *check.C) {
+	name := "test-stop-with-stop-signal"
+
+	command.PouchRun("run", "-d", "--name", name,
+		"--stop-signal", "SIGQUIT", "--stop-timeout", "30",
+		busyboxImage, "sh", "-c", "trap 'exit 3' QUIT; while true; do sleep 1; done").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	stopSignal, err := inspectFilter(name, ".Config.StopSignal")
+	c.Assert(err, check.IsNil)
+	c.Assert(stopSignal, check.Equals, "SIGQUIT")
+
+	stopTimeout, err := inspectFilter(name, ".Config.StopTimeout")
+	c.Assert(err, check.IsNil)
+	c.Assert(stopTimeout, check.Equals, "30")
+
+	start := time.Now()
+	command.PouchRun("stop", name).Assert(c, icmd.Success)
+	c.Assert(time.Since(start) < 30*time.Second, check.Equals, true)
+
+	exitCode, err := inspectFilter(name, ".State.ExitCode")
+	c.Assert(err, check.IsNil)
+	c.Assert(exitCode, check.Equals, "3")
+}
+
+// TestStopWithStopTimeout ensures the container is killed after the stop
+// timeout, and the timeout can be overridden by stop command.
+func (suite *PouchStopSuite) TestStopWithStopTimeout(c *check.C) {
+	name := "test-stop-with-stop-timeout"
+
+	// the signal handler ignores SIGTERM.
+	command.PouchRun("run", "-d", "--name", name, "--stop-timeout", "2",
+		busyboxImage, "sh", "-c", "trap '' TERM; while true; do sleep 1; done").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, name)
+
+	start := time.Now()
+	command.PouchRun("stop", name).Assert(c, icmd.Success)
+	elapsed := time.Since(start)
+	c.Assert(elapsed >= 2*time.Second && elapsed < 10*time.Second, check.Equals, true)
+
+	command.PouchRun("start", name).Assert(c, icmd.Success)
+	start = time.Now()
+	command.PouchRun("stop", "--time", "5", name).Assert(c, icmd.Success)
+	c.Assert(time.Since(start) >= 5*time.Second, check.Equals, true)
+
+	// zero timeout kills the container immediately.
+	command.PouchRun("start", name).Assert(c, icmd.Success)
+	start = time.Now()
+	command.PouchRun("stop", "-t", "0", name).Assert(c, icmd.Success)
+	c.Assert(time.Since(start) < 2*time.Second, check.Equals, true)
+}
+
+// TestStopWithInvalidStopSignal ensures the invalid stop signal is rejected.
+func (suite *PouchStopSuite) TestStopWithInvalidStopSignal(c *check.C) {
+	res := command.PouchRun("create", "--stop-signal", "SIGFOO", busyboxImage)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*invalid stop signal SIGFOO.*")
+}
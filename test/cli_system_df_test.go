@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/alibaba/pouch/apis/types"
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchSystemDfSuite is the test suite for system df CLI.
+type PouchSystemDfSuite struct{}
+
+func init() {
+	check.Suite(&PouchSystemDfSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchSystemDfSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	PullImage(c, busyboxImage)
+}
+
+// TestSystemDfWorks tests "pouch system df" reports the running container,
+// its image and volume as active.
+func (suite *PouchSystemDfSuite) TestSystemDfWorks(c *check.C) {
+	cname := "TestSystemDfWorks"
+	vname := "TestSystemDfWorksVolume"
+
+	command.PouchRun("volume", "create", "--name", vname).Assert(c, icmd.Success)
+	defer command.PouchRun("volume", "rm", vname)
+
+	command.PouchRun("run", "-d", "--name", cname, "-v", vname+":/data", busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	res := command.PouchRun("system", "df")
+	res.Assert(c, icmd.Success)
+	lines := strings.Split(strings.TrimSpace(res.Stdout()), "\n")
+	c.Assert(len(lines), check.Equals, 5)
+	c.Assert(strings.Fields(lines[0]), check.DeepEquals, []string{"TYPE", "TOTAL", "ACTIVE", "SIZE", "RECLAIMABLE"})
+
+	res = command.PouchRun("system", "df", "--verbose", "--format", "json")
+	res.Assert(c, icmd.Success)
+
+	du := types.DiskUsage{}
+	c.Assert(json.Unmarshal([]byte(res.Stdout()), &du), check.IsNil)
+
+	inUse := func(objs []*types.DiskUsageObject, name string) bool {
+		for _, obj := range objs {
+			if obj.Name == name {
+				return obj.InUse
+			}
+		}
+		c.Fatalf("%s not found in disk usage %s", name, res.Stdout())
+		return false
+	}
+	c.Assert(inUse(du.Containers, cname), check.Equals, true)
+	c.Assert(inUse(du.Images, busyboxImage), check.Equals, true)
+	c.Assert(inUse(du.Volumes, vname), check.Equals, true)
+	c.Assert(du.BuildCache, check.HasLen, 0)
+}
+
+// TestSystemDfFormat tests "pouch system df --format" prints the summary of
+// each type.
+func (suite *PouchSystemDfSuite) TestSystemDfFormat(c *check.C) {
+	res := command.PouchRun("system", "df", "--format", "{{.Type}}")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, "Images\nContainers\nLocal Volumes\nBuild Cache")
+
+	res = command.PouchRun("system", "df", "--format", "{{.Foo}}")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+}
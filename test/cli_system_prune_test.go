@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/alibaba/pouch/test/command"
+	"github.com/alibaba/pouch/test/environment"
+
+	"github.com/go-check/check"
+	"github.com/gotestyourself/gotestyourself/icmd"
+)
+
+// PouchSystemPruneSuite is the test suite for system prune CLI.
+type PouchSystemPruneSuite struct{}
+
+func init() {
+	check.Suite(&PouchSystemPruneSuite{})
+}
+
+// SetUpSuite does common setup in the beginning of each test suite.
+func (suite *PouchSystemPruneSuite) SetUpSuite(c *check.C) {
+	SkipIfFalse(c, environment.IsLinux)
+
+	PullImage(c, busyboxImage)
+}
+
+// TestSystemPruneWithLabel tests "pouch system prune" removes the stopped
+// containers and unused networks and volumes, but keeps the running ones.
+func (suite *PouchSystemPruneSuite) TestSystemPruneWithLabel(c *check.C) {
+	label := "test=TestSystemPruneWithLabel"
+	stopped := "TestSystemPruneWithLabelStopped"
+	running := "TestSystemPruneWithLabelRunning"
+	network := "TestSystemPruneWithLabelNetwork"
+	volume := "TestSystemPruneWithLabelVolume"
+
+	command.PouchRun("create", "--name", stopped, "--label", label, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, stopped)
+	command.PouchRun("run", "-d", "--name", running, "--label", label, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, running)
+
+	command.PouchRun("network", "create", "--label", label, network).Assert(c, icmd.Success)
+	defer command.PouchRun("network", "rm", network)
+	command.PouchRun("volume", "create", "--label", label, "--name", volume).Assert(c, icmd.Success)
+	defer command.PouchRun("volume", "rm", volume)
+
+	res := command.PouchRun("system", "prune", "-f", "--volumes", "--filter", "label="+label)
+	res.Assert(c, icmd.Success)
+	out := res.Stdout()
+	c.Assert(strings.Contains(out, "Deleted Networks:\n"+network+"\n"), check.Equals, true, check.Commentf(out))
+	c.Assert(strings.Contains(out, "Deleted Volumes:\n"+volume+"\n"), check.Equals, true, check.Commentf(out))
+	c.Assert(strings.Contains(out, "Total reclaimed space:"), check.Equals, true, check.Commentf(out))
+
+	c.Assert(command.PouchRun("inspect", stopped).ExitCode, check.Not(check.Equals), 0)
+	command.PouchRun("inspect", running).Assert(c, icmd.Success)
+	c.Assert(command.PouchRun("network", "inspect", network).ExitCode, check.Not(check.Equals), 0)
+	c.Assert(command.PouchRun("volume", "inspect", volume).ExitCode, check.Not(check.Equals), 0)
+}
+
+// TestSystemPruneInvalidFilter tests "pouch system prune" fails with the
+// filters which are not supported.
+func (suite *PouchSystemPruneSuite) TestSystemPruneInvalidFilter(c *check.C) {
+	for _, args := range [][]string{
+		{"--filter", "dangling=true"},
+		{"--filter", "until=1h", "--volumes"},
+		{"--filter", "foo=bar"},
+	} {
+		res := command.PouchRun(append([]string{"system", "prune", "-f"}, args...)...)
+		c.Assert(res.ExitCode, check.Not(check.Equals), 0, check.Commentf("%v", args))
+	}
+}
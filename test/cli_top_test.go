@@ -108,3 +108,20 @@ func (suite *PouchTopSuite) TestTopContainerWithOptions(c *check.C) {
 		c.Fatalf("unexpected output %s expected %s", out, expectString)
 	}
 }
+
+// TestTopContainerWithPIDAlias is to verify pouch top refuses the ps options renaming other field to PID.
+func (suite *PouchTopSuite) TestTopContainerWithPIDAlias(c *check.C) {
+	name := "TestTopContainerWithPIDAlias"
+
+	res := command.PouchRun("run", "-d", "--name", name, busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+
+	res = command.PouchRun("top", name, "-o", "ppid=PID")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+
+	expectString := "only pid can be displayed as PID"
+	if out := res.Combined(); !strings.Contains(out, expectString) {
+		c.Fatalf("unexpected output %s expected %s", out, expectString)
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"strconv"
@@ -261,6 +262,30 @@ func (suite *PouchUpdateSuite) TestUpdateCpuMemoryFail(c *check.C) {
 	c.Assert(res.Stderr(), check.NotNil)
 }
 
+// TestUpdateRunningContainerCpus is to verify updating cpus and restart policy of a running container.
+func (suite *PouchUpdateSuite) TestUpdateRunningContainerCpus(c *check.C) {
+	name := "update-running-container-cpus"
+
+	res := command.PouchRun("run", "-d", "--name", name, busyboxImage, "top")
+	defer DelContainerForceMultyTime(c, name)
+	res.Assert(c, icmd.Success)
+
+	command.PouchRun("update", "--cpus", "0.5", "--restart", "always", name).Assert(c, icmd.Success)
+
+	nanoCpus, err := inspectFilter(name, ".HostConfig.NanoCpus")
+	c.Assert(err, check.IsNil)
+	c.Assert(nanoCpus, check.Equals, "500000000")
+	checkContainerCPUQuota(c, name, "50000")
+
+	restart, err := inspectFilter(name, ".HostConfig.RestartPolicy.Name")
+	c.Assert(err, check.IsNil)
+	c.Assert(restart, check.Equals, "always")
+
+	res = command.PouchRun("update", "--cpus", "1", "--cpu-quota", "20000", name)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*Conflicting options.*")
+}
+
 // TestUpdateStoppedContainer is to verify the correctness of updating a stopped container.
 func (suite *PouchUpdateSuite) TestUpdateStoppedContainer(c *check.C) {
 	name := "update-stopped-container"
@@ -596,3 +621,103 @@ func (suite *PouchUpdateSuite) TestUpdateAnnotation(c *check.C) {
 	checkContainerAnnotation(c, cname, "key1", "value1.new")
 	checkContainerAnnotation(c, cname, "key2", "value2.new")
 }
+
+// TestUpdateLabels tests that the labels read from file and flags can be
+// updated on the running container, and used by ps filter.
+func (suite *PouchUpdateSuite) TestUpdateLabels(c *check.C) {
+	cname := "TestUpdateLabels"
+
+	labelFile, err := ioutil.TempFile("", "label-file")
+	c.Assert(err, check.IsNil)
+	defer os.Remove(labelFile.Name())
+	_, err = labelFile.WriteString("# scheduling labels\nzone=a\nrack=1\n")
+	c.Assert(err, check.IsNil)
+	labelFile.Close()
+
+	command.PouchRun("run", "-d", "--name", cname, "--label-file", labelFile.Name(),
+		"--label", "rack=2", busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	res := command.PouchRun("inspect", "-f", `{{index .Config.Labels "zone"}} {{index .Config.Labels "rack"}}`, cname)
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "a 2\n")
+
+	command.PouchRun("update", "--label", "zone=b", "--label", "rack=", cname).Assert(c, icmd.Success)
+
+	res = command.PouchRun("ps", "-q", "--no-trunc", "--filter", "label=zone=b")
+	res.Assert(c, icmd.Success)
+	id, err := inspectFilter(cname, ".ID")
+	c.Assert(err, check.IsNil)
+	c.Assert(res.Stdout(), check.Matches, "(?s).*"+id+".*")
+
+	res = command.PouchRun("ps", "-q", "--no-trunc", "--filter", "label=rack")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), id), check.Equals, false)
+
+	res = command.PouchRun("update", "--label", "=foo", cname)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, "(?s).*label key should not be empty.*")
+}
+
+// TestUpdateOOMScoreAdj is to verify updating oom score adj of a running container.
+func (suite *PouchUpdateSuite) TestUpdateOOMScoreAdj(c *check.C) {
+	cname := "TestUpdateOOMScoreAdj"
+
+	command.PouchRun("run", "-d", "--name", cname, "--oom-score-adj", "200", busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	score, err := inspectFilter(cname, ".HostConfig.OomScoreAdj")
+	c.Assert(err, check.IsNil)
+	c.Assert(score, check.Equals, "200")
+	res := command.PouchRun("exec", cname, "cat", "/proc/1/oom_score_adj")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, "200")
+
+	command.PouchRun("update", "--oom-score-adj", "-100", cname).Assert(c, icmd.Success)
+
+	score, err = inspectFilter(cname, ".HostConfig.OomScoreAdj")
+	c.Assert(err, check.IsNil)
+	c.Assert(score, check.Equals, "-100")
+	res = command.PouchRun("exec", cname, "cat", "/proc/1/oom_score_adj")
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.TrimSpace(res.Stdout()), check.Equals, "-100")
+
+	// other updates should not change the oom score adj.
+	command.PouchRun("update", "--restart", "always", cname).Assert(c, icmd.Success)
+	score, err = inspectFilter(cname, ".HostConfig.OomScoreAdj")
+	c.Assert(err, check.IsNil)
+	c.Assert(score, check.Equals, "-100")
+
+	res = command.PouchRun("update", "--oom-score-adj", "1001", cname)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, `(?s).*should be in range \[-1000, 1000\].*`)
+
+	res = command.PouchRun("create", "--oom-score-adj", "-1001", busyboxImage)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stderr(), check.Matches, `(?s).*should be in range \[-1000, 1000\].*`)
+}
+
+// TestUpdateInvalidBlkIO is to verify the invalid block IO settings are rejected.
+func (suite *PouchUpdateSuite) TestUpdateInvalidBlkIO(c *check.C) {
+	cname := "TestUpdateInvalidBlkIO"
+
+	command.PouchRun("run", "-d", "--name", cname, "--blkio-weight", "100", busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, cname)
+
+	weight, err := inspectFilter(cname, ".HostConfig.BlkioWeight")
+	c.Assert(err, check.IsNil)
+	c.Assert(weight, check.Equals, "100")
+
+	for _, args := range [][]string{
+		{"--blkio-weight", "5"},
+		{"--blkio-weight", "1001"},
+		{"--device-read-bps", "sda:1mb"},
+		{"--device-write-iops", "/dev/not-exist-device:100"},
+	} {
+		res := command.PouchRun(append(append([]string{"update"}, args...), cname)...)
+		c.Assert(res.ExitCode, check.Not(check.Equals), 0, check.Commentf("%v", args))
+
+		res = command.PouchRun(append(append([]string{"create"}, args...), busyboxImage)...)
+		c.Assert(res.ExitCode, check.Not(check.Equals), 0, check.Commentf("%v", args))
+	}
+}
@@ -306,6 +306,40 @@ func (suite *PouchVolumeSuite) TestVolumeListOptions(c *check.C) {
 	}
 }
 
+// TestVolumePrune tests pruning volumes only removes the unused ones.
+func (suite *PouchVolumeSuite) TestVolumePrune(c *check.C) {
+	funcname := "TestVolumePrune"
+
+	unused := funcname + "-unused"
+	used := funcname + "-used"
+	unmatched := funcname + "-unmatched"
+	command.PouchRun("volume", "create", "--name", unused, "--label", "prune="+funcname).Assert(c, icmd.Success)
+	defer command.PouchRun("volume", "remove", unused)
+	command.PouchRun("volume", "create", "--name", used, "--label", "prune="+funcname).Assert(c, icmd.Success)
+	defer command.PouchRun("volume", "remove", used)
+	command.PouchRun("volume", "create", "--name", unmatched).Assert(c, icmd.Success)
+	defer command.PouchRun("volume", "remove", unmatched)
+
+	command.PouchRun("create", "-v", used+":/mnt", "--name", funcname, busyboxImage).Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, funcname)
+
+	res := command.PouchRun("volume", "prune", "-f", "--filter", "label=prune="+funcname)
+	res.Assert(c, icmd.Success)
+	c.Assert(strings.Contains(res.Stdout(), unused), check.Equals, true)
+	c.Assert(strings.Contains(res.Stdout(), used), check.Equals, false)
+	c.Assert(strings.Contains(res.Stdout(), unmatched), check.Equals, false)
+
+	command.PouchRun("volume", "inspect", unused).Assert(c, icmd.Expected{ExitCode: 1})
+	command.PouchRun("volume", "inspect", used).Assert(c, icmd.Success)
+	command.PouchRun("volume", "inspect", unmatched).Assert(c, icmd.Success)
+}
+
+// TestVolumePruneInvalidFilter tests pruning volumes with invalid filter fails.
+func (suite *PouchVolumeSuite) TestVolumePruneInvalidFilter(c *check.C) {
+	res := command.PouchRun("volume", "prune", "-f", "--filter", "driver=local")
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+}
+
 // volumesToKV parse the output of "pouch volume list" into key-value pair
 func volumesToKV(volumes string) map[string][]string {
 	// skip header
@@ -110,3 +110,23 @@ func (suite *PouchWaitSuite) TestWaitBlockedExitRandom(c *check.C) {
 		c.Errorf("timeout waiting for `pouch wait` to exit")
 	}
 }
+
+// TestWaitTimeout is to verify waiting multiple containers prints one code per line, and fails on timeout
+func (suite *PouchWaitSuite) TestWaitTimeout(c *check.C) {
+	exited := "TestWaitTimeoutExited"
+	command.PouchRun("run", "-d", "--name", exited, busyboxImage, "sh", "-c", "exit 3").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, exited)
+
+	running := "TestWaitTimeoutRunning"
+	command.PouchRun("run", "-d", "--name", running, busyboxImage, "top").Assert(c, icmd.Success)
+	defer DelContainerForceMultyTime(c, running)
+
+	res := command.PouchRun("wait", "--timeout", "1s", exited, exited)
+	res.Assert(c, icmd.Success)
+	c.Assert(res.Stdout(), check.Equals, "3\n3\n")
+
+	res = command.PouchRun("wait", "--timeout", "1s", exited, running)
+	c.Assert(res.ExitCode, check.Not(check.Equals), 0)
+	c.Assert(res.Stdout(), check.Equals, "3\n")
+	c.Assert(res.Stderr(), check.Matches, "(?s).*timed out after 1s waiting for container "+running+" to stop.*")
+}
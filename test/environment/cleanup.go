@@ -38,7 +38,7 @@ func PruneAllContainers(apiClient client.ContainerAPIClient) error {
 
 	for _, ctr := range containers {
 		// force to remove the containers
-		if err := apiClient.ContainerRemove(ctx, ctr.ID, &types.ContainerRemoveOptions{Force: true}); err != nil {
+		if _, err := apiClient.ContainerRemove(ctx, ctr.ID, &types.ContainerRemoveOptions{Force: true}); err != nil {
 			return errors.Wrap(err, fmt.Sprintf("fail to remove container (%s)", ctr.ID))
 		}
 	}